@@ -65,12 +65,21 @@ func main() {
 
 	err := db.AutoMigrate(
 		&entity.User{},
+		&entity.UserRecoveryCode{},
+		&entity.LoginAttempt{},
+		&entity.Role{},
+		&entity.RolePermission{},
+		&entity.UserRoleAssignment{},
+		&entity.APIKey{},
+		&entity.APIKeyScope{},
+		&entity.AuditLog{},
 		&entity.Testimonial{},
 		&entity.HeroSlide{},
 		&entity.Gallery{},
 		&entity.Facility{},
 		&entity.ContactInfo{},
 		&entity.Activity{},
+		&entity.ActivityException{},
 		&entity.SiteIdentity{},
 		&entity.AboutSection{},
 		&entity.AboutValue{},
@@ -78,7 +87,11 @@ func main() {
 		&entity.OrganizationDetail{},
 		&entity.Remark{},
 		&entity.Category{},
+		&entity.Tag{},
 		&entity.Article{},
+		&entity.ArticleTag{},
+		&entity.ArticleRevision{},
+		&entity.ArticleView{},
 	)
 	if err != nil {
 		logger.Fatalf("Failed to run migrations: %v", err)
@@ -86,6 +99,7 @@ func main() {
 	logger.Info("Database migration completed")
 
 	if viperConfig.GetBool("database.seed_on_start") {
+		seeder.SeedRoles(db)
 		seeder.SeedUsers(db)
 		logger.Info("Seeding completed")
 	}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS user_recovery_codes;
+
+ALTER TABLE users
+    DROP COLUMN two_factor_enabled,
+    DROP COLUMN two_factor_secret;
@@ -0,0 +1,16 @@
+ALTER TABLE users
+    ADD COLUMN two_factor_secret  VARCHAR(64) NULL AFTER is_active,
+    ADD COLUMN two_factor_enabled BOOLEAN     NOT NULL DEFAULT FALSE AFTER two_factor_secret;
+
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id         VARCHAR(100) NOT NULL PRIMARY KEY,
+    user_id    VARCHAR(100) NOT NULL,
+    code_hash  CHAR(64)     NOT NULL,
+    used_at    TIMESTAMP    NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_user_recovery_codes_user
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE = InnoDB;
+
+CREATE INDEX idx_user_recovery_codes_user ON user_recovery_codes(user_id, code_hash);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id         VARCHAR(100) NOT NULL PRIMARY KEY,
+    email      VARCHAR(100) NOT NULL,
+    user_id    VARCHAR(100) NULL,
+    ip         VARCHAR(45)  NULL,
+    user_agent VARCHAR(255) NULL,
+    result     ENUM('SUCCESS', 'INVALID_CREDENTIALS', 'INVALID_SECOND_FACTOR', 'LOCKED', 'DISABLED') NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE INDEX idx_login_attempts_email ON login_attempts(email, created_at);
+CREATE INDEX idx_login_attempts_user ON login_attempts(user_id);
+CREATE INDEX idx_login_attempts_created_at ON login_attempts(created_at);
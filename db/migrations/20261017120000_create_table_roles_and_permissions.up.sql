@@ -0,0 +1,96 @@
+CREATE TABLE IF NOT EXISTS roles (
+    id          VARCHAR(100) NOT NULL PRIMARY KEY,
+    name        VARCHAR(50)  NOT NULL UNIQUE,
+    description VARCHAR(255) NULL,
+    is_system   BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id    VARCHAR(100) NOT NULL,
+    permission VARCHAR(50)  NOT NULL,
+
+    PRIMARY KEY (role_id, permission),
+    CONSTRAINT fk_role_permissions_role
+    FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE
+) ENGINE = InnoDB;
+
+CREATE TABLE IF NOT EXISTS user_role_assignments (
+    id          VARCHAR(100) NOT NULL PRIMARY KEY,
+    user_id     VARCHAR(100) NOT NULL,
+    role_id     VARCHAR(100) NOT NULL,
+    entity_type ENUM('pura', 'yayasan', 'pasraman') NOT NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_user_role_assignments UNIQUE (user_id, role_id, entity_type),
+    CONSTRAINT fk_user_role_assignments_user
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_role_assignments_role
+    FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE
+) ENGINE = InnoDB;
+
+INSERT INTO roles (id, name, description, is_system) VALUES
+    ('00000000-0000-0000-0000-00000000e001', 'editor', 'Full content management of an entity', TRUE),
+    ('00000000-0000-0000-0000-00000000e002', 'reviewer', 'Read-only access to an entity', TRUE);
+
+INSERT INTO role_permissions (role_id, permission) VALUES
+    ('00000000-0000-0000-0000-00000000e001', 'articles:read'),
+    ('00000000-0000-0000-0000-00000000e001', 'articles:write'),
+    ('00000000-0000-0000-0000-00000000e001', 'articles:publish'),
+    ('00000000-0000-0000-0000-00000000e001', 'articles:delete'),
+    ('00000000-0000-0000-0000-00000000e001', 'categories:read'),
+    ('00000000-0000-0000-0000-00000000e001', 'categories:write'),
+    ('00000000-0000-0000-0000-00000000e001', 'categories:delete'),
+    ('00000000-0000-0000-0000-00000000e001', 'gallery:read'),
+    ('00000000-0000-0000-0000-00000000e001', 'gallery:write'),
+    ('00000000-0000-0000-0000-00000000e001', 'gallery:delete'),
+    ('00000000-0000-0000-0000-00000000e001', 'hero-slides:read'),
+    ('00000000-0000-0000-0000-00000000e001', 'hero-slides:write'),
+    ('00000000-0000-0000-0000-00000000e001', 'hero-slides:delete'),
+    ('00000000-0000-0000-0000-00000000e001', 'facilities:read'),
+    ('00000000-0000-0000-0000-00000000e001', 'facilities:write'),
+    ('00000000-0000-0000-0000-00000000e001', 'facilities:delete'),
+    ('00000000-0000-0000-0000-00000000e001', 'activities:read'),
+    ('00000000-0000-0000-0000-00000000e001', 'activities:write'),
+    ('00000000-0000-0000-0000-00000000e001', 'activities:delete'),
+    ('00000000-0000-0000-0000-00000000e001', 'testimonials:read'),
+    ('00000000-0000-0000-0000-00000000e001', 'testimonials:write'),
+    ('00000000-0000-0000-0000-00000000e001', 'testimonials:delete'),
+    ('00000000-0000-0000-0000-00000000e001', 'about:read'),
+    ('00000000-0000-0000-0000-00000000e001', 'about:write'),
+    ('00000000-0000-0000-0000-00000000e001', 'about:delete'),
+    ('00000000-0000-0000-0000-00000000e001', 'organization:read'),
+    ('00000000-0000-0000-0000-00000000e001', 'organization:write'),
+    ('00000000-0000-0000-0000-00000000e001', 'organization:delete'),
+    ('00000000-0000-0000-0000-00000000e001', 'remarks:read'),
+    ('00000000-0000-0000-0000-00000000e001', 'remarks:write'),
+    ('00000000-0000-0000-0000-00000000e001', 'remarks:delete'),
+    ('00000000-0000-0000-0000-00000000e001', 'contact-info:read'),
+    ('00000000-0000-0000-0000-00000000e001', 'contact-info:write'),
+    ('00000000-0000-0000-0000-00000000e001', 'contact-info:delete'),
+    ('00000000-0000-0000-0000-00000000e001', 'site-identity:read'),
+    ('00000000-0000-0000-0000-00000000e001', 'site-identity:write'),
+    ('00000000-0000-0000-0000-00000000e001', 'site-identity:delete'),
+    ('00000000-0000-0000-0000-00000000e001', 'storage:read'),
+    ('00000000-0000-0000-0000-00000000e001', 'storage:write'),
+    ('00000000-0000-0000-0000-00000000e001', 'storage:delete'),
+    ('00000000-0000-0000-0000-00000000e002', 'articles:read'),
+    ('00000000-0000-0000-0000-00000000e002', 'categories:read'),
+    ('00000000-0000-0000-0000-00000000e002', 'gallery:read'),
+    ('00000000-0000-0000-0000-00000000e002', 'hero-slides:read'),
+    ('00000000-0000-0000-0000-00000000e002', 'facilities:read'),
+    ('00000000-0000-0000-0000-00000000e002', 'activities:read'),
+    ('00000000-0000-0000-0000-00000000e002', 'testimonials:read'),
+    ('00000000-0000-0000-0000-00000000e002', 'about:read'),
+    ('00000000-0000-0000-0000-00000000e002', 'organization:read'),
+    ('00000000-0000-0000-0000-00000000e002', 'remarks:read'),
+    ('00000000-0000-0000-0000-00000000e002', 'contact-info:read'),
+    ('00000000-0000-0000-0000-00000000e002', 'site-identity:read'),
+    ('00000000-0000-0000-0000-00000000e002', 'storage:read');
+
+-- Existing entity admins keep their access as editors of their own entity.
+INSERT INTO user_role_assignments (id, user_id, role_id, entity_type)
+SELECT UUID(), id, '00000000-0000-0000-0000-00000000e001', role
+FROM users
+WHERE role <> 'super';
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+    id           VARCHAR(100) NOT NULL PRIMARY KEY,
+    name         VARCHAR(100) NOT NULL,
+    prefix       VARCHAR(20)  NOT NULL,
+    key_hash     CHAR(64)     NOT NULL UNIQUE,
+    created_by   VARCHAR(100) NULL,
+    last_used_at TIMESTAMP    NULL,
+    revoked_at   TIMESTAMP    NULL,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_api_keys_created_by
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+) ENGINE = InnoDB;
+
+CREATE TABLE IF NOT EXISTS api_key_scopes (
+    api_key_id  VARCHAR(100) NOT NULL,
+    entity_type ENUM('pura', 'yayasan', 'pasraman') NOT NULL,
+    permission  VARCHAR(50)  NOT NULL,
+
+    PRIMARY KEY (api_key_id, entity_type, permission),
+    CONSTRAINT fk_api_key_scopes_api_key
+    FOREIGN KEY (api_key_id) REFERENCES api_keys(id) ON DELETE CASCADE
+) ENGINE = InnoDB;
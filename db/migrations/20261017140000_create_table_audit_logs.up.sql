@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id          VARCHAR(100) NOT NULL PRIMARY KEY,
+    actor_id    VARCHAR(100) NULL,
+    actor_role  VARCHAR(50)  NOT NULL,
+    entity_type VARCHAR(20)  NULL,
+    resource    VARCHAR(100) NOT NULL,
+    record_id   VARCHAR(100) NOT NULL,
+    action      ENUM('create', 'update', 'delete') NOT NULL,
+    changes     JSON         NULL,
+    ip          VARCHAR(45)  NULL,
+    request_id  VARCHAR(100) NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE INDEX idx_audit_logs_actor ON audit_logs(actor_id, created_at);
+CREATE INDEX idx_audit_logs_record ON audit_logs(resource, record_id, created_at);
+CREATE INDEX idx_audit_logs_entity_type ON audit_logs(entity_type, created_at);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS article_revisions (
+    id            VARCHAR(100) NOT NULL PRIMARY KEY,
+    article_id    VARCHAR(100) NOT NULL,
+    version       INT          NOT NULL,
+    category_id   VARCHAR(100) NULL,
+    title         VARCHAR(255) NOT NULL,
+    slug          VARCHAR(255) NOT NULL,
+    author_name   VARCHAR(100) NOT NULL,
+    author_role   VARCHAR(100) NULL,
+    excerpt       TEXT,
+    content       LONGTEXT,
+    images        JSON         NULL,
+    status        ENUM('DRAFT', 'PUBLISHED', 'ARCHIVED') NOT NULL,
+    is_featured   BOOLEAN DEFAULT FALSE,
+    published_at  TIMESTAMP    NULL,
+    restored_from INT          NULL,
+    created_by    VARCHAR(100) NULL,
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY idx_article_revisions_version (article_id, version),
+    CONSTRAINT fk_article_revisions_article
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE
+) ENGINE = InnoDB;
@@ -0,0 +1,13 @@
+UPDATE article_revisions SET status = 'DRAFT' WHERE status = 'SCHEDULED';
+
+ALTER TABLE article_revisions
+    DROP COLUMN expires_at,
+    MODIFY COLUMN status ENUM('DRAFT', 'PUBLISHED', 'ARCHIVED') NOT NULL;
+
+DROP INDEX idx_articles_expires_at ON articles;
+
+UPDATE articles SET status = 'DRAFT' WHERE status = 'SCHEDULED';
+
+ALTER TABLE articles
+    DROP COLUMN expires_at,
+    MODIFY COLUMN status ENUM('DRAFT', 'PUBLISHED', 'ARCHIVED') DEFAULT 'DRAFT';
@@ -0,0 +1,9 @@
+ALTER TABLE articles
+    MODIFY COLUMN status ENUM('DRAFT', 'SCHEDULED', 'PUBLISHED', 'ARCHIVED') DEFAULT 'DRAFT',
+    ADD COLUMN expires_at TIMESTAMP NULL AFTER published_at;
+
+CREATE INDEX idx_articles_expires_at ON articles(expires_at);
+
+ALTER TABLE article_revisions
+    MODIFY COLUMN status ENUM('DRAFT', 'SCHEDULED', 'PUBLISHED', 'ARCHIVED') NOT NULL,
+    ADD COLUMN expires_at TIMESTAMP NULL AFTER published_at;
@@ -0,0 +1,14 @@
+UPDATE article_revisions SET status = 'DRAFT' WHERE status = 'IN_REVIEW';
+
+ALTER TABLE article_revisions
+    MODIFY COLUMN status ENUM('DRAFT', 'SCHEDULED', 'PUBLISHED', 'ARCHIVED') NOT NULL;
+
+UPDATE articles SET status = 'DRAFT' WHERE status = 'IN_REVIEW';
+
+ALTER TABLE articles
+    DROP COLUMN reviewed_at,
+    DROP COLUMN reviewed_by,
+    DROP COLUMN submitted_at,
+    DROP COLUMN submitted_by,
+    DROP COLUMN review_comment,
+    MODIFY COLUMN status ENUM('DRAFT', 'SCHEDULED', 'PUBLISHED', 'ARCHIVED') DEFAULT 'DRAFT';
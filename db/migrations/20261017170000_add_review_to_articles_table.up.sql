@@ -0,0 +1,10 @@
+ALTER TABLE articles
+    MODIFY COLUMN status ENUM('DRAFT', 'IN_REVIEW', 'SCHEDULED', 'PUBLISHED', 'ARCHIVED') DEFAULT 'DRAFT',
+    ADD COLUMN review_comment TEXT NULL AFTER expires_at,
+    ADD COLUMN submitted_by VARCHAR(100) NULL AFTER review_comment,
+    ADD COLUMN submitted_at TIMESTAMP NULL AFTER submitted_by,
+    ADD COLUMN reviewed_by VARCHAR(100) NULL AFTER submitted_at,
+    ADD COLUMN reviewed_at TIMESTAMP NULL AFTER reviewed_by;
+
+ALTER TABLE article_revisions
+    MODIFY COLUMN status ENUM('DRAFT', 'IN_REVIEW', 'SCHEDULED', 'PUBLISHED', 'ARCHIVED') NOT NULL;
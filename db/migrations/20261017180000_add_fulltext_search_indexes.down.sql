@@ -0,0 +1,7 @@
+DROP INDEX idx_facilities_search ON facilities;
+
+DROP INDEX idx_galleries_search ON galleries;
+
+DROP INDEX idx_activities_search ON activities;
+
+DROP INDEX idx_articles_search ON articles;
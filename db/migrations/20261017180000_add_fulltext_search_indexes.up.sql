@@ -0,0 +1,7 @@
+CREATE FULLTEXT INDEX idx_articles_search ON articles(title, excerpt, content);
+
+CREATE FULLTEXT INDEX idx_activities_search ON activities(title, description, location);
+
+CREATE FULLTEXT INDEX idx_galleries_search ON galleries(title, description);
+
+CREATE FULLTEXT INDEX idx_facilities_search ON facilities(name, description);
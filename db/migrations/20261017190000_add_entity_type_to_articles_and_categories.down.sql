@@ -0,0 +1,9 @@
+ALTER TABLE `articles`
+    DROP INDEX `idx_articles_entity_slug`,
+    ADD UNIQUE INDEX `slug` (`slug`),
+    DROP COLUMN `entity_type`;
+
+ALTER TABLE `categories`
+    DROP INDEX `idx_categories_entity_slug`,
+    ADD UNIQUE INDEX `slug` (`slug`),
+    DROP COLUMN `entity_type`;
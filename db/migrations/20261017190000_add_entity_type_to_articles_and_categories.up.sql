@@ -0,0 +1,9 @@
+ALTER TABLE `categories`
+    ADD COLUMN `entity_type` ENUM('pura', 'yayasan', 'pasraman') NOT NULL DEFAULT 'pura' AFTER `id`,
+    DROP INDEX `slug`,
+    ADD UNIQUE INDEX `idx_categories_entity_slug` (`entity_type`, `slug`);
+
+ALTER TABLE `articles`
+    ADD COLUMN `entity_type` ENUM('pura', 'yayasan', 'pasraman') NOT NULL DEFAULT 'pura' AFTER `id`,
+    DROP INDEX `slug`,
+    ADD UNIQUE INDEX `idx_articles_entity_slug` (`entity_type`, `slug`);
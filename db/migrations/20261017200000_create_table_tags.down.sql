@@ -0,0 +1,4 @@
+DELETE FROM role_permissions WHERE permission IN ('tags:read', 'tags:write', 'tags:delete');
+
+DROP TABLE IF EXISTS article_tags;
+DROP TABLE IF EXISTS tags;
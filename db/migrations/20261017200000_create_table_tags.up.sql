@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS tags (
+    id          VARCHAR(100) NOT NULL PRIMARY KEY,
+    entity_type ENUM('pura', 'yayasan', 'pasraman') NOT NULL DEFAULT 'pura',
+    name        VARCHAR(50)  NOT NULL,
+    slug        VARCHAR(50)  NOT NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY idx_tags_entity_slug (entity_type, slug)
+) ENGINE = InnoDB;
+
+CREATE TABLE IF NOT EXISTS article_tags (
+    article_id VARCHAR(100) NOT NULL,
+    tag_id     VARCHAR(100) NOT NULL,
+
+    PRIMARY KEY (article_id, tag_id),
+    KEY idx_article_tags_tag_id (tag_id),
+    CONSTRAINT fk_article_tags_article
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE,
+    CONSTRAINT fk_article_tags_tag
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+) ENGINE = InnoDB;
+
+-- Roles that manage categories manage tags as well.
+INSERT INTO role_permissions (role_id, permission)
+SELECT role_id, REPLACE(permission, 'categories:', 'tags:')
+FROM role_permissions
+WHERE permission IN ('categories:read', 'categories:write', 'categories:delete');
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS article_views (
+    article_id VARCHAR(100) NOT NULL,
+    view_date  DATE         NOT NULL,
+    views      BIGINT       NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (article_id, view_date),
+    KEY idx_article_views_view_date (view_date),
+    CONSTRAINT fk_article_views_article
+    FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE
+) ENGINE = InnoDB;
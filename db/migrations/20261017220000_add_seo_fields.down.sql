@@ -0,0 +1,12 @@
+ALTER TABLE `site_identity`
+    DROP COLUMN `og_image_variant`,
+    DROP COLUMN `og_images`,
+    DROP COLUMN `canonical_url`,
+    DROP COLUMN `meta_description`,
+    DROP COLUMN `meta_title`;
+
+ALTER TABLE `articles`
+    DROP COLUMN `og_image_variant`,
+    DROP COLUMN `canonical_url`,
+    DROP COLUMN `meta_description`,
+    DROP COLUMN `meta_title`;
@@ -0,0 +1,12 @@
+ALTER TABLE `articles`
+    ADD COLUMN `meta_title`       VARCHAR(70)  NULL AFTER `expires_at`,
+    ADD COLUMN `meta_description` VARCHAR(160) NULL AFTER `meta_title`,
+    ADD COLUMN `canonical_url`    VARCHAR(255) NULL AFTER `meta_description`,
+    ADD COLUMN `og_image_variant` VARCHAR(10)  NULL AFTER `canonical_url`;
+
+ALTER TABLE `site_identity`
+    ADD COLUMN `meta_title`       VARCHAR(70)  NULL AFTER `secondary_button_link`,
+    ADD COLUMN `meta_description` VARCHAR(160) NULL AFTER `meta_title`,
+    ADD COLUMN `canonical_url`    VARCHAR(255) NULL AFTER `meta_description`,
+    ADD COLUMN `og_images`        JSON         NULL AFTER `canonical_url`,
+    ADD COLUMN `og_image_variant` VARCHAR(10)  NULL AFTER `og_images`;
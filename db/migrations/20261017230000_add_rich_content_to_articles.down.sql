@@ -0,0 +1,7 @@
+ALTER TABLE `article_revisions` DROP COLUMN `content_format`;
+
+ALTER TABLE `articles`
+    DROP COLUMN `reading_time_minutes`,
+    DROP COLUMN `word_count`,
+    DROP COLUMN `content_html`,
+    DROP COLUMN `content_format`;
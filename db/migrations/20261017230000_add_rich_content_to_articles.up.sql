@@ -0,0 +1,8 @@
+ALTER TABLE `articles`
+    ADD COLUMN `content_format`       ENUM('html', 'markdown', 'blocks') NOT NULL DEFAULT 'html' AFTER `expires_at`,
+    ADD COLUMN `content_html`         LONGTEXT NULL AFTER `content_format`,
+    ADD COLUMN `word_count`           INT      NOT NULL DEFAULT 0 AFTER `content_html`,
+    ADD COLUMN `reading_time_minutes` INT      NOT NULL DEFAULT 0 AFTER `word_count`;
+
+ALTER TABLE `article_revisions`
+    ADD COLUMN `content_format` VARCHAR(10) NULL AFTER `content`;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS activity_exceptions;
+
+ALTER TABLE `activities`
+    DROP COLUMN `pawukon_rule`,
+    DROP COLUMN `recurrence_rule`;
@@ -0,0 +1,21 @@
+ALTER TABLE `activities`
+    ADD COLUMN `recurrence_rule` VARCHAR(255) NOT NULL DEFAULT '' AFTER `updated_at`,
+    ADD COLUMN `pawukon_rule`    VARCHAR(50)  NOT NULL DEFAULT '' AFTER `recurrence_rule`;
+
+CREATE TABLE IF NOT EXISTS activity_exceptions (
+    id              VARCHAR(100) NOT NULL PRIMARY KEY,
+    activity_id     VARCHAR(100) NOT NULL,
+    occurrence_date DATE         NOT NULL,
+    is_cancelled    BOOLEAN      NOT NULL DEFAULT FALSE,
+    title           VARCHAR(150) NULL,
+    description     TEXT         NULL,
+    time_info       VARCHAR(100) NULL,
+    location        VARCHAR(100) NULL,
+    event_date      DATETIME     NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY idx_activity_exceptions_occurrence (activity_id, occurrence_date),
+    CONSTRAINT fk_activity_exceptions_activity
+    FOREIGN KEY (activity_id) REFERENCES activities(id) ON DELETE CASCADE
+) ENGINE = InnoDB;
@@ -0,0 +1,5 @@
+DROP INDEX idx_articles_search ON articles;
+
+CREATE FULLTEXT INDEX idx_articles_search ON articles(title, excerpt, content);
+
+ALTER TABLE `articles` DROP COLUMN `content_text`;
@@ -0,0 +1,10 @@
+ALTER TABLE `articles`
+    ADD COLUMN `content_text` LONGTEXT NULL AFTER `content_html`;
+
+-- Saving an article stores its exact plain text; until then the tags are stripped from its HTML.
+UPDATE `articles`
+SET `content_text` = REGEXP_REPLACE(COALESCE(NULLIF(`content_html`, ''), `content`), '<[^>]*>', ' ');
+
+DROP INDEX idx_articles_search ON articles;
+
+CREATE FULLTEXT INDEX idx_articles_search ON articles(title, excerpt, content_text);
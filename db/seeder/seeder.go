@@ -3,11 +3,49 @@ package seeder
 import (
 	"log"
 	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"strings"
 
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+// SeedRoles creates the built-in editor and reviewer roles when they are missing.
+func SeedRoles(db *gorm.DB) {
+	var readOnly []string
+	for _, permission := range model.Permissions {
+		if strings.HasSuffix(permission, ":read") {
+			readOnly = append(readOnly, permission)
+		}
+	}
+
+	roles := []struct {
+		Name        string
+		Description string
+		Permissions []string
+	}{
+		{entity.RoleEditor, "Full content management of an entity", model.Permissions},
+		{entity.RoleReviewer, "Read-only access to an entity", readOnly},
+	}
+
+	for _, r := range roles {
+		var count int64
+		db.Model(&entity.Role{}).Where("name = ?", r.Name).Count(&count)
+		if count > 0 {
+			continue
+		}
+
+		role := entity.Role{Name: r.Name, Description: r.Description, IsSystem: true}
+		for _, permission := range r.Permissions {
+			role.Permissions = append(role.Permissions, entity.RolePermission{Permission: permission})
+		}
+		if err := db.Create(&role).Error; err != nil {
+			log.Fatalf("Seeder error: %v", err)
+		}
+	}
+	log.Println("Seeder: roles seeded successfully!")
+}
+
 func SeedUsers(db *gorm.DB) {
 	var count int64
 	db.Model(&entity.User{}).Count(&count)
@@ -54,5 +92,20 @@ func SeedUsers(db *gorm.DB) {
 	if err := db.Create(&users).Error; err != nil {
 		log.Fatalf("Seeder error: %v", err)
 	}
+
+	var editor entity.Role
+	if err := db.Where("name = ?", entity.RoleEditor).Take(&editor).Error; err != nil {
+		log.Fatalf("Seeder error: editor role missing, run SeedRoles first: %v", err)
+	}
+
+	for _, user := range users {
+		if user.Role == "super" {
+			continue
+		}
+		assignment := entity.UserRoleAssignment{UserID: user.ID, RoleID: editor.ID, EntityType: user.Role}
+		if err := db.Create(&assignment).Error; err != nil {
+			log.Fatalf("Seeder error: %v", err)
+		}
+	}
 	log.Println("Seeder: users table seeded successfully!")
 }
@@ -0,0 +1,97 @@
+package calendar
+
+import "time"
+
+// pawukonHolyDays are the holy days that return with every Pawukon cycle.
+var pawukonHolyDays = map[PawukonDay][]string{}
+
+func init() {
+	for spec, name := range map[string]string{
+		"Redite Paing Sinta":          "Banyu Pinaruh",
+		"Soma Pon Sinta":              "Soma Ribek",
+		"Anggara Wage Sinta":          "Sabuh Mas",
+		"Buda Kliwon Sinta":           "Pagerwesi",
+		"Saniscara Kliwon Landep":     "Tumpek Landep",
+		"Saniscara Kliwon Wariga":     "Tumpek Wariga",
+		"Wraspati Wage Sungsang":      "Sugihan Jawa",
+		"Sukra Kliwon Sungsang":       "Sugihan Bali",
+		"Redite Paing Dungulan":       "Penyekeban",
+		"Soma Pon Dungulan":           "Penyajaan",
+		"Anggara Wage Dungulan":       "Penampahan Galungan",
+		"Buda Kliwon Dungulan":        "Galungan",
+		"Wraspati Umanis Dungulan":    "Umanis Galungan",
+		"Saniscara Pon Dungulan":      "Pemaridan Guru",
+		"Redite Wage Kuningan":        "Ulihan",
+		"Soma Kliwon Kuningan":        "Pemacekan Agung",
+		"Sukra Wage Kuningan":         "Penampahan Kuningan",
+		"Saniscara Kliwon Kuningan":   "Kuningan",
+		"Buda Kliwon Pahang":          "Pegat Wakan",
+		"Saniscara Kliwon Krulut":     "Tumpek Krulut",
+		"Saniscara Kliwon Uye":        "Tumpek Kandang",
+		"Saniscara Kliwon Wayang":     "Tumpek Wayang",
+		"Buda Wage Kelawu":            "Buda Cemeng Kelawu",
+		"Saniscara Umanis Watugunung": "Saraswati",
+	} {
+		day := mustParsePawukonDay(spec)
+		pawukonHolyDays[day] = append(pawukonHolyDays[day], name)
+	}
+}
+
+// Day is one date in the Balinese calendar.
+type Day struct {
+	Date     time.Time
+	Pawukon  PawukonDay
+	Lunar    Lunar
+	HolyDays []string
+}
+
+// Compute places a date in the Balinese calendar. Only the date is used, in the time's location.
+func Compute(date time.Time) Day {
+	date = civilDate(date)
+	pawukon := PawukonOf(date)
+	lunar := LunarOf(date)
+
+	holyDays := append([]string{}, pawukonHolyDays[pawukon]...)
+	if pawukon.Triwara() == 2 && pawukon.Pancawara() == 4 {
+		holyDays = append(holyDays, "Kajeng Kliwon")
+	}
+	if pawukon.Saptawara() == 2 && pawukon.Pancawara() == 4 {
+		holyDays = append(holyDays, "Anggara Kasih")
+	}
+
+	switch {
+	case lunar.Purnama:
+		holyDays = append(holyDays, "Purnama "+lunar.Sasih)
+	case lunar.Tilem:
+		holyDays = append(holyDays, "Tilem "+lunar.Sasih)
+	}
+	if lunar.Tilem && lunar.Sasih == "Kasanga" {
+		holyDays = append(holyDays, "Pengerupukan")
+	}
+	if yesterday := LunarOf(date.AddDate(0, 0, -1)); yesterday.Tilem && yesterday.Sasih == "Kasanga" {
+		holyDays = append(holyDays, "Nyepi")
+	}
+	if tomorrow := LunarOf(date.AddDate(0, 0, 1)); tomorrow.Tilem && tomorrow.Sasih == "Kapitu" {
+		holyDays = append(holyDays, "Siwaratri")
+	}
+
+	return Day{Date: date, Pawukon: pawukon, Lunar: lunar, HolyDays: holyDays}
+}
+
+// Range computes every date from from to to, both included.
+func Range(from time.Time, to time.Time) []Day {
+	from, to = civilDate(from), civilDate(to)
+	var days []Day
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		days = append(days, Compute(d))
+	}
+	return days
+}
+
+func mustParsePawukonDay(spec string) PawukonDay {
+	day, err := ParsePawukonDay(spec)
+	if err != nil {
+		panic(err)
+	}
+	return day
+}
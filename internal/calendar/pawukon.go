@@ -0,0 +1,115 @@
+// Package calendar computes the Balinese calendar: the 210-day Pawukon cycle and the lunar Sasih with
+// its Purnama and Tilem, and the holy days that follow from them.
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Location is the time of Bali (WITA, Asia/Makassar), by which Balinese dates change.
+var Location = time.FixedZone("WITA", 8*60*60)
+
+// Today returns the current date in Bali.
+func Today() time.Time {
+	return civilDate(time.Now().In(Location))
+}
+
+// PawukonLength is the number of days in one Pawukon cycle: 30 wuku of 7 days.
+const PawukonLength = 210
+
+var Wuku = []string{
+	"Sinta", "Landep", "Ukir", "Kulantir", "Tolu", "Gumbreg", "Wariga", "Warigadean", "Julungwangi", "Sungsang",
+	"Dungulan", "Kuningan", "Langkir", "Medangsia", "Pujut", "Pahang", "Krulut", "Merakih", "Tambir", "Medangkungan",
+	"Matal", "Uye", "Menail", "Prangbakat", "Bala", "Ugu", "Wayang", "Kelawu", "Dukut", "Watugunung",
+}
+
+// Saptawara follows the Gregorian week: Redite is Sunday.
+var Saptawara = []string{"Redite", "Soma", "Anggara", "Buda", "Wraspati", "Sukra", "Saniscara"}
+
+var Pancawara = []string{"Umanis", "Paing", "Pon", "Wage", "Kliwon"}
+
+var Triwara = []string{"Pasah", "Beteng", "Kajeng"}
+
+// pawukonEpoch is a Redite Paing of wuku Sinta, the first day of a cycle.
+var pawukonEpoch = dayNumber(time.Date(2025, time.February, 9, 0, 0, 0, 0, time.UTC))
+
+// PawukonDay is a day of the Pawukon cycle, counted from 0 (Redite Sinta) to 209 (Saniscara Watugunung).
+type PawukonDay int
+
+func PawukonOf(date time.Time) PawukonDay {
+	return PawukonDay(floorMod(dayNumber(date)-pawukonEpoch, PawukonLength))
+}
+
+// Wuku returns the index of the day's wuku, from 0 (Sinta) to 29 (Watugunung).
+func (p PawukonDay) Wuku() int      { return int(p) / 7 }
+func (p PawukonDay) Saptawara() int { return int(p) % 7 }
+func (p PawukonDay) Pancawara() int { return (int(p) + 1) % 5 }
+func (p PawukonDay) Triwara() int   { return int(p) % 3 }
+
+// String names the day the way it is written in Bali, e.g. "Buda Kliwon Dungulan".
+func (p PawukonDay) String() string {
+	return Saptawara[p.Saptawara()] + " " + Pancawara[p.Pancawara()] + " " + Wuku[p.Wuku()]
+}
+
+// Next returns the first date on or after from that falls on the Pawukon day.
+func (p PawukonDay) Next(from time.Time) time.Time {
+	from = civilDate(from)
+	return from.AddDate(0, 0, floorMod(int(p)-int(PawukonOf(from)), PawukonLength))
+}
+
+// ParsePawukonDay reads a day written as saptawara, pancawara and wuku, e.g. "Buda Kliwon Dungulan",
+// in any case. Not every combination exists: pancawara and saptawara meet only once in 35 days.
+func ParsePawukonDay(spec string) (PawukonDay, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 3 {
+		return 0, fmt.Errorf("pawukon day %q must be written as saptawara, pancawara and wuku", spec)
+	}
+	sapta, panca, wuku := indexOf(Saptawara, fields[0]), indexOf(Pancawara, fields[1]), indexOf(Wuku, fields[2])
+	switch {
+	case sapta < 0:
+		return 0, fmt.Errorf("unknown saptawara %q", fields[0])
+	case panca < 0:
+		return 0, fmt.Errorf("unknown pancawara %q", fields[1])
+	case wuku < 0:
+		return 0, fmt.Errorf("unknown wuku %q", fields[2])
+	}
+
+	day := PawukonDay(wuku*7 + sapta)
+	if day.Pancawara() != panca {
+		return 0, fmt.Errorf("%s %s does not fall in wuku %s", Saptawara[sapta], Pancawara[panca], Wuku[wuku])
+	}
+	return day, nil
+}
+
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if strings.EqualFold(n, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// civilDate drops the time of day, keeping the date as written in the time's location.
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// dayNumber counts the days from 1970-01-01 to the date.
+func dayNumber(t time.Time) int {
+	return int(civilDate(t).Unix() / 86400)
+}
+
+func floorMod(a, b int) int {
+	return ((a % b) + b) % b
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,337 @@
+package calendar
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxPeriods bounds the expansion of a rule that never produces a date, such as the 30th of February.
+const maxPeriods = 100000
+
+// Recurrence lists the dates on which an event first held on start comes back.
+type Recurrence interface {
+	// Between returns the dates from from to to, both included. start itself is the first date.
+	Between(start time.Time, from time.Time, to time.Time) []time.Time
+}
+
+// Between returns the days every 210 days from the first Pawukon day on or after start.
+func (p PawukonDay) Between(start time.Time, from time.Time, to time.Time) []time.Time {
+	from, to = civilDate(from), civilDate(to)
+	d := p.Next(start)
+	if d.Before(from) {
+		cycles := (dayNumber(from) - dayNumber(d) + PawukonLength - 1) / PawukonLength
+		d = d.AddDate(0, 0, cycles*PawukonLength)
+	}
+
+	var dates []time.Time
+	for ; !d.After(to); d = d.AddDate(0, 0, PawukonLength) {
+		dates = append(dates, d)
+	}
+	return dates
+}
+
+// Occurs tells whether the event recurs on the date.
+func Occurs(r Recurrence, start time.Time, date time.Time) bool {
+	return len(r.Between(start, date, date)) > 0
+}
+
+// WeekdayNum is a BYDAY value: a weekday, and with a monthly or yearly rule optionally the nth of
+// them in the month, counted from the end when negative.
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// RRule is the part of an RFC 5545 recurrence rule that dated events need: FREQ, INTERVAL, COUNT,
+// UNTIL, BYDAY, BYMONTHDAY and BYMONTH. It works on dates; the time of day is not repeated.
+type RRule struct {
+	Freq       string
+	Interval   int
+	Count      int
+	Until      *time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// ParseRRule reads a rule such as "FREQ=WEEKLY;BYDAY=SA" or "RRULE:FREQ=MONTHLY;BYDAY=-1FR".
+func ParseRRule(s string) (*RRule, error) {
+	r := &RRule{Interval: 1}
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimSpace(s), "RRULE:"), ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid RRULE part %q", part)
+		}
+
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.Freq = strings.ToUpper(value)
+			if !slices.Contains([]string{"DAILY", "WEEKLY", "MONTHLY", "YEARLY"}, r.Freq) {
+				return nil, fmt.Errorf("unsupported FREQ %q", value)
+			}
+		case "INTERVAL":
+			r.Interval, err = parseRRuleInt(name, value, 1, 1000)
+		case "COUNT":
+			r.Count, err = parseRRuleInt(name, value, 1, 10000)
+		case "UNTIL":
+			until, parseErr := time.Parse("20060102", value[:min(len(value), 8)])
+			if parseErr != nil {
+				return nil, fmt.Errorf("invalid UNTIL %q", value)
+			}
+			r.Until = &until
+		case "BYDAY":
+			for _, v := range strings.Split(value, ",") {
+				day, ok := rruleWeekdays[strings.ToUpper(v[max(len(v)-2, 0):])]
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY %q", v)
+				}
+				n := 0
+				if ordinal := v[:len(v)-2]; ordinal != "" {
+					if n, err = strconv.Atoi(ordinal); err != nil || n == 0 || n < -5 || n > 5 {
+						return nil, fmt.Errorf("invalid BYDAY %q", v)
+					}
+				}
+				r.ByDay = append(r.ByDay, WeekdayNum{N: n, Day: day})
+			}
+		case "BYMONTHDAY":
+			for _, v := range strings.Split(value, ",") {
+				var n int
+				if n, err = parseRRuleInt(name, v, -31, 31); err == nil && n == 0 {
+					err = fmt.Errorf("invalid BYMONTHDAY %q", v)
+				}
+				if err != nil {
+					break
+				}
+				r.ByMonthDay = append(r.ByMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, v := range strings.Split(value, ",") {
+				var n int
+				if n, err = parseRRuleInt(name, v, 1, 12); err != nil {
+					break
+				}
+				r.ByMonth = append(r.ByMonth, time.Month(n))
+			}
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				return nil, fmt.Errorf("only WKST=MO is supported")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported RRULE part %q", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case r.Freq == "":
+		return nil, fmt.Errorf("RRULE needs a FREQ")
+	case r.Count > 0 && r.Until != nil:
+		return nil, fmt.Errorf("RRULE must not have both COUNT and UNTIL")
+	}
+	for _, d := range r.ByDay {
+		if d.N != 0 && r.Freq != "MONTHLY" && r.Freq != "YEARLY" {
+			return nil, fmt.Errorf("numbered BYDAY needs FREQ=MONTHLY or FREQ=YEARLY")
+		}
+	}
+	return r, nil
+}
+
+func parseRRuleInt(name string, value string, low int, high int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < low || n > high {
+		return 0, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return n, nil
+}
+
+func (r *RRule) Between(start time.Time, from time.Time, to time.Time) []time.Time {
+	start, from, end := civilDate(start), civilDate(from), civilDate(to)
+	if r.Until != nil && r.Until.Before(end) {
+		end = civilDate(*r.Until)
+	}
+
+	var dates []time.Time
+	count := 0
+	emit := func(d time.Time) bool {
+		count++
+		if d.After(end) || r.Count > 0 && count > r.Count {
+			return false
+		}
+		if !d.Before(from) {
+			dates = append(dates, d)
+		}
+		return true
+	}
+
+	if !emit(start) {
+		return dates
+	}
+	for period := 0; period < maxPeriods; period++ {
+		first, candidates := r.period(start, period)
+		if first.After(end) {
+			break
+		}
+		for _, d := range candidates {
+			if d.After(start) && !emit(d) {
+				return dates
+			}
+		}
+	}
+	return dates
+}
+
+// period returns the first day of the nth period after the one holding start, and the dates the
+// rule picks in it in order.
+func (r *RRule) period(start time.Time, n int) (time.Time, []time.Time) {
+	var first time.Time
+	var days []time.Time
+	switch r.Freq {
+	case "DAILY":
+		first = start.AddDate(0, 0, n*r.Interval)
+		days = []time.Time{first}
+	case "WEEKLY":
+		monday := start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+		first = monday.AddDate(0, 0, 7*n*r.Interval)
+		for i := 0; i < 7; i++ {
+			days = append(days, first.AddDate(0, 0, i))
+		}
+	case "MONTHLY":
+		first = time.Date(start.Year(), start.Month()+time.Month(n*r.Interval), 1, 0, 0, 0, 0, time.UTC)
+		days = r.monthDays(start, first)
+	case "YEARLY":
+		first = time.Date(start.Year()+n*r.Interval, time.January, 1, 0, 0, 0, 0, time.UTC)
+		// Without BYMONTH, BYMONTHDAY and BYDAY pick from the whole year, and a bare rule repeats the
+		// month of start.
+		months := r.ByMonth
+		switch {
+		case len(months) > 0:
+		case len(r.ByMonthDay) > 0:
+			for m := time.January; m <= time.December; m++ {
+				months = append(months, m)
+			}
+		case len(r.ByDay) > 0:
+			days = r.yearDays(first)
+		default:
+			months = []time.Month{start.Month()}
+		}
+		for _, m := range months {
+			days = append(days, r.monthDays(start, time.Date(first.Year(), m, 1, 0, 0, 0, 0, time.UTC))...)
+		}
+	}
+
+	picked := days[:0]
+	for _, d := range days {
+		if r.matches(start, d) {
+			picked = append(picked, d)
+		}
+	}
+	slices.SortFunc(picked, func(a, b time.Time) int { return a.Compare(b) })
+	return first, slices.CompactFunc(picked, func(a, b time.Time) bool { return a.Equal(b) })
+}
+
+// monthDays returns the days of a month a monthly or yearly rule picks: the BYMONTHDAY days, the
+// BYDAY weekdays, or else the day of the month of start.
+func (r *RRule) monthDays(start time.Time, month time.Time) []time.Time {
+	length := month.AddDate(0, 1, -1).Day()
+	var days []time.Time
+	add := func(day int) {
+		if day >= 1 && day <= length {
+			days = append(days, month.AddDate(0, 0, day-1))
+		}
+	}
+
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, n := range r.ByMonthDay {
+			if n < 0 {
+				n = length + n + 1
+			}
+			add(n)
+		}
+	case len(r.ByDay) > 0:
+		for _, wd := range r.ByDay {
+			firstDay := 1 + (int(wd.Day)-int(month.Weekday())+7)%7
+			switch {
+			case wd.N == 0:
+				for day := firstDay; day <= length; day += 7 {
+					add(day)
+				}
+			case wd.N > 0:
+				add(firstDay + 7*(wd.N-1))
+			default:
+				lastDay := firstDay + 7*((length-firstDay)/7)
+				add(lastDay + 7*(wd.N+1))
+			}
+		}
+	default:
+		add(start.Day())
+	}
+	return days
+}
+
+// yearDays returns the BYDAY weekdays of a yearly rule without BYMONTH, a numbered one being the nth
+// of them in the year.
+func (r *RRule) yearDays(year time.Time) []time.Time {
+	last := year.AddDate(1, 0, -1)
+	var days []time.Time
+	for _, wd := range r.ByDay {
+		firstDay := year.AddDate(0, 0, (int(wd.Day)-int(year.Weekday())+7)%7)
+		lastDay := last.AddDate(0, 0, -(int(last.Weekday())-int(wd.Day)+7)%7)
+		switch {
+		case wd.N == 0:
+			for d := firstDay; !d.After(last); d = d.AddDate(0, 0, 7) {
+				days = append(days, d)
+			}
+		case wd.N > 0:
+			days = append(days, firstDay.AddDate(0, 0, 7*(wd.N-1)))
+		default:
+			days = append(days, lastDay.AddDate(0, 0, 7*(wd.N+1)))
+		}
+	}
+	return days
+}
+
+// matches applies the filters the period did not already expand by.
+func (r *RRule) matches(start time.Time, d time.Time) bool {
+	if len(r.ByMonth) > 0 && !slices.Contains(r.ByMonth, d.Month()) {
+		return false
+	}
+	switch r.Freq {
+	case "DAILY":
+		if len(r.ByMonthDay) > 0 && !r.onMonthDay(d) {
+			return false
+		}
+	case "WEEKLY":
+		if len(r.ByDay) == 0 {
+			return d.Weekday() == start.Weekday()
+		}
+	case "MONTHLY", "YEARLY":
+		if len(r.ByMonthDay) == 0 || len(r.ByDay) == 0 {
+			return true
+		}
+	}
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(r.ByDay, func(wd WeekdayNum) bool { return wd.Day == d.Weekday() })
+}
+
+// onMonthDay tells whether d is one of the BYMONTHDAY days, negative ones counted back from the
+// end of its month as in monthDays.
+func (r *RRule) onMonthDay(d time.Time) bool {
+	length := time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return slices.ContainsFunc(r.ByMonthDay, func(n int) bool {
+		return n == d.Day() || n < 0 && length+n+1 == d.Day()
+	})
+}
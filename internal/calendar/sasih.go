@@ -0,0 +1,91 @@
+package calendar
+
+import "time"
+
+// The lunar calendar counts 30 lunar days (tithi) from one Tilem to the next: penanggal 1 to 15 while
+// waxing, ending on Purnama, and pangelong 1 to 15 while waning, ending on Tilem. Following the
+// pengalantaka, 64 lunar days pass in every 63 solar days, so once in 63 days a solar day spans two
+// lunar days (ngunaratri). The phase is fitted so that Nyepi falls on the day after Tilem Kasanga in
+// every year from 2016 to 2026.
+var lunarEpoch = dayNumber(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+const lunarPhase = 1556
+
+// sasihNames are the months of a common year, starting after Tilem Kasanga.
+var sasihNames = []string{
+	"Kadasa", "Desta", "Sada", "Kasa", "Karo", "Katiga", "Kapat", "Kalima", "Kanem", "Kapitu", "Kawolu", "Kasanga",
+}
+
+// leapSasih is the month inserted after Sada when 13 months pass between two Tilem Kasanga.
+const leapSasih = "Nampih Sada"
+
+// Lunar is the position of a day in the lunar calendar. Exactly one of Penanggal and Pangelong is set.
+type Lunar struct {
+	Sasih      string
+	Penanggal  int
+	Pangelong  int
+	Purnama    bool
+	Tilem      bool
+	Ngunaratri bool
+}
+
+func LunarOf(date time.Time) Lunar {
+	day := dayNumber(date)
+	first, last := tithi(day-1)+1, tithi(day)
+
+	// A day spanning two lunar days is named after the Purnama or Tilem among them.
+	t := last
+	for k := first; k <= last; k++ {
+		if floorMod(k, 15) == 14 {
+			t = k
+		}
+	}
+
+	month := floorDiv(t, 30)
+	l := Lunar{Sasih: sasihOf(month), Ngunaratri: last > first}
+	if n := t - month*30; n < 15 {
+		l.Penanggal = n + 1
+		l.Purnama = n == 14
+	} else {
+		l.Pangelong = n - 14
+		l.Tilem = n == 29
+	}
+	return l
+}
+
+// tithi numbers the lunar day current at the end of a solar day.
+func tithi(day int) int {
+	return floorDiv(64*(day-lunarEpoch)+lunarPhase, 63)
+}
+
+// tilemDay returns the solar day on which the lunar month ends.
+func tilemDay(month int) int {
+	k := month*30 + 29
+	return lunarEpoch - floorDiv(lunarPhase-63*k, 64)
+}
+
+// kasangaMonth returns the lunar month of Kasanga in a year: the one whose Tilem is the first on or
+// after 1 March, so that Nyepi falls in March.
+func kasangaMonth(year int) int {
+	march := dayNumber(time.Date(year, time.March, 1, 0, 0, 0, 0, time.UTC))
+	return floorDiv(tithi(march-1)-29, 30) + 1
+}
+
+func sasihOf(month int) string {
+	year := time.Unix(int64(tilemDay(month))*86400, 0).UTC().Year()
+	start, next := kasangaMonth(year), kasangaMonth(year+1)
+	if month <= start {
+		start, next = kasangaMonth(year-1), start
+	}
+
+	n := month - start
+	if next-start == 13 {
+		switch {
+		case n == 4:
+			return leapSasih
+		case n > 4:
+			n--
+		}
+	}
+	return sasihNames[n-1]
+}
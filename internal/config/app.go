@@ -1,9 +1,12 @@
 package config
 
 import (
+	"context"
+	"pura-agung-kertajaya-backend/internal/calendar"
 	"pura-agung-kertajaya-backend/internal/delivery/http"
 	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
 	"pura-agung-kertajaya-backend/internal/delivery/http/route"
+	"pura-agung-kertajaya-backend/internal/delivery/scheduler"
 	"pura-agung-kertajaya-backend/internal/repository"
 	"pura-agung-kertajaya-backend/internal/usecase"
 	"pura-agung-kertajaya-backend/internal/util"
@@ -35,11 +38,44 @@ func Bootstrap(cfg *BootstrapConfig) {
 
 	// Setup TokenUtil (JWT + Redis)
 	secretKey := cfg.Config.GetString("jwt.secret")
-	tokenUtil := util.NewTokenUtil(secretKey, redisClient.RDB)
+	accessTTL := cfg.Config.GetDuration("jwt.access_ttl")
+	refreshTTL := cfg.Config.GetDuration("jwt.refresh_ttl")
+	tokenUtil := util.NewTokenUtil(secretKey, redisClient.RDB, accessTTL, refreshTTL)
 
 	// Setup RecaptchaUtil
 	recaptchaUtil := util.NewRecaptchaUtil(cfg.Config)
 
+	// Setup TOTPUtil (two-factor authentication)
+	totpUtil := util.NewTOTPUtil(cfg.Config.GetString("totp.issuer"), redisClient.RDB)
+
+	// Setup LockoutUtil (failed login tracking per email)
+	lockoutUtil := util.NewLockoutUtil(redisClient.RDB)
+
+	// Setup ViewCounterUtil (article views buffered until flushed)
+	viewCounterUtil := util.NewViewCounterUtil(redisClient.RDB)
+
+	// Setup SiteURLUtil (links into the public sites)
+	siteURLUtil := util.NewSiteURLUtil(cfg.Config)
+
+	// Setup MailUtil (SMTP in production, .eml files in development)
+	mailUtil, err := util.NewMailUtil(cfg.Config)
+	if err != nil {
+		cfg.Log.WithError(err).Fatal("invalid mail configuration")
+	}
+
+	// Setup the odalan of every temple, written as a Pawukon day such as "Buda Kliwon Dungulan"
+	odalan := make(map[string]calendar.PawukonDay)
+	for entityType, spec := range cfg.Config.GetStringMapString("calendar.odalan") {
+		if spec == "" {
+			continue
+		}
+		day, err := calendar.ParsePawukonDay(spec)
+		if err != nil {
+			cfg.Log.WithError(err).Fatalf("invalid odalan of %s", entityType)
+		}
+		odalan[entityType] = day
+	}
+
 	r2Client, err := util.NewR2Client(cfg.Config)
 	if err != nil {
 		cfg.Log.WithError(err).Fatal("failed to initialize R2 client")
@@ -47,10 +83,38 @@ func Bootstrap(cfg *BootstrapConfig) {
 
 	// Setup repositories
 	userRepository := repository.NewUserRepository(cfg.Log)
+	userRecoveryCodeRepository := repository.NewUserRecoveryCodeRepository(cfg.Log)
+	loginAttemptRepository := repository.NewLoginAttemptRepository(cfg.Log)
+	roleRepository := repository.NewRoleRepository(cfg.Log)
+	userRoleAssignmentRepository := repository.NewUserRoleAssignmentRepository(cfg.Log)
+	apiKeyRepository := repository.NewAPIKeyRepository(cfg.Log)
+	auditLogRepository := repository.NewAuditLogRepository(cfg.Log)
 	storageRepository := repository.NewStorageRepository(r2Client, cfg.Config, cfg.Log)
+	searchRepository := repository.NewSearchRepository(cfg.DB, cfg.Log)
 
 	// Setup usecases
-	userUseCase := usecase.NewUserUseCase(cfg.DB, cfg.Validate, userRepository, tokenUtil, recaptchaUtil)
+	userUseCase := usecase.NewUserUseCase(
+		cfg.DB,
+		cfg.Validate,
+		userRepository,
+		userRecoveryCodeRepository,
+		loginAttemptRepository,
+		userRoleAssignmentRepository,
+		tokenUtil,
+		recaptchaUtil,
+		totpUtil,
+		lockoutUtil,
+		mailUtil,
+	)
+	permissionUseCase := usecase.NewPermissionUseCase(
+		cfg.DB,
+		cfg.Validate,
+		userRepository,
+		roleRepository,
+		userRoleAssignmentRepository,
+	)
+	apiKeyUseCase := usecase.NewAPIKeyUseCase(cfg.DB, cfg.Validate, apiKeyRepository)
+	auditLogUseCase := usecase.NewAuditLogUseCase(cfg.DB, cfg.Validate, auditLogRepository)
 	storageUseCase := usecase.NewStorageUsecase(storageRepository)
 	testimonialUseCase := usecase.NewTestimonialUsecase(cfg.DB, cfg.Validate)
 	heroSlideUseCase := usecase.NewHeroSlideUsecase(cfg.DB, cfg.Validate)
@@ -64,10 +128,27 @@ func Bootstrap(cfg *BootstrapConfig) {
 	remarkUseCase := usecase.NewRemarkUsecase(cfg.DB, cfg.Validate)
 	organizationDetailUsecase := usecase.NewOrganizationDetailUsecase(cfg.DB, cfg.Validate)
 	categoryUsecase := usecase.NewCategoryUsecase(cfg.DB, cfg.Validate)
-	articleUsecase := usecase.NewArticleUsecase(cfg.DB, cfg.Validate)
+	tagUsecase := usecase.NewTagUsecase(cfg.DB, cfg.Validate)
+	articleUsecase := usecase.NewArticleUsecase(cfg.DB, cfg.Validate, siteURLUtil)
+	articleViewUsecase := usecase.NewArticleViewUsecase(cfg.DB, viewCounterUtil)
+	searchUsecase := usecase.NewSearchUsecase(searchRepository, cfg.Validate)
+	feedUsecase := usecase.NewFeedUsecase(cfg.DB, articleUsecase, activityUseCase, siteIdentityUseCase, siteURLUtil)
+	sitemapUsecase := usecase.NewSitemapUsecase(cfg.DB, siteURLUtil)
+	seoUsecase := usecase.NewSEOUsecase(cfg.DB, articleUsecase, siteIdentityUseCase, siteURLUtil)
+	calendarUsecase := usecase.NewCalendarUsecase(cfg.Validate, odalan)
+
+	// Setup schedulers (scheduled publishing and expiry of articles, flushing article views)
+	articleScheduler := scheduler.NewArticleScheduler(articleUsecase, cfg.Log, cfg.Config.GetDuration("scheduler.article_interval"))
+	articleViewScheduler := scheduler.NewArticleViewScheduler(articleViewUsecase, cfg.Log, cfg.Config.GetDuration("scheduler.view_flush_interval"))
+	schedulerCtx, stopSchedulers := context.WithCancel(context.Background())
+	go articleScheduler.Run(schedulerCtx)
+	go articleViewScheduler.Run(schedulerCtx)
 
 	// Setup controllers
 	userController := http.NewUserController(userUseCase, cfg.Log, cfg.Config)
+	permissionController := http.NewPermissionController(permissionUseCase, cfg.Log)
+	apiKeyController := http.NewAPIKeyController(apiKeyUseCase, cfg.Log)
+	auditLogController := http.NewAuditLogController(auditLogUseCase, cfg.Log)
 	storageController := http.NewStorageController(storageUseCase, cfg.Log)
 	testimonialController := http.NewTestimonialController(testimonialUseCase, cfg.Log)
 	heroSlideController := http.NewHeroSlideController(heroSlideUseCase, cfg.Log)
@@ -81,12 +162,20 @@ func Bootstrap(cfg *BootstrapConfig) {
 	remarkcontroller := http.NewRemarkController(remarkUseCase, cfg.Log)
 	organizationDetailController := http.NewOrganizationDetailController(organizationDetailUsecase, cfg.Log)
 	categoryController := http.NewCategoryController(categoryUsecase, cfg.Log)
-	articleController := http.NewArticleController(articleUsecase, cfg.Log)
+	tagController := http.NewTagController(tagUsecase, cfg.Log)
+	articleController := http.NewArticleController(articleUsecase, articleViewUsecase, cfg.Log)
+	searchController := http.NewSearchController(searchUsecase, cfg.Log)
+	feedController := http.NewFeedController(feedUsecase, cfg.Log)
+	sitemapController := http.NewSitemapController(sitemapUsecase, cfg.Log)
+	seoController := http.NewSEOController(seoUsecase, cfg.Log)
+	calendarController := http.NewCalendarController(calendarUsecase, cfg.Log)
 
 	// Setup redis storage
 	storage := NewFiberRedisStorage(redisHost, redisPort, redisPass, rateLimiterDB, redisTLS)
 
 	cfg.App.Hooks().OnShutdown(func() error {
+		stopSchedulers()
+
 		cfg.Log.Info("Closing Redis connections...")
 		if err := storage.Close(); err != nil {
 			cfg.Log.WithError(err).Error("Failed to close Redis Storage")
@@ -98,8 +187,12 @@ func Bootstrap(cfg *BootstrapConfig) {
 	})
 
 	// Setup middleware
-	authMiddleware := middleware.AuthMiddleware(tokenUtil)
-	entityTypeMiddleware := middleware.EntityTypeMiddleware()
+	authMiddleware := middleware.AuthMiddleware(tokenUtil, apiKeyUseCase)
+	entityTypeMiddleware := middleware.EntityTypeMiddleware(permissionUseCase)
+	superAdminMiddleware := middleware.SuperAdminMiddleware()
+	userSessionMiddleware := middleware.UserSessionMiddleware()
+	auditMiddleware := middleware.AuditMiddleware()
+	articlePublishMiddleware := middleware.ArticlePublishMiddleware()
 
 	// Rate Limiter
 	publicRateLimiter := middleware.PublicRateLimiter(storage)
@@ -126,10 +219,24 @@ func Bootstrap(cfg *BootstrapConfig) {
 		RemarkController:             remarkcontroller,
 		OrganizationDetailController: organizationDetailController,
 		CategoryController:           categoryController,
+		TagController:                tagController,
 		ArticleController:            articleController,
-
-		AuthMiddleware:       authMiddleware,
-		EntityTypeMiddleware: entityTypeMiddleware,
+		PermissionController:         permissionController,
+		APIKeyController:             apiKeyController,
+		AuditLogController:           auditLogController,
+		SearchController:             searchController,
+		FeedController:               feedController,
+		SitemapController:            sitemapController,
+		SEOController:                seoController,
+		CalendarController:           calendarController,
+
+		AuthMiddleware:           authMiddleware,
+		EntityTypeMiddleware:     entityTypeMiddleware,
+		SuperAdminMiddleware:     superAdminMiddleware,
+		UserSessionMiddleware:    userSessionMiddleware,
+		AuditMiddleware:          auditMiddleware,
+		PermissionMiddleware:     middleware.PermissionMiddleware,
+		ArticlePublishMiddleware: articlePublishMiddleware,
 
 		PublicRateLimiter:   publicRateLimiter,
 		AuthRateLimiter:     authRateLimiter,
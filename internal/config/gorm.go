@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"pura-agung-kertajaya-backend/internal/repository"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -41,6 +42,10 @@ func NewDatabase(viper *viper.Viper, log *logrus.Logger) *gorm.DB {
 		log.Fatalf("failed to connect database: %v", err)
 	}
 
+	if err := repository.RegisterAuditCallbacks(db); err != nil {
+		log.Fatalf("failed to register audit callbacks: %v", err)
+	}
+
 	connection, err := db.DB()
 	if err != nil {
 		log.Fatalf("failed to connect database: %v", err)
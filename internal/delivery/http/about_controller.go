@@ -27,7 +27,7 @@ func (c *AboutController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
 	userRole := "unknown"
 
 	if user != nil {
-		userID = fmt.Sprintf("%d", user.ID)
+		userID = fmt.Sprintf("%v", user.ID)
 		userRole = user.Role
 	}
 
@@ -40,32 +40,45 @@ func (c *AboutController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
 }
 
 func (c *AboutController) GetAll(ctx *fiber.Ctx) error {
-	entityType := ctx.Query("entity_type")
-	data, err := c.UseCase.GetAll(entityType)
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	data, paging, err := c.UseCase.GetAll(entityType, newListRequest(ctx))
 	if err != nil {
 		c.getLogger(ctx).WithError(err).Error("failed to fetch about sections")
 		return err
 	}
-	return ctx.JSON(model.WebResponse[any]{Data: data})
+	return ctx.JSON(model.WebResponse[any]{Data: data, Paging: paging})
 }
 
 func (c *AboutController) GetAllPublic(ctx *fiber.Ctx) error {
 	entityType := ctx.Query("entity_type")
-	data, err := c.UseCase.GetPublic(entityType)
+	data, paging, err := c.UseCase.GetPublic(entityType, newListRequest(ctx))
 	if err != nil {
 		c.getLogger(ctx).WithError(err).Error("failed to fetch public about sections")
 		return err
 	}
-	return ctx.JSON(model.WebResponse[any]{Data: data})
+	return ctx.JSON(model.WebResponse[any]{Data: data, Paging: paging})
 }
 
 func (c *AboutController) GetByID(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	id := ctx.Params("id")
 	if id == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
 	}
 
-	data, err := c.UseCase.GetByID(id)
+	data, err := c.UseCase.GetByID(entityType, id)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
@@ -79,13 +92,20 @@ func (c *AboutController) GetByID(ctx *fiber.Ctx) error {
 }
 
 func (c *AboutController) Create(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals during create")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	var req model.AboutSectionRequest
 	if err := ctx.BodyParser(&req); err != nil {
 		c.getLogger(ctx).Warnf("invalid request body: %v", err)
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body"})
 	}
 
-	data, err := c.UseCase.Create(req)
+	data, err := c.UseCase.Create(ctx.UserContext(), entityType, req)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
@@ -101,6 +121,13 @@ func (c *AboutController) Create(ctx *fiber.Ctx) error {
 }
 
 func (c *AboutController) Update(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals during update")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	id := ctx.Params("id")
 	if id == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
@@ -111,7 +138,7 @@ func (c *AboutController) Update(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body"})
 	}
 
-	data, err := c.UseCase.Update(id, req)
+	data, err := c.UseCase.Update(ctx.UserContext(), entityType, id, req)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
@@ -130,12 +157,19 @@ func (c *AboutController) Update(ctx *fiber.Ctx) error {
 }
 
 func (c *AboutController) Delete(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals during delete")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	id := ctx.Params("id")
 	if id == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
 	}
 
-	if err := c.UseCase.Delete(id); err != nil {
+	if err := c.UseCase.Delete(ctx.UserContext(), entityType, id); err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
 			c.getLogger(ctx).WithField("about_id", id).Warn("attempted delete non-existent about section")
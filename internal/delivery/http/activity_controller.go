@@ -27,7 +27,7 @@ func (c *ActivityController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
 	userRole := "unknown"
 
 	if user != nil {
-		userID = fmt.Sprintf("%d", user.ID)
+		userID = fmt.Sprintf("%v", user.ID)
 		userRole = user.Role
 	}
 
@@ -47,31 +47,43 @@ func (c *ActivityController) GetAll(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
 	}
 
-	data, err := c.UseCase.GetAll(entityType)
+	data, paging, err := c.UseCase.GetAll(entityType, newListRequest(ctx))
 	if err != nil {
 		c.getLogger(ctx).WithError(err).Error("failed to fetch activities")
 		return err
 	}
-	return ctx.JSON(model.WebResponse[any]{Data: data})
+	return ctx.JSON(model.WebResponse[any]{Data: data, Paging: paging})
 }
 
 func (c *ActivityController) GetAllPublic(ctx *fiber.Ctx) error {
 	entityType := ctx.Query("entity_type")
-	data, err := c.UseCase.GetPublic(entityType)
+	window, err := newDateRange(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, paging, err := c.UseCase.GetPublic(entityType, newListRequest(ctx), window)
 	if err != nil {
 		c.getLogger(ctx).WithError(err).Error("failed to fetch public activities")
 		return err
 	}
-	return ctx.JSON(model.WebResponse[any]{Data: data})
+	return ctx.JSON(model.WebResponse[any]{Data: data, Paging: paging})
 }
 
 func (c *ActivityController) GetByID(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	id := ctx.Params("id")
 	if id == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
 	}
 
-	data, err := c.UseCase.GetByID(id)
+	data, err := c.UseCase.GetByID(entityType, id)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
@@ -99,7 +111,7 @@ func (c *ActivityController) Create(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body"})
 	}
 
-	data, err := c.UseCase.Create(entityType, req)
+	data, err := c.UseCase.Create(ctx.UserContext(), entityType, req)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
@@ -115,6 +127,13 @@ func (c *ActivityController) Create(ctx *fiber.Ctx) error {
 }
 
 func (c *ActivityController) Update(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals during update")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	id := ctx.Params("id")
 	if id == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
@@ -126,7 +145,7 @@ func (c *ActivityController) Update(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body"})
 	}
 
-	data, err := c.UseCase.Update(id, req)
+	data, err := c.UseCase.Update(ctx.UserContext(), entityType, id, req)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) {
@@ -151,12 +170,19 @@ func (c *ActivityController) Update(ctx *fiber.Ctx) error {
 }
 
 func (c *ActivityController) Delete(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals during delete")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	id := ctx.Params("id")
 	if id == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
 	}
 
-	if err := c.UseCase.Delete(id); err != nil {
+	if err := c.UseCase.Delete(ctx.UserContext(), entityType, id); err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
 			c.getLogger(ctx).WithField("activity_id", id).Warn("attempted delete non-existent activity")
@@ -169,3 +195,80 @@ func (c *ActivityController) Delete(ctx *fiber.Ctx) error {
 	c.getLogger(ctx).WithField("activity_id", id).Info("activity deleted successfully")
 	return ctx.JSON(model.WebResponse[string]{Data: "Activity deleted successfully"})
 }
+
+func (c *ActivityController) GetExceptions(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	id := ctx.Params("id")
+	data, err := c.UseCase.GetExceptions(entityType, id)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
+			c.getLogger(ctx).WithField("activity_id", id).Warn("activity not found")
+		} else {
+			c.getLogger(ctx).WithField("activity_id", id).WithError(err).Error("failed to get activity exceptions")
+		}
+		return err
+	}
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
+
+// SaveException cancels or overrides the occurrence of a recurring activity on the date parameter.
+func (c *ActivityController) SaveException(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals during exception save")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	id, date := ctx.Params("id"), ctx.Params("date")
+	var req model.ActivityExceptionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		c.getLogger(ctx).Warnf("invalid request body: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body"})
+	}
+
+	data, err := c.UseCase.SaveException(ctx.UserContext(), entityType, id, date, req)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
+			c.getLogger(ctx).WithFields(logrus.Fields{"activity_id": id, "date": date}).Warnf("failed to save activity exception: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithFields(logrus.Fields{"activity_id": id, "date": date}).WithError(err).Error("failed to save activity exception")
+		}
+		return err
+	}
+
+	c.getLogger(ctx).WithFields(logrus.Fields{"activity_id": id, "date": date}).Info("activity exception saved successfully")
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
+
+// DeleteException restores the occurrence on the date parameter to what the rule makes of it.
+func (c *ActivityController) DeleteException(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals during exception delete")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	id, date := ctx.Params("id"), ctx.Params("date")
+	if err := c.UseCase.DeleteException(ctx.UserContext(), entityType, id, date); err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
+			c.getLogger(ctx).WithFields(logrus.Fields{"activity_id": id, "date": date}).Warnf("failed to delete activity exception: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithFields(logrus.Fields{"activity_id": id, "date": date}).WithError(err).Error("failed to delete activity exception")
+		}
+		return err
+	}
+
+	c.getLogger(ctx).WithFields(logrus.Fields{"activity_id": id, "date": date}).Info("activity exception deleted successfully")
+	return ctx.JSON(model.WebResponse[string]{Data: "Activity exception deleted successfully"})
+}
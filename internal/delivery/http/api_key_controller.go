@@ -0,0 +1,147 @@
+package http
+
+import (
+	"errors"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type APIKeyController struct {
+	Log     *logrus.Logger
+	UseCase usecase.APIKeyUseCase
+}
+
+func NewAPIKeyController(useCase usecase.APIKeyUseCase, logger *logrus.Logger) *APIKeyController {
+	return &APIKeyController{
+		Log:     logger,
+		UseCase: useCase,
+	}
+}
+
+func (c *APIKeyController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
+	user := middleware.GetUser(ctx)
+
+	userID := "guest"
+	userRole := "unknown"
+
+	if user != nil {
+		userID = user.ID
+		userRole = user.Role
+	}
+
+	return c.Log.WithFields(logrus.Fields{
+		"user_id":   userID,
+		"user_role": userRole,
+		"ip":        ctx.IP(),
+		"req_id":    ctx.Get("X-Request-ID"),
+	})
+}
+
+func (c *APIKeyController) GetAll(ctx *fiber.Ctx) error {
+	response, err := c.UseCase.GetAll(ctx.UserContext())
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Error("Failed to get api keys")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.APIKeyResponse]{Data: response})
+}
+
+func (c *APIKeyController) GetByID(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	response, err := c.UseCase.GetByID(ctx.UserContext(), id)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
+			c.getLogger(ctx).WithField("api_key_id", id).Warn("API key not found")
+		} else {
+			c.getLogger(ctx).WithField("api_key_id", id).WithError(err).Error("Failed to get api key by id")
+		}
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.APIKeyResponse]{Data: response})
+}
+
+func (c *APIKeyController) Create(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth == nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(model.WebResponse[any]{Errors: fiber.ErrUnauthorized.Message})
+	}
+
+	req := new(model.CreateAPIKeyRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("Failed to parse request body: %+v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
+	}
+
+	response, err := c.UseCase.Create(ctx.UserContext(), auth.ID, req)
+	if err != nil {
+		c.getLogger(ctx).WithField("api_key_name", req.Name).WithError(err).Warn("Failed to create api key")
+		return err
+	}
+
+	c.getLogger(ctx).WithField("api_key_id", response.APIKey.ID).Info("API key created")
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse[*model.APIKeySecretResponse]{Data: response})
+}
+
+func (c *APIKeyController) Update(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	req := new(model.UpdateAPIKeyRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("Failed to parse request body: %+v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
+	}
+
+	response, err := c.UseCase.Update(ctx.UserContext(), id, req)
+	if err != nil {
+		c.getLogger(ctx).WithField("api_key_id", id).WithError(err).Warn("Failed to update api key")
+		return err
+	}
+
+	c.getLogger(ctx).WithField("api_key_id", id).Info("API key updated")
+	return ctx.JSON(model.WebResponse[*model.APIKeyResponse]{Data: response})
+}
+
+func (c *APIKeyController) Rotate(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	response, err := c.UseCase.Rotate(ctx.UserContext(), id)
+	if err != nil {
+		c.getLogger(ctx).WithField("api_key_id", id).WithError(err).Warn("Failed to rotate api key")
+		return err
+	}
+
+	c.getLogger(ctx).WithField("api_key_id", id).Info("API key rotated")
+	return ctx.JSON(model.WebResponse[*model.APIKeySecretResponse]{Data: response})
+}
+
+func (c *APIKeyController) Revoke(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	if err := c.UseCase.Revoke(ctx.UserContext(), id); err != nil {
+		c.getLogger(ctx).WithField("api_key_id", id).WithError(err).Warn("Failed to revoke api key")
+		return err
+	}
+
+	c.getLogger(ctx).WithField("api_key_id", id).Info("API key revoked")
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
@@ -6,19 +6,19 @@ import (
 	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
 	"pura-agung-kertajaya-backend/internal/model"
 	"pura-agung-kertajaya-backend/internal/usecase"
-	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 )
 
 type ArticleController struct {
-	UseCase usecase.ArticleUsecase
-	Log     *logrus.Logger
+	UseCase     usecase.ArticleUsecase
+	ViewUseCase usecase.ArticleViewUsecase
+	Log         *logrus.Logger
 }
 
-func NewArticleController(usecase usecase.ArticleUsecase, log *logrus.Logger) *ArticleController {
-	return &ArticleController{UseCase: usecase, Log: log}
+func NewArticleController(usecase usecase.ArticleUsecase, viewUseCase usecase.ArticleViewUsecase, log *logrus.Logger) *ArticleController {
+	return &ArticleController{UseCase: usecase, ViewUseCase: viewUseCase, Log: log}
 }
 
 func (c *ArticleController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
@@ -28,7 +28,7 @@ func (c *ArticleController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
 	userRole := "unknown"
 
 	if user != nil {
-		userID = fmt.Sprintf("%d", user.ID)
+		userID = fmt.Sprintf("%v", user.ID)
 		userRole = user.Role
 	}
 
@@ -41,15 +41,17 @@ func (c *ArticleController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
 }
 
 func (c *ArticleController) GetPublic(ctx *fiber.Ctx) error {
-	limitQuery := ctx.Query("limit", "0")
-	limit, _ := strconv.Atoi(limitQuery)
+	req := newListRequest(ctx)
+	if req.Size == 0 {
+		req.Size = ctx.QueryInt("limit", 0)
+	}
 
-	data, err := c.UseCase.GetPublic(limit)
+	data, paging, err := c.UseCase.GetPublic(ctx.Query("entity_type", "pura"), req)
 	if err != nil {
 		c.getLogger(ctx).WithError(err).Error("failed to fetch public articles")
 		return err
 	}
-	return ctx.JSON(model.WebResponse[any]{Data: data})
+	return ctx.JSON(model.WebResponse[any]{Data: data, Paging: paging})
 }
 
 func (c *ArticleController) GetBySlug(ctx *fiber.Ctx) error {
@@ -58,7 +60,7 @@ func (c *ArticleController) GetBySlug(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid Slug"})
 	}
 
-	data, err := c.UseCase.GetBySlug(slug)
+	data, err := c.UseCase.GetBySlug(ctx.Query("entity_type", "pura"), slug)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
@@ -68,27 +70,78 @@ func (c *ArticleController) GetBySlug(ctx *fiber.Ctx) error {
 		}
 		return err
 	}
+
+	// A view that cannot be counted must not keep the article from being served.
+	if err := c.ViewUseCase.RecordView(ctx.UserContext(), data.ID, ctx.IP(), ctx.Get(fiber.HeaderUserAgent)); err != nil {
+		c.getLogger(ctx).WithField("article_id", data.ID).WithError(err).Warn("failed to record article view")
+	}
+
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
+
+func (c *ArticleController) GetPopular(ctx *fiber.Ctx) error {
+	data, err := c.ViewUseCase.GetPopular(ctx.Query("entity_type", "pura"), ctx.QueryInt("days", 30), ctx.QueryInt("limit", 5))
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
+			c.getLogger(ctx).Warnf("failed to get popular articles: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithError(err).Error("failed to get popular articles")
+		}
+		return err
+	}
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
+
+func (c *ArticleController) GetRelated(ctx *fiber.Ctx) error {
+	slug := ctx.Params("slug")
+	if slug == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid Slug"})
+	}
+
+	data, err := c.UseCase.GetRelated(ctx.Query("entity_type", "pura"), slug, ctx.QueryInt("limit", 4))
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
+			c.getLogger(ctx).WithField("slug", slug).Warnf("failed to get related articles: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithField("slug", slug).WithError(err).Error("failed to get related articles")
+		}
+		return err
+	}
 	return ctx.JSON(model.WebResponse[any]{Data: data})
 }
 
 func (c *ArticleController) GetAll(ctx *fiber.Ctx) error {
-	filter := ctx.Query("search")
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
 
-	data, err := c.UseCase.GetAll(filter)
+	data, paging, err := c.UseCase.GetAll(entityType, newListRequest(ctx))
 	if err != nil {
 		c.getLogger(ctx).WithError(err).Error("failed to fetch articles")
 		return err
 	}
-	return ctx.JSON(model.WebResponse[any]{Data: data})
+	return ctx.JSON(model.WebResponse[any]{Data: data, Paging: paging})
 }
 
 func (c *ArticleController) GetByID(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	id := ctx.Params("id")
 	if id == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
 	}
 
-	data, err := c.UseCase.GetByID(id)
+	data, err := c.UseCase.GetByID(entityType, id)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
@@ -102,13 +155,20 @@ func (c *ArticleController) GetByID(ctx *fiber.Ctx) error {
 }
 
 func (c *ArticleController) Create(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	var req model.CreateArticleRequest
 	if err := ctx.BodyParser(&req); err != nil {
 		c.getLogger(ctx).Warnf("invalid request body: %v", err)
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body"})
 	}
 
-	data, err := c.UseCase.Create(req)
+	data, err := c.UseCase.Create(ctx.UserContext(), entityType, req)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
@@ -123,6 +183,13 @@ func (c *ArticleController) Create(ctx *fiber.Ctx) error {
 }
 
 func (c *ArticleController) Update(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	id := ctx.Params("id")
 	if id == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
@@ -134,7 +201,7 @@ func (c *ArticleController) Update(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body"})
 	}
 
-	data, err := c.UseCase.Update(id, req)
+	data, err := c.UseCase.Update(ctx.UserContext(), entityType, id, req)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
@@ -153,12 +220,19 @@ func (c *ArticleController) Update(ctx *fiber.Ctx) error {
 }
 
 func (c *ArticleController) Delete(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	id := ctx.Params("id")
 	if id == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
 	}
 
-	if err := c.UseCase.Delete(id); err != nil {
+	if err := c.UseCase.Delete(ctx.UserContext(), entityType, id); err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
 			c.getLogger(ctx).WithField("article_id", id).Warn("attempted delete non-existent article")
@@ -170,3 +244,241 @@ func (c *ArticleController) Delete(ctx *fiber.Ctx) error {
 	c.getLogger(ctx).WithField("article_id", id).Info("article deleted successfully")
 	return ctx.JSON(model.WebResponse[string]{Data: "Article deleted successfully"})
 }
+
+func (c *ArticleController) GetStats(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	data, err := c.ViewUseCase.GetStats(ctx.UserContext(), entityType, id, ctx.QueryInt("days", 30))
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
+			c.getLogger(ctx).WithField("article_id", id).Warnf("failed to get article stats: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithField("article_id", id).WithError(err).Error("failed to get article stats")
+		}
+		return err
+	}
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
+
+func (c *ArticleController) GetRevisions(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	data, err := c.UseCase.GetRevisions(ctx.UserContext(), entityType, id)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
+			c.getLogger(ctx).WithField("article_id", id).Warn("article not found")
+		} else {
+			c.getLogger(ctx).WithField("article_id", id).WithError(err).Error("failed to get article revisions")
+		}
+		return err
+	}
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
+
+func (c *ArticleController) GetRevision(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	id := ctx.Params("id")
+	version, err := ctx.ParamsInt("version")
+	if id == "" || err != nil || version < 1 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID or version"})
+	}
+
+	data, err := c.UseCase.GetRevision(ctx.UserContext(), entityType, id, version)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
+			c.getLogger(ctx).WithFields(logrus.Fields{"article_id": id, "version": version}).Warn("article revision not found")
+		} else {
+			c.getLogger(ctx).WithFields(logrus.Fields{"article_id": id, "version": version}).WithError(err).Error("failed to get article revision")
+		}
+		return err
+	}
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
+
+func (c *ArticleController) DiffRevisions(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	from := ctx.QueryInt("from", 0)
+	to := ctx.QueryInt("to", 0)
+
+	data, err := c.UseCase.DiffRevisions(ctx.UserContext(), entityType, id, from, to)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
+			c.getLogger(ctx).WithFields(logrus.Fields{"article_id": id, "from": from, "to": to}).Warnf("failed to diff article revisions: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithFields(logrus.Fields{"article_id": id, "from": from, "to": to}).WithError(err).Error("failed to diff article revisions")
+		}
+		return err
+	}
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
+
+func (c *ArticleController) RestoreRevision(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	id := ctx.Params("id")
+	version, err := ctx.ParamsInt("version")
+	if id == "" || err != nil || version < 1 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID or version"})
+	}
+
+	user := middleware.GetUser(ctx)
+	canPublish := user != nil && user.Can(entityType, model.PermissionArticlesPublish)
+
+	data, err := c.UseCase.RestoreRevision(ctx.UserContext(), entityType, id, version, canPublish)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
+			c.getLogger(ctx).WithFields(logrus.Fields{"article_id": id, "version": version}).Warn("attempted restore of non-existent article revision")
+		} else if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
+			c.getLogger(ctx).WithFields(logrus.Fields{"article_id": id, "version": version}).Warnf("failed to restore article revision: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithFields(logrus.Fields{"article_id": id, "version": version}).WithError(err).Error("failed to restore article revision")
+		}
+		return err
+	}
+	c.getLogger(ctx).WithFields(logrus.Fields{"article_id": id, "version": version}).Info("article revision restored successfully")
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
+
+func (c *ArticleController) GetReviewQueue(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	data, err := c.UseCase.GetReviewQueue(ctx.UserContext(), entityType)
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Error("failed to fetch article review queue")
+		return err
+	}
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
+
+func (c *ArticleController) SubmitForReview(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	data, err := c.UseCase.SubmitForReview(ctx.UserContext(), entityType, id)
+	if err != nil {
+		c.logReviewError(ctx, id, "submit", err)
+		return err
+	}
+	c.getLogger(ctx).WithField("article_id", id).Info("article submitted for review")
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
+
+func (c *ArticleController) ApproveReview(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	data, err := c.UseCase.ApproveReview(ctx.UserContext(), entityType, id)
+	if err != nil {
+		c.logReviewError(ctx, id, "approve", err)
+		return err
+	}
+	c.getLogger(ctx).WithFields(logrus.Fields{"article_id": id, "status": data.Status}).Info("article review approved")
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
+
+func (c *ArticleController) RejectReview(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	req := new(model.RejectArticleRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("invalid request body: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body"})
+	}
+
+	data, err := c.UseCase.RejectReview(ctx.UserContext(), entityType, id, req)
+	if err != nil {
+		c.logReviewError(ctx, id, "reject", err)
+		return err
+	}
+	c.getLogger(ctx).WithField("article_id", id).Info("article review rejected")
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
+
+func (c *ArticleController) logReviewError(ctx *fiber.Ctx, id string, action string, err error) {
+	var e *model.ResponseError
+	if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
+		c.getLogger(ctx).WithField("article_id", id).Warnf("failed to %s article review: %s", action, e.Message)
+	} else {
+		c.getLogger(ctx).WithField("article_id", id).WithError(err).Errorf("failed to %s article review", action)
+	}
+}
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"math"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type AuditLogController struct {
+	Log     *logrus.Logger
+	UseCase usecase.AuditLogUseCase
+}
+
+func NewAuditLogController(useCase usecase.AuditLogUseCase, logger *logrus.Logger) *AuditLogController {
+	return &AuditLogController{
+		Log:     logger,
+		UseCase: useCase,
+	}
+}
+
+func (c *AuditLogController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
+	user := middleware.GetUser(ctx)
+
+	userID := "guest"
+	userRole := "unknown"
+
+	if user != nil {
+		userID = user.ID
+		userRole = user.Role
+	}
+
+	return c.Log.WithFields(logrus.Fields{
+		"user_id":   userID,
+		"user_role": userRole,
+		"ip":        ctx.IP(),
+		"req_id":    ctx.Get("X-Request-ID"),
+	})
+}
+
+func (c *AuditLogController) Search(ctx *fiber.Ctx) error {
+	req := &model.SearchAuditLogRequest{
+		ActorID:    ctx.Query("actor_id"),
+		Resource:   ctx.Query("resource"),
+		RecordID:   ctx.Query("record_id"),
+		EntityType: ctx.Query("entity_type"),
+		Action:     ctx.Query("action"),
+		Page:       ctx.QueryInt("page", 1),
+		Size:       ctx.QueryInt("size", 20),
+	}
+
+	var err error
+	if req.From, err = parseTimeQuery(ctx, "from", false); err != nil {
+		return err
+	}
+	if req.To, err = parseTimeQuery(ctx, "to", true); err != nil {
+		return err
+	}
+
+	response, total, err := c.UseCase.Search(ctx.UserContext(), req)
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Warn("Failed to search audit logs")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.AuditLogResponse]{
+		Data: response,
+		Paging: &model.PageMetadata{
+			Page:      req.Page,
+			Size:      req.Size,
+			TotalItem: total,
+			TotalPage: int64(math.Ceil(float64(total) / float64(req.Size))),
+		},
+	})
+}
+
+// parseTimeQuery accepts an RFC 3339 timestamp or a plain date. A plain date used as the end of a
+// range covers the whole day.
+func parseTimeQuery(ctx *fiber.Ctx, key string, endOfDay bool) (*time.Time, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return nil, model.ErrBadRequest(key + " must be a date (YYYY-MM-DD) or an RFC 3339 timestamp")
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
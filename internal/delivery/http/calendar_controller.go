@@ -0,0 +1,65 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const calendarCacheControl = "public, max-age=3600"
+
+type CalendarController struct {
+	UseCase usecase.CalendarUsecase
+	Log     *logrus.Logger
+}
+
+func NewCalendarController(usecase usecase.CalendarUsecase, log *logrus.Logger) *CalendarController {
+	return &CalendarController{UseCase: usecase, Log: log}
+}
+
+func (c *CalendarController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
+	user := middleware.GetUser(ctx)
+
+	userID := "guest"
+	userRole := "unknown"
+
+	if user != nil {
+		userID = fmt.Sprintf("%v", user.ID)
+		userRole = user.Role
+	}
+
+	return c.Log.WithFields(logrus.Fields{
+		"user_id":   userID,
+		"user_role": userRole,
+		"ip":        ctx.IP(),
+		"req_id":    ctx.Get("X-Request-ID"),
+	})
+}
+
+// GetRange returns the Balinese calendar between the from and to query parameters.
+func (c *CalendarController) GetRange(ctx *fiber.Ctx) error {
+	req := &model.PublicCalendarRequest{
+		EntityType: ctx.Query("entity_type", "pura"),
+		From:       ctx.Query("from"),
+		To:         ctx.Query("to"),
+	}
+
+	data, err := c.UseCase.GetRange(req)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
+			c.getLogger(ctx).Warnf("failed to compute calendar: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithError(err).Error("failed to compute calendar")
+		}
+		return err
+	}
+
+	ctx.Set(fiber.HeaderCacheControl, calendarCacheControl)
+	return ctx.JSON(model.WebResponse[[]model.CalendarDayResponse]{Data: data})
+}
@@ -27,7 +27,7 @@ func (c *CategoryController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
 	userRole := "unknown"
 
 	if user != nil {
-		userID = fmt.Sprintf("%d", user.ID)
+		userID = fmt.Sprintf("%v", user.ID)
 		userRole = user.Role
 	}
 
@@ -40,30 +40,44 @@ func (c *CategoryController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
 }
 
 func (c *CategoryController) GetAll(ctx *fiber.Ctx) error {
-	data, err := c.UseCase.GetAll()
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	data, paging, err := c.UseCase.GetAll(entityType, newListRequest(ctx))
 	if err != nil {
 		c.getLogger(ctx).WithError(err).Error("failed to fetch categories")
 		return err
 	}
-	return ctx.JSON(model.WebResponse[any]{Data: data})
+	return ctx.JSON(model.WebResponse[any]{Data: data, Paging: paging})
 }
 
 func (c *CategoryController) GetAllPublic(ctx *fiber.Ctx) error {
-	data, err := c.UseCase.GetAll()
+	data, paging, err := c.UseCase.GetAll(ctx.Query("entity_type", "pura"), newListRequest(ctx))
 	if err != nil {
 		c.getLogger(ctx).WithError(err).Error("failed to fetch public categories")
 		return err
 	}
-	return ctx.JSON(model.WebResponse[any]{Data: data})
+	return ctx.JSON(model.WebResponse[any]{Data: data, Paging: paging})
 }
 
 func (c *CategoryController) GetByID(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	id := ctx.Params("id")
 	if id == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
 	}
 
-	data, err := c.UseCase.GetByID(id)
+	data, err := c.UseCase.GetByID(entityType, id)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
@@ -77,13 +91,20 @@ func (c *CategoryController) GetByID(ctx *fiber.Ctx) error {
 }
 
 func (c *CategoryController) Create(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	var req model.CreateCategoryRequest
 	if err := ctx.BodyParser(&req); err != nil {
 		c.getLogger(ctx).Warnf("invalid request body: %v", err)
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body"})
 	}
 
-	data, err := c.UseCase.Create(req)
+	data, err := c.UseCase.Create(ctx.UserContext(), entityType, req)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
@@ -99,6 +120,13 @@ func (c *CategoryController) Create(ctx *fiber.Ctx) error {
 }
 
 func (c *CategoryController) Update(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	id := ctx.Params("id")
 	if id == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
@@ -110,7 +138,7 @@ func (c *CategoryController) Update(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body"})
 	}
 
-	data, err := c.UseCase.Update(id, req)
+	data, err := c.UseCase.Update(ctx.UserContext(), entityType, id, req)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
@@ -129,12 +157,19 @@ func (c *CategoryController) Update(ctx *fiber.Ctx) error {
 }
 
 func (c *CategoryController) Delete(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	id := ctx.Params("id")
 	if id == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
 	}
 
-	if err := c.UseCase.Delete(id); err != nil {
+	if err := c.UseCase.Delete(ctx.UserContext(), entityType, id); err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) {
 			if e.Code == fiber.StatusNotFound {
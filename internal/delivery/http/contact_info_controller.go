@@ -27,7 +27,7 @@ func (c *ContactInfoController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
 	userRole := "unknown"
 
 	if user != nil {
-		userID = fmt.Sprintf("%d", user.ID)
+		userID = fmt.Sprintf("%v", user.ID)
 		userRole = user.Role
 	}
 
@@ -41,21 +41,28 @@ func (c *ContactInfoController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
 
 func (c *ContactInfoController) GetAll(ctx *fiber.Ctx) error {
 	entityType := ctx.Query("entity_type")
-	data, err := c.UseCase.GetAll(entityType)
+	data, paging, err := c.UseCase.GetAll(entityType, newListRequest(ctx))
 	if err != nil {
 		c.getLogger(ctx).WithError(err).Error("failed to fetch contact info")
 		return err
 	}
-	return ctx.JSON(model.WebResponse[any]{Data: data})
+	return ctx.JSON(model.WebResponse[any]{Data: data, Paging: paging})
 }
 
 func (c *ContactInfoController) GetByID(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	id := ctx.Params("id")
 	if id == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
 	}
 
-	data, err := c.UseCase.GetByID(id)
+	data, err := c.UseCase.GetByID(entityType, id)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
@@ -69,13 +76,20 @@ func (c *ContactInfoController) GetByID(ctx *fiber.Ctx) error {
 }
 
 func (c *ContactInfoController) Create(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals during create")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	var req model.CreateContactInfoRequest
 	if err := ctx.BodyParser(&req); err != nil {
 		c.getLogger(ctx).Warnf("invalid request body: %v", err)
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body"})
 	}
 
-	data, err := c.UseCase.Create(req)
+	data, err := c.UseCase.Create(ctx.UserContext(), entityType, req)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
@@ -91,6 +105,13 @@ func (c *ContactInfoController) Create(ctx *fiber.Ctx) error {
 }
 
 func (c *ContactInfoController) Update(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals during update")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	id := ctx.Params("id")
 	if id == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
@@ -102,7 +123,7 @@ func (c *ContactInfoController) Update(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body"})
 	}
 
-	data, err := c.UseCase.Update(id, req)
+	data, err := c.UseCase.Update(ctx.UserContext(), entityType, id, req)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
@@ -121,12 +142,19 @@ func (c *ContactInfoController) Update(ctx *fiber.Ctx) error {
 }
 
 func (c *ContactInfoController) Delete(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals during delete")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
 	id := ctx.Params("id")
 	if id == "" {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
 	}
 
-	if err := c.UseCase.Delete(id); err != nil {
+	if err := c.UseCase.Delete(ctx.UserContext(), entityType, id); err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
 			c.getLogger(ctx).WithField("contact_id", id).Warn("attempted delete non-existent contact info")
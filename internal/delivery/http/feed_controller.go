@@ -0,0 +1,136 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+	"pura-agung-kertajaya-backend/internal/util"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const feedCacheControl = "public, max-age=300"
+
+type FeedController struct {
+	UseCase usecase.FeedUsecase
+	Log     *logrus.Logger
+}
+
+func NewFeedController(usecase usecase.FeedUsecase, log *logrus.Logger) *FeedController {
+	return &FeedController{UseCase: usecase, Log: log}
+}
+
+func (c *FeedController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
+	user := middleware.GetUser(ctx)
+
+	userID := "guest"
+	userRole := "unknown"
+
+	if user != nil {
+		userID = fmt.Sprintf("%v", user.ID)
+		userRole = user.Role
+	}
+
+	return c.Log.WithFields(logrus.Fields{
+		"user_id":   userID,
+		"user_role": userRole,
+		"ip":        ctx.IP(),
+		"req_id":    ctx.Get("X-Request-ID"),
+	})
+}
+
+// GetArticles serves the article feed as rss, atom or json, depending on the format parameter.
+func (c *FeedController) GetArticles(ctx *fiber.Ctx) error {
+	var render func(*model.Feed, string) ([]byte, error)
+	var contentType string
+	switch ctx.Params("format") {
+	case "rss":
+		render, contentType = util.RenderRSS, util.ContentTypeRSS
+	case "atom":
+		render, contentType = util.RenderAtom, util.ContentTypeAtom
+	case "json":
+		render, contentType = util.RenderJSONFeed, util.ContentTypeJSONFeed
+	default:
+		return ctx.Status(fiber.StatusNotFound).JSON(model.WebResponse[any]{Errors: "Unknown feed format"})
+	}
+
+	feed, err := c.UseCase.GetArticleFeed(ctx.Query("entity_type", "pura"), ctx.Query("category"))
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
+			c.getLogger(ctx).Warnf("failed to get article feed: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithError(err).Error("failed to get article feed")
+		}
+		return err
+	}
+
+	body, err := render(feed, ctx.BaseURL()+ctx.OriginalURL())
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Error("failed to render article feed")
+		return err
+	}
+
+	return sendCacheable(ctx, contentType, body, feed.Updated, feedCacheControl)
+}
+
+// GetActivities serves the calendar of public activities as iCalendar, for calendar apps to subscribe to.
+func (c *FeedController) GetActivities(ctx *fiber.Ctx) error {
+	cal, err := c.UseCase.GetActivityCalendar(ctx.Query("entity_type", "pura"))
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
+			c.getLogger(ctx).Warnf("failed to get activity calendar: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithError(err).Error("failed to get activity calendar")
+		}
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentDisposition, `inline; filename="activities.ics"`)
+	return sendCacheable(ctx, util.ContentTypeICalendar, util.RenderICalendar(cal), cal.Updated, feedCacheControl)
+}
+
+// GetActivity serves one activity as an iCalendar file to download, optionally a single occurrence
+// of a recurring one given by the date parameter.
+func (c *FeedController) GetActivity(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	cal, err := c.UseCase.GetActivityEvent(ctx.Query("entity_type", "pura"), id, ctx.Query("date"))
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
+			c.getLogger(ctx).Warnf("failed to get activity event: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithError(err).Error("failed to get activity event")
+		}
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.ics"`, id))
+	return sendCacheable(ctx, util.ContentTypeICalendar, util.RenderICalendar(cal), cal.Updated, feedCacheControl)
+}
+
+// sendCacheable sends body with an ETag and Last-Modified, or 304 Not Modified when the client's
+// copy is still current.
+func sendCacheable(ctx *fiber.Ctx, contentType string, body []byte, lastModified time.Time, cacheControl string) error {
+	sum := sha256.Sum256(body)
+	ctx.Set(fiber.HeaderETag, `"`+hex.EncodeToString(sum[:16])+`"`)
+	if !lastModified.IsZero() {
+		ctx.Set(fiber.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+	}
+	ctx.Set(fiber.HeaderCacheControl, cacheControl)
+
+	if ctx.Fresh() {
+		return ctx.SendStatus(fiber.StatusNotModified)
+	}
+
+	ctx.Set(fiber.HeaderContentType, contentType)
+	return ctx.Send(body)
+}
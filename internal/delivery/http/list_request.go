@@ -0,0 +1,69 @@
+package http
+
+import (
+	"pura-agung-kertajaya-backend/internal/calendar"
+	"pura-agung-kertajaya-backend/internal/model"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// listParams are the query parameters that are not field filters.
+var listParams = map[string]bool{
+	"page":        true,
+	"size":        true,
+	"limit":       true,
+	"cursor":      true,
+	"sort":        true,
+	"search":      true,
+	"entity_type": true,
+	"from":        true,
+	"to":          true,
+}
+
+// newListRequest reads paging, sorting and filtering from the query string. Every other non-empty
+// parameter is passed on as a filter; the usecase only applies the ones it whitelists.
+func newListRequest(ctx *fiber.Ctx) *model.ListRequest {
+	req := &model.ListRequest{
+		Page:    ctx.QueryInt("page", 0),
+		Size:    ctx.QueryInt("size", 0),
+		Cursor:  ctx.Query("cursor"),
+		Sort:    ctx.Query("sort"),
+		Search:  ctx.Query("search"),
+		Filters: map[string]string{},
+	}
+
+	for key, value := range ctx.Queries() {
+		if !listParams[key] && value != "" {
+			req.Filters[key] = value
+		}
+	}
+	return req
+}
+
+// dateRangeDays is the length of the window of a date range that only gives where it starts.
+const dateRangeDays = 31
+
+// newDateRange reads the from and to dates of a window. It returns nil when neither is given; a
+// missing from is today and a missing to a month after from.
+func newDateRange(ctx *fiber.Ctx) (*model.DateRange, error) {
+	from, to := ctx.Query("from"), ctx.Query("to")
+	if from == "" && to == "" {
+		return nil, nil
+	}
+
+	window := &model.DateRange{From: calendar.Today()}
+	var err error
+	if from != "" {
+		if window.From, err = time.Parse("2006-01-02", from); err != nil {
+			return nil, model.ErrBadRequest("from must be a date in YYYY-MM-DD format")
+		}
+	}
+	window.To = window.From.AddDate(0, 0, dateRangeDays-1)
+	if to != "" {
+		if window.To, err = time.Parse("2006-01-02", to); err != nil {
+			return nil, model.ErrBadRequest("to must be a date in YYYY-MM-DD format")
+		}
+	}
+	return window, nil
+}
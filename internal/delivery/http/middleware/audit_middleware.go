@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/util"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// AuditMiddleware puts the authenticated actor into the request context, where the GORM audit
+// callback picks it up for every mutation made while serving the request. It runs after
+// EntityTypeMiddleware so the resolved entity is known.
+func AuditMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user := GetUser(c)
+		if user == nil {
+			return fiber.ErrUnauthorized
+		}
+
+		requestID := c.Get(fiber.HeaderXRequestID)
+		if requestID == "" {
+			requestID = uuid.New().String()
+			c.Request().Header.Set(fiber.HeaderXRequestID, requestID)
+		}
+		c.Set(fiber.HeaderXRequestID, requestID)
+
+		entityType, _ := c.Locals(CtxEntityType).(string)
+
+		c.SetUserContext(util.WithAuditActor(c.UserContext(), &model.AuditActor{
+			ID:         user.ID,
+			Role:       user.Role,
+			EntityType: entityType,
+			IP:         c.IP(),
+			RequestID:  requestID,
+		}))
+		return c.Next()
+	}
+}
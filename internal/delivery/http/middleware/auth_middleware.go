@@ -1,18 +1,61 @@
 package middleware
 
 import (
+	"errors"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
 	"pura-agung-kertajaya-backend/internal/util"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// RoleAPIKey is the role of requests authenticated with an API key instead of a user session.
+const RoleAPIKey = "api_key"
+
 type Auth struct {
-	ID   string
-	Role string
+	ID        string
+	Role      string
+	SessionID string
+	APIKeyID  string
+	Grants    model.PermissionGrants
 }
 
-func AuthMiddleware(tokenUtil *util.TokenUtil) fiber.Handler {
+// Can reports whether the user holds the permission on the entity. Super admins hold every permission.
+func (a *Auth) Can(entityType string, permission string) bool {
+	if a.Role == "super" {
+		return true
+	}
+	return a.Grants.Has(entityType, permission)
+}
+
+// AuthMiddleware accepts either the access_token cookie of a CMS session or an API key sent as
+// "Authorization: Bearer <key>". API keys carry their own grants and never act as a user.
+func AuthMiddleware(tokenUtil *util.TokenUtil, apiKeyUseCase usecase.APIKeyUseCase) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		if bearer, ok := bearerToken(c); ok {
+			if !util.IsAPIKey(bearer) {
+				return fiber.ErrUnauthorized
+			}
+
+			key, err := apiKeyUseCase.Authenticate(c.UserContext(), bearer)
+			if err != nil {
+				var e *model.ResponseError
+				if errors.As(err, &e) && e.Code == fiber.StatusUnauthorized {
+					return fiber.ErrUnauthorized
+				}
+				return err
+			}
+
+			c.Locals("user", &Auth{
+				ID:       key.ID,
+				Role:     RoleAPIKey,
+				APIKeyID: key.ID,
+				Grants:   key.Grants,
+			})
+			return c.Next()
+		}
+
 		token := c.Cookies("access_token")
 		if token == "" {
 			return fiber.ErrUnauthorized
@@ -24,13 +67,38 @@ func AuthMiddleware(tokenUtil *util.TokenUtil) fiber.Handler {
 		}
 
 		c.Locals("user", &Auth{
-			ID:   auth.ID,
-			Role: auth.Role,
+			ID:        auth.ID,
+			Role:      auth.Role,
+			SessionID: auth.FamilyID,
 		})
 		return c.Next()
 	}
 }
 
+// UserSessionMiddleware keeps API keys away from routes that only make sense for a logged in user.
+func UserSessionMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user := GetUser(c)
+		if user == nil {
+			return fiber.ErrUnauthorized
+		}
+		if user.APIKeyID != "" {
+			return fiber.ErrForbidden
+		}
+		return c.Next()
+	}
+}
+
+// bearerToken reports the token of a Bearer Authorization header. Any other scheme is left alone, so
+// that the request falls through to the session cookie.
+func bearerToken(c *fiber.Ctx) (string, bool) {
+	scheme, token, found := strings.Cut(c.Get(fiber.HeaderAuthorization), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
 func GetUser(c *fiber.Ctx) *Auth {
 	user, ok := c.Locals("user").(*Auth)
 	if !ok {
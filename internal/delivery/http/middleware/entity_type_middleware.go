@@ -1,6 +1,11 @@
 package middleware
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
 
 const CtxEntityType = "entity_type"
 
@@ -8,7 +13,10 @@ type entityTypeBody struct {
 	EntityType string `json:"entity_type"`
 }
 
-func EntityTypeMiddleware() fiber.Handler {
+// EntityTypeMiddleware loads the user's permission grants and resolves the entity the request works on.
+// The requested entity_type is kept even when the user holds nothing on it, so PermissionMiddleware
+// can refuse the request instead of silently switching entities.
+func EntityTypeMiddleware(permissionUseCase usecase.PermissionUseCase) fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
 		user := GetUser(ctx)
 		if user == nil {
@@ -24,14 +32,8 @@ func EntityTypeMiddleware() fiber.Handler {
 			}
 		}
 
-		valid := map[string]bool{
-			"pura":     true,
-			"yayasan":  true,
-			"pasraman": true,
-		}
-
 		if user.Role == "super" {
-			if !valid[entityType] {
+			if !model.IsEntityType(entityType) {
 				entityType = "pura"
 			}
 
@@ -39,7 +41,36 @@ func EntityTypeMiddleware() fiber.Handler {
 			return ctx.Next()
 		}
 
-		ctx.Locals(CtxEntityType, user.Role)
+		// API keys arrive with their grants already resolved by AuthMiddleware.
+		if user.APIKeyID == "" {
+			grants, err := permissionUseCase.GetGrants(ctx.UserContext(), user.ID)
+			if err != nil {
+				return err
+			}
+			user.Grants = grants
+		}
+
+		if !model.IsEntityType(entityType) {
+			entityType = defaultEntityType(user)
+		}
+
+		if entityType != "" {
+			ctx.Locals(CtxEntityType, entityType)
+		}
 		return ctx.Next()
 	}
 }
+
+// defaultEntityType prefers the entity named by the user's legacy role, then the first entity
+// the user holds any permission on.
+func defaultEntityType(user *Auth) string {
+	if _, ok := user.Grants[user.Role]; ok {
+		return user.Role
+	}
+	for _, entityType := range model.EntityTypes {
+		if _, ok := user.Grants[entityType]; ok {
+			return entityType
+		}
+	}
+	return ""
+}
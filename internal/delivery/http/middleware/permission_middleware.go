@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"pura-agung-kertajaya-backend/internal/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PermissionMiddleware requires the permission on the entity resolved by EntityTypeMiddleware.
+func PermissionMiddleware(permission string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		user := GetUser(ctx)
+		if user == nil {
+			return fiber.ErrUnauthorized
+		}
+
+		entityType, _ := ctx.Locals(CtxEntityType).(string)
+		if !user.Can(entityType, permission) {
+			return fiber.NewError(fiber.StatusForbidden, "You do not have permission to perform this action")
+		}
+
+		return ctx.Next()
+	}
+}
+
+type articleStatusBody struct {
+	Status string `json:"status"`
+}
+
+// ArticlePublishMiddleware additionally requires articles:publish when the article is saved as
+// PUBLISHED or SCHEDULED.
+func ArticlePublishMiddleware() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		var body articleStatusBody
+		if err := ctx.BodyParser(&body); err != nil || (body.Status != "PUBLISHED" && body.Status != "SCHEDULED") {
+			return ctx.Next()
+		}
+
+		return PermissionMiddleware(model.PermissionArticlesPublish)(ctx)
+	}
+}
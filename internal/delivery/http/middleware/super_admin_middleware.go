@@ -0,0 +1,18 @@
+package middleware
+
+import "github.com/gofiber/fiber/v2"
+
+func SuperAdminMiddleware() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		user := GetUser(ctx)
+		if user == nil {
+			return fiber.ErrUnauthorized
+		}
+
+		if user.Role != "super" {
+			return fiber.ErrForbidden
+		}
+
+		return ctx.Next()
+	}
+}
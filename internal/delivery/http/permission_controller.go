@@ -0,0 +1,196 @@
+package http
+
+import (
+	"errors"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type PermissionController struct {
+	Log     *logrus.Logger
+	UseCase usecase.PermissionUseCase
+}
+
+func NewPermissionController(useCase usecase.PermissionUseCase, logger *logrus.Logger) *PermissionController {
+	return &PermissionController{
+		Log:     logger,
+		UseCase: useCase,
+	}
+}
+
+func (c *PermissionController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
+	user := middleware.GetUser(ctx)
+
+	userID := "guest"
+	userRole := "unknown"
+
+	if user != nil {
+		userID = user.ID
+		userRole = user.Role
+	}
+
+	return c.Log.WithFields(logrus.Fields{
+		"user_id":   userID,
+		"user_role": userRole,
+		"ip":        ctx.IP(),
+		"req_id":    ctx.Get("X-Request-ID"),
+	})
+}
+
+func (c *PermissionController) GetPermissions(ctx *fiber.Ctx) error {
+	return ctx.JSON(model.WebResponse[[]string]{Data: model.Permissions})
+}
+
+// Current returns the permissions of the logged in user so the CMS can hide what they cannot do.
+func (c *PermissionController) Current(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth == nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(model.WebResponse[any]{Errors: fiber.ErrUnauthorized.Message})
+	}
+
+	response := &model.UserPermissionsResponse{
+		Super:  auth.Role == "super",
+		Grants: auth.Grants,
+	}
+
+	if response.Super {
+		response.Grants = make(model.PermissionGrants, len(model.EntityTypes))
+		for _, entityType := range model.EntityTypes {
+			response.Grants[entityType] = model.Permissions
+		}
+	}
+	if response.Grants == nil {
+		response.Grants = model.PermissionGrants{}
+	}
+
+	return ctx.JSON(model.WebResponse[*model.UserPermissionsResponse]{Data: response})
+}
+
+func (c *PermissionController) GetRoles(ctx *fiber.Ctx) error {
+	response, err := c.UseCase.GetRoles(ctx.UserContext())
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Error("Failed to get roles")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.RoleResponse]{Data: response})
+}
+
+func (c *PermissionController) GetRoleByID(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	response, err := c.UseCase.GetRoleByID(ctx.UserContext(), id)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
+			c.getLogger(ctx).WithField("role_id", id).Warn("Role not found")
+		} else {
+			c.getLogger(ctx).WithField("role_id", id).WithError(err).Error("Failed to get role by id")
+		}
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.RoleResponse]{Data: response})
+}
+
+func (c *PermissionController) CreateRole(ctx *fiber.Ctx) error {
+	req := new(model.CreateRoleRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("Failed to parse request body: %+v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
+	}
+
+	response, err := c.UseCase.CreateRole(ctx.UserContext(), req)
+	if err != nil {
+		c.getLogger(ctx).WithField("role_name", req.Name).WithError(err).Warn("Failed to create role")
+		return err
+	}
+
+	c.getLogger(ctx).WithField("role_id", response.ID).Info("Role created")
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse[*model.RoleResponse]{Data: response})
+}
+
+func (c *PermissionController) UpdateRole(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	req := new(model.UpdateRoleRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("Failed to parse request body: %+v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
+	}
+
+	response, err := c.UseCase.UpdateRole(ctx.UserContext(), id, req)
+	if err != nil {
+		c.getLogger(ctx).WithField("role_id", id).WithError(err).Warn("Failed to update role")
+		return err
+	}
+
+	c.getLogger(ctx).WithField("role_id", id).Info("Role updated")
+	return ctx.JSON(model.WebResponse[*model.RoleResponse]{Data: response})
+}
+
+func (c *PermissionController) DeleteRole(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	if err := c.UseCase.DeleteRole(ctx.UserContext(), id); err != nil {
+		c.getLogger(ctx).WithField("role_id", id).WithError(err).Warn("Failed to delete role")
+		return err
+	}
+
+	c.getLogger(ctx).WithField("role_id", id).Info("Role deleted")
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *PermissionController) GetUserRoles(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	response, err := c.UseCase.GetUserRoles(ctx.UserContext(), id)
+	if err != nil {
+		c.getLogger(ctx).WithField("target_user_id", id).WithError(err).Warn("Failed to get user roles")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.RoleAssignmentResponse]{Data: response})
+}
+
+func (c *PermissionController) SetUserRoles(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	req := new(model.SetUserRolesRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("Failed to parse request body: %+v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
+	}
+
+	response, err := c.UseCase.SetUserRoles(ctx.UserContext(), id, req)
+	if err != nil {
+		c.getLogger(ctx).WithField("target_user_id", id).WithError(err).Warn("Failed to set user roles")
+		return err
+	}
+
+	c.getLogger(ctx).WithFields(logrus.Fields{
+		"target_user_id": id,
+		"assignments":    len(response),
+	}).Info("User roles updated")
+
+	return ctx.JSON(model.WebResponse[[]model.RoleAssignmentResponse]{Data: response})
+}
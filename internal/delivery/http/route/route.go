@@ -2,6 +2,7 @@ package route
 
 import (
 	"pura-agung-kertajaya-backend/internal/delivery/http"
+	"pura-agung-kertajaya-backend/internal/model"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -22,9 +23,23 @@ type RouteConfig struct {
 	OrganizationDetailController *http.OrganizationDetailController
 	RemarkController             *http.RemarkController
 	CategoryController           *http.CategoryController
+	TagController                *http.TagController
 	ArticleController            *http.ArticleController
+	PermissionController         *http.PermissionController
+	APIKeyController             *http.APIKeyController
+	AuditLogController           *http.AuditLogController
+	SearchController             *http.SearchController
+	FeedController               *http.FeedController
+	SitemapController            *http.SitemapController
+	SEOController                *http.SEOController
+	CalendarController           *http.CalendarController
 	AuthMiddleware               fiber.Handler
 	EntityTypeMiddleware         fiber.Handler
+	SuperAdminMiddleware         fiber.Handler
+	UserSessionMiddleware        fiber.Handler
+	AuditMiddleware              fiber.Handler
+	PermissionMiddleware         func(permission string) fiber.Handler
+	ArticlePublishMiddleware     fiber.Handler
 
 	PublicRateLimiter   fiber.Handler
 	AuthRateLimiter     fiber.Handler
@@ -48,6 +63,8 @@ func (c *RouteConfig) SetupGuestRoute() {
 	public.Get("/facilities", c.FacilityController.GetAllPublic)
 	public.Get("/contact-info", c.ContactInfoController.GetAll)
 	public.Get("/activities", c.ActivityController.GetAllPublic)
+	public.Get("/activities.ics", c.FeedController.GetActivities)
+	public.Get("/activities/:id.ics", c.FeedController.GetActivity)
 	public.Get("/site-identity", c.SiteIdentityController.GetPublic)
 	public.Get("/about", c.AboutController.GetAllPublic)
 	public.Get("/organization-members", c.OrganizationController.GetAllPublic)
@@ -55,96 +72,166 @@ func (c *RouteConfig) SetupGuestRoute() {
 	public.Get("/remarks", c.RemarkController.GetAllPublic)
 	public.Get("/categories", c.CategoryController.GetAllPublic)
 	public.Get("/articles", c.ArticleController.GetPublic)
+	public.Get("/tags", c.TagController.GetAllPublic)
+	public.Get("/articles/popular", c.ArticleController.GetPopular)
 	public.Get("/articles/:slug", c.ArticleController.GetBySlug)
+	public.Get("/articles/:slug/related", c.ArticleController.GetRelated)
+	public.Get("/search", c.SearchController.Search)
+	public.Get("/feeds/articles.:format", c.FeedController.GetArticles)
+	public.Get("/sitemap.xml", c.SitemapController.GetIndex)
+	public.Get("/sitemaps/:entity.xml", c.SitemapController.GetSitemap)
+	public.Get("/seo", c.SEOController.Get)
+	public.Get("/calendar", c.CalendarController.GetRange)
 
 	c.App.Post("/api/users/_login", c.AuthRateLimiter, c.UserController.Login)
+	c.App.Post("/api/users/_login/2fa", c.AuthRateLimiter, c.UserController.VerifyTwoFactorLogin)
+	c.App.Post("/api/users/_refresh", c.AuthRateLimiter, c.UserController.Refresh)
+	c.App.Post("/api/users/_logout", c.AuthRateLimiter, c.UserController.Logout)
+	c.App.Post("/api/users/_forgot-password", c.AuthRateLimiter, c.UserController.ForgotPassword)
+	c.App.Post("/api/users/_reset-password", c.AuthRateLimiter, c.UserController.ResetPasswordWithToken)
 }
 
 func (c *RouteConfig) SetupAuthRoute() {
-	auth := c.App.Group("/api", c.AuthMiddleware, c.EntityTypeMiddleware)
-
-	auth.Post("/users/_logout", c.CMSWriteRateLimiter, c.UserController.Logout)
-	auth.Patch("/users/_current", c.CMSWriteRateLimiter, c.UserController.UpdateProfile)
-	auth.Get("/users/_current", c.CMSReadRateLimiter, c.UserController.Current)
+	auth := c.App.Group("/api", c.AuthMiddleware, c.EntityTypeMiddleware, c.AuditMiddleware)
+	can := c.PermissionMiddleware
+	session := c.UserSessionMiddleware
+
+	auth.Patch("/users/_current", session, c.CMSWriteRateLimiter, c.UserController.UpdateProfile)
+	auth.Get("/users/_current", session, c.CMSReadRateLimiter, c.UserController.Current)
+	auth.Get("/users/_current/sessions", session, c.CMSReadRateLimiter, c.UserController.ListCurrentSessions)
+	auth.Delete("/users/_current/sessions", session, c.CMSWriteRateLimiter, c.UserController.RevokeAllCurrentSessions)
+	auth.Delete("/users/_current/sessions/:id", session, c.CMSWriteRateLimiter, c.UserController.RevokeCurrentSession)
+	auth.Post("/users/_current/2fa/_setup", session, c.CMSWriteRateLimiter, c.UserController.SetupTwoFactor)
+	auth.Post("/users/_current/2fa/_enable", session, c.CMSWriteRateLimiter, c.UserController.EnableTwoFactor)
+	auth.Post("/users/_current/2fa/_disable", session, c.CMSWriteRateLimiter, c.UserController.DisableTwoFactor)
+	auth.Post("/users/_current/2fa/recovery-codes", session, c.CMSWriteRateLimiter, c.UserController.RegenerateRecoveryCodes)
+	auth.Get("/users/_current/permissions", c.CMSReadRateLimiter, c.PermissionController.Current)
+
+	auth.Get("/users", c.SuperAdminMiddleware, c.CMSReadRateLimiter, c.UserController.GetAll)
+	auth.Get("/users/login-attempts", c.SuperAdminMiddleware, c.CMSReadRateLimiter, c.UserController.GetLoginAttempts)
+	auth.Get("/users/:id", c.SuperAdminMiddleware, c.CMSReadRateLimiter, c.UserController.GetByID)
+	auth.Post("/users", c.SuperAdminMiddleware, c.CMSWriteRateLimiter, c.UserController.Create)
+	auth.Patch("/users/:id/role", c.SuperAdminMiddleware, c.CMSWriteRateLimiter, c.UserController.UpdateRole)
+	auth.Patch("/users/:id/status", c.SuperAdminMiddleware, c.CMSWriteRateLimiter, c.UserController.UpdateStatus)
+	auth.Put("/users/:id/password", c.SuperAdminMiddleware, c.CMSWriteRateLimiter, c.UserController.ResetPassword)
+	auth.Get("/users/:id/sessions", c.SuperAdminMiddleware, c.CMSReadRateLimiter, c.UserController.ListUserSessions)
+	auth.Delete("/users/:id/sessions", c.SuperAdminMiddleware, c.DeleteRateLimiter, c.UserController.RevokeUserSessions)
+	auth.Delete("/users/:id/2fa", c.SuperAdminMiddleware, c.DeleteRateLimiter, c.UserController.ResetTwoFactor)
+	auth.Get("/users/:id/roles", c.SuperAdminMiddleware, c.CMSReadRateLimiter, c.PermissionController.GetUserRoles)
+	auth.Put("/users/:id/roles", c.SuperAdminMiddleware, c.CMSWriteRateLimiter, c.PermissionController.SetUserRoles)
+
+	auth.Get("/permissions", c.SuperAdminMiddleware, c.CMSReadRateLimiter, c.PermissionController.GetPermissions)
+	auth.Get("/roles", c.SuperAdminMiddleware, c.CMSReadRateLimiter, c.PermissionController.GetRoles)
+	auth.Get("/roles/:id", c.SuperAdminMiddleware, c.CMSReadRateLimiter, c.PermissionController.GetRoleByID)
+	auth.Post("/roles", c.SuperAdminMiddleware, c.CMSWriteRateLimiter, c.PermissionController.CreateRole)
+	auth.Put("/roles/:id", c.SuperAdminMiddleware, c.CMSWriteRateLimiter, c.PermissionController.UpdateRole)
+	auth.Delete("/roles/:id", c.SuperAdminMiddleware, c.DeleteRateLimiter, c.PermissionController.DeleteRole)
+
+	auth.Get("/api-keys", c.SuperAdminMiddleware, c.CMSReadRateLimiter, c.APIKeyController.GetAll)
+	auth.Get("/api-keys/:id", c.SuperAdminMiddleware, c.CMSReadRateLimiter, c.APIKeyController.GetByID)
+	auth.Post("/api-keys", c.SuperAdminMiddleware, c.CMSWriteRateLimiter, c.APIKeyController.Create)
+	auth.Put("/api-keys/:id", c.SuperAdminMiddleware, c.CMSWriteRateLimiter, c.APIKeyController.Update)
+	auth.Post("/api-keys/:id/_rotate", c.SuperAdminMiddleware, c.CMSWriteRateLimiter, c.APIKeyController.Rotate)
+	auth.Post("/api-keys/:id/_revoke", c.SuperAdminMiddleware, c.DeleteRateLimiter, c.APIKeyController.Revoke)
+
+	auth.Get("/audit-logs", c.SuperAdminMiddleware, c.CMSReadRateLimiter, c.AuditLogController.Search)
 
 	storage := auth.Group("/storage", c.StorageRateLimiter)
-	storage.Post("/upload", c.StorageController.Upload)
-	storage.Post("/upload/single", c.StorageController.UploadSingle)
-	storage.Delete("/delete", c.StorageController.Delete)
-	storage.Get("/presigned-url", c.StorageController.GetPresignedURL)
-
-	auth.Get("/testimonials", c.CMSReadRateLimiter, c.TestimonialController.GetAll)
-	auth.Get("/testimonials/:id", c.CMSReadRateLimiter, c.TestimonialController.GetByID)
-	auth.Post("/testimonials", c.CMSWriteRateLimiter, c.TestimonialController.Create)
-	auth.Put("/testimonials/:id", c.CMSWriteRateLimiter, c.TestimonialController.Update)
-	auth.Delete("/testimonials/:id", c.DeleteRateLimiter, c.TestimonialController.Delete)
-
-	auth.Get("/hero-slides", c.CMSReadRateLimiter, c.HeroSlideController.GetAll)
-	auth.Get("/hero-slides/:id", c.CMSReadRateLimiter, c.HeroSlideController.GetByID)
-	auth.Post("/hero-slides", c.CMSWriteRateLimiter, c.HeroSlideController.Create)
-	auth.Put("/hero-slides/:id", c.CMSWriteRateLimiter, c.HeroSlideController.Update)
-	auth.Delete("/hero-slides/:id", c.DeleteRateLimiter, c.HeroSlideController.Delete)
-
-	auth.Get("/galleries", c.CMSReadRateLimiter, c.GalleryController.GetAll)
-	auth.Get("/galleries/:id", c.CMSReadRateLimiter, c.GalleryController.GetByID)
-	auth.Post("/galleries", c.CMSWriteRateLimiter, c.GalleryController.Create)
-	auth.Put("/galleries/:id", c.CMSWriteRateLimiter, c.GalleryController.Update)
-	auth.Delete("/galleries/:id", c.DeleteRateLimiter, c.GalleryController.Delete)
-
-	auth.Get("/facilities", c.CMSReadRateLimiter, c.FacilityController.GetAll)
-	auth.Get("/facilities/:id", c.CMSReadRateLimiter, c.FacilityController.GetByID)
-	auth.Post("/facilities", c.CMSWriteRateLimiter, c.FacilityController.Create)
-	auth.Put("/facilities/:id", c.CMSWriteRateLimiter, c.FacilityController.Update)
-	auth.Delete("/facilities/:id", c.DeleteRateLimiter, c.FacilityController.Delete)
-
-	auth.Get("/contact-info", c.CMSReadRateLimiter, c.ContactInfoController.GetAll)
-	auth.Get("/contact-info/:id", c.CMSReadRateLimiter, c.ContactInfoController.GetByID)
-	auth.Post("/contact-info", c.CMSWriteRateLimiter, c.ContactInfoController.Create)
-	auth.Put("/contact-info/:id", c.CMSWriteRateLimiter, c.ContactInfoController.Update)
-	auth.Delete("/contact-info/:id", c.DeleteRateLimiter, c.ContactInfoController.Delete)
-
-	auth.Get("/activities", c.CMSReadRateLimiter, c.ActivityController.GetAll)
-	auth.Get("/activities/:id", c.CMSReadRateLimiter, c.ActivityController.GetByID)
-	auth.Post("/activities", c.CMSWriteRateLimiter, c.ActivityController.Create)
-	auth.Put("/activities/:id", c.CMSWriteRateLimiter, c.ActivityController.Update)
-	auth.Delete("/activities/:id", c.DeleteRateLimiter, c.ActivityController.Delete)
-
-	auth.Get("/site-identity", c.CMSReadRateLimiter, c.SiteIdentityController.GetAll)
-	auth.Get("/site-identity/:id", c.CMSReadRateLimiter, c.SiteIdentityController.GetByID)
-	auth.Post("/site-identity", c.CMSWriteRateLimiter, c.SiteIdentityController.Create)
-	auth.Put("/site-identity/:id", c.CMSWriteRateLimiter, c.SiteIdentityController.Update)
-	auth.Delete("/site-identity/:id", c.DeleteRateLimiter, c.SiteIdentityController.Delete)
-
-	auth.Get("/about", c.CMSReadRateLimiter, c.AboutController.GetAll)
-	auth.Get("/about/:id", c.CMSReadRateLimiter, c.AboutController.GetByID)
-	auth.Post("/about", c.CMSWriteRateLimiter, c.AboutController.Create)
-	auth.Put("/about/:id", c.CMSWriteRateLimiter, c.AboutController.Update)
-	auth.Delete("/about/:id", c.DeleteRateLimiter, c.AboutController.Delete)
-
-	auth.Get("/organization-members", c.CMSReadRateLimiter, c.OrganizationController.GetAll)
-	auth.Get("/organization-members/:id", c.CMSReadRateLimiter, c.OrganizationController.GetByID)
-	auth.Post("/organization-members", c.CMSWriteRateLimiter, c.OrganizationController.Create)
-	auth.Put("/organization-members/:id", c.CMSWriteRateLimiter, c.OrganizationController.Update)
-	auth.Delete("/organization-members/:id", c.DeleteRateLimiter, c.OrganizationController.Delete)
-
-	auth.Get("/remarks", c.CMSReadRateLimiter, c.RemarkController.GetAll)
-	auth.Get("/remarks/:id", c.CMSReadRateLimiter, c.RemarkController.GetByID)
-	auth.Post("/remarks", c.CMSWriteRateLimiter, c.RemarkController.Create)
-	auth.Put("/remarks/:id", c.CMSWriteRateLimiter, c.RemarkController.Update)
-	auth.Delete("/remarks/:id", c.DeleteRateLimiter, c.RemarkController.Delete)
-
-	auth.Get("/organization-details", c.CMSReadRateLimiter, c.OrganizationDetailController.GetAdmin)
-	auth.Put("/organization-details", c.CMSWriteRateLimiter, c.OrganizationDetailController.Update)
-
-	auth.Get("/categories", c.CMSReadRateLimiter, c.CategoryController.GetAll)
-	auth.Get("/categories/:id", c.CMSReadRateLimiter, c.CategoryController.GetByID)
-	auth.Post("/categories", c.CMSWriteRateLimiter, c.CategoryController.Create)
-	auth.Put("/categories/:id", c.CMSWriteRateLimiter, c.CategoryController.Update)
-	auth.Delete("/categories/:id", c.DeleteRateLimiter, c.CategoryController.Delete)
-
-	auth.Get("/articles", c.CMSReadRateLimiter, c.ArticleController.GetAll)
-	auth.Get("/articles/:id", c.CMSReadRateLimiter, c.ArticleController.GetByID)
-	auth.Post("/articles", c.CMSWriteRateLimiter, c.ArticleController.Create)
-	auth.Put("/articles/:id", c.CMSWriteRateLimiter, c.ArticleController.Update)
-	auth.Delete("/articles/:id", c.DeleteRateLimiter, c.ArticleController.Delete)
+	storage.Post("/upload", can(model.PermissionStorageWrite), c.StorageController.Upload)
+	storage.Post("/upload/single", can(model.PermissionStorageWrite), c.StorageController.UploadSingle)
+	storage.Delete("/delete", can(model.PermissionStorageDelete), c.StorageController.Delete)
+	storage.Get("/presigned-url", can(model.PermissionStorageRead), c.StorageController.GetPresignedURL)
+
+	auth.Get("/testimonials", can(model.PermissionTestimonialsRead), c.CMSReadRateLimiter, c.TestimonialController.GetAll)
+	auth.Get("/testimonials/:id", can(model.PermissionTestimonialsRead), c.CMSReadRateLimiter, c.TestimonialController.GetByID)
+	auth.Post("/testimonials", can(model.PermissionTestimonialsWrite), c.CMSWriteRateLimiter, c.TestimonialController.Create)
+	auth.Put("/testimonials/:id", can(model.PermissionTestimonialsWrite), c.CMSWriteRateLimiter, c.TestimonialController.Update)
+	auth.Delete("/testimonials/:id", can(model.PermissionTestimonialsDelete), c.DeleteRateLimiter, c.TestimonialController.Delete)
+
+	auth.Get("/hero-slides", can(model.PermissionHeroSlidesRead), c.CMSReadRateLimiter, c.HeroSlideController.GetAll)
+	auth.Get("/hero-slides/:id", can(model.PermissionHeroSlidesRead), c.CMSReadRateLimiter, c.HeroSlideController.GetByID)
+	auth.Post("/hero-slides", can(model.PermissionHeroSlidesWrite), c.CMSWriteRateLimiter, c.HeroSlideController.Create)
+	auth.Put("/hero-slides/:id", can(model.PermissionHeroSlidesWrite), c.CMSWriteRateLimiter, c.HeroSlideController.Update)
+	auth.Delete("/hero-slides/:id", can(model.PermissionHeroSlidesDelete), c.DeleteRateLimiter, c.HeroSlideController.Delete)
+
+	auth.Get("/galleries", can(model.PermissionGalleryRead), c.CMSReadRateLimiter, c.GalleryController.GetAll)
+	auth.Get("/galleries/:id", can(model.PermissionGalleryRead), c.CMSReadRateLimiter, c.GalleryController.GetByID)
+	auth.Post("/galleries", can(model.PermissionGalleryWrite), c.CMSWriteRateLimiter, c.GalleryController.Create)
+	auth.Put("/galleries/:id", can(model.PermissionGalleryWrite), c.CMSWriteRateLimiter, c.GalleryController.Update)
+	auth.Delete("/galleries/:id", can(model.PermissionGalleryDelete), c.DeleteRateLimiter, c.GalleryController.Delete)
+
+	auth.Get("/facilities", can(model.PermissionFacilitiesRead), c.CMSReadRateLimiter, c.FacilityController.GetAll)
+	auth.Get("/facilities/:id", can(model.PermissionFacilitiesRead), c.CMSReadRateLimiter, c.FacilityController.GetByID)
+	auth.Post("/facilities", can(model.PermissionFacilitiesWrite), c.CMSWriteRateLimiter, c.FacilityController.Create)
+	auth.Put("/facilities/:id", can(model.PermissionFacilitiesWrite), c.CMSWriteRateLimiter, c.FacilityController.Update)
+	auth.Delete("/facilities/:id", can(model.PermissionFacilitiesDelete), c.DeleteRateLimiter, c.FacilityController.Delete)
+
+	auth.Get("/contact-info", can(model.PermissionContactInfoRead), c.CMSReadRateLimiter, c.ContactInfoController.GetAll)
+	auth.Get("/contact-info/:id", can(model.PermissionContactInfoRead), c.CMSReadRateLimiter, c.ContactInfoController.GetByID)
+	auth.Post("/contact-info", can(model.PermissionContactInfoWrite), c.CMSWriteRateLimiter, c.ContactInfoController.Create)
+	auth.Put("/contact-info/:id", can(model.PermissionContactInfoWrite), c.CMSWriteRateLimiter, c.ContactInfoController.Update)
+	auth.Delete("/contact-info/:id", can(model.PermissionContactInfoDelete), c.DeleteRateLimiter, c.ContactInfoController.Delete)
+
+	auth.Get("/activities", can(model.PermissionActivitiesRead), c.CMSReadRateLimiter, c.ActivityController.GetAll)
+	auth.Get("/activities/:id", can(model.PermissionActivitiesRead), c.CMSReadRateLimiter, c.ActivityController.GetByID)
+	auth.Post("/activities", can(model.PermissionActivitiesWrite), c.CMSWriteRateLimiter, c.ActivityController.Create)
+	auth.Put("/activities/:id", can(model.PermissionActivitiesWrite), c.CMSWriteRateLimiter, c.ActivityController.Update)
+	auth.Delete("/activities/:id", can(model.PermissionActivitiesDelete), c.DeleteRateLimiter, c.ActivityController.Delete)
+	auth.Get("/activities/:id/exceptions", can(model.PermissionActivitiesRead), c.CMSReadRateLimiter, c.ActivityController.GetExceptions)
+	auth.Put("/activities/:id/exceptions/:date", can(model.PermissionActivitiesWrite), c.CMSWriteRateLimiter, c.ActivityController.SaveException)
+	auth.Delete("/activities/:id/exceptions/:date", can(model.PermissionActivitiesWrite), c.CMSWriteRateLimiter, c.ActivityController.DeleteException)
+
+	auth.Get("/site-identity", can(model.PermissionSiteIdentityRead), c.CMSReadRateLimiter, c.SiteIdentityController.GetAll)
+	auth.Get("/site-identity/:id", can(model.PermissionSiteIdentityRead), c.CMSReadRateLimiter, c.SiteIdentityController.GetByID)
+	auth.Post("/site-identity", can(model.PermissionSiteIdentityWrite), c.CMSWriteRateLimiter, c.SiteIdentityController.Create)
+	auth.Put("/site-identity/:id", can(model.PermissionSiteIdentityWrite), c.CMSWriteRateLimiter, c.SiteIdentityController.Update)
+	auth.Delete("/site-identity/:id", can(model.PermissionSiteIdentityDelete), c.DeleteRateLimiter, c.SiteIdentityController.Delete)
+
+	auth.Get("/about", can(model.PermissionAboutRead), c.CMSReadRateLimiter, c.AboutController.GetAll)
+	auth.Get("/about/:id", can(model.PermissionAboutRead), c.CMSReadRateLimiter, c.AboutController.GetByID)
+	auth.Post("/about", can(model.PermissionAboutWrite), c.CMSWriteRateLimiter, c.AboutController.Create)
+	auth.Put("/about/:id", can(model.PermissionAboutWrite), c.CMSWriteRateLimiter, c.AboutController.Update)
+	auth.Delete("/about/:id", can(model.PermissionAboutDelete), c.DeleteRateLimiter, c.AboutController.Delete)
+
+	auth.Get("/organization-members", can(model.PermissionOrganizationRead), c.CMSReadRateLimiter, c.OrganizationController.GetAll)
+	auth.Get("/organization-members/:id", can(model.PermissionOrganizationRead), c.CMSReadRateLimiter, c.OrganizationController.GetByID)
+	auth.Post("/organization-members", can(model.PermissionOrganizationWrite), c.CMSWriteRateLimiter, c.OrganizationController.Create)
+	auth.Put("/organization-members/:id", can(model.PermissionOrganizationWrite), c.CMSWriteRateLimiter, c.OrganizationController.Update)
+	auth.Delete("/organization-members/:id", can(model.PermissionOrganizationDelete), c.DeleteRateLimiter, c.OrganizationController.Delete)
+
+	auth.Get("/remarks", can(model.PermissionRemarksRead), c.CMSReadRateLimiter, c.RemarkController.GetAll)
+	auth.Get("/remarks/:id", can(model.PermissionRemarksRead), c.CMSReadRateLimiter, c.RemarkController.GetByID)
+	auth.Post("/remarks", can(model.PermissionRemarksWrite), c.CMSWriteRateLimiter, c.RemarkController.Create)
+	auth.Put("/remarks/:id", can(model.PermissionRemarksWrite), c.CMSWriteRateLimiter, c.RemarkController.Update)
+	auth.Delete("/remarks/:id", can(model.PermissionRemarksDelete), c.DeleteRateLimiter, c.RemarkController.Delete)
+
+	auth.Get("/organization-details", can(model.PermissionOrganizationRead), c.CMSReadRateLimiter, c.OrganizationDetailController.GetAdmin)
+	auth.Put("/organization-details", can(model.PermissionOrganizationWrite), c.CMSWriteRateLimiter, c.OrganizationDetailController.Update)
+
+	auth.Get("/categories", can(model.PermissionCategoriesRead), c.CMSReadRateLimiter, c.CategoryController.GetAll)
+	auth.Get("/categories/:id", can(model.PermissionCategoriesRead), c.CMSReadRateLimiter, c.CategoryController.GetByID)
+	auth.Post("/categories", can(model.PermissionCategoriesWrite), c.CMSWriteRateLimiter, c.CategoryController.Create)
+	auth.Put("/categories/:id", can(model.PermissionCategoriesWrite), c.CMSWriteRateLimiter, c.CategoryController.Update)
+	auth.Delete("/categories/:id", can(model.PermissionCategoriesDelete), c.DeleteRateLimiter, c.CategoryController.Delete)
+
+	auth.Get("/tags", can(model.PermissionTagsRead), c.CMSReadRateLimiter, c.TagController.GetAll)
+	auth.Get("/tags/:id", can(model.PermissionTagsRead), c.CMSReadRateLimiter, c.TagController.GetByID)
+	auth.Post("/tags", can(model.PermissionTagsWrite), c.CMSWriteRateLimiter, c.TagController.Create)
+	auth.Put("/tags/:id", can(model.PermissionTagsWrite), c.CMSWriteRateLimiter, c.TagController.Update)
+	auth.Delete("/tags/:id", can(model.PermissionTagsDelete), c.DeleteRateLimiter, c.TagController.Delete)
+
+	auth.Get("/articles", can(model.PermissionArticlesRead), c.CMSReadRateLimiter, c.ArticleController.GetAll)
+	auth.Get("/articles/_review-queue", can(model.PermissionArticlesPublish), c.CMSReadRateLimiter, c.ArticleController.GetReviewQueue)
+	auth.Get("/articles/:id", can(model.PermissionArticlesRead), c.CMSReadRateLimiter, c.ArticleController.GetByID)
+	auth.Post("/articles", can(model.PermissionArticlesWrite), c.ArticlePublishMiddleware, c.CMSWriteRateLimiter, c.ArticleController.Create)
+	auth.Put("/articles/:id", can(model.PermissionArticlesWrite), c.ArticlePublishMiddleware, c.CMSWriteRateLimiter, c.ArticleController.Update)
+	auth.Delete("/articles/:id", can(model.PermissionArticlesDelete), c.DeleteRateLimiter, c.ArticleController.Delete)
+	auth.Post("/articles/:id/_submit", can(model.PermissionArticlesWrite), c.CMSWriteRateLimiter, c.ArticleController.SubmitForReview)
+	auth.Post("/articles/:id/_approve", can(model.PermissionArticlesPublish), c.CMSWriteRateLimiter, c.ArticleController.ApproveReview)
+	auth.Post("/articles/:id/_reject", can(model.PermissionArticlesPublish), c.CMSWriteRateLimiter, c.ArticleController.RejectReview)
+	auth.Get("/articles/:id/stats", can(model.PermissionArticlesRead), c.CMSReadRateLimiter, c.ArticleController.GetStats)
+	auth.Get("/articles/:id/revisions", can(model.PermissionArticlesRead), c.CMSReadRateLimiter, c.ArticleController.GetRevisions)
+	auth.Get("/articles/:id/revisions/_diff", can(model.PermissionArticlesRead), c.CMSReadRateLimiter, c.ArticleController.DiffRevisions)
+	auth.Get("/articles/:id/revisions/:version", can(model.PermissionArticlesRead), c.CMSReadRateLimiter, c.ArticleController.GetRevision)
+	auth.Post("/articles/:id/revisions/:version/_restore", can(model.PermissionArticlesWrite), c.CMSWriteRateLimiter, c.ArticleController.RestoreRevision)
 }
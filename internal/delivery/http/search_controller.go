@@ -0,0 +1,69 @@
+package http
+
+import (
+	"fmt"
+	"math"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type SearchController struct {
+	UseCase usecase.SearchUsecase
+	Log     *logrus.Logger
+}
+
+func NewSearchController(usecase usecase.SearchUsecase, log *logrus.Logger) *SearchController {
+	return &SearchController{UseCase: usecase, Log: log}
+}
+
+func (c *SearchController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
+	user := middleware.GetUser(ctx)
+
+	userID := "guest"
+	userRole := "unknown"
+
+	if user != nil {
+		userID = fmt.Sprintf("%v", user.ID)
+		userRole = user.Role
+	}
+
+	return c.Log.WithFields(logrus.Fields{
+		"user_id":   userID,
+		"user_role": userRole,
+		"ip":        ctx.IP(),
+		"req_id":    ctx.Get("X-Request-ID"),
+	})
+}
+
+func (c *SearchController) Search(ctx *fiber.Ctx) error {
+	req := &model.PublicSearchRequest{
+		Query:      strings.TrimSpace(ctx.Query("q")),
+		EntityType: ctx.Query("entity_type"),
+		Page:       ctx.QueryInt("page", 1),
+		Size:       ctx.QueryInt("size", 10),
+	}
+	if types := ctx.Query("type"); types != "" {
+		req.Types = strings.Split(types, ",")
+	}
+
+	data, total, err := c.UseCase.Search(ctx.UserContext(), req)
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Warn("failed to search content")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.SearchResultResponse]{
+		Data: data,
+		Paging: &model.PageMetadata{
+			Page:      req.Page,
+			Size:      req.Size,
+			TotalItem: total,
+			TotalPage: int64(math.Ceil(float64(total) / float64(req.Size))),
+		},
+	})
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type SEOController struct {
+	UseCase usecase.SEOUsecase
+	Log     *logrus.Logger
+}
+
+func NewSEOController(usecase usecase.SEOUsecase, log *logrus.Logger) *SEOController {
+	return &SEOController{UseCase: usecase, Log: log}
+}
+
+func (c *SEOController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
+	user := middleware.GetUser(ctx)
+
+	userID := "guest"
+	userRole := "unknown"
+
+	if user != nil {
+		userID = fmt.Sprintf("%v", user.ID)
+		userRole = user.Role
+	}
+
+	return c.Log.WithFields(logrus.Fields{
+		"user_id":   userID,
+		"user_role": userRole,
+		"ip":        ctx.IP(),
+		"req_id":    ctx.Get("X-Request-ID"),
+	})
+}
+
+// Get resolves the metadata of the public page at the path query parameter.
+func (c *SEOController) Get(ctx *fiber.Ctx) error {
+	path := ctx.Query("path")
+	if path == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "path is required"})
+	}
+
+	data, err := c.UseCase.Resolve(ctx.Query("entity_type", "pura"), path)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
+			c.getLogger(ctx).WithField("path", path).Warnf("failed to resolve seo metadata: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithError(err).Error("failed to resolve seo metadata")
+		}
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
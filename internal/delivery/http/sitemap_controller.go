@@ -0,0 +1,93 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+	"pura-agung-kertajaya-backend/internal/util"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const sitemapCacheControl = "public, max-age=3600"
+
+type SitemapController struct {
+	UseCase usecase.SitemapUsecase
+	Log     *logrus.Logger
+}
+
+func NewSitemapController(usecase usecase.SitemapUsecase, log *logrus.Logger) *SitemapController {
+	return &SitemapController{UseCase: usecase, Log: log}
+}
+
+func (c *SitemapController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
+	user := middleware.GetUser(ctx)
+
+	userID := "guest"
+	userRole := "unknown"
+
+	if user != nil {
+		userID = fmt.Sprintf("%v", user.ID)
+		userRole = user.Role
+	}
+
+	return c.Log.WithFields(logrus.Fields{
+		"user_id":   userID,
+		"user_role": userRole,
+		"ip":        ctx.IP(),
+		"req_id":    ctx.Get("X-Request-ID"),
+	})
+}
+
+// GetIndex serves the sitemap index pointing at the sitemap of every entity, next to this route.
+func (c *SitemapController) GetIndex(ctx *fiber.Ctx) error {
+	entries, err := c.UseCase.GetIndex()
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Error("failed to get sitemap index")
+		return err
+	}
+
+	base := ctx.BaseURL() + strings.TrimSuffix(ctx.Path(), "sitemap.xml")
+	body, err := util.RenderSitemapIndex(entries, func(entityType string) string {
+		return base + "sitemaps/" + entityType + ".xml"
+	})
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Error("failed to render sitemap index")
+		return err
+	}
+
+	var lastModified time.Time
+	for _, e := range entries {
+		if e.LastMod != nil && e.LastMod.After(lastModified) {
+			lastModified = *e.LastMod
+		}
+	}
+	return sendCacheable(ctx, util.ContentTypeXML, body, lastModified, sitemapCacheControl)
+}
+
+func (c *SitemapController) GetSitemap(ctx *fiber.Ctx) error {
+	entityType := ctx.Params("entity")
+
+	sitemap, err := c.UseCase.GetSitemap(entityType)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
+			c.getLogger(ctx).WithField("entity_type", entityType).Warn("sitemap not found")
+		} else {
+			c.getLogger(ctx).WithField("entity_type", entityType).WithError(err).Error("failed to get sitemap")
+		}
+		return err
+	}
+
+	body, err := util.RenderSitemap(sitemap.URLs)
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Error("failed to render sitemap")
+		return err
+	}
+	return sendCacheable(ctx, util.ContentTypeXML, body, sitemap.Updated, sitemapCacheControl)
+}
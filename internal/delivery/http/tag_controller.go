@@ -0,0 +1,184 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type TagController struct {
+	UseCase usecase.TagUsecase
+	Log     *logrus.Logger
+}
+
+func NewTagController(usecase usecase.TagUsecase, log *logrus.Logger) *TagController {
+	return &TagController{UseCase: usecase, Log: log}
+}
+
+func (c *TagController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
+	user := middleware.GetUser(ctx)
+
+	userID := "guest"
+	userRole := "unknown"
+
+	if user != nil {
+		userID = fmt.Sprintf("%v", user.ID)
+		userRole = user.Role
+	}
+
+	return c.Log.WithFields(logrus.Fields{
+		"user_id":   userID,
+		"user_role": userRole,
+		"ip":        ctx.IP(),
+		"req_id":    ctx.Get("X-Request-ID"),
+	})
+}
+
+func (c *TagController) GetAll(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	data, paging, err := c.UseCase.GetAll(entityType, newListRequest(ctx))
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Error("failed to fetch tags")
+		return err
+	}
+	return ctx.JSON(model.WebResponse[any]{Data: data, Paging: paging})
+}
+
+func (c *TagController) GetAllPublic(ctx *fiber.Ctx) error {
+	data, paging, err := c.UseCase.GetAll(ctx.Query("entity_type", "pura"), newListRequest(ctx))
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Error("failed to fetch public tags")
+		return err
+	}
+	return ctx.JSON(model.WebResponse[any]{Data: data, Paging: paging})
+}
+
+func (c *TagController) GetByID(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	data, err := c.UseCase.GetByID(entityType, id)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
+			c.getLogger(ctx).WithField("tag_id", id).Warn("tag not found")
+		} else {
+			c.getLogger(ctx).WithField("tag_id", id).WithError(err).Error("failed to get tag by id")
+		}
+		return err
+	}
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
+
+func (c *TagController) Create(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	var req model.CreateTagRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		c.getLogger(ctx).Warnf("invalid request body: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body"})
+	}
+
+	data, err := c.UseCase.Create(ctx.UserContext(), entityType, req)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
+			c.getLogger(ctx).WithField("payload", req).Warnf("failed to create tag: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithField("payload", req).WithError(err).Error("failed to create tag")
+		}
+		return err
+	}
+
+	c.getLogger(ctx).WithField("tag_id", data.ID).Info("tag created successfully")
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse[any]{Data: data})
+}
+
+func (c *TagController) Update(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	var req model.UpdateTagRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		c.getLogger(ctx).Warnf("invalid request body: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body"})
+	}
+
+	data, err := c.UseCase.Update(ctx.UserContext(), entityType, id, req)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
+			c.getLogger(ctx).WithField("tag_id", id).Warn("attempted update on non-existent tag")
+		} else {
+			c.getLogger(ctx).WithFields(logrus.Fields{
+				"tag_id":  id,
+				"payload": req,
+			}).WithError(err).Error("failed to update tag")
+		}
+		return err
+	}
+
+	c.getLogger(ctx).WithField("tag_id", data.ID).Info("tag updated successfully")
+	return ctx.JSON(model.WebResponse[any]{Data: data})
+}
+
+func (c *TagController) Delete(ctx *fiber.Ctx) error {
+	val := ctx.Locals(middleware.CtxEntityType)
+	entityType, ok := val.(string)
+	if !ok {
+		c.getLogger(ctx).Error("entity_type missing from context locals")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{Errors: "Internal Configuration Error"})
+	}
+
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	if err := c.UseCase.Delete(ctx.UserContext(), entityType, id); err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
+			c.getLogger(ctx).WithField("tag_id", id).Warn("attempted delete non-existent tag")
+		} else {
+			c.getLogger(ctx).WithField("tag_id", id).WithError(err).Error("failed to delete tag")
+		}
+		return err
+	}
+
+	c.getLogger(ctx).WithField("tag_id", id).Info("tag deleted successfully")
+	return ctx.JSON(model.WebResponse[string]{Data: "Tag deleted successfully"})
+}
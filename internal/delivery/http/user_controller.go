@@ -44,6 +44,56 @@ func (c *UserController) getLogger(ctx *fiber.Ctx) *logrus.Entry {
 	})
 }
 
+func (c *UserController) setAuthCookies(ctx *fiber.Ctx, token *model.TokenPair) {
+	domain := c.Config.GetString("cookie.domain")
+
+	ctx.Cookie(&fiber.Cookie{
+		Name:     "access_token",
+		Value:    token.AccessToken,
+		HTTPOnly: true,
+		SameSite: "Lax",
+		Secure:   false,
+		Path:     "/",
+		Domain:   domain,
+		MaxAge:   int(token.AccessExpiresIn.Seconds()),
+	})
+
+	ctx.Cookie(&fiber.Cookie{
+		Name:     "refresh_token",
+		Value:    token.RefreshToken,
+		HTTPOnly: true,
+		SameSite: "Lax",
+		Secure:   false,
+		Path:     "/api/users",
+		Domain:   domain,
+		MaxAge:   int(token.RefreshExpiresIn.Seconds()),
+	})
+}
+
+func (c *UserController) clearAuthCookies(ctx *fiber.Ctx) {
+	domain := c.Config.GetString("cookie.domain")
+
+	ctx.Cookie(&fiber.Cookie{
+		Name:     "access_token",
+		Value:    "",
+		HTTPOnly: true,
+		SameSite: "Lax",
+		Path:     "/",
+		Domain:   domain,
+		MaxAge:   -1,
+	})
+
+	ctx.Cookie(&fiber.Cookie{
+		Name:     "refresh_token",
+		Value:    "",
+		HTTPOnly: true,
+		SameSite: "Lax",
+		Path:     "/api/users",
+		Domain:   domain,
+		MaxAge:   -1,
+	})
+}
+
 func (c *UserController) Login(ctx *fiber.Ctx) error {
 	req := new(model.LoginUserRequest)
 	if err := ctx.BodyParser(req); err != nil {
@@ -51,7 +101,10 @@ func (c *UserController) Login(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
 	}
 
-	response, tokenString, err := c.UseCase.Login(ctx.UserContext(), req)
+	req.IP = ctx.IP()
+	req.UserAgent = ctx.Get(fiber.HeaderUserAgent)
+
+	result, err := c.UseCase.Login(ctx.UserContext(), req)
 	if err != nil {
 		var e *model.ResponseError
 		if errors.As(err, &e) {
@@ -62,41 +115,137 @@ func (c *UserController) Login(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	domain := c.Config.GetString("cookie.domain")
+	if result.Challenge != nil {
+		c.getLogger(ctx).Info("Password accepted, waiting for second factor")
+		return ctx.JSON(model.WebResponse[*model.TwoFactorChallengeResponse]{Data: result.Challenge})
+	}
 
-	ctx.Cookie(&fiber.Cookie{
-		Name:     "access_token",
-		Value:    tokenString,
-		HTTPOnly: true,
-		SameSite: "Lax",
-		Secure:   false,
-		Path:     "/",
-		Domain:   domain,
-		MaxAge:   86400,
-	})
+	c.setAuthCookies(ctx, result.Token)
+
+	c.getLogger(ctx).WithFields(logrus.Fields{
+		"user_id":   result.User.ID,
+		"user_role": result.User.Role,
+	}).Info("User logged in successfully")
+
+	return ctx.JSON(model.WebResponse[*model.UserResponse]{Data: result.User})
+}
+
+func (c *UserController) VerifyTwoFactorLogin(ctx *fiber.Ctx) error {
+	req := new(model.VerifyTwoFactorLoginRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("Failed to parse request body: %+v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
+	}
+
+	req.IP = ctx.IP()
+	req.UserAgent = ctx.Get(fiber.HeaderUserAgent)
+
+	response, token, err := c.UseCase.VerifyTwoFactorLogin(ctx.UserContext(), req)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) {
+			c.getLogger(ctx).Warnf("Two-factor login failed: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithError(err).Error("Two-factor login system error")
+		}
+		return err
+	}
+
+	c.setAuthCookies(ctx, token)
 
 	c.getLogger(ctx).WithFields(logrus.Fields{
 		"user_id":   response.ID,
 		"user_role": response.Role,
-	}).Info("User logged in successfully")
+	}).Info("User logged in successfully with two-factor authentication")
 
 	return ctx.JSON(model.WebResponse[*model.UserResponse]{Data: response})
 }
 
-func (c *UserController) Logout(ctx *fiber.Ctx) error {
-	tokenString := ctx.Cookies("access_token")
+func (c *UserController) Refresh(ctx *fiber.Ctx) error {
+	refreshToken := ctx.Cookies("refresh_token")
+	if refreshToken == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(model.WebResponse[any]{Errors: fiber.ErrUnauthorized.Message})
+	}
 
-	err := c.UseCase.Logout(ctx.UserContext(), tokenString)
+	response, token, err := c.UseCase.Refresh(ctx.UserContext(), &model.RefreshTokenRequest{
+		RefreshToken: refreshToken,
+		IP:           ctx.IP(),
+		UserAgent:    ctx.Get(fiber.HeaderUserAgent),
+	})
 	if err != nil {
-		c.getLogger(ctx).WithError(err).Warn("Failed to revoke token during logout")
+		var e *model.ResponseError
+		if errors.As(err, &e) {
+			c.getLogger(ctx).Warnf("Token refresh rejected: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithError(err).Error("Token refresh system error")
+		}
+		c.clearAuthCookies(ctx)
+		return err
+	}
+
+	c.setAuthCookies(ctx, token)
+
+	return ctx.JSON(model.WebResponse[*model.UserResponse]{Data: response})
+}
+
+// Logout is a public route so that a session can still be ended once its access token has expired.
+func (c *UserController) Logout(ctx *fiber.Ctx) error {
+	req := &model.LogoutRequest{
+		RefreshToken: ctx.Cookies("refresh_token"),
+		AccessToken:  ctx.Cookies("access_token"),
+	}
+	if req.RefreshToken == "" && req.AccessToken == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(model.WebResponse[any]{Errors: fiber.ErrUnauthorized.Message})
 	}
 
-	ctx.ClearCookie("access_token")
+	err := c.UseCase.Logout(ctx.UserContext(), req)
+	c.clearAuthCookies(ctx)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) {
+			c.getLogger(ctx).Warnf("Logout rejected: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithError(err).Error("Logout system error")
+		}
+		return err
+	}
 
 	c.getLogger(ctx).Info("User logged out")
 	return ctx.JSON(model.WebResponse[bool]{Data: true})
 }
 
+func (c *UserController) ForgotPassword(ctx *fiber.Ctx) error {
+	req := new(model.ForgotPasswordRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("Failed to parse request body: %+v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
+	}
+
+	if err := c.UseCase.ForgotPassword(ctx.UserContext(), req); err != nil {
+		c.getLogger(ctx).WithError(err).Warn("Failed to process forgot password request")
+		return err
+	}
+
+	c.getLogger(ctx).Info("Password reset requested")
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *UserController) ResetPasswordWithToken(ctx *fiber.Ctx) error {
+	req := new(model.CompletePasswordResetRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("Failed to parse request body: %+v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
+	}
+
+	if err := c.UseCase.CompletePasswordReset(ctx.UserContext(), req); err != nil {
+		c.getLogger(ctx).WithError(err).Warn("Failed to reset password")
+		return err
+	}
+
+	c.getLogger(ctx).Info("Password reset completed")
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
 func (c *UserController) Current(ctx *fiber.Ctx) error {
 	auth := middleware.GetUser(ctx)
 	if auth == nil {
@@ -131,6 +280,8 @@ func (c *UserController) UpdateProfile(ctx *fiber.Ctx) error {
 		return fiber.ErrBadRequest
 	}
 
+	req.SessionID = auth.SessionID
+
 	response, err := c.UseCase.UpdateProfile(ctx.UserContext(), auth.ID, req)
 	if err != nil {
 		c.getLogger(ctx).WithError(err).Error("Failed to update profile")
@@ -140,3 +291,342 @@ func (c *UserController) UpdateProfile(ctx *fiber.Ctx) error {
 	c.getLogger(ctx).Info("User profile updated")
 	return ctx.JSON(model.WebResponse[*model.UserResponse]{Data: response})
 }
+
+func (c *UserController) GetAll(ctx *fiber.Ctx) error {
+	response, paging, err := c.UseCase.GetAll(ctx.UserContext(), newListRequest(ctx))
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Error("Failed to fetch users")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.UserResponse]{Data: response, Paging: paging})
+}
+
+func (c *UserController) GetByID(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	response, err := c.UseCase.GetByID(ctx.UserContext(), id)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code == fiber.StatusNotFound {
+			c.getLogger(ctx).WithField("target_user_id", id).Warn("User not found")
+		} else {
+			c.getLogger(ctx).WithField("target_user_id", id).WithError(err).Error("Failed to get user by id")
+		}
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.UserResponse]{Data: response})
+}
+
+func (c *UserController) Create(ctx *fiber.Ctx) error {
+	req := new(model.CreateUserRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("Failed to parse request body: %+v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
+	}
+
+	response, err := c.UseCase.Create(ctx.UserContext(), req)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code < fiber.StatusInternalServerError {
+			c.getLogger(ctx).WithField("email", req.Email).Warnf("Failed to create user: %s", e.Message)
+		} else {
+			c.getLogger(ctx).WithField("email", req.Email).WithError(err).Error("Failed to create user")
+		}
+		return err
+	}
+
+	c.getLogger(ctx).WithFields(logrus.Fields{
+		"target_user_id": response.ID,
+		"target_role":    response.Role,
+	}).Info("User created")
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse[*model.UserResponse]{Data: response})
+}
+
+func (c *UserController) UpdateRole(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth == nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(model.WebResponse[any]{Errors: fiber.ErrUnauthorized.Message})
+	}
+
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	req := new(model.UpdateUserRoleRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("Failed to parse request body: %+v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
+	}
+
+	response, err := c.UseCase.UpdateRole(ctx.UserContext(), auth.ID, id, req)
+	if err != nil {
+		c.getLogger(ctx).WithField("target_user_id", id).WithError(err).Warn("Failed to update user role")
+		return err
+	}
+
+	c.getLogger(ctx).WithFields(logrus.Fields{
+		"target_user_id": id,
+		"target_role":    response.Role,
+	}).Info("User role updated")
+
+	return ctx.JSON(model.WebResponse[*model.UserResponse]{Data: response})
+}
+
+func (c *UserController) UpdateStatus(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth == nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(model.WebResponse[any]{Errors: fiber.ErrUnauthorized.Message})
+	}
+
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	req := new(model.UpdateUserStatusRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("Failed to parse request body: %+v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
+	}
+
+	response, err := c.UseCase.UpdateStatus(ctx.UserContext(), auth.ID, id, req)
+	if err != nil {
+		c.getLogger(ctx).WithField("target_user_id", id).WithError(err).Warn("Failed to update user status")
+		return err
+	}
+
+	c.getLogger(ctx).WithFields(logrus.Fields{
+		"target_user_id": id,
+		"is_active":      response.IsActive,
+	}).Info("User status updated")
+
+	return ctx.JSON(model.WebResponse[*model.UserResponse]{Data: response})
+}
+
+func (c *UserController) ResetPassword(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	req := new(model.ResetUserPasswordRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("Failed to parse request body: %+v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
+	}
+
+	if err := c.UseCase.ResetPassword(ctx.UserContext(), id, req); err != nil {
+		c.getLogger(ctx).WithField("target_user_id", id).WithError(err).Warn("Failed to reset user password")
+		return err
+	}
+
+	c.getLogger(ctx).WithField("target_user_id", id).Info("User password reset")
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *UserController) ListCurrentSessions(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth == nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(model.WebResponse[any]{Errors: fiber.ErrUnauthorized.Message})
+	}
+
+	response, err := c.UseCase.ListSessions(ctx.UserContext(), auth.ID, auth.SessionID)
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Error("Failed to list sessions")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.SessionResponse]{Data: response})
+}
+
+func (c *UserController) RevokeCurrentSession(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth == nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(model.WebResponse[any]{Errors: fiber.ErrUnauthorized.Message})
+	}
+
+	sessionID := ctx.Params("id")
+	if sessionID == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	if err := c.UseCase.RevokeSession(ctx.UserContext(), auth.ID, sessionID); err != nil {
+		c.getLogger(ctx).WithField("session_id", sessionID).WithError(err).Warn("Failed to revoke session")
+		return err
+	}
+
+	if sessionID == auth.SessionID {
+		c.clearAuthCookies(ctx)
+	}
+
+	c.getLogger(ctx).WithField("session_id", sessionID).Info("Session revoked")
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *UserController) RevokeAllCurrentSessions(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth == nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(model.WebResponse[any]{Errors: fiber.ErrUnauthorized.Message})
+	}
+
+	if err := c.UseCase.RevokeAllSessions(ctx.UserContext(), auth.ID, ""); err != nil {
+		c.getLogger(ctx).WithError(err).Error("Failed to revoke all sessions")
+		return err
+	}
+
+	c.clearAuthCookies(ctx)
+
+	c.getLogger(ctx).Info("User logged out from all sessions")
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *UserController) ListUserSessions(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	response, err := c.UseCase.ListSessions(ctx.UserContext(), id, "")
+	if err != nil {
+		c.getLogger(ctx).WithField("target_user_id", id).WithError(err).Error("Failed to list user sessions")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.SessionResponse]{Data: response})
+}
+
+func (c *UserController) RevokeUserSessions(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	if err := c.UseCase.RevokeAllSessions(ctx.UserContext(), id, ""); err != nil {
+		c.getLogger(ctx).WithField("target_user_id", id).WithError(err).Error("Failed to force logout user")
+		return err
+	}
+
+	c.getLogger(ctx).WithField("target_user_id", id).Info("User forcibly logged out")
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *UserController) SetupTwoFactor(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth == nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(model.WebResponse[any]{Errors: fiber.ErrUnauthorized.Message})
+	}
+
+	response, err := c.UseCase.SetupTwoFactor(ctx.UserContext(), auth.ID)
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Warn("Failed to start two-factor setup")
+		return err
+	}
+
+	c.getLogger(ctx).Info("Two-factor setup started")
+	return ctx.JSON(model.WebResponse[*model.TwoFactorSetupResponse]{Data: response})
+}
+
+func (c *UserController) EnableTwoFactor(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth == nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(model.WebResponse[any]{Errors: fiber.ErrUnauthorized.Message})
+	}
+
+	req := new(model.TwoFactorCodeRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("Failed to parse request body: %+v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
+	}
+
+	response, err := c.UseCase.EnableTwoFactor(ctx.UserContext(), auth.ID, req)
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Warn("Failed to enable two-factor authentication")
+		return err
+	}
+
+	c.getLogger(ctx).Info("Two-factor authentication enabled")
+	return ctx.JSON(model.WebResponse[*model.RecoveryCodesResponse]{Data: response})
+}
+
+func (c *UserController) DisableTwoFactor(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth == nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(model.WebResponse[any]{Errors: fiber.ErrUnauthorized.Message})
+	}
+
+	req := new(model.DisableTwoFactorRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("Failed to parse request body: %+v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
+	}
+
+	if err := c.UseCase.DisableTwoFactor(ctx.UserContext(), auth.ID, req); err != nil {
+		c.getLogger(ctx).WithError(err).Warn("Failed to disable two-factor authentication")
+		return err
+	}
+
+	c.getLogger(ctx).Info("Two-factor authentication disabled")
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *UserController) RegenerateRecoveryCodes(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth == nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(model.WebResponse[any]{Errors: fiber.ErrUnauthorized.Message})
+	}
+
+	req := new(model.TwoFactorCodeRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		c.getLogger(ctx).Warnf("Failed to parse request body: %+v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid request body format"})
+	}
+
+	response, err := c.UseCase.RegenerateRecoveryCodes(ctx.UserContext(), auth.ID, req)
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Warn("Failed to regenerate recovery codes")
+		return err
+	}
+
+	c.getLogger(ctx).Info("Recovery codes regenerated")
+	return ctx.JSON(model.WebResponse[*model.RecoveryCodesResponse]{Data: response})
+}
+
+func (c *UserController) ResetTwoFactor(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{Errors: "Invalid ID"})
+	}
+
+	if err := c.UseCase.ResetTwoFactor(ctx.UserContext(), id); err != nil {
+		c.getLogger(ctx).WithField("target_user_id", id).WithError(err).Warn("Failed to reset two-factor authentication")
+		return err
+	}
+
+	c.getLogger(ctx).WithField("target_user_id", id).Info("User two-factor authentication reset")
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *UserController) GetLoginAttempts(ctx *fiber.Ctx) error {
+	req := &model.SearchLoginAttemptRequest{
+		Email:  ctx.Query("email"),
+		UserID: ctx.Query("user_id"),
+		IP:     ctx.Query("ip"),
+		Result: ctx.Query("result"),
+		Limit:  ctx.QueryInt("limit", 0),
+	}
+
+	response, err := c.UseCase.GetLoginAttempts(ctx.UserContext(), req)
+	if err != nil {
+		c.getLogger(ctx).WithError(err).Warn("Failed to fetch login attempts")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.LoginAttemptResponse]{Data: response})
+}
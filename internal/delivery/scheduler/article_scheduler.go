@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"context"
+	"pura-agung-kertajaya-backend/internal/usecase"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const DefaultArticleScheduleInterval = time.Minute
+
+// ArticleScheduler publishes scheduled articles and archives expired ones in the background.
+// Every instance runs its own scheduler; ArticleUsecase.ApplySchedule keeps that safe.
+type ArticleScheduler struct {
+	UseCase  usecase.ArticleUsecase
+	Log      *logrus.Logger
+	Interval time.Duration
+	Now      func() time.Time
+}
+
+func NewArticleScheduler(useCase usecase.ArticleUsecase, log *logrus.Logger, interval time.Duration) *ArticleScheduler {
+	if interval <= 0 {
+		interval = DefaultArticleScheduleInterval
+	}
+
+	return &ArticleScheduler{
+		UseCase:  useCase,
+		Log:      log,
+		Interval: interval,
+		Now:      time.Now,
+	}
+}
+
+// Run applies the schedule right away and then every interval until ctx is cancelled.
+func (s *ArticleScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.apply(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ArticleScheduler) apply(ctx context.Context) {
+	result, err := s.UseCase.ApplySchedule(ctx, s.Now())
+	if err != nil {
+		if ctx.Err() == nil {
+			s.Log.WithError(err).Error("Failed to apply article schedule")
+		}
+		return
+	}
+
+	if result.Published > 0 || result.Archived > 0 {
+		s.Log.WithFields(logrus.Fields{
+			"published": result.Published,
+			"archived":  result.Archived,
+		}).Info("Applied article schedule")
+	}
+}
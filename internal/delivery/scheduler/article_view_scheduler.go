@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"context"
+	"pura-agung-kertajaya-backend/internal/usecase"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const DefaultArticleViewFlushInterval = 5 * time.Minute
+
+// ArticleViewScheduler moves the article views buffered in Redis into the database in the background.
+type ArticleViewScheduler struct {
+	UseCase  usecase.ArticleViewUsecase
+	Log      *logrus.Logger
+	Interval time.Duration
+}
+
+func NewArticleViewScheduler(useCase usecase.ArticleViewUsecase, log *logrus.Logger, interval time.Duration) *ArticleViewScheduler {
+	if interval <= 0 {
+		interval = DefaultArticleViewFlushInterval
+	}
+
+	return &ArticleViewScheduler{
+		UseCase:  useCase,
+		Log:      log,
+		Interval: interval,
+	}
+}
+
+// Run flushes the views every interval until ctx is cancelled.
+func (s *ArticleViewScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.flush(ctx)
+		}
+	}
+}
+
+func (s *ArticleViewScheduler) flush(ctx context.Context) {
+	flushed, err := s.UseCase.FlushViews(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.Log.WithError(err).Error("Failed to flush article views")
+		}
+		return
+	}
+
+	if flushed > 0 {
+		s.Log.WithField("views", flushed).Info("Flushed article views")
+	}
+}
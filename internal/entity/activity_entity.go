@@ -5,17 +5,43 @@ import "time"
 type Activity struct {
 	ID          string    `gorm:"column:id;primaryKey;type:varchar(100)"`
 	EntityType  string    `gorm:"column:entity_type;type:enum('pura','yayasan','pasraman');default:pura';not null;index"`
-	Title       string    `gorm:"column:title;type:varchar(150);not null"`
-	Description string    `gorm:"column:description;type:text;not null"`
+	Title       string    `gorm:"column:title;type:varchar(150);not null;index:idx_activities_search,class:FULLTEXT"`
+	Description string    `gorm:"column:description;type:text;not null;index:idx_activities_search,class:FULLTEXT"`
 	TimeInfo    string    `gorm:"column:time_info;type:varchar(100)"`
-	Location    string    `gorm:"column:location;type:varchar(100)"`
+	Location    string    `gorm:"column:location;type:varchar(100);index:idx_activities_search,class:FULLTEXT"`
 	EventDate   time.Time `gorm:"column:event_date;type:datetime"`
 	OrderIndex  int       `gorm:"column:order_index;not null;default:1"`
 	IsActive    bool      `gorm:"column:is_active"`
 	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt   time.Time `gorm:"column:updated_at;autoUpdateTime"`
+
+	// An activity with a rule recurs from EventDate: RecurrenceRule is an RFC 5545 RRULE and
+	// PawukonRule a Pawukon day such as "Buda Kliwon Matal" that comes back every 210 days.
+	RecurrenceRule string `gorm:"column:recurrence_rule;type:varchar(255)"`
+	PawukonRule    string `gorm:"column:pawukon_rule;type:varchar(50)"`
 }
 
 func (Activity) TableName() string {
 	return "activities"
 }
+
+// ActivityException cancels or changes one occurrence of a recurring activity. OccurrenceDate is the
+// date the rule puts the occurrence on; empty fields keep the value of the activity.
+type ActivityException struct {
+	ID             string     `gorm:"column:id;primaryKey;type:varchar(100)"`
+	ActivityID     string     `gorm:"column:activity_id;type:varchar(100);not null;uniqueIndex:idx_activity_exceptions_occurrence"`
+	Activity       *Activity  `gorm:"foreignKey:ActivityID;constraint:OnDelete:CASCADE"`
+	OccurrenceDate time.Time  `gorm:"column:occurrence_date;type:date;not null;uniqueIndex:idx_activity_exceptions_occurrence"`
+	IsCancelled    bool       `gorm:"column:is_cancelled;not null"`
+	Title          string     `gorm:"column:title;type:varchar(150)"`
+	Description    string     `gorm:"column:description;type:text"`
+	TimeInfo       string     `gorm:"column:time_info;type:varchar(100)"`
+	Location       string     `gorm:"column:location;type:varchar(100)"`
+	EventDate      *time.Time `gorm:"column:event_date;type:datetime"`
+	CreatedAt      time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (ActivityException) TableName() string {
+	return "activity_exceptions"
+}
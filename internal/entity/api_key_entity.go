@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIKey authenticates a machine client. Only the SHA-256 hash of the key is stored;
+// Prefix keeps the first characters so admins can recognise a key in the CMS.
+type APIKey struct {
+	ID         string        `gorm:"column:id;primaryKey;type:varchar(100)"`
+	Name       string        `gorm:"column:name;size:100;not null"`
+	Prefix     string        `gorm:"column:prefix;size:20;not null"`
+	KeyHash    string        `gorm:"column:key_hash;size:64;unique;not null"`
+	CreatedBy  string        `gorm:"column:created_by;type:varchar(100)"`
+	LastUsedAt *time.Time    `gorm:"column:last_used_at"`
+	RevokedAt  *time.Time    `gorm:"column:revoked_at"`
+	Scopes     []APIKeyScope `gorm:"foreignKey:APIKeyID;references:ID"`
+	CreatedAt  time.Time     `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt  time.Time     `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+func (k *APIKey) BeforeCreate(tx *gorm.DB) (err error) {
+	if k.ID == "" {
+		k.ID = uuid.New().String()
+	}
+	return
+}
+
+type APIKeyScope struct {
+	APIKeyID   string `gorm:"column:api_key_id;primaryKey;type:varchar(100)"`
+	EntityType string `gorm:"column:entity_type;primaryKey;type:enum('pura','yayasan','pasraman')"`
+	Permission string `gorm:"column:permission;primaryKey;size:50"`
+}
+
+func (APIKeyScope) TableName() string {
+	return "api_key_scopes"
+}
@@ -12,24 +12,46 @@ type ArticleStatus string
 
 const (
 	ArticleStatusDraft     ArticleStatus = "DRAFT"
+	ArticleStatusInReview  ArticleStatus = "IN_REVIEW"
+	ArticleStatusScheduled ArticleStatus = "SCHEDULED"
 	ArticleStatusPublished ArticleStatus = "PUBLISHED"
 	ArticleStatusArchived  ArticleStatus = "ARCHIVED"
 )
 
 type Article struct {
 	ID          string        `gorm:"column:id;primaryKey;type:varchar(100)"`
+	EntityType  string        `gorm:"column:entity_type;type:enum('pura','yayasan','pasraman');default:'pura';not null;uniqueIndex:idx_articles_entity_slug,priority:1"`
 	CategoryID  *string       `gorm:"column:category_id;type:varchar(100)"`
 	Category    *Category     `gorm:"foreignKey:CategoryID"`
-	Title       string        `gorm:"column:title;type:varchar(255);not null"`
-	Slug        string        `gorm:"column:slug;type:varchar(255);unique;not null;index"`
+	Tags        []Tag         `gorm:"many2many:article_tags"`
+	Title       string        `gorm:"column:title;type:varchar(255);not null;index:idx_articles_search,class:FULLTEXT"`
+	Slug        string        `gorm:"column:slug;type:varchar(255);not null;uniqueIndex:idx_articles_entity_slug,priority:2"`
 	AuthorName  string        `gorm:"column:author_name;type:varchar(100);not null"`
 	AuthorRole  string        `gorm:"column:author_role;type:varchar(100)"`
-	Excerpt     string        `gorm:"column:excerpt;type:text"`
+	Excerpt     string        `gorm:"column:excerpt;type:text;index:idx_articles_search,class:FULLTEXT"`
 	Content     string        `gorm:"column:content;type:longtext"`
 	Images      util.ImageMap `gorm:"column:images;types:json"`
-	Status      ArticleStatus `gorm:"column:status;type:enum('DRAFT','PUBLISHED','ARCHIVED');default:'DRAFT';index"`
+	Status      ArticleStatus `gorm:"column:status;type:enum('DRAFT','IN_REVIEW','SCHEDULED','PUBLISHED','ARCHIVED');default:'DRAFT';index"`
 	IsFeatured  bool          `gorm:"column:is_featured;default:false;index"`
 	PublishedAt *time.Time    `gorm:"column:published_at"`
+	ExpiresAt   *time.Time    `gorm:"column:expires_at;index"`
+
+	ContentFormat      string `gorm:"column:content_format;type:enum('html','markdown','blocks');default:'html';not null"`
+	ContentHTML        string `gorm:"column:content_html;type:longtext"`
+	ContentText        string `gorm:"column:content_text;type:longtext;index:idx_articles_search,class:FULLTEXT"`
+	WordCount          int    `gorm:"column:word_count;not null;default:0"`
+	ReadingTimeMinutes int    `gorm:"column:reading_time_minutes;not null;default:0"`
+
+	MetaTitle       string `gorm:"column:meta_title;type:varchar(70)"`
+	MetaDescription string `gorm:"column:meta_description;type:varchar(160)"`
+	CanonicalURL    string `gorm:"column:canonical_url;type:varchar(255)"`
+	OGImageVariant  string `gorm:"column:og_image_variant;type:varchar(10)"`
+
+	ReviewComment string     `gorm:"column:review_comment;type:text"`
+	SubmittedBy   *string    `gorm:"column:submitted_by;type:varchar(100)"`
+	SubmittedAt   *time.Time `gorm:"column:submitted_at"`
+	ReviewedBy    *string    `gorm:"column:reviewed_by;type:varchar(100)"`
+	ReviewedAt    *time.Time `gorm:"column:reviewed_at"`
 
 	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
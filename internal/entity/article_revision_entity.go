@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"pura-agung-kertajaya-backend/internal/util"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ArticleRevision is a snapshot of an article as it was saved. Versions count up from 1 per article.
+type ArticleRevision struct {
+	ID            string        `gorm:"column:id;primaryKey;type:varchar(100)"`
+	ArticleID     string        `gorm:"column:article_id;type:varchar(100);not null;uniqueIndex:idx_article_revisions_version"`
+	Article       *Article      `gorm:"foreignKey:ArticleID;constraint:OnDelete:CASCADE"`
+	Version       int           `gorm:"column:version;not null;uniqueIndex:idx_article_revisions_version"`
+	CategoryID    *string       `gorm:"column:category_id;type:varchar(100)"`
+	Title         string        `gorm:"column:title;type:varchar(255);not null"`
+	Slug          string        `gorm:"column:slug;type:varchar(255);not null"`
+	AuthorName    string        `gorm:"column:author_name;type:varchar(100);not null"`
+	AuthorRole    string        `gorm:"column:author_role;type:varchar(100)"`
+	Excerpt       string        `gorm:"column:excerpt;type:text"`
+	Content       string        `gorm:"column:content;type:longtext"`
+	ContentFormat string        `gorm:"column:content_format;type:varchar(10)"`
+	Images        util.ImageMap `gorm:"column:images;type:json"`
+	Status        ArticleStatus `gorm:"column:status;type:enum('DRAFT','IN_REVIEW','SCHEDULED','PUBLISHED','ARCHIVED');not null"`
+	IsFeatured    bool          `gorm:"column:is_featured;default:false"`
+	PublishedAt   *time.Time    `gorm:"column:published_at"`
+	ExpiresAt     *time.Time    `gorm:"column:expires_at"`
+	RestoredFrom  *int          `gorm:"column:restored_from"`
+	CreatedBy     *string       `gorm:"column:created_by;type:varchar(100)"`
+	CreatedAt     time.Time     `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (ArticleRevision) TableName() string {
+	return "article_revisions"
+}
+
+func (r *ArticleRevision) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return
+}
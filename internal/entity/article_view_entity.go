@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// ArticleView is the number of counted views of an article on one day.
+type ArticleView struct {
+	ArticleID string    `gorm:"column:article_id;primaryKey;type:varchar(100)"`
+	ViewDate  time.Time `gorm:"column:view_date;primaryKey;type:date;index"`
+	Views     int64     `gorm:"column:views;not null;default:0"`
+}
+
+func (v *ArticleView) TableName() string {
+	return "article_views"
+}
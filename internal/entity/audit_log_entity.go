@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditChanges maps a column name to its value before and after the mutation.
+type AuditChanges map[string]AuditChange
+
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(c)
+	return string(b), err
+}
+
+func (c *AuditChanges) Scan(value interface{}) error {
+	if value == nil {
+		*c = nil
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("type assertion to []byte or string failed")
+	}
+
+	return json.Unmarshal(bytes, c)
+}
+
+func (AuditChanges) GormDataType() string {
+	return "json"
+}
+
+type AuditLog struct {
+	ID         string       `gorm:"column:id;primaryKey;type:varchar(100)"`
+	ActorID    *string      `gorm:"column:actor_id;type:varchar(100);index"`
+	ActorRole  string       `gorm:"column:actor_role;size:50;not null"`
+	EntityType string       `gorm:"column:entity_type;size:20;index"`
+	Resource   string       `gorm:"column:resource;size:100;not null"`
+	RecordID   string       `gorm:"column:record_id;type:varchar(100);not null"`
+	Action     AuditAction  `gorm:"column:action;type:enum('create','update','delete');not null"`
+	Changes    AuditChanges `gorm:"column:changes;type:json"`
+	IP         string       `gorm:"column:ip;size:45"`
+	RequestID  string       `gorm:"column:request_id;size:100"`
+	CreatedAt  time.Time    `gorm:"column:created_at;autoCreateTime;index"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+func (a *AuditLog) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return
+}
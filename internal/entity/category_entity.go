@@ -8,11 +8,12 @@ import (
 )
 
 type Category struct {
-	ID        string    `gorm:"column:id;primaryKey;type:varchar(100)"`
-	Name      string    `gorm:"column:name;type:varchar(100);not null"`
-	Slug      string    `gorm:"column:slug;type:varchar(100);unique;not null;index"`
-	CreatedAt time.Time `gorm:"created_at;autoCreateTime"`
-	UpdatedAt time.Time `gorm:"updated_at;autoUpdateTime"`
+	ID         string    `gorm:"column:id;primaryKey;type:varchar(100)"`
+	EntityType string    `gorm:"column:entity_type;type:enum('pura','yayasan','pasraman');default:'pura';not null;uniqueIndex:idx_categories_entity_slug,priority:1"`
+	Name       string    `gorm:"column:name;type:varchar(100);not null"`
+	Slug       string    `gorm:"column:slug;type:varchar(100);not null;uniqueIndex:idx_categories_entity_slug,priority:2"`
+	CreatedAt  time.Time `gorm:"created_at;autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"updated_at;autoUpdateTime"`
 }
 
 func (Category) TableName() string {
@@ -8,8 +8,8 @@ import (
 type Gallery struct {
 	ID          string        `gorm:"column:id;primaryKey;type:varchar(100)"`
 	EntityType  string        `gorm:"column:entity_type;type:enum('pura','yayasan','pasraman');default:pura';not null;index"`
-	Title       string        `gorm:"column:title;type:varchar(150);not null"`
-	Description string        `gorm:"column:description;type:text"`
+	Title       string        `gorm:"column:title;type:varchar(150);not null;index:idx_galleries_search,class:FULLTEXT"`
+	Description string        `gorm:"column:description;type:text;index:idx_galleries_search,class:FULLTEXT"`
 	Images      util.ImageMap `gorm:"column:images;type:json"`
 	OrderIndex  int           `gorm:"column:order_index;default:1"`
 	IsActive    bool          `gorm:"column:is_active;"`
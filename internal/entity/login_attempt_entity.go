@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LoginAttemptResult string
+
+const (
+	LoginAttemptSuccess             LoginAttemptResult = "SUCCESS"
+	LoginAttemptInvalidCredentials  LoginAttemptResult = "INVALID_CREDENTIALS"
+	LoginAttemptInvalidSecondFactor LoginAttemptResult = "INVALID_SECOND_FACTOR"
+	LoginAttemptLocked              LoginAttemptResult = "LOCKED"
+	LoginAttemptDisabled            LoginAttemptResult = "DISABLED"
+)
+
+type LoginAttempt struct {
+	ID        string             `gorm:"column:id;primaryKey;type:varchar(100)"`
+	Email     string             `gorm:"column:email;size:100;not null;index"`
+	UserID    *string            `gorm:"column:user_id;type:varchar(100);index"`
+	IP        string             `gorm:"column:ip;size:45"`
+	UserAgent string             `gorm:"column:user_agent;size:255"`
+	Result    LoginAttemptResult `gorm:"column:result;type:enum('SUCCESS','INVALID_CREDENTIALS','INVALID_SECOND_FACTOR','LOCKED','DISABLED');not null"`
+	CreatedAt time.Time          `gorm:"column:created_at;autoCreateTime;index"`
+}
+
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
+
+func (a *LoginAttempt) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return
+}
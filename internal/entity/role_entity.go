@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	RoleEditor   = "editor"
+	RoleReviewer = "reviewer"
+)
+
+type Role struct {
+	ID          string           `gorm:"column:id;primaryKey;type:varchar(100)"`
+	Name        string           `gorm:"column:name;size:50;unique;not null"`
+	Description string           `gorm:"column:description;size:255"`
+	IsSystem    bool             `gorm:"column:is_system;not null;default:false"`
+	Permissions []RolePermission `gorm:"foreignKey:RoleID;references:ID"`
+	CreatedAt   time.Time        `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time        `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Role) TableName() string {
+	return "roles"
+}
+
+func (r *Role) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return
+}
+
+type RolePermission struct {
+	RoleID     string `gorm:"column:role_id;primaryKey;type:varchar(100)"`
+	Permission string `gorm:"column:permission;primaryKey;size:50"`
+}
+
+func (RolePermission) TableName() string {
+	return "role_permissions"
+}
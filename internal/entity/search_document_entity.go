@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+const (
+	SearchTypeArticle  = "article"
+	SearchTypeActivity = "activity"
+	SearchTypeGallery  = "gallery"
+	SearchTypeFacility = "facility"
+)
+
+// SearchDocument is a single search hit, read from whichever table the search backend matched.
+// It has no table of its own.
+type SearchDocument struct {
+	Type       string     `gorm:"column:type"`
+	ID         string     `gorm:"column:id"`
+	EntityType string     `gorm:"column:entity_type"`
+	Title      string     `gorm:"column:title"`
+	Slug       string     `gorm:"column:slug"`
+	Body       string     `gorm:"column:body"`
+	Date       *time.Time `gorm:"column:date"`
+	Score      float64    `gorm:"column:score"`
+}
@@ -1,6 +1,9 @@
 package entity
 
-import "time"
+import (
+	"pura-agung-kertajaya-backend/internal/util"
+	"time"
+)
 
 // SiteIdentity mirrors the DB schema from migrations: site_identity
 // id VARCHAR(100) PRIMARY KEY,
@@ -11,21 +14,33 @@ import "time"
 // primary_button_link VARCHAR(255),
 // secondary_button_text VARCHAR(50),
 // secondary_button_link VARCHAR(255),
+// meta_title VARCHAR(70),
+// meta_description VARCHAR(160),
+// canonical_url VARCHAR(255),
+// og_images JSON,
+// og_image_variant VARCHAR(10),
 // created_at TIMESTAMP,
 // updated_at TIMESTAMP
 
 type SiteIdentity struct {
-	ID                  string    `gorm:"column:id;primaryKey;type:varchar(100)"`
-	EntityType          string    `gorm:"column:entity_type;type:enum('pura', 'yayasan', 'pasraman');not null;default:'pura'"`
-	SiteName            string    `gorm:"column:site_name;type:varchar(150);not null"`
-	LogoURL             string    `gorm:"column:logo_url;type:text"`
-	Tagline             string    `gorm:"column:tagline;type:varchar(255)"`
-	PrimaryButtonText   string    `gorm:"column:primary_button_text;type:varchar(50)"`
-	PrimaryButtonLink   string    `gorm:"column:primary_button_link;type:varchar(255)"`
-	SecondaryButtonText string    `gorm:"column:secondary_button_text;type:varchar(50)"`
-	SecondaryButtonLink string    `gorm:"column:secondary_button_link;type:varchar(255)"`
-	CreatedAt           time.Time `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt           time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	ID                  string `gorm:"column:id;primaryKey;type:varchar(100)"`
+	EntityType          string `gorm:"column:entity_type;type:enum('pura', 'yayasan', 'pasraman');not null;default:'pura'"`
+	SiteName            string `gorm:"column:site_name;type:varchar(150);not null"`
+	LogoURL             string `gorm:"column:logo_url;type:text"`
+	Tagline             string `gorm:"column:tagline;type:varchar(255)"`
+	PrimaryButtonText   string `gorm:"column:primary_button_text;type:varchar(50)"`
+	PrimaryButtonLink   string `gorm:"column:primary_button_link;type:varchar(255)"`
+	SecondaryButtonText string `gorm:"column:secondary_button_text;type:varchar(50)"`
+	SecondaryButtonLink string `gorm:"column:secondary_button_link;type:varchar(255)"`
+
+	MetaTitle       string        `gorm:"column:meta_title;type:varchar(70)"`
+	MetaDescription string        `gorm:"column:meta_description;type:varchar(160)"`
+	CanonicalURL    string        `gorm:"column:canonical_url;type:varchar(255)"`
+	OGImages        util.ImageMap `gorm:"column:og_images;type:json"`
+	OGImageVariant  string        `gorm:"column:og_image_variant;type:varchar(10)"`
+
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
 }
 
 func (SiteIdentity) TableName() string { return "site_identity" }
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Tag struct {
+	ID         string    `gorm:"column:id;primaryKey;type:varchar(100)"`
+	EntityType string    `gorm:"column:entity_type;type:enum('pura','yayasan','pasraman');default:'pura';not null;uniqueIndex:idx_tags_entity_slug,priority:1"`
+	Name       string    `gorm:"column:name;type:varchar(50);not null"`
+	Slug       string    `gorm:"column:slug;type:varchar(50);not null;uniqueIndex:idx_tags_entity_slug,priority:2"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (Tag) TableName() string {
+	return "tags"
+}
+
+func (t *Tag) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	return
+}
+
+type ArticleTag struct {
+	ArticleID string `gorm:"column:article_id;primaryKey;type:varchar(100)"`
+	TagID     string `gorm:"column:tag_id;primaryKey;type:varchar(100);index"`
+}
+
+func (ArticleTag) TableName() string {
+	return "article_tags"
+}
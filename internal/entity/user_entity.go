@@ -8,13 +8,16 @@ import (
 )
 
 type User struct {
-	ID        string    `gorm:"column:id;primaryKey;type:varchar(100)"`
-	Name      string    `gorm:"column:name;size:100;not null"`
-	Email     string    `gorm:"column:email;size:100;unique;not null"`
-	Password  string    `gorm:"column:password;size:100;not null"`
-	Role      string    `gorm:"column:role;type:enum('pura','yayasan','pasraman','super');not null"`
-	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	ID               string    `gorm:"column:id;primaryKey;type:varchar(100)"`
+	Name             string    `gorm:"column:name;size:100;not null"`
+	Email            string    `gorm:"column:email;size:100;unique;not null"`
+	Password         string    `gorm:"column:password;size:100;not null"`
+	Role             string    `gorm:"column:role;type:enum('pura','yayasan','pasraman','super');not null"`
+	IsActive         bool      `gorm:"column:is_active;not null;default:true"`
+	TwoFactorSecret  string    `gorm:"column:two_factor_secret;size:64"`
+	TwoFactorEnabled bool      `gorm:"column:two_factor_enabled;not null;default:false"`
+	CreatedAt        time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt        time.Time `gorm:"column:updated_at;autoUpdateTime"`
 }
 
 func (User) TableName() string {
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UserRecoveryCode struct {
+	ID        string     `gorm:"column:id;primaryKey;type:varchar(100)"`
+	UserID    string     `gorm:"column:user_id;type:varchar(100);not null;index"`
+	CodeHash  string     `gorm:"column:code_hash;type:char(64);not null"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (UserRecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
+
+func (c *UserRecoveryCode) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return
+}
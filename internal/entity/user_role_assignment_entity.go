@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UserRoleAssignment struct {
+	ID         string    `gorm:"column:id;primaryKey;type:varchar(100)"`
+	UserID     string    `gorm:"column:user_id;type:varchar(100);not null;index"`
+	RoleID     string    `gorm:"column:role_id;type:varchar(100);not null"`
+	EntityType string    `gorm:"column:entity_type;type:enum('pura','yayasan','pasraman');not null"`
+	Role       Role      `gorm:"foreignKey:RoleID;references:ID"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (UserRoleAssignment) TableName() string {
+	return "user_role_assignments"
+}
+
+func (a *UserRoleAssignment) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return
+}
@@ -11,9 +11,13 @@ type CreateActivityRequest struct {
 	EventDate   string `json:"event_date" validate:"required,datetime=2006-01-02"`
 	OrderIndex  int    `json:"order_index" validate:"required,min=1"`
 	IsActive    bool   `json:"is_active" validate:"boolean"`
+
+	RecurrenceRule string `json:"recurrence_rule" validate:"omitempty,max=255"`
+	PawukonRule    string `json:"pawukon_rule" validate:"omitempty,max=50"`
 }
 
 type UpdateActivityRequest struct {
+	EntityType  string `json:"entity_type" validate:"omitempty,oneof=pura yayasan pasraman"`
 	Title       string `json:"title" validate:"required,min=1,max=150"`
 	Description string `json:"description" validate:"required"`
 	TimeInfo    string `json:"time_info" validate:"omitempty,max=100"`
@@ -21,6 +25,9 @@ type UpdateActivityRequest struct {
 	EventDate   string `json:"event_date" validate:"required,datetime=2006-01-02"`
 	OrderIndex  int    `json:"order_index" validate:"required,min=1"`
 	IsActive    bool   `json:"is_active" validate:"boolean"`
+
+	RecurrenceRule string `json:"recurrence_rule" validate:"omitempty,max=255"`
+	PawukonRule    string `json:"pawukon_rule" validate:"omitempty,max=50"`
 }
 type ActivityResponse struct {
 	ID          string    `json:"id"`
@@ -34,4 +41,40 @@ type ActivityResponse struct {
 	IsActive    bool      `json:"is_active"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+
+	RecurrenceRule string `json:"recurrence_rule,omitempty"`
+	PawukonRule    string `json:"pawukon_rule,omitempty"`
+	// OccurrenceDate is the date the rule puts an occurrence of a recurring activity on. It is set
+	// only when occurrences are listed, and identifies the occurrence to its exception.
+	OccurrenceDate string `json:"occurrence_date,omitempty"`
+}
+
+// DateRange is a window of whole dates; both ends are included.
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// ActivityExceptionRequest cancels one occurrence or overrides it; empty fields keep the activity's value.
+type ActivityExceptionRequest struct {
+	IsCancelled bool   `json:"is_cancelled" validate:"boolean"`
+	Title       string `json:"title" validate:"omitempty,max=150"`
+	Description string `json:"description"`
+	TimeInfo    string `json:"time_info" validate:"omitempty,max=100"`
+	Location    string `json:"location" validate:"omitempty,max=100"`
+	EventDate   string `json:"event_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type ActivityExceptionResponse struct {
+	ID             string     `json:"id"`
+	ActivityID     string     `json:"activity_id"`
+	OccurrenceDate string     `json:"occurrence_date"`
+	IsCancelled    bool       `json:"is_cancelled"`
+	Title          string     `json:"title,omitempty"`
+	Description    string     `json:"description,omitempty"`
+	TimeInfo       string     `json:"time_info,omitempty"`
+	Location       string     `json:"location,omitempty"`
+	EventDate      *time.Time `json:"event_date,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
 }
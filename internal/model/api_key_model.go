@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+type APIKeyResponse struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Prefix      string     `json:"prefix"`
+	EntityTypes []string   `json:"entity_types"`
+	Permissions []string   `json:"permissions"`
+	CreatedBy   string     `json:"created_by"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// APIKeySecretResponse carries the plain key. It is only returned when a key is created or rotated.
+type APIKeySecretResponse struct {
+	APIKey *APIKeyResponse `json:"api_key"`
+	Key    string          `json:"key"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name        string   `json:"name" validate:"required,min=2,max=100"`
+	EntityTypes []string `json:"entity_types" validate:"required,min=1,dive,oneof=pura yayasan pasraman"`
+	Permissions []string `json:"permissions" validate:"required,min=1,dive,required"`
+}
+
+type UpdateAPIKeyRequest struct {
+	Name        string   `json:"name" validate:"required,min=2,max=100"`
+	EntityTypes []string `json:"entity_types" validate:"required,min=1,dive,oneof=pura yayasan pasraman"`
+	Permissions []string `json:"permissions" validate:"required,min=1,dive,required"`
+}
+
+// APIKeyAuth is the identity resolved from a valid API key.
+type APIKeyAuth struct {
+	ID     string
+	Name   string
+	Grants PermissionGrants
+}
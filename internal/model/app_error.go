@@ -27,4 +27,5 @@ var (
 	ErrForbidden    = func(msg string) *ResponseError {
 		return NewError(http.StatusForbidden, msg)
 	}
+	ErrTooManyRequests = func(msg string) *ResponseError { return NewError(http.StatusTooManyRequests, msg) }
 )
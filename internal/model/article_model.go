@@ -2,25 +2,49 @@ package model
 
 import "time"
 
+// ArticleResponse carries the body as the editor wrote it in Content for the admin API only;
+// public responses leave it out and carry the sanitized ContentHTML.
 type ArticleResponse struct {
 	ID          string            `json:"id"`
+	EntityType  string            `json:"entity_type"`
 	Category    *CategoryResponse `json:"category,omitempty"`
+	Tags        []TagResponse     `json:"tags,omitempty"`
 	Title       string            `json:"title"`
 	Slug        string            `json:"slug"`
 	AuthorName  string            `json:"author_name"`
 	AuthorRole  string            `json:"author_role"`
 	Excerpt     string            `json:"excerpt"`
-	Content     string            `json:"content"`
+	Content     string            `json:"content,omitempty"`
 	Images      ImageVariants     `json:"images"`
 	Status      string            `json:"status"`
 	IsFeatured  bool              `json:"is_featured"`
 	PublishedAt *time.Time        `json:"published_at"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
+	ExpiresAt   *time.Time        `json:"expires_at"`
+
+	ContentFormat      string `json:"content_format"`
+	ContentHTML        string `json:"content_html"`
+	WordCount          int    `json:"word_count"`
+	ReadingTimeMinutes int    `json:"reading_time_minutes"`
+
+	MetaTitle       string `json:"meta_title"`
+	MetaDescription string `json:"meta_description"`
+	CanonicalURL    string `json:"canonical_url"`
+	OGImageVariant  string `json:"og_image_variant"`
+
+	ReviewComment string     `json:"review_comment,omitempty"`
+	SubmittedBy   *string    `json:"submitted_by,omitempty"`
+	SubmittedAt   *time.Time `json:"submitted_at,omitempty"`
+	ReviewedBy    *string    `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type CreateArticleRequest struct {
+	EntityType  string            `json:"entity_type" validate:"required,oneof=pura yayasan pasraman"`
 	CategoryID  string            `json:"category_id"`
+	TagIDs      []string          `json:"tag_ids" validate:"max=20,dive,required"`
 	Title       string            `json:"title" validate:"required,min=5,max=200"`
 	AuthorName  string            `json:"author_name" validate:"required,min=2,max=100"`
 	AuthorRole  string            `json:"author_role" validate:"omitempty,max=100"`
@@ -28,12 +52,26 @@ type CreateArticleRequest struct {
 	Content     string            `json:"content" validate:"required,min=10"`
 	Images      map[string]string `json:"images" validate:"required"`
 	IsFeatured  bool              `json:"is_featured"`
-	Status      string            `json:"status" validate:"required,oneof=DRAFT PUBLISHED ARCHIVED"`
+	Status      string            `json:"status" validate:"required,oneof=DRAFT SCHEDULED PUBLISHED ARCHIVED"`
 	PublishedAt *time.Time        `json:"published_at"`
+	ExpiresAt   *time.Time        `json:"expires_at"`
+
+	// ContentFormat tells how Content is written: html (the default), markdown or blocks, a JSON
+	// array of content blocks.
+	ContentFormat string `json:"content_format" validate:"omitempty,oneof=html markdown blocks"`
+
+	MetaTitle       string `json:"meta_title" validate:"omitempty,max=70"`
+	MetaDescription string `json:"meta_description" validate:"omitempty,max=160"`
+	CanonicalURL    string `json:"canonical_url" validate:"omitempty,url,max=255"`
+	OGImageVariant  string `json:"og_image_variant" validate:"omitempty,oneof=sm md lg xl 2xl fhd"`
 }
 
+// UpdateArticleRequest replaces the article's tags only when TagIDs is sent; an empty list removes
+// them all.
 type UpdateArticleRequest struct {
+	EntityType  string            `json:"entity_type" validate:"omitempty,oneof=pura yayasan pasraman"`
 	CategoryID  string            `json:"category_id"`
+	TagIDs      []string          `json:"tag_ids" validate:"max=20,dive,required"`
 	Title       string            `json:"title" validate:"required,min=5,max=200"`
 	AuthorName  string            `json:"author_name" validate:"required,min=2,max=100"`
 	AuthorRole  string            `json:"author_role" validate:"omitempty,max=100"`
@@ -41,6 +79,76 @@ type UpdateArticleRequest struct {
 	Content     string            `json:"content" validate:"required,min=10"`
 	Images      map[string]string `json:"images" validate:"required"`
 	IsFeatured  bool              `json:"is_featured"`
-	Status      string            `json:"status" validate:"required,oneof=DRAFT PUBLISHED ARCHIVED"`
+	Status      string            `json:"status" validate:"required,oneof=DRAFT IN_REVIEW SCHEDULED PUBLISHED ARCHIVED"`
 	PublishedAt *time.Time        `json:"published_at"`
+	ExpiresAt   *time.Time        `json:"expires_at"`
+
+	// ContentFormat tells how Content is written: html (the default), markdown or blocks, a JSON
+	// array of content blocks.
+	ContentFormat string `json:"content_format" validate:"omitempty,oneof=html markdown blocks"`
+
+	MetaTitle       string `json:"meta_title" validate:"omitempty,max=70"`
+	MetaDescription string `json:"meta_description" validate:"omitempty,max=160"`
+	CanonicalURL    string `json:"canonical_url" validate:"omitempty,url,max=255"`
+	OGImageVariant  string `json:"og_image_variant" validate:"omitempty,oneof=sm md lg xl 2xl fhd"`
+}
+
+type RejectArticleRequest struct {
+	Comment string `json:"comment" validate:"required,min=5,max=2000"`
+}
+
+type ArticleRevisionSummaryResponse struct {
+	Version      int       `json:"version"`
+	Title        string    `json:"title"`
+	Status       string    `json:"status"`
+	RestoredFrom *int      `json:"restored_from"`
+	CreatedBy    *string   `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ArticleRevisionResponse struct {
+	ID            string        `json:"id"`
+	ArticleID     string        `json:"article_id"`
+	Version       int           `json:"version"`
+	CategoryID    *string       `json:"category_id"`
+	Title         string        `json:"title"`
+	Slug          string        `json:"slug"`
+	AuthorName    string        `json:"author_name"`
+	AuthorRole    string        `json:"author_role"`
+	Excerpt       string        `json:"excerpt"`
+	Content       string        `json:"content"`
+	ContentFormat string        `json:"content_format"`
+	Images        ImageVariants `json:"images"`
+	Status        string        `json:"status"`
+	IsFeatured    bool          `json:"is_featured"`
+	PublishedAt   *time.Time    `json:"published_at"`
+	ExpiresAt     *time.Time    `json:"expires_at"`
+	RestoredFrom  *int          `json:"restored_from"`
+	CreatedBy     *string       `json:"created_by"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+type ArticleRevisionChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+type DiffLineResponse struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// ArticleRevisionDiffResponse lists the fields that differ between two revisions. The content is
+// compared line by line in ContentDiff instead of being repeated in Changes.
+type ArticleRevisionDiffResponse struct {
+	ArticleID   string                           `json:"article_id"`
+	From        int                              `json:"from"`
+	To          int                              `json:"to"`
+	Changes     map[string]ArticleRevisionChange `json:"changes"`
+	ContentDiff []DiffLineResponse               `json:"content_diff,omitempty"`
+}
+
+type ArticleScheduleResult struct {
+	Published int
+	Archived  int
 }
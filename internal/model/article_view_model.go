@@ -0,0 +1,19 @@
+package model
+
+type PopularArticleResponse struct {
+	ArticleResponse
+	Views int64 `json:"views"`
+}
+
+type ArticleDailyViewsResponse struct {
+	Date  string `json:"date"`
+	Views int64  `json:"views"`
+}
+
+type ArticleViewStatsResponse struct {
+	ArticleID   string                      `json:"article_id"`
+	TotalViews  int64                       `json:"total_views"`
+	Days        int                         `json:"days"`
+	PeriodViews int64                       `json:"period_views"`
+	Daily       []ArticleDailyViewsResponse `json:"daily"`
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// AuditActor identifies who performs a mutation. It travels in the request context down to the
+// GORM audit callback.
+type AuditActor struct {
+	ID         string
+	Role       string
+	EntityType string
+	IP         string
+	RequestID  string
+}
+
+type AuditChangeResponse struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+type AuditLogResponse struct {
+	ID         string                         `json:"id"`
+	ActorID    *string                        `json:"actor_id"`
+	ActorRole  string                         `json:"actor_role"`
+	EntityType string                         `json:"entity_type"`
+	Resource   string                         `json:"resource"`
+	RecordID   string                         `json:"record_id"`
+	Action     string                         `json:"action"`
+	Changes    map[string]AuditChangeResponse `json:"changes"`
+	IP         string                         `json:"ip"`
+	RequestID  string                         `json:"request_id"`
+	CreatedAt  time.Time                      `json:"created_at"`
+}
+
+type SearchAuditLogRequest struct {
+	ActorID    string     `json:"actor_id" validate:"max=100"`
+	Resource   string     `json:"resource" validate:"max=100"`
+	RecordID   string     `json:"record_id" validate:"max=100"`
+	EntityType string     `json:"entity_type" validate:"omitempty,oneof=pura yayasan pasraman"`
+	Action     string     `json:"action" validate:"omitempty,oneof=create update delete"`
+	From       *time.Time `json:"from"`
+	To         *time.Time `json:"to"`
+	Page       int        `json:"page" validate:"min=1"`
+	Size       int        `json:"size" validate:"min=1,max=100"`
+}
@@ -1,8 +1,29 @@
 package model
 
+import "time"
+
 type Auth struct {
 	// Login user id
-	ID    string
-	Role  string
-	Email string
+	ID       string
+	Role     string
+	Email    string
+	FamilyID string
+}
+
+type TokenPair struct {
+	AccessToken      string
+	RefreshToken     string
+	AccessExpiresIn  time.Duration
+	RefreshExpiresIn time.Duration
+}
+
+type SessionDevice struct {
+	IP        string
+	UserAgent string
+}
+
+type LoginResult struct {
+	User      *UserResponse
+	Token     *TokenPair
+	Challenge *TwoFactorChallengeResponse
 }
@@ -0,0 +1,24 @@
+package model
+
+type PublicCalendarRequest struct {
+	EntityType string `json:"entity_type" validate:"omitempty,oneof=pura yayasan pasraman"`
+	From       string `json:"from" validate:"omitempty,datetime=2006-01-02"`
+	To         string `json:"to" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// CalendarDayResponse is one date in the Balinese calendar. Penanggal counts the waxing days up to
+// Purnama and Pangelong the waning days up to Tilem; only one of them is set.
+type CalendarDayResponse struct {
+	Date       string   `json:"date"`
+	Wuku       string   `json:"wuku"`
+	Saptawara  string   `json:"saptawara"`
+	Pancawara  string   `json:"pancawara"`
+	Triwara    string   `json:"triwara"`
+	PawukonDay int      `json:"pawukon_day"`
+	Sasih      string   `json:"sasih"`
+	Penanggal  int      `json:"penanggal,omitempty"`
+	Pangelong  int      `json:"pangelong,omitempty"`
+	Purnama    bool     `json:"purnama"`
+	Tilem      bool     `json:"tilem"`
+	HolyDays   []string `json:"holy_days"`
+}
@@ -14,6 +14,7 @@ type CreateContactInfoRequest struct {
 
 // UpdateContactInfoRequest defines the payload for updating contact info
 type UpdateContactInfoRequest struct {
+	EntityType    string `json:"entity_type" validate:"omitempty,oneof=pura yayasan pasraman"`
 	Address       string `json:"address" validate:"required,max=1000"`
 	Phone         string `json:"phone" validate:"omitempty,max=50"`
 	Email         string `json:"email" validate:"omitempty,email,max=100"`
@@ -3,6 +3,7 @@ package converter
 import (
 	"pura-agung-kertajaya-backend/internal/entity"
 	"pura-agung-kertajaya-backend/internal/model"
+	"time"
 )
 
 func ToActivityResponse(a *entity.Activity) model.ActivityResponse {
@@ -18,7 +19,63 @@ func ToActivityResponse(a *entity.Activity) model.ActivityResponse {
 		IsActive:    a.IsActive,
 		CreatedAt:   a.CreatedAt,
 		UpdatedAt:   a.UpdatedAt,
+
+		RecurrenceRule: a.RecurrenceRule,
+		PawukonRule:    a.PawukonRule,
+	}
+}
+
+// ToActivityOccurrenceResponse describes the occurrence of a recurring activity on date, changed by
+// its exception if it has one. The occurrence keeps the time of day of the activity.
+func ToActivityOccurrenceResponse(a *entity.Activity, date time.Time, ex *entity.ActivityException) model.ActivityResponse {
+	r := ToActivityResponse(a)
+	r.OccurrenceDate = date.Format("2006-01-02")
+	r.EventDate = time.Date(date.Year(), date.Month(), date.Day(),
+		a.EventDate.Hour(), a.EventDate.Minute(), a.EventDate.Second(), 0, a.EventDate.Location())
+
+	if ex == nil {
+		return r
+	}
+	if ex.Title != "" {
+		r.Title = ex.Title
+	}
+	if ex.Description != "" {
+		r.Description = ex.Description
+	}
+	if ex.TimeInfo != "" {
+		r.TimeInfo = ex.TimeInfo
+	}
+	if ex.Location != "" {
+		r.Location = ex.Location
 	}
+	if ex.EventDate != nil {
+		r.EventDate = *ex.EventDate
+	}
+	return r
+}
+
+func ToActivityExceptionResponse(ex *entity.ActivityException) model.ActivityExceptionResponse {
+	return model.ActivityExceptionResponse{
+		ID:             ex.ID,
+		ActivityID:     ex.ActivityID,
+		OccurrenceDate: ex.OccurrenceDate.Format("2006-01-02"),
+		IsCancelled:    ex.IsCancelled,
+		Title:          ex.Title,
+		Description:    ex.Description,
+		TimeInfo:       ex.TimeInfo,
+		Location:       ex.Location,
+		EventDate:      ex.EventDate,
+		CreatedAt:      ex.CreatedAt,
+		UpdatedAt:      ex.UpdatedAt,
+	}
+}
+
+func ToActivityExceptionResponses(exceptions []entity.ActivityException) []model.ActivityExceptionResponse {
+	responses := make([]model.ActivityExceptionResponse, len(exceptions))
+	for i := range exceptions {
+		responses[i] = ToActivityExceptionResponse(&exceptions[i])
+	}
+	return responses
 }
 
 func ToActivityResponses(activities []entity.Activity) []model.ActivityResponse {
@@ -0,0 +1,56 @@
+package converter
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+)
+
+func ToAPIKeyResponse(k *entity.APIKey) *model.APIKeyResponse {
+	grants := ToAPIKeyGrants(k.Scopes)
+
+	entityTypes := make([]string, 0, len(grants))
+	for _, entityType := range model.EntityTypes {
+		if _, ok := grants[entityType]; ok {
+			entityTypes = append(entityTypes, entityType)
+		}
+	}
+
+	permissions := make([]string, 0)
+	for _, permission := range model.Permissions {
+		for _, entityType := range entityTypes {
+			if grants.Has(entityType, permission) {
+				permissions = append(permissions, permission)
+				break
+			}
+		}
+	}
+
+	return &model.APIKeyResponse{
+		ID:          k.ID,
+		Name:        k.Name,
+		Prefix:      k.Prefix,
+		EntityTypes: entityTypes,
+		Permissions: permissions,
+		CreatedBy:   k.CreatedBy,
+		LastUsedAt:  k.LastUsedAt,
+		RevokedAt:   k.RevokedAt,
+		CreatedAt:   k.CreatedAt,
+		UpdatedAt:   k.UpdatedAt,
+	}
+}
+
+func ToAPIKeyResponses(keys []entity.APIKey) []model.APIKeyResponse {
+	responses := make([]model.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		responses = append(responses, *ToAPIKeyResponse(&key))
+	}
+	return responses
+}
+
+func ToAPIKeyGrants(scopes []entity.APIKeyScope) model.PermissionGrants {
+	grants := make(model.PermissionGrants)
+	for _, s := range scopes {
+		grants[s.EntityType] = append(grants[s.EntityType], s.Permission)
+	}
+	return grants
+}
@@ -3,6 +3,7 @@ package converter
 import (
 	"pura-agung-kertajaya-backend/internal/entity"
 	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/util"
 )
 
 func ToArticleResponse(a *entity.Article) model.ArticleResponse {
@@ -14,7 +15,9 @@ func ToArticleResponse(a *entity.Article) model.ArticleResponse {
 
 	return model.ArticleResponse{
 		ID:          a.ID,
+		EntityType:  a.EntityType,
 		Category:    categoryResp,
+		Tags:        ToTagResponses(a.Tags),
 		Title:       a.Title,
 		Slug:        a.Slug,
 		AuthorName:  a.AuthorName,
@@ -25,8 +28,26 @@ func ToArticleResponse(a *entity.Article) model.ArticleResponse {
 		Status:      string(a.Status),
 		IsFeatured:  a.IsFeatured,
 		PublishedAt: a.PublishedAt,
-		CreatedAt:   a.CreatedAt,
-		UpdatedAt:   a.UpdatedAt,
+		ExpiresAt:   a.ExpiresAt,
+
+		ContentFormat:      a.ContentFormat,
+		ContentHTML:        contentHTML(a),
+		WordCount:          a.WordCount,
+		ReadingTimeMinutes: a.ReadingTimeMinutes,
+
+		MetaTitle:       a.MetaTitle,
+		MetaDescription: a.MetaDescription,
+		CanonicalURL:    a.CanonicalURL,
+		OGImageVariant:  a.OGImageVariant,
+
+		ReviewComment: a.ReviewComment,
+		SubmittedBy:   a.SubmittedBy,
+		SubmittedAt:   a.SubmittedAt,
+		ReviewedBy:    a.ReviewedBy,
+		ReviewedAt:    a.ReviewedAt,
+
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
 	}
 }
 
@@ -37,3 +58,28 @@ func ToArticleResponses(articles []entity.Article) []model.ArticleResponse {
 	}
 	return responses
 }
+
+// ToPublicArticleResponse leaves out the raw body, which public readers must not render; they get
+// the sanitized ContentHTML.
+func ToPublicArticleResponse(a *entity.Article) model.ArticleResponse {
+	resp := ToArticleResponse(a)
+	resp.Content = ""
+	return resp
+}
+
+func ToPublicArticleResponses(articles []entity.Article) []model.ArticleResponse {
+	var responses []model.ArticleResponse
+	for _, article := range articles {
+		responses = append(responses, ToPublicArticleResponse(&article))
+	}
+	return responses
+}
+
+// contentHTML falls back to sanitizing the body of articles saved before it was rendered on save,
+// which were all written in HTML.
+func contentHTML(a *entity.Article) string {
+	if a.ContentHTML == "" && a.Content != "" {
+		return util.SanitizeHTML(a.Content)
+	}
+	return a.ContentHTML
+}
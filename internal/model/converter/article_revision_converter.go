@@ -0,0 +1,54 @@
+package converter
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+func ToArticleRevisionResponse(r *entity.ArticleRevision) model.ArticleRevisionResponse {
+	return model.ArticleRevisionResponse{
+		ID:            r.ID,
+		ArticleID:     r.ArticleID,
+		Version:       r.Version,
+		CategoryID:    r.CategoryID,
+		Title:         r.Title,
+		Slug:          r.Slug,
+		AuthorName:    r.AuthorName,
+		AuthorRole:    r.AuthorRole,
+		Excerpt:       r.Excerpt,
+		Content:       r.Content,
+		ContentFormat: r.ContentFormat,
+		Images:        ToImageVariants(r.Images),
+		Status:        string(r.Status),
+		IsFeatured:    r.IsFeatured,
+		PublishedAt:   r.PublishedAt,
+		ExpiresAt:     r.ExpiresAt,
+		RestoredFrom:  r.RestoredFrom,
+		CreatedBy:     r.CreatedBy,
+		CreatedAt:     r.CreatedAt,
+	}
+}
+
+func ToArticleRevisionSummaryResponses(revisions []entity.ArticleRevision) []model.ArticleRevisionSummaryResponse {
+	responses := make([]model.ArticleRevisionSummaryResponse, 0, len(revisions))
+	for _, r := range revisions {
+		responses = append(responses, model.ArticleRevisionSummaryResponse{
+			Version:      r.Version,
+			Title:        r.Title,
+			Status:       string(r.Status),
+			RestoredFrom: r.RestoredFrom,
+			CreatedBy:    r.CreatedBy,
+			CreatedAt:    r.CreatedAt,
+		})
+	}
+	return responses
+}
+
+func ToDiffLineResponses(lines []util.DiffLine) []model.DiffLineResponse {
+	responses := make([]model.DiffLineResponse, 0, len(lines))
+	for _, line := range lines {
+		responses = append(responses, model.DiffLineResponse{Op: string(line.Op), Text: line.Text})
+	}
+	return responses
+}
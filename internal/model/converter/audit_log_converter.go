@@ -0,0 +1,38 @@
+package converter
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+)
+
+func ToAuditLogResponse(l *entity.AuditLog) model.AuditLogResponse {
+	changes := make(map[string]model.AuditChangeResponse, len(l.Changes))
+	for name, change := range l.Changes {
+		changes[name] = model.AuditChangeResponse{
+			Before: change.Before,
+			After:  change.After,
+		}
+	}
+
+	return model.AuditLogResponse{
+		ID:         l.ID,
+		ActorID:    l.ActorID,
+		ActorRole:  l.ActorRole,
+		EntityType: l.EntityType,
+		Resource:   l.Resource,
+		RecordID:   l.RecordID,
+		Action:     string(l.Action),
+		Changes:    changes,
+		IP:         l.IP,
+		RequestID:  l.RequestID,
+		CreatedAt:  l.CreatedAt,
+	}
+}
+
+func ToAuditLogResponses(logs []entity.AuditLog) []model.AuditLogResponse {
+	responses := make([]model.AuditLogResponse, 0, len(logs))
+	for _, l := range logs {
+		responses = append(responses, ToAuditLogResponse(&l))
+	}
+	return responses
+}
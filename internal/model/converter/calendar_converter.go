@@ -0,0 +1,23 @@
+package converter
+
+import (
+	"pura-agung-kertajaya-backend/internal/calendar"
+	"pura-agung-kertajaya-backend/internal/model"
+)
+
+func ToCalendarDayResponse(d *calendar.Day) model.CalendarDayResponse {
+	return model.CalendarDayResponse{
+		Date:       d.Date.Format("2006-01-02"),
+		Wuku:       calendar.Wuku[d.Pawukon.Wuku()],
+		Saptawara:  calendar.Saptawara[d.Pawukon.Saptawara()],
+		Pancawara:  calendar.Pancawara[d.Pawukon.Pancawara()],
+		Triwara:    calendar.Triwara[d.Pawukon.Triwara()],
+		PawukonDay: int(d.Pawukon) + 1,
+		Sasih:      d.Lunar.Sasih,
+		Penanggal:  d.Lunar.Penanggal,
+		Pangelong:  d.Lunar.Pangelong,
+		Purnama:    d.Lunar.Purnama,
+		Tilem:      d.Lunar.Tilem,
+		HolyDays:   d.HolyDays,
+	}
+}
@@ -7,11 +7,12 @@ import (
 
 func ToCategoryResponse(c *entity.Category) model.CategoryResponse {
 	return model.CategoryResponse{
-		ID:        c.ID,
-		Name:      c.Name,
-		Slug:      c.Slug,
-		CreatedAt: c.CreatedAt,
-		UpdatedAt: c.UpdatedAt,
+		ID:         c.ID,
+		EntityType: c.EntityType,
+		Name:       c.Name,
+		Slug:       c.Slug,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
 	}
 }
 
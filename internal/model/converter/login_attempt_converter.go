@@ -0,0 +1,26 @@
+package converter
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+)
+
+func ToLoginAttemptResponse(a *entity.LoginAttempt) model.LoginAttemptResponse {
+	return model.LoginAttemptResponse{
+		ID:        a.ID,
+		Email:     a.Email,
+		UserID:    a.UserID,
+		IP:        a.IP,
+		UserAgent: a.UserAgent,
+		Result:    string(a.Result),
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+func ToLoginAttemptResponses(attempts []entity.LoginAttempt) []model.LoginAttemptResponse {
+	var responses []model.LoginAttemptResponse
+	for _, attempt := range attempts {
+		responses = append(responses, ToLoginAttemptResponse(&attempt))
+	}
+	return responses
+}
@@ -0,0 +1,49 @@
+package converter
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+)
+
+func ToRoleResponse(r *entity.Role) model.RoleResponse {
+	permissions := make([]string, 0, len(r.Permissions))
+	for _, p := range r.Permissions {
+		permissions = append(permissions, p.Permission)
+	}
+
+	return model.RoleResponse{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		IsSystem:    r.IsSystem,
+		Permissions: permissions,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+func ToRoleResponses(roles []entity.Role) []model.RoleResponse {
+	var responses []model.RoleResponse
+	for _, role := range roles {
+		responses = append(responses, ToRoleResponse(&role))
+	}
+	return responses
+}
+
+func ToRoleAssignmentResponse(a *entity.UserRoleAssignment) model.RoleAssignmentResponse {
+	return model.RoleAssignmentResponse{
+		ID:         a.ID,
+		RoleID:     a.RoleID,
+		RoleName:   a.Role.Name,
+		EntityType: a.EntityType,
+		CreatedAt:  a.CreatedAt,
+	}
+}
+
+func ToRoleAssignmentResponses(assignments []entity.UserRoleAssignment) []model.RoleAssignmentResponse {
+	responses := make([]model.RoleAssignmentResponse, 0, len(assignments))
+	for _, assignment := range assignments {
+		responses = append(responses, ToRoleAssignmentResponse(&assignment))
+	}
+	return responses
+}
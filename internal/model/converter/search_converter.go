@@ -0,0 +1,19 @@
+package converter
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+)
+
+func ToSearchResultResponse(d *entity.SearchDocument, snippet string) model.SearchResultResponse {
+	return model.SearchResultResponse{
+		Type:       d.Type,
+		ID:         d.ID,
+		EntityType: d.EntityType,
+		Title:      d.Title,
+		Slug:       d.Slug,
+		Snippet:    snippet,
+		Score:      d.Score,
+		Date:       d.Date,
+	}
+}
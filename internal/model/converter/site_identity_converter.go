@@ -16,7 +16,14 @@ func ToSiteIdentityResponse(e entity.SiteIdentity) model.SiteIdentityResponse {
 		PrimaryButtonLink:   e.PrimaryButtonLink,
 		SecondaryButtonText: e.SecondaryButtonText,
 		SecondaryButtonLink: e.SecondaryButtonLink,
-		CreatedAt:           e.CreatedAt,
-		UpdatedAt:           e.UpdatedAt,
+
+		MetaTitle:       e.MetaTitle,
+		MetaDescription: e.MetaDescription,
+		CanonicalURL:    e.CanonicalURL,
+		OGImages:        ToImageVariants(e.OGImages),
+		OGImageVariant:  e.OGImageVariant,
+
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
 	}
 }
@@ -0,0 +1,25 @@
+package converter
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+)
+
+func ToTagResponse(t *entity.Tag) model.TagResponse {
+	return model.TagResponse{
+		ID:         t.ID,
+		EntityType: t.EntityType,
+		Name:       t.Name,
+		Slug:       t.Slug,
+		CreatedAt:  t.CreatedAt,
+		UpdatedAt:  t.UpdatedAt,
+	}
+}
+
+func ToTagResponses(tags []entity.Tag) []model.TagResponse {
+	var responses []model.TagResponse
+	for _, tag := range tags {
+		responses = append(responses, ToTagResponse(&tag))
+	}
+	return responses
+}
@@ -7,12 +7,14 @@ import (
 
 func UserToResponse(user *entity.User) *model.UserResponse {
 	return &model.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		Role:      user.Role,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:               user.ID,
+		Name:             user.Name,
+		Email:            user.Email,
+		Role:             user.Role,
+		IsActive:         user.IsActive,
+		TwoFactorEnabled: user.TwoFactorEnabled,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
 	}
 }
 
@@ -25,3 +27,11 @@ func UserToEvent(user *entity.User) *model.UserEvent {
 		UpdatedAt: user.UpdatedAt,
 	}
 }
+
+func UserToResponses(users []entity.User) []model.UserResponse {
+	var responses []model.UserResponse
+	for _, user := range users {
+		responses = append(responses, *UserToResponse(&user))
+	}
+	return responses
+}
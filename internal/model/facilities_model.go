@@ -12,6 +12,7 @@ type CreateFacilityRequest struct {
 }
 
 type UpdateFacilityRequest struct {
+	EntityType  string            `json:"entity_type" validate:"omitempty,oneof=pura yayasan pasraman"`
 	Name        string            `json:"name" validate:"required,min=1"`
 	Description string            `json:"description"`
 	Images      map[string]string `json:"images" validate:"required"`
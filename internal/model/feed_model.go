@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// Feed is a format independent feed, rendered as RSS, Atom or JSON Feed.
+type Feed struct {
+	Title       string
+	Description string
+	Link        string
+	Language    string
+	Updated     time.Time
+	Items       []FeedItem
+}
+
+type FeedItem struct {
+	ID          string
+	Title       string
+	Link        string
+	Summary     string
+	ContentHTML string
+	Author      string
+	Categories  []string
+	ImageURL    string
+	Published   time.Time
+	Updated     time.Time
+}
+
+// ICalendar is a calendar of events, rendered as iCalendar (RFC 5545).
+type ICalendar struct {
+	Name        string
+	Description string
+	URL         string
+	Updated     time.Time
+	Events      []ICalendarEvent
+}
+
+// ICalendarEvent is one event. An all-day event uses only the dates of Start and End, End being the
+// day after the last one.
+type ICalendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Updated     time.Time
+}
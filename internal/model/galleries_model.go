@@ -12,6 +12,7 @@ type CreateGalleryRequest struct {
 }
 
 type UpdateGalleryRequest struct {
+	EntityType  string            `json:"entity_type" validate:"omitempty,oneof=pura yayasan pasraman"`
 	Title       string            `json:"title" validate:"required,min=1,max=150"`
 	Description string            `json:"description"`
 	Images      map[string]string `json:"images" validate:"required"`
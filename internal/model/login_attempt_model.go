@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+type LoginAttemptResponse struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	UserID    *string   `json:"user_id"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	Result    string    `json:"result"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SearchLoginAttemptRequest struct {
+	Email  string `json:"email" validate:"max=100"`
+	UserID string `json:"user_id" validate:"max=100"`
+	IP     string `json:"ip" validate:"max=45"`
+	Result string `json:"result" validate:"omitempty,oneof=SUCCESS INVALID_CREDENTIALS INVALID_SECOND_FACTOR LOCKED DISABLED"`
+	Limit  int    `json:"limit" validate:"min=0,max=200"`
+}
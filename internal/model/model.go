@@ -12,10 +12,23 @@ type PageResponse[T any] struct {
 }
 
 type PageMetadata struct {
-	Page      int   `json:"page"`
-	Size      int   `json:"size"`
-	TotalItem int64 `json:"total_item"`
-	TotalPage int64 `json:"total_page"`
+	Page       int    `json:"page"`
+	Size       int    `json:"size"`
+	TotalItem  int64  `json:"total_item"`
+	TotalPage  int64  `json:"total_page"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ListRequest holds the paging, sorting and filtering parameters of a list endpoint. Sort is a
+// comma separated list of fields, each prefixed with "-" for descending order. Filters are matched
+// exactly; a comma separated value matches any of its parts.
+type ListRequest struct {
+	Page    int
+	Size    int
+	Cursor  string
+	Sort    string
+	Search  string
+	Filters map[string]string
 }
 
 type ImageVariants struct {
@@ -12,6 +12,7 @@ type CreateOrganizationRequest struct {
 }
 
 type UpdateOrganizationRequest struct {
+	EntityType    string `json:"entity_type" validate:"omitempty,oneof=pura yayasan pasraman"`
 	Name          string `json:"name" validate:"required,min=1,max=100"`
 	Position      string `json:"position" validate:"required,min=1,max=100"`
 	PositionOrder int    `json:"position_order" validate:"required,min=1"`
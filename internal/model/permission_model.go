@@ -0,0 +1,145 @@
+package model
+
+import "time"
+
+const (
+	PermissionArticlesRead     = "articles:read"
+	PermissionArticlesWrite    = "articles:write"
+	PermissionArticlesPublish  = "articles:publish"
+	PermissionArticlesDelete   = "articles:delete"
+	PermissionCategoriesRead   = "categories:read"
+	PermissionCategoriesWrite  = "categories:write"
+	PermissionCategoriesDelete = "categories:delete"
+	PermissionTagsRead         = "tags:read"
+	PermissionTagsWrite        = "tags:write"
+	PermissionTagsDelete       = "tags:delete"
+
+	PermissionGalleryRead        = "gallery:read"
+	PermissionGalleryWrite       = "gallery:write"
+	PermissionGalleryDelete      = "gallery:delete"
+	PermissionHeroSlidesRead     = "hero-slides:read"
+	PermissionHeroSlidesWrite    = "hero-slides:write"
+	PermissionHeroSlidesDelete   = "hero-slides:delete"
+	PermissionFacilitiesRead     = "facilities:read"
+	PermissionFacilitiesWrite    = "facilities:write"
+	PermissionFacilitiesDelete   = "facilities:delete"
+	PermissionActivitiesRead     = "activities:read"
+	PermissionActivitiesWrite    = "activities:write"
+	PermissionActivitiesDelete   = "activities:delete"
+	PermissionTestimonialsRead   = "testimonials:read"
+	PermissionTestimonialsWrite  = "testimonials:write"
+	PermissionTestimonialsDelete = "testimonials:delete"
+	PermissionAboutRead          = "about:read"
+	PermissionAboutWrite         = "about:write"
+	PermissionAboutDelete        = "about:delete"
+	PermissionOrganizationRead   = "organization:read"
+	PermissionOrganizationWrite  = "organization:write"
+	PermissionOrganizationDelete = "organization:delete"
+	PermissionRemarksRead        = "remarks:read"
+	PermissionRemarksWrite       = "remarks:write"
+	PermissionRemarksDelete      = "remarks:delete"
+	PermissionContactInfoRead    = "contact-info:read"
+	PermissionContactInfoWrite   = "contact-info:write"
+	PermissionContactInfoDelete  = "contact-info:delete"
+	PermissionSiteIdentityRead   = "site-identity:read"
+	PermissionSiteIdentityWrite  = "site-identity:write"
+	PermissionSiteIdentityDelete = "site-identity:delete"
+	PermissionStorageRead        = "storage:read"
+	PermissionStorageWrite       = "storage:write"
+	PermissionStorageDelete      = "storage:delete"
+)
+
+// Permissions is the catalog of every permission a role can hold.
+var Permissions = []string{
+	PermissionArticlesRead, PermissionArticlesWrite, PermissionArticlesPublish, PermissionArticlesDelete,
+	PermissionCategoriesRead, PermissionCategoriesWrite, PermissionCategoriesDelete,
+	PermissionTagsRead, PermissionTagsWrite, PermissionTagsDelete,
+	PermissionGalleryRead, PermissionGalleryWrite, PermissionGalleryDelete,
+	PermissionHeroSlidesRead, PermissionHeroSlidesWrite, PermissionHeroSlidesDelete,
+	PermissionFacilitiesRead, PermissionFacilitiesWrite, PermissionFacilitiesDelete,
+	PermissionActivitiesRead, PermissionActivitiesWrite, PermissionActivitiesDelete,
+	PermissionTestimonialsRead, PermissionTestimonialsWrite, PermissionTestimonialsDelete,
+	PermissionAboutRead, PermissionAboutWrite, PermissionAboutDelete,
+	PermissionOrganizationRead, PermissionOrganizationWrite, PermissionOrganizationDelete,
+	PermissionRemarksRead, PermissionRemarksWrite, PermissionRemarksDelete,
+	PermissionContactInfoRead, PermissionContactInfoWrite, PermissionContactInfoDelete,
+	PermissionSiteIdentityRead, PermissionSiteIdentityWrite, PermissionSiteIdentityDelete,
+	PermissionStorageRead, PermissionStorageWrite, PermissionStorageDelete,
+}
+
+// EntityTypes lists the entities content and role assignments are scoped to.
+var EntityTypes = []string{"pura", "yayasan", "pasraman"}
+
+func IsPermission(permission string) bool {
+	for _, p := range Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func IsEntityType(entityType string) bool {
+	for _, e := range EntityTypes {
+		if e == entityType {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionGrants maps an entity type to the permissions a user holds on it.
+type PermissionGrants map[string][]string
+
+func (g PermissionGrants) Has(entityType string, permission string) bool {
+	for _, p := range g[entityType] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+type RoleResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	IsSystem    bool      `json:"is_system"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type CreateRoleRequest struct {
+	Name        string   `json:"name" validate:"required,min=2,max=50"`
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions" validate:"required,min=1,dive,required"`
+}
+
+type UpdateRoleRequest struct {
+	Name        string   `json:"name" validate:"required,min=2,max=50"`
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions" validate:"required,min=1,dive,required"`
+}
+
+type RoleAssignmentResponse struct {
+	ID         string    `json:"id"`
+	RoleID     string    `json:"role_id"`
+	RoleName   string    `json:"role_name"`
+	EntityType string    `json:"entity_type"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type RoleAssignmentRequest struct {
+	RoleID     string `json:"role_id" validate:"required,max=100"`
+	EntityType string `json:"entity_type" validate:"required,oneof=pura yayasan pasraman"`
+}
+
+type SetUserRolesRequest struct {
+	Assignments []RoleAssignmentRequest `json:"assignments" validate:"max=50,dive"`
+}
+
+type UserPermissionsResponse struct {
+	Super  bool             `json:"super"`
+	Grants PermissionGrants `json:"grants"`
+}
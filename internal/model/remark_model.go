@@ -26,6 +26,7 @@ type CreateRemarkRequest struct {
 }
 
 type UpdateRemarkRequest struct {
+	EntityType string `json:"entity_type" validate:"omitempty,oneof=pura yayasan pasraman"`
 	Name       string `json:"name" validate:"required,max=100"`
 	Position   string `json:"position" validate:"required,max=100"`
 	ImageURL   string `json:"image_url"`
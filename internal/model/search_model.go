@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type PublicSearchRequest struct {
+	Query      string   `json:"q" validate:"required,min=2,max=100"`
+	EntityType string   `json:"entity_type" validate:"omitempty,oneof=pura yayasan pasraman"`
+	Types      []string `json:"types" validate:"dive,oneof=article activity gallery facility"`
+	Page       int      `json:"page" validate:"min=1"`
+	Size       int      `json:"size" validate:"min=1,max=50"`
+}
+
+type SearchResultResponse struct {
+	Type       string     `json:"type"`
+	ID         string     `json:"id"`
+	EntityType string     `json:"entity_type,omitempty"`
+	Title      string     `json:"title"`
+	Slug       string     `json:"slug,omitempty"`
+	Snippet    string     `json:"snippet"`
+	Score      float64    `json:"score"`
+	Date       *time.Time `json:"date,omitempty"`
+}
@@ -0,0 +1,23 @@
+package model
+
+type SEOImageResponse struct {
+	URL   string `json:"url"`
+	Width int    `json:"width,omitempty"`
+	Alt   string `json:"alt,omitempty"`
+}
+
+// SEOMetadataResponse is everything a public page needs in its head: the title and description,
+// the canonical address, Open Graph and Twitter card tags and the JSON-LD documents.
+type SEOMetadataResponse struct {
+	Path         string            `json:"path"`
+	Type         string            `json:"type"`
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	CanonicalURL string            `json:"canonical_url"`
+	SiteName     string            `json:"site_name"`
+	Locale       string            `json:"locale"`
+	Image        *SEOImageResponse `json:"image,omitempty"`
+	OpenGraph    map[string]string `json:"open_graph"`
+	Twitter      map[string]string `json:"twitter"`
+	JSONLD       []map[string]any  `json:"json_ld"`
+}
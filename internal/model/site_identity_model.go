@@ -15,18 +15,31 @@ type SiteIdentityRequest struct {
 	PrimaryButtonLink   string `json:"primary_button_link" validate:"omitempty,url|uri|startswith=http"`
 	SecondaryButtonText string `json:"secondary_button_text" validate:"omitempty,max=50"`
 	SecondaryButtonLink string `json:"secondary_button_link" validate:"omitempty,url|uri|startswith=http"`
+
+	MetaTitle       string            `json:"meta_title" validate:"omitempty,max=70"`
+	MetaDescription string            `json:"meta_description" validate:"omitempty,max=160"`
+	CanonicalURL    string            `json:"canonical_url" validate:"omitempty,url,max=255"`
+	OGImages        map[string]string `json:"og_images"`
+	OGImageVariant  string            `json:"og_image_variant" validate:"omitempty,oneof=sm md lg xl 2xl fhd"`
 }
 
 type SiteIdentityResponse struct {
-	ID                  string    `json:"id"`
-	EntityType          string    `json:"entity_type"`
-	SiteName            string    `json:"site_name"`
-	LogoURL             string    `json:"logo_url"`
-	Tagline             string    `json:"tagline"`
-	PrimaryButtonText   string    `json:"primary_button_text"`
-	PrimaryButtonLink   string    `json:"primary_button_link"`
-	SecondaryButtonText string    `json:"secondary_button_text"`
-	SecondaryButtonLink string    `json:"secondary_button_link"`
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
+	ID                  string `json:"id"`
+	EntityType          string `json:"entity_type"`
+	SiteName            string `json:"site_name"`
+	LogoURL             string `json:"logo_url"`
+	Tagline             string `json:"tagline"`
+	PrimaryButtonText   string `json:"primary_button_text"`
+	PrimaryButtonLink   string `json:"primary_button_link"`
+	SecondaryButtonText string `json:"secondary_button_text"`
+	SecondaryButtonLink string `json:"secondary_button_link"`
+
+	MetaTitle       string        `json:"meta_title"`
+	MetaDescription string        `json:"meta_description"`
+	CanonicalURL    string        `json:"canonical_url"`
+	OGImages        ImageVariants `json:"og_images"`
+	OGImageVariant  string        `json:"og_image_variant"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
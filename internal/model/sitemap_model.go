@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+type SitemapURL struct {
+	Loc     string
+	LastMod *time.Time
+}
+
+// Sitemap lists every page of an entity's public site search engines should know about.
+type Sitemap struct {
+	EntityType string
+	Updated    time.Time
+	URLs       []SitemapURL
+}
+
+type SitemapIndexEntry struct {
+	EntityType string
+	LastMod    *time.Time
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type CreateTagRequest struct {
+	EntityType string `json:"entity_type" validate:"required,oneof=pura yayasan pasraman"`
+	Name       string `json:"name" validate:"required,min=1,max=50"`
+}
+
+type UpdateTagRequest struct {
+	EntityType string `json:"entity_type" validate:"omitempty,oneof=pura yayasan pasraman"`
+	Name       string `json:"name" validate:"required,min=1,max=50"`
+}
+
+type TagResponse struct {
+	ID         string    `json:"id"`
+	EntityType string    `json:"entity_type"`
+	Name       string    `json:"name"`
+	Slug       string    `json:"slug"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,32 @@
+package model
+
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+	ExpiresIn         int    `json:"expires_in"`
+}
+
+type VerifyTwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required,max=100"`
+	Code           string `json:"code" validate:"required,max=32"`
+	IP             string `json:"-"`
+	UserAgent      string `json:"-"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required,max=32"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" validate:"required,max=100"`
+	Code     string `json:"code" validate:"required,max=32"`
+}
+
+type RecoveryCodesResponse struct {
+	Codes []string `json:"codes"`
+}
@@ -3,12 +3,14 @@ package model
 import "time"
 
 type UserResponse struct {
-	ID        string    `json:"id,omitempty"`
-	Name      string    `json:"name,omitempty"`
-	Email     string    `json:"email,omitempty"`
-	Role      string    `json:"role,omitempty"`
-	CreatedAt time.Time `json:"created_at,omitempty"`
-	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	ID               string    `json:"id,omitempty"`
+	Name             string    `json:"name,omitempty"`
+	Email            string    `json:"email,omitempty"`
+	Role             string    `json:"role,omitempty"`
+	IsActive         bool      `json:"is_active"`
+	TwoFactorEnabled bool      `json:"two_factor_enabled"`
+	CreatedAt        time.Time `json:"created_at,omitempty"`
+	UpdatedAt        time.Time `json:"updated_at,omitempty"`
 }
 
 type RegisterUserRequest struct {
@@ -21,14 +23,68 @@ type LoginUserRequest struct {
 	Email          string `json:"email" validate:"required,email,max=100"`
 	Password       string `json:"password" validate:"required,max=100"`
 	RecaptchaToken string `json:"recaptcha_token,omitempty"`
+	IP             string `json:"-"`
+	UserAgent      string `json:"-"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"-"`
+	IP           string `json:"-"`
+	UserAgent    string `json:"-"`
+}
+
+// LogoutRequest carries the tokens from the auth cookies. The refresh token identifies the session
+// even after the access token has expired.
+type LogoutRequest struct {
+	RefreshToken string `json:"-"`
+	AccessToken  string `json:"-"`
 }
 
 type UpdateUserRequest struct {
-	ID       string `json:"-"`
-	Name     string `json:"name,omitempty" validate:"max=100"`
-	Password string `json:"password,omitempty" validate:"max=100"`
+	ID        string `json:"-"`
+	SessionID string `json:"-"`
+	Name      string `json:"name,omitempty" validate:"max=100"`
+	Password  string `json:"password,omitempty" validate:"max=100"`
 }
 
 type GetUserRequest struct {
 	ID string `json:"id" validate:"required"`
 }
+
+type CreateUserRequest struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	Email    string `json:"email" validate:"required,email,max=100"`
+	Password string `json:"password" validate:"required,min=8,max=100"`
+	Role     string `json:"role" validate:"required,oneof=pura yayasan pasraman super"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=pura yayasan pasraman super"`
+}
+
+type UpdateUserStatusRequest struct {
+	IsActive bool `json:"is_active"`
+}
+
+type ResetUserPasswordRequest struct {
+	Password string `json:"password" validate:"required,min=8,max=100"`
+}
+
+type ForgotPasswordRequest struct {
+	Email          string `json:"email" validate:"required,email,max=100"`
+	RecaptchaToken string `json:"recaptcha_token,omitempty"`
+}
+
+type CompletePasswordResetRequest struct {
+	Token    string `json:"token" validate:"required,max=100"`
+	Password string `json:"password" validate:"required,min=8,max=100"`
+}
+
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	IssuedAt   time.Time `json:"issued_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository struct {
+	Repository[entity.APIKey]
+	Log *logrus.Logger
+}
+
+func NewAPIKeyRepository(log *logrus.Logger) *APIKeyRepository {
+	return &APIKeyRepository{
+		Log: log,
+	}
+}
+
+func (r *APIKeyRepository) FindAllWithScopes(db *gorm.DB, keys *[]entity.APIKey) error {
+	return db.Preload("Scopes").Order("created_at DESC").Find(keys).Error
+}
+
+func (r *APIKeyRepository) FindByIdWithScopes(db *gorm.DB, key *entity.APIKey, id string) error {
+	return db.Preload("Scopes").Where("id = ?", id).Take(key).Error
+}
+
+func (r *APIKeyRepository) FindActiveByHash(db *gorm.DB, key *entity.APIKey, hash string) error {
+	return db.Preload("Scopes").Where("key_hash = ? AND revoked_at IS NULL", hash).Take(key).Error
+}
+
+// UpdateWithoutScopes saves the key columns only; scopes are changed through ReplaceScopes.
+func (r *APIKeyRepository) UpdateWithoutScopes(db *gorm.DB, key *entity.APIKey) error {
+	return db.Omit("Scopes").Save(key).Error
+}
+
+// ReplaceScopes overwrites the entity/permission pairs an API key is allowed to use.
+func (r *APIKeyRepository) ReplaceScopes(db *gorm.DB, keyID string, scopes []entity.APIKeyScope) error {
+	if err := db.Where("api_key_id = ?", keyID).Delete(new(entity.APIKeyScope)).Error; err != nil {
+		return err
+	}
+	return db.Create(&scopes).Error
+}
+
+// TouchLastUsed records a use of the key at most once per interval so busy clients
+// do not turn every request into a write.
+func (r *APIKeyRepository) TouchLastUsed(db *gorm.DB, id string, now time.Time, interval time.Duration) error {
+	return db.Model(new(entity.APIKey)).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, now.Add(-interval)).
+		UpdateColumn("last_used_at", now).Error
+}
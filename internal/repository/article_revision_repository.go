@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+type ArticleRevisionRepository struct {
+	Repository[entity.ArticleRevision]
+}
+
+func (r *ArticleRevisionRepository) FindByArticleID(db *gorm.DB, revisions *[]entity.ArticleRevision, articleID string) error {
+	return db.Where("article_id = ?", articleID).Order("version DESC").Find(revisions).Error
+}
+
+func (r *ArticleRevisionRepository) FindByVersion(db *gorm.DB, revision *entity.ArticleRevision, articleID string, version int) error {
+	return db.Where("article_id = ? AND version = ?", articleID, version).Take(revision).Error
+}
+
+// LatestVersion returns 0 when the article has no revision yet.
+func (r *ArticleRevisionRepository) LatestVersion(db *gorm.DB, articleID string) (int, error) {
+	var version int
+	err := db.Model(&entity.ArticleRevision{}).
+		Select("COALESCE(MAX(version), 0)").
+		Where("article_id = ?", articleID).
+		Scan(&version).Error
+	return version, err
+}
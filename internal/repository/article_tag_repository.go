@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	relatedTagWeight      = 2
+	relatedCategoryWeight = 1
+)
+
+type ArticleTagRepository struct {
+	Repository[entity.ArticleTag]
+}
+
+// ReplaceTags sets the article's tags to exactly tags.
+func (r *ArticleTagRepository) ReplaceTags(db *gorm.DB, articleID string, tags []entity.Tag) error {
+	if err := r.DeleteByArticleID(db, articleID); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	rows := make([]entity.ArticleTag, len(tags))
+	for i, tag := range tags {
+		rows[i] = entity.ArticleTag{ArticleID: articleID, TagID: tag.ID}
+	}
+	return db.Create(&rows).Error
+}
+
+func (r *ArticleTagRepository) DeleteByArticleID(db *gorm.DB, articleID string) error {
+	return db.Where("article_id = ?", articleID).Delete(new(entity.ArticleTag)).Error
+}
+
+func (r *ArticleTagRepository) DeleteByTagID(db *gorm.DB, tagID string) error {
+	return db.Where("tag_id = ?", tagID).Delete(new(entity.ArticleTag)).Error
+}
+
+// TaggedWith is a subquery selecting the ids of the articles carrying one of the tag slugs.
+func (r *ArticleTagRepository) TaggedWith(db *gorm.DB, entityType string, slugs []string) *gorm.DB {
+	return db.Model(new(entity.ArticleTag)).
+		Select("article_tags.article_id").
+		Joins("JOIN tags ON tags.id = article_tags.tag_id").
+		Where("tags.entity_type = ? AND tags.slug IN ?", entityType, slugs)
+}
+
+// FindRelated lists the other articles of the entity sharing a tag or the category with article.
+// Every shared tag weighs more than a shared category; ties go to the most recently published.
+func (r *ArticleTagRepository) FindRelated(db *gorm.DB, articles *[]entity.Article, article *entity.Article, limit int) error {
+	tagIDs := make([]string, len(article.Tags))
+	for i, tag := range article.Tags {
+		tagIDs[i] = tag.ID
+	}
+
+	var matches, scores []string
+	var matchArgs, scoreArgs []any
+	if len(tagIDs) > 0 {
+		matches = append(matches, "id IN (SELECT article_id FROM article_tags WHERE tag_id IN ?)")
+		matchArgs = append(matchArgs, tagIDs)
+		scores = append(scores, "(SELECT COUNT(*) FROM article_tags WHERE article_tags.article_id = articles.id AND article_tags.tag_id IN ?) * ?")
+		scoreArgs = append(scoreArgs, tagIDs, relatedTagWeight)
+	}
+	if article.CategoryID != nil {
+		matches = append(matches, "category_id = ?")
+		matchArgs = append(matchArgs, *article.CategoryID)
+		scores = append(scores, "(category_id = ?) * ?")
+		scoreArgs = append(scoreArgs, *article.CategoryID, relatedCategoryWeight)
+	}
+	if len(matches) == 0 {
+		*articles = nil
+		return nil
+	}
+
+	return db.Where("entity_type = ? AND id <> ?", article.EntityType, article.ID).
+		Where(strings.Join(matches, " OR "), matchArgs...).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  strings.Join(scores, " + ") + " DESC, published_at DESC, id ASC",
+			Vars: scoreArgs,
+		}}).
+		Limit(limit).
+		Find(articles).Error
+}
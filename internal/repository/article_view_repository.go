@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ArticleViewRepository struct {
+	Repository[entity.ArticleView]
+}
+
+type ArticleViewCount struct {
+	ArticleID string
+	Views     int64
+}
+
+// AddViews adds views to the article's count for the day.
+func (r *ArticleViewRepository) AddViews(db *gorm.DB, articleID string, date time.Time, views int64) error {
+	return db.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{"views": gorm.Expr("views + ?", views)}),
+	}).Create(&entity.ArticleView{ArticleID: articleID, ViewDate: date, Views: views}).Error
+}
+
+func (r *ArticleViewRepository) FindDaily(db *gorm.DB, views *[]entity.ArticleView, articleID string, since time.Time) error {
+	return db.Where("article_id = ? AND view_date >= ?", articleID, since).Order("view_date ASC").Find(views).Error
+}
+
+func (r *ArticleViewRepository) TotalViews(db *gorm.DB, articleID string) (int64, error) {
+	var total int64
+	err := db.Model(&entity.ArticleView{}).
+		Select("COALESCE(SUM(views), 0)").
+		Where("article_id = ?", articleID).
+		Scan(&total).Error
+	return total, err
+}
+
+// FindPopular ranks the articles of db by their views since the given day, most viewed first.
+func (r *ArticleViewRepository) FindPopular(db *gorm.DB, counts *[]ArticleViewCount, since time.Time, limit int) error {
+	viewsSince := db.Session(&gorm.Session{NewDB: true}).
+		Model(&entity.ArticleView{}).
+		Select("article_id, SUM(views) AS views").
+		Where("view_date >= ?", since).
+		Group("article_id")
+
+	return db.Table("articles").
+		Select("articles.id AS article_id, popular.views").
+		Joins("JOIN (?) AS popular ON popular.article_id = articles.id", viewsSince).
+		Order("popular.views DESC, articles.published_at DESC, articles.id ASC").
+		Limit(limit).
+		Scan(counts).Error
+}
@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/util"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// AuditRoleSystem is recorded as the actor role for mutations made outside an authenticated request.
+const AuditRoleSystem = "system"
+
+const (
+	auditStateKey = "audit:state"
+	auditRedacted = "[REDACTED]"
+)
+
+var (
+	auditSkippedTables = map[string]bool{
+		"audit_logs":     true,
+		"login_attempts": true,
+	}
+	auditIgnoredColumns = map[string]bool{
+		"created_at":   true,
+		"updated_at":   true,
+		"last_used_at": true,
+	}
+	auditRedactedColumns = map[string]bool{
+		"password":          true,
+		"two_factor_secret": true,
+		"key_hash":          true,
+		"code_hash":         true,
+	}
+)
+
+type auditState struct {
+	id     any
+	before map[string]any
+}
+
+// RegisterAuditCallbacks writes an audit_logs row for every record created, updated or deleted
+// through GORM, inside the transaction of the mutation itself. Bulk statements that do not target
+// a record by its primary key, such as replacing a user's role assignments, are not audited.
+func RegisterAuditCallbacks(db *gorm.DB) error {
+	callback := db.Callback()
+
+	if err := callback.Create().Before("gorm:commit_or_rollback_transaction").Register("audit:after_create", auditAfterCreate); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("audit:before_update", auditBeforeChange); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:commit_or_rollback_transaction").Register("audit:after_update", auditAfterUpdate); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register("audit:before_delete", auditBeforeChange); err != nil {
+		return err
+	}
+	return callback.Delete().Before("gorm:commit_or_rollback_transaction").Register("audit:after_delete", auditAfterDelete)
+}
+
+func auditAfterCreate(db *gorm.DB) {
+	pk, ok := auditPrimaryField(db)
+	if !ok || db.RowsAffected == 0 {
+		return
+	}
+
+	for _, record := range auditRecords(db.Statement.ReflectValue) {
+		id, zero := pk.ValueOf(db.Statement.Context, record)
+		if zero {
+			continue
+		}
+
+		after := auditSnapshot(db.Statement, record)
+		writeAuditLog(db, entity.AuditActionCreate, id, after, auditDiff(nil, after))
+	}
+}
+
+func auditBeforeChange(db *gorm.DB) {
+	pk, ok := auditPrimaryField(db)
+	if !ok {
+		return
+	}
+
+	record := reflect.Indirect(db.Statement.ReflectValue)
+	if record.Kind() != reflect.Struct {
+		return
+	}
+
+	id, zero := pk.ValueOf(db.Statement.Context, record)
+	if zero {
+		return
+	}
+
+	before, err := loadAuditSnapshot(db, pk, id)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			db.AddError(err)
+		}
+		return
+	}
+
+	db.InstanceSet(auditStateKey, &auditState{id: id, before: before})
+}
+
+func auditAfterUpdate(db *gorm.DB) {
+	state, ok := auditStateOf(db)
+	if !ok {
+		return
+	}
+
+	pk, _ := auditPrimaryField(db)
+	after, err := loadAuditSnapshot(db, pk, state.id)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	changes := auditDiff(state.before, after)
+	if len(changes) == 0 {
+		return
+	}
+
+	writeAuditLog(db, entity.AuditActionUpdate, state.id, after, changes)
+}
+
+func auditAfterDelete(db *gorm.DB) {
+	state, ok := auditStateOf(db)
+	if !ok {
+		return
+	}
+
+	writeAuditLog(db, entity.AuditActionDelete, state.id, state.before, auditDiff(state.before, nil))
+}
+
+func auditPrimaryField(db *gorm.DB) (*schema.Field, bool) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || auditSkippedTables[stmt.Table] {
+		return nil, false
+	}
+	if len(stmt.Schema.PrimaryFields) != 1 {
+		return nil, false
+	}
+	return stmt.Schema.PrimaryFields[0], true
+}
+
+func auditStateOf(db *gorm.DB) (*auditState, bool) {
+	if db.Error != nil || db.RowsAffected == 0 {
+		return nil, false
+	}
+	value, ok := db.InstanceGet(auditStateKey)
+	if !ok {
+		return nil, false
+	}
+	state, ok := value.(*auditState)
+	return state, ok
+}
+
+func auditRecords(value reflect.Value) []reflect.Value {
+	value = reflect.Indirect(value)
+
+	switch value.Kind() {
+	case reflect.Struct:
+		return []reflect.Value{value}
+	case reflect.Slice, reflect.Array:
+		records := make([]reflect.Value, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			if record := reflect.Indirect(value.Index(i)); record.Kind() == reflect.Struct {
+				records = append(records, record)
+			}
+		}
+		return records
+	default:
+		return nil
+	}
+}
+
+// loadAuditSnapshot reads the stored row through the statement's connection, so it sees the
+// uncommitted state of the surrounding transaction.
+func loadAuditSnapshot(db *gorm.DB, pk *schema.Field, id any) (map[string]any, error) {
+	stmt := db.Statement
+	record := reflect.New(stmt.Schema.ModelType)
+
+	err := db.Session(&gorm.Session{NewDB: true}).
+		Table(stmt.Table).
+		Where(clause.Eq{Column: clause.Column{Name: pk.DBName}, Value: id}).
+		Take(record.Interface()).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return auditSnapshot(stmt, record.Elem()), nil
+}
+
+func auditSnapshot(stmt *gorm.Statement, record reflect.Value) map[string]any {
+	values := make(map[string]any, len(stmt.Schema.DBNames))
+	for _, name := range stmt.Schema.DBNames {
+		if auditIgnoredColumns[name] {
+			continue
+		}
+		value, _ := stmt.Schema.FieldsByDBName[name].ValueOf(stmt.Context, record)
+		values[name] = value
+	}
+	return values
+}
+
+// auditDiff keeps the columns whose value differs. A nil side stands for a record that does not
+// exist yet or anymore, in which case every column is kept.
+func auditDiff(before map[string]any, after map[string]any) entity.AuditChanges {
+	changes := entity.AuditChanges{}
+
+	names := make(map[string]struct{}, len(before)+len(after))
+	for name := range before {
+		names[name] = struct{}{}
+	}
+	for name := range after {
+		names[name] = struct{}{}
+	}
+
+	for name := range names {
+		oldValue, newValue := before[name], after[name]
+		if before != nil && after != nil && auditEqual(oldValue, newValue) {
+			continue
+		}
+		if auditRedactedColumns[name] {
+			oldValue, newValue = auditRedact(oldValue), auditRedact(newValue)
+		}
+		changes[name] = entity.AuditChange{Before: oldValue, After: newValue}
+	}
+
+	return changes
+}
+
+func auditEqual(a any, b any) bool {
+	left, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	right, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(left, right)
+}
+
+func auditRedact(value any) any {
+	if value == nil || reflect.ValueOf(value).IsZero() {
+		return nil
+	}
+	return auditRedacted
+}
+
+func writeAuditLog(db *gorm.DB, action entity.AuditAction, id any, record map[string]any, changes entity.AuditChanges) {
+	auditLog := entity.AuditLog{
+		ActorRole: AuditRoleSystem,
+		Resource:  db.Statement.Table,
+		RecordID:  fmt.Sprint(id),
+		Action:    action,
+		Changes:   changes,
+	}
+
+	if entityType, ok := record["entity_type"].(string); ok {
+		auditLog.EntityType = entityType
+	}
+
+	if actor := util.AuditActorFromContext(db.Statement.Context); actor != nil {
+		if actor.ID != "" {
+			auditLog.ActorID = &actor.ID
+		}
+		auditLog.ActorRole = actor.Role
+		auditLog.IP = actor.IP
+		auditLog.RequestID = actor.RequestID
+		if auditLog.EntityType == "" {
+			auditLog.EntityType = actor.EntityType
+		}
+	}
+
+	if err := db.Session(&gorm.Session{NewDB: true}).Create(&auditLog).Error; err != nil {
+		db.AddError(err)
+	}
+}
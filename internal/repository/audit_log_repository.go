@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository struct {
+	Repository[entity.AuditLog]
+	Log *logrus.Logger
+}
+
+func NewAuditLogRepository(log *logrus.Logger) *AuditLogRepository {
+	return &AuditLogRepository{
+		Log: log,
+	}
+}
+
+func (r *AuditLogRepository) Search(db *gorm.DB, request *model.SearchAuditLogRequest) ([]entity.AuditLog, int64, error) {
+	var logs []entity.AuditLog
+	if err := db.Scopes(r.FilterAuditLog(request)).
+		Order("created_at DESC").
+		Offset((request.Page - 1) * request.Size).
+		Limit(request.Size).
+		Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := db.Model(&entity.AuditLog{}).Scopes(r.FilterAuditLog(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
+
+func (r *AuditLogRepository) FilterAuditLog(request *model.SearchAuditLogRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if request.ActorID != "" {
+			tx = tx.Where("actor_id = ?", request.ActorID)
+		}
+		if request.Resource != "" {
+			tx = tx.Where("resource = ?", request.Resource)
+		}
+		if request.RecordID != "" {
+			tx = tx.Where("record_id = ?", request.RecordID)
+		}
+		if request.EntityType != "" {
+			tx = tx.Where("entity_type = ?", request.EntityType)
+		}
+		if request.Action != "" {
+			tx = tx.Where("action = ?", request.Action)
+		}
+		if request.From != nil {
+			tx = tx.Where("created_at >= ?", *request.From)
+		}
+		if request.To != nil {
+			tx = tx.Where("created_at <= ?", *request.To)
+		}
+		return tx
+	}
+}
@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"pura-agung-kertajaya-backend/internal/model"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// ListSpec whitelists how clients may list a resource. Sorts and Filters map the names used in the
+// query string to columns; Search lists the columns matched by the search term.
+type ListSpec struct {
+	Sorts       map[string]string
+	Filters     map[string]string
+	Search      []string
+	DefaultSort string
+	// DefaultSize pages the list even when the client does not ask for it. Zero returns every row
+	// unless a page, size or cursor is given.
+	DefaultSize int
+	// Preload is applied to the row query only, so it never runs along with the count.
+	Preload func(db *gorm.DB) *gorm.DB
+}
+
+type sortKey struct {
+	column string
+	desc   bool
+}
+
+// FindPage lists the records matching db and the request. It returns nil metadata when the list is
+// not paged. When a cursor is given the rows continue after it and the total is not counted.
+func (r *Repository[T]) FindPage(db *gorm.DB, dest *[]T, spec ListSpec, request *model.ListRequest) (*model.PageMetadata, error) {
+	keys, err := spec.sortKeys(request.Sort)
+	if err != nil {
+		return nil, err
+	}
+	if request.Page < 0 || request.Size < 0 || request.Size > MaxPageSize {
+		return nil, model.ErrBadRequest("page must be positive and size between 1 and 100")
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, err
+	}
+	if stmt.Schema.PrioritizedPrimaryField == nil {
+		return nil, gorm.ErrPrimaryKeyRequired
+	}
+	keys = append(keys, sortKey{column: stmt.Schema.PrioritizedPrimaryField.DBName})
+
+	query := db.Scopes(spec.filter(request))
+
+	size := request.Size
+	if size == 0 {
+		size = spec.DefaultSize
+	}
+	paged := size > 0 || request.Page > 0 || request.Cursor != ""
+	if paged && size == 0 {
+		size = DefaultPageSize
+	}
+
+	var paging *model.PageMetadata
+	if paged {
+		paging = &model.PageMetadata{Size: size}
+
+		if request.Cursor != "" {
+			values, err := decodeCursor(stmt, keys, request.Cursor)
+			if err != nil {
+				return nil, err
+			}
+			condition, args := keysetCondition(keys, values)
+			query = query.Where(condition, args...)
+		} else {
+			var total int64
+			if err := query.Session(&gorm.Session{}).Model(new(T)).Count(&total).Error; err != nil {
+				return nil, err
+			}
+
+			paging.Page = max(request.Page, 1)
+			paging.TotalItem = total
+			paging.TotalPage = int64(math.Ceil(float64(total) / float64(size)))
+			query = query.Offset((paging.Page - 1) * size)
+		}
+
+		query = query.Limit(size + 1)
+	}
+
+	for _, key := range keys {
+		if key.desc {
+			query = query.Order(key.column + " DESC")
+		} else {
+			query = query.Order(key.column + " ASC")
+		}
+	}
+	if spec.Preload != nil {
+		query = spec.Preload(query)
+	}
+
+	if err := query.Find(dest).Error; err != nil {
+		return nil, err
+	}
+
+	if paged && len(*dest) > size {
+		*dest = (*dest)[:size]
+		cursor, err := encodeCursor(stmt, keys, &(*dest)[size-1])
+		if err != nil {
+			return nil, err
+		}
+		paging.NextCursor = cursor
+	}
+
+	return paging, nil
+}
+
+func (s ListSpec) sortKeys(sort string) ([]sortKey, error) {
+	if sort == "" {
+		sort = s.DefaultSort
+	}
+
+	var keys []sortKey
+	for _, name := range strings.Split(sort, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		field := strings.TrimPrefix(name, "-")
+		column, ok := s.Sorts[field]
+		if !ok {
+			return nil, model.ErrBadRequest("unsupported sort field: " + field)
+		}
+		keys = append(keys, sortKey{column: column, desc: field != name})
+	}
+	return keys, nil
+}
+
+func (s ListSpec) filter(request *model.ListRequest) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		names := make([]string, 0, len(request.Filters))
+		for name := range request.Filters {
+			if _, ok := s.Filters[name]; ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			column := clause.Column{Name: s.Filters[name]}
+			parts := strings.Split(request.Filters[name], ",")
+			if len(parts) == 1 {
+				db = db.Where(clause.Eq{Column: column, Value: filterValue(parts[0])})
+				continue
+			}
+
+			values := make([]any, len(parts))
+			for i, part := range parts {
+				values[i] = filterValue(part)
+			}
+			db = db.Where(clause.IN{Column: column, Values: values})
+		}
+
+		if term := strings.TrimSpace(request.Search); term != "" && len(s.Search) > 0 {
+			conditions := make([]string, len(s.Search))
+			args := make([]any, len(s.Search))
+			for i, column := range s.Search {
+				conditions[i] = column + " LIKE ?"
+				args[i] = "%" + term + "%"
+			}
+			db = db.Where(strings.Join(conditions, " OR "), args...)
+		}
+		return db
+	}
+}
+
+// filterValue turns boolean query values into booleans; MySQL would compare 'true' to a
+// tinyint as 0.
+func filterValue(value string) any {
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	default:
+		return value
+	}
+}
+
+// keysetCondition matches the rows ordered after values. MySQL sorts NULL first in ascending and
+// last in descending order, which the conditions mirror.
+func keysetCondition(keys []sortKey, values []any) (string, []any) {
+	var (
+		alternatives []string
+		args         []any
+	)
+
+	for i, key := range keys {
+		var after string
+		var afterArgs []any
+		switch {
+		case values[i] == nil && key.desc:
+			continue
+		case values[i] == nil:
+			after = key.column + " IS NOT NULL"
+		case key.desc:
+			after = "(" + key.column + " < ? OR " + key.column + " IS NULL)"
+			afterArgs = []any{values[i]}
+		default:
+			after = key.column + " > ?"
+			afterArgs = []any{values[i]}
+		}
+
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, keys[j].column+" <=> ?")
+			args = append(args, values[j])
+		}
+		parts = append(parts, after)
+		args = append(args, afterArgs...)
+		alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	if len(alternatives) == 0 {
+		return "1 = 0", nil
+	}
+	return strings.Join(alternatives, " OR "), args
+}
+
+func encodeCursor(stmt *gorm.Statement, keys []sortKey, record any) (string, error) {
+	value := reflect.ValueOf(record).Elem()
+
+	values := make([]any, len(keys))
+	for i, key := range keys {
+		field := stmt.Schema.LookUpField(key.column)
+		if field == nil {
+			return "", model.ErrBadRequest("unsupported sort field: " + key.column)
+		}
+		v, zero := field.ValueOf(stmt.Context, value)
+		if zero && field.FieldType.Kind() == reflect.Ptr {
+			v = nil
+		}
+		values[i] = v
+	}
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func decodeCursor(stmt *gorm.Statement, keys []sortKey, cursor string) ([]any, error) {
+	invalid := model.ErrBadRequest("invalid cursor")
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, invalid
+	}
+
+	var values []any
+	if err := json.Unmarshal(raw, &values); err != nil || len(values) != len(keys) {
+		return nil, invalid
+	}
+
+	timeType := reflect.TypeOf(time.Time{})
+	for i, key := range keys {
+		field := stmt.Schema.LookUpField(key.column)
+		if field == nil || values[i] == nil {
+			continue
+		}
+
+		fieldType := field.FieldType
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType == timeType {
+			s, ok := values[i].(string)
+			if !ok {
+				return nil, invalid
+			}
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return nil, invalid
+			}
+			values[i] = t
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+
+	"github.com/sirupsen/logrus"
+)
+
+type LoginAttemptRepository struct {
+	Repository[entity.LoginAttempt]
+	Log *logrus.Logger
+}
+
+func NewLoginAttemptRepository(log *logrus.Logger) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		Log: log,
+	}
+}
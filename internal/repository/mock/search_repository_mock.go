@@ -0,0 +1,27 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+func NewMockSearchRepository() *MockSearchRepository {
+	return &MockSearchRepository{}
+}
+
+func (m *MockSearchRepository) Search(ctx context.Context, request *model.PublicSearchRequest, now time.Time) ([]entity.SearchDocument, int64, error) {
+	args := m.Called(ctx, request, now)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]entity.SearchDocument), args.Get(1).(int64), args.Error(2)
+}
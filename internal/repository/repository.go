@@ -57,3 +57,9 @@ func (r *Repository[T]) CountReference(db *gorm.DB, modelToCheck any, column str
 	err := db.Model(modelToCheck).Where(column+" = ?", value).Count(&total).Error
 	return total, err
 }
+
+// FindByIdAndEntityType only matches the record when it belongs to the given entity,
+// so a lookup from another entity's scope behaves as if the record did not exist.
+func (r *Repository[T]) FindByIdAndEntityType(db *gorm.DB, entity *T, id any, entityType string) error {
+	return db.Where("id = ? AND entity_type = ?", id, entityType).Take(entity).Error
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type RoleRepository struct {
+	Repository[entity.Role]
+	Log *logrus.Logger
+}
+
+func NewRoleRepository(log *logrus.Logger) *RoleRepository {
+	return &RoleRepository{
+		Log: log,
+	}
+}
+
+func (r *RoleRepository) FindAllWithPermissions(db *gorm.DB, roles *[]entity.Role) error {
+	return db.Preload("Permissions").Order("name ASC").Find(roles).Error
+}
+
+func (r *RoleRepository) FindByIdWithPermissions(db *gorm.DB, role *entity.Role, id string) error {
+	return db.Preload("Permissions").Where("id = ?", id).Take(role).Error
+}
+
+func (r *RoleRepository) CountByNameIgnoringID(db *gorm.DB, name string, id string) (int64, error) {
+	var total int64
+	err := db.Model(new(entity.Role)).Where("name = ? AND id != ?", name, id).Count(&total).Error
+	return total, err
+}
+
+func (r *RoleRepository) CountByIds(db *gorm.DB, ids []string) (int64, error) {
+	var total int64
+	err := db.Model(new(entity.Role)).Where("id IN ?", ids).Count(&total).Error
+	return total, err
+}
+
+// ReplacePermissions overwrites the permission set of a role.
+func (r *RoleRepository) ReplacePermissions(db *gorm.DB, roleID string, permissions []string) error {
+	if err := db.Where("role_id = ?", roleID).Delete(new(entity.RolePermission)).Error; err != nil {
+		return err
+	}
+
+	rows := make([]entity.RolePermission, 0, len(permissions))
+	for _, permission := range permissions {
+		rows = append(rows, entity.RolePermission{RoleID: roleID, Permission: permission})
+	}
+	return db.Create(&rows).Error
+}
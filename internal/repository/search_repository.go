@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/util"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// SearchRepository finds public content matching a free text query, best match first. The MySQL
+// implementation relies on the FULLTEXT indexes; another engine can be plugged in behind it.
+type SearchRepository interface {
+	Search(ctx context.Context, request *model.PublicSearchRequest, now time.Time) ([]entity.SearchDocument, int64, error)
+}
+
+type mysqlSearchSource struct {
+	Type   string
+	Table  string
+	Match  string
+	Fields string
+	Where  string
+	Scoped bool
+}
+
+var mysqlSearchSources = []mysqlSearchSource{
+	{
+		Type:   entity.SearchTypeArticle,
+		Table:  "articles",
+		Match:  "title, excerpt, content_text",
+		Fields: "entity_type, title, slug, CONCAT_WS(' ', excerpt, content_text) AS body, published_at AS date",
+		Where:  "status = @published AND (published_at IS NULL OR published_at <= @now) AND (expires_at IS NULL OR expires_at > @now)",
+		Scoped: true,
+	},
+	{
+		Type:   entity.SearchTypeActivity,
+		Table:  "activities",
+		Match:  "title, description, location",
+		Fields: "entity_type, title, '' AS slug, CONCAT_WS(' ', description, location) AS body, event_date AS date",
+		Where:  "is_active = TRUE",
+		Scoped: true,
+	},
+	{
+		Type:   entity.SearchTypeGallery,
+		Table:  "galleries",
+		Match:  "title, description",
+		Fields: "entity_type, title, '' AS slug, description AS body, created_at AS date",
+		Where:  "is_active = TRUE",
+		Scoped: true,
+	},
+	{
+		Type:   entity.SearchTypeFacility,
+		Table:  "facilities",
+		Match:  "name, description",
+		Fields: "entity_type, name AS title, '' AS slug, description AS body, created_at AS date",
+		Where:  "is_active = TRUE",
+		Scoped: true,
+	},
+}
+
+type mysqlSearchRepository struct {
+	db  *gorm.DB
+	log *logrus.Logger
+}
+
+func NewSearchRepository(db *gorm.DB, log *logrus.Logger) SearchRepository {
+	return &mysqlSearchRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *mysqlSearchRepository) Search(ctx context.Context, request *model.PublicSearchRequest, now time.Time) ([]entity.SearchDocument, int64, error) {
+	terms := util.SearchTerms(request.Query)
+	if len(terms) == 0 {
+		return nil, 0, nil
+	}
+
+	union := r.unionQuery(request)
+	if union == "" {
+		return nil, 0, nil
+	}
+
+	args := map[string]interface{}{
+		"query":       booleanQuery(terms),
+		"entity_type": request.EntityType,
+		"published":   entity.ArticleStatusPublished,
+		"now":         now,
+		"limit":       request.Size,
+		"offset":      (request.Page - 1) * request.Size,
+	}
+
+	db := r.db.WithContext(ctx)
+
+	var total int64
+	if err := db.Raw("SELECT COUNT(*) FROM ("+union+") AS search_results", args).Scan(&total).Error; err != nil {
+		r.log.WithError(err).Error("failed to count search results")
+		return nil, 0, err
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	var documents []entity.SearchDocument
+	if err := db.Raw("SELECT * FROM ("+union+") AS search_results ORDER BY score DESC, date DESC, id ASC LIMIT @limit OFFSET @offset", args).
+		Scan(&documents).Error; err != nil {
+		r.log.WithError(err).Error("failed to search content")
+		return nil, 0, err
+	}
+
+	return documents, total, nil
+}
+
+func (r *mysqlSearchRepository) unionQuery(request *model.PublicSearchRequest) string {
+	selects := make([]string, 0, len(mysqlSearchSources))
+	for _, source := range mysqlSearchSources {
+		if len(request.Types) > 0 && !slices.Contains(request.Types, source.Type) {
+			continue
+		}
+
+		match := fmt.Sprintf("MATCH(%s) AGAINST (@query IN BOOLEAN MODE)", source.Match)
+		query := fmt.Sprintf("SELECT '%s' AS type, id, %s, %s AS score FROM %s WHERE %s AND %s",
+			source.Type, source.Fields, match, source.Table, match, source.Where)
+		if source.Scoped && request.EntityType != "" {
+			query += " AND entity_type = @entity_type"
+		}
+		selects = append(selects, query)
+	}
+	return strings.Join(selects, " UNION ALL ")
+}
+
+// booleanQuery turns the terms into a boolean mode expression that ranks rows by how many terms they
+// contain, matching each term as a word prefix.
+func booleanQuery(terms []string) string {
+	words := make([]string, len(terms))
+	for i, term := range terms {
+		words[i] = term + "*"
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type UserRecoveryCodeRepository struct {
+	Repository[entity.UserRecoveryCode]
+	Log *logrus.Logger
+}
+
+func NewUserRecoveryCodeRepository(log *logrus.Logger) *UserRecoveryCodeRepository {
+	return &UserRecoveryCodeRepository{
+		Log: log,
+	}
+}
+
+func (r *UserRecoveryCodeRepository) DeleteByUserID(db *gorm.DB, userID string) error {
+	return db.Where("user_id = ?", userID).Delete(new(entity.UserRecoveryCode)).Error
+}
+
+// MarkUsed consumes an unused code and reports whether one matched.
+func (r *UserRecoveryCodeRepository) MarkUsed(db *gorm.DB, userID string, codeHash string, usedAt time.Time) (bool, error) {
+	result := db.Model(new(entity.UserRecoveryCode)).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	return result.RowsAffected == 1, result.Error
+}
@@ -21,3 +21,9 @@ func NewUserRepository(log *logrus.Logger) *UserRepository {
 func (r *UserRepository) FindByEmail(db *gorm.DB, user *entity.User, email string) error {
 	return db.Where("email = ? ", email).First(user).Error
 }
+
+func (r *UserRepository) CountByEmail(db *gorm.DB, email string) (int64, error) {
+	var total int64
+	err := db.Model(new(entity.User)).Where("email = ?", email).Count(&total).Error
+	return total, err
+}
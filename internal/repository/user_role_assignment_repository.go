@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type UserRoleAssignmentRepository struct {
+	Repository[entity.UserRoleAssignment]
+	Log *logrus.Logger
+}
+
+func NewUserRoleAssignmentRepository(log *logrus.Logger) *UserRoleAssignmentRepository {
+	return &UserRoleAssignmentRepository{
+		Log: log,
+	}
+}
+
+func (r *UserRoleAssignmentRepository) FindByUserID(db *gorm.DB, assignments *[]entity.UserRoleAssignment, userID string) error {
+	return db.Preload("Role").Where("user_id = ?", userID).Order("entity_type ASC").Find(assignments).Error
+}
+
+func (r *UserRoleAssignmentRepository) DeleteByUserID(db *gorm.DB, userID string) error {
+	return db.Where("user_id = ?", userID).Delete(new(entity.UserRoleAssignment)).Error
+}
+
+// AssignByRoleName gives the user the named role on an entity. It is a no-op when the
+// assignment already exists or no role carries that name.
+func (r *UserRoleAssignmentRepository) AssignByRoleName(db *gorm.DB, userID string, roleName string, entityType string) error {
+	return db.Exec(
+		"INSERT IGNORE INTO user_role_assignments (id, user_id, role_id, entity_type, created_at) "+
+			"SELECT ?, ?, id, ?, ? FROM roles WHERE name = ?",
+		uuid.New().String(), userID, entityType, time.Now(), roleName,
+	).Error
+}
+
+// RevokeByRoleName removes the user's assignment of the named role on an entity.
+func (r *UserRoleAssignmentRepository) RevokeByRoleName(db *gorm.DB, userID string, roleName string, entityType string) error {
+	return db.Exec(
+		"DELETE FROM user_role_assignments WHERE user_id = ? AND entity_type = ? "+
+			"AND role_id IN (SELECT id FROM roles WHERE name = ?)",
+		userID, entityType, roleName,
+	).Error
+}
+
+// FindGrants resolves the user's assignments into the permissions held per entity type.
+func (r *UserRoleAssignmentRepository) FindGrants(db *gorm.DB, userID string) (map[string][]string, error) {
+	var rows []struct {
+		EntityType string
+		Permission string
+	}
+
+	err := db.Table("user_role_assignments AS a").
+		Select("DISTINCT a.entity_type, p.permission").
+		Joins("JOIN role_permissions AS p ON p.role_id = a.role_id").
+		Where("a.user_id = ?", userID).
+		Order("a.entity_type ASC, p.permission ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	grants := make(map[string][]string)
+	for _, row := range rows {
+		grants[row.EntityType] = append(grants[row.EntityType], row.Permission)
+	}
+	return grants, nil
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"pura-agung-kertajaya-backend/internal/entity"
 	"pura-agung-kertajaya-backend/internal/model"
@@ -14,12 +15,12 @@ import (
 )
 
 type AboutUsecase interface {
-	GetAll(entityType string) ([]model.AboutSectionResponse, error)
-	GetPublic(entityType string) ([]model.AboutSectionResponse, error)
-	GetByID(id string) (*model.AboutSectionResponse, error)
-	Create(req model.AboutSectionRequest) (*model.AboutSectionResponse, error)
-	Update(id string, req model.AboutSectionRequest) (*model.AboutSectionResponse, error)
-	Delete(id string) error
+	GetAll(entityType string, req *model.ListRequest) ([]model.AboutSectionResponse, *model.PageMetadata, error)
+	GetPublic(entityType string, req *model.ListRequest) ([]model.AboutSectionResponse, *model.PageMetadata, error)
+	GetByID(entityType string, id string) (*model.AboutSectionResponse, error)
+	Create(ctx context.Context, entityType string, req model.AboutSectionRequest) (*model.AboutSectionResponse, error)
+	Update(ctx context.Context, entityType string, id string, req model.AboutSectionRequest) (*model.AboutSectionResponse, error)
+	Delete(ctx context.Context, entityType string, id string) error
 }
 
 type aboutUsecase struct {
@@ -42,41 +43,63 @@ func preloadValuesOrdered(tx *gorm.DB) *gorm.DB {
 	return tx.Preload("Values", func(db *gorm.DB) *gorm.DB { return db.Order("order_index ASC") })
 }
 
-func (u *aboutUsecase) GetAll(entityType string) ([]model.AboutSectionResponse, error) {
+var aboutSectionListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"title":      "title",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	Filters: map[string]string{
+		"is_active": "is_active",
+	},
+	Search:      []string{"title"},
+	DefaultSort: "created_at",
+	Preload:     preloadValuesOrdered,
+}
+
+func (u *aboutUsecase) GetAll(entityType string, req *model.ListRequest) ([]model.AboutSectionResponse, *model.PageMetadata, error) {
 	var list []entity.AboutSection
-	query := preloadValuesOrdered(u.db).Order("created_at ASC")
+
+	query := u.db
 	if entityType != "" {
 		query = query.Where("entity_type = ?", entityType)
 	}
-	if err := query.Find(&list).Error; err != nil {
-		return nil, err
+
+	paging, err := u.repoAbout.FindPage(query, &list, aboutSectionListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
+
 	resp := make([]model.AboutSectionResponse, 0, len(list))
 	for _, a := range list {
 		resp = append(resp, converter.ToAboutSectionResponse(a))
 	}
-	return resp, nil
+	return resp, paging, nil
 }
 
-func (u *aboutUsecase) GetPublic(entityType string) ([]model.AboutSectionResponse, error) {
+func (u *aboutUsecase) GetPublic(entityType string, req *model.ListRequest) ([]model.AboutSectionResponse, *model.PageMetadata, error) {
 	var list []entity.AboutSection
-	query := preloadValuesOrdered(u.db).Where("is_active = ?", true).Order("created_at ASC")
+
+	query := u.db.Where("is_active = ?", true)
 	if entityType != "" {
 		query = query.Where("entity_type = ?", entityType)
 	}
-	if err := query.Find(&list).Error; err != nil {
-		return nil, err
+
+	paging, err := u.repoAbout.FindPage(query, &list, aboutSectionListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
+
 	resp := make([]model.AboutSectionResponse, 0, len(list))
 	for _, a := range list {
 		resp = append(resp, converter.ToAboutSectionResponse(a))
 	}
-	return resp, nil
+	return resp, paging, nil
 }
 
-func (u *aboutUsecase) GetByID(id string) (*model.AboutSectionResponse, error) {
+func (u *aboutUsecase) GetByID(entityType string, id string) (*model.AboutSectionResponse, error) {
 	var a entity.AboutSection
-	if err := preloadValuesOrdered(u.db).Where("id = ?", id).First(&a).Error; err != nil {
+	if err := preloadValuesOrdered(u.db).Where("id = ? AND entity_type = ?", id, entityType).First(&a).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("about section not found")
 		}
@@ -86,22 +109,27 @@ func (u *aboutUsecase) GetByID(id string) (*model.AboutSectionResponse, error) {
 	return &r, nil
 }
 
-func (u *aboutUsecase) Create(req model.AboutSectionRequest) (*model.AboutSectionResponse, error) {
+func (u *aboutUsecase) Create(ctx context.Context, entityType string, req model.AboutSectionRequest) (*model.AboutSectionResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 
 	id := uuid.New().String()
 	a := entity.AboutSection{
 		ID:          id,
-		EntityType:  req.EntityType,
+		EntityType:  entityType,
 		Title:       req.Title,
 		Description: req.Description,
 		Images:      util.ImageMap(req.Images),
 		IsActive:    req.IsActive,
 	}
 
-	err := u.db.Transaction(func(tx *gorm.DB) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
 		if err := u.repoAbout.Create(tx, &a); err != nil {
 			return err
 		}
@@ -126,33 +154,37 @@ func (u *aboutUsecase) Create(req model.AboutSectionRequest) (*model.AboutSectio
 		return nil, err
 	}
 
-	if err := preloadValuesOrdered(u.db).Where("id = ?", a.ID).Take(&a).Error; err != nil {
+	if err := preloadValuesOrdered(db).Where("id = ?", a.ID).Take(&a).Error; err != nil {
 		return nil, err
 	}
 	r := converter.ToAboutSectionResponse(a)
 	return &r, nil
 }
 
-func (u *aboutUsecase) Update(id string, req model.AboutSectionRequest) (*model.AboutSectionResponse, error) {
+func (u *aboutUsecase) Update(ctx context.Context, entityType string, id string, req model.AboutSectionRequest) (*model.AboutSectionResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 
 	var a entity.AboutSection
-	if err := u.repoAbout.FindById(u.db, &a, id); err != nil {
+	if err := u.repoAbout.FindByIdAndEntityType(db, &a, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("about section not found")
 		}
 		return nil, err
 	}
 
-	a.EntityType = req.EntityType
 	a.Title = req.Title
 	a.Description = req.Description
 	a.Images = util.ImageMap(req.Images)
 	a.IsActive = req.IsActive
 
-	err := u.db.Transaction(func(tx *gorm.DB) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
 		if err := u.repoAbout.Update(tx, &a); err != nil {
 			return err
 		}
@@ -180,20 +212,22 @@ func (u *aboutUsecase) Update(id string, req model.AboutSectionRequest) (*model.
 		return nil, err
 	}
 
-	if err := preloadValuesOrdered(u.db).Where("id = ?", a.ID).Take(&a).Error; err != nil {
+	if err := preloadValuesOrdered(db).Where("id = ?", a.ID).Take(&a).Error; err != nil {
 		return nil, err
 	}
 	r := converter.ToAboutSectionResponse(a)
 	return &r, nil
 }
 
-func (u *aboutUsecase) Delete(id string) error {
-	exists, err := u.repoAbout.CountById(u.db, id)
-	if err != nil {
+func (u *aboutUsecase) Delete(ctx context.Context, entityType string, id string) error {
+	db := u.db.WithContext(ctx)
+
+	var a entity.AboutSection
+	if err := u.repoAbout.FindByIdAndEntityType(db, &a, id, entityType); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrNotFound("about section not found")
+		}
 		return err
 	}
-	if exists == 0 {
-		return model.ErrNotFound("about section not found")
-	}
-	return u.repoAbout.Delete(u.db, &entity.AboutSection{ID: id})
+	return u.repoAbout.Delete(db, &a)
 }
@@ -1,11 +1,14 @@
 package usecase
 
 import (
+	"context"
 	"errors"
+	"pura-agung-kertajaya-backend/internal/calendar"
 	"pura-agung-kertajaya-backend/internal/entity"
 	"pura-agung-kertajaya-backend/internal/model"
 	"pura-agung-kertajaya-backend/internal/model/converter"
 	"pura-agung-kertajaya-backend/internal/repository"
+	"sort"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -14,55 +17,87 @@ import (
 )
 
 type ActivityUsecase interface {
-	GetAll(entityType string) ([]model.ActivityResponse, error)
-	GetPublic(entityType string) ([]model.ActivityResponse, error)
-	GetByID(id string) (*model.ActivityResponse, error)
-	Create(entityType string, req model.CreateActivityRequest) (*model.ActivityResponse, error)
-	Update(id string, req model.UpdateActivityRequest) (*model.ActivityResponse, error)
-	Delete(id string) error
+	GetAll(entityType string, req *model.ListRequest) ([]model.ActivityResponse, *model.PageMetadata, error)
+	GetPublic(entityType string, req *model.ListRequest, window *model.DateRange) ([]model.ActivityResponse, *model.PageMetadata, error)
+	GetByID(entityType string, id string) (*model.ActivityResponse, error)
+	Create(ctx context.Context, entityType string, req model.CreateActivityRequest) (*model.ActivityResponse, error)
+	Update(ctx context.Context, entityType string, id string, req model.UpdateActivityRequest) (*model.ActivityResponse, error)
+	Delete(ctx context.Context, entityType string, id string) error
+	GetExceptions(entityType string, id string) ([]model.ActivityExceptionResponse, error)
+	SaveException(ctx context.Context, entityType string, id string, date string, req model.ActivityExceptionRequest) (*model.ActivityExceptionResponse, error)
+	DeleteException(ctx context.Context, entityType string, id string, date string) error
 }
 
+// activityMaxWindowDays bounds the window recurring activities are expanded over.
+const activityMaxWindowDays = 366
+
 type activityUsecase struct {
-	db       *gorm.DB
-	repo     *repository.Repository[entity.Activity]
-	validate *validator.Validate
+	db            *gorm.DB
+	repo          *repository.Repository[entity.Activity]
+	exceptionRepo *repository.Repository[entity.ActivityException]
+	validate      *validator.Validate
 }
 
 func NewActivityUsecase(db *gorm.DB, validate *validator.Validate) ActivityUsecase {
 	return &activityUsecase{
-		db:       db,
-		repo:     &repository.Repository[entity.Activity]{DB: db},
-		validate: validate,
+		db:            db,
+		repo:          &repository.Repository[entity.Activity]{DB: db},
+		exceptionRepo: &repository.Repository[entity.ActivityException]{DB: db},
+		validate:      validate,
 	}
 }
 
-func (u *activityUsecase) GetAll(entityType string) ([]model.ActivityResponse, error) {
+var activityListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"title":       "title",
+		"event_date":  "event_date",
+		"order_index": "order_index",
+		"created_at":  "created_at",
+	},
+	Filters: map[string]string{
+		"id":        "id",
+		"is_active": "is_active",
+		"location":  "location",
+	},
+	Search:      []string{"title", "location"},
+	DefaultSort: "-event_date,order_index",
+}
+
+func (u *activityUsecase) GetAll(entityType string, req *model.ListRequest) ([]model.ActivityResponse, *model.PageMetadata, error) {
 	var items []entity.Activity
 
-	query := u.db.Where("entity_type = ?", entityType).Order("event_date DESC").Order("order_index ASC")
+	query := u.db.Where("entity_type = ?", entityType)
 
-	if err := u.repo.FindAll(query, &items); err != nil {
-		return nil, err
+	paging, err := u.repo.FindPage(query, &items, activityListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return converter.ToActivityResponses(items), nil
+	return converter.ToActivityResponses(items), paging, nil
 }
 
-func (u *activityUsecase) GetPublic(entityType string) ([]model.ActivityResponse, error) {
+// GetPublic lists the active activities. Given a window, it lists instead every occurrence in it,
+// recurring activities expanded by their rule and exceptions, in date order.
+func (u *activityUsecase) GetPublic(entityType string, req *model.ListRequest, window *model.DateRange) ([]model.ActivityResponse, *model.PageMetadata, error) {
+	if window != nil {
+		return u.getOccurrences(entityType, req, window)
+	}
+
 	var items []entity.Activity
 
-	query := u.db.Where("entity_type = ?", entityType).Where("is_active = ?", true).Order("event_date DESC").Order("order_index ASC")
+	query := u.db.Where("entity_type = ?", entityType).Where("is_active = ?", true)
 
-	if err := u.repo.FindAll(query, &items); err != nil {
-		return nil, err
+	paging, err := u.repo.FindPage(query, &items, activityListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return converter.ToActivityResponses(items), nil
+	return converter.ToActivityResponses(items), paging, nil
 }
 
-func (u *activityUsecase) GetByID(id string) (*model.ActivityResponse, error) {
+func (u *activityUsecase) GetByID(entityType string, id string) (*model.ActivityResponse, error) {
 	var a entity.Activity
-	if err := u.repo.FindById(u.db, &a, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(u.db, &a, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("activity not found")
 		}
@@ -72,16 +107,25 @@ func (u *activityUsecase) GetByID(id string) (*model.ActivityResponse, error) {
 	return &r, nil
 }
 
-func (u *activityUsecase) Create(entityType string, req model.CreateActivityRequest) (*model.ActivityResponse, error) {
+func (u *activityUsecase) Create(ctx context.Context, entityType string, req model.CreateActivityRequest) (*model.ActivityResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 
 	eventDate, err := time.Parse("2006-01-02", req.EventDate)
 	if err != nil {
 		return nil, model.ErrBadRequest("invalid event_date format, expected YYYY-MM-DD")
 	}
 
+	if err := validateRecurrence(req.RecurrenceRule, req.PawukonRule, eventDate); err != nil {
+		return nil, err
+	}
+
 	a := entity.Activity{
 		ID:          uuid.New().String(),
 		EntityType:  entityType,
@@ -92,22 +136,30 @@ func (u *activityUsecase) Create(entityType string, req model.CreateActivityRequ
 		EventDate:   eventDate,
 		OrderIndex:  req.OrderIndex,
 		IsActive:    req.IsActive,
+
+		RecurrenceRule: req.RecurrenceRule,
+		PawukonRule:    req.PawukonRule,
 	}
 
-	if err := u.repo.Create(u.db, &a); err != nil {
+	if err := u.repo.Create(db, &a); err != nil {
 		return nil, err
 	}
 	r := converter.ToActivityResponse(&a)
 	return &r, nil
 }
 
-func (u *activityUsecase) Update(id string, req model.UpdateActivityRequest) (*model.ActivityResponse, error) {
+func (u *activityUsecase) Update(ctx context.Context, entityType string, id string, req model.UpdateActivityRequest) (*model.ActivityResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 
 	var a entity.Activity
-	if err := u.repo.FindById(u.db, &a, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &a, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("activity not found")
 		}
@@ -119,6 +171,10 @@ func (u *activityUsecase) Update(id string, req model.UpdateActivityRequest) (*m
 		return nil, model.ErrBadRequest("invalid event_date format, expected YYYY-MM-DD")
 	}
 
+	if err := validateRecurrence(req.RecurrenceRule, req.PawukonRule, eventDate); err != nil {
+		return nil, err
+	}
+
 	a.Title = req.Title
 	a.Description = req.Description
 	a.TimeInfo = req.TimeInfo
@@ -126,21 +182,273 @@ func (u *activityUsecase) Update(id string, req model.UpdateActivityRequest) (*m
 	a.EventDate = eventDate
 	a.OrderIndex = req.OrderIndex
 	a.IsActive = req.IsActive
+	a.RecurrenceRule = req.RecurrenceRule
+	a.PawukonRule = req.PawukonRule
 
-	if err := u.repo.Update(u.db, &a); err != nil {
+	if err := u.repo.Update(db, &a); err != nil {
 		return nil, err
 	}
 	r := converter.ToActivityResponse(&a)
 	return &r, nil
 }
 
-func (u *activityUsecase) Delete(id string) error {
+func (u *activityUsecase) Delete(ctx context.Context, entityType string, id string) error {
+	db := u.db.WithContext(ctx)
+
 	var a entity.Activity
-	if err := u.repo.FindById(u.db, &a, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &a, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return model.ErrNotFound("activity not found")
 		}
 		return err
 	}
-	return u.repo.Delete(u.db, &a)
+	return u.repo.Delete(db, &a)
+}
+
+func (u *activityUsecase) GetExceptions(entityType string, id string) ([]model.ActivityExceptionResponse, error) {
+	var a entity.Activity
+	if err := u.repo.FindByIdAndEntityType(u.db, &a, id, entityType); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("activity not found")
+		}
+		return nil, err
+	}
+
+	var exceptions []entity.ActivityException
+	if err := u.db.Where("activity_id = ?", a.ID).Order("occurrence_date ASC").Find(&exceptions).Error; err != nil {
+		return nil, err
+	}
+	return converter.ToActivityExceptionResponses(exceptions), nil
+}
+
+// SaveException cancels or overrides the occurrence of a recurring activity on date, replacing the
+// exception the occurrence already has.
+func (u *activityUsecase) SaveException(ctx context.Context, entityType string, id string, date string, req model.ActivityExceptionRequest) (*model.ActivityExceptionResponse, error) {
+	db := u.db.WithContext(ctx)
+
+	if err := u.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	a, occurrence, err := u.findOccurrence(db, entityType, id, date)
+	if err != nil {
+		return nil, err
+	}
+
+	var eventDate *time.Time
+	if req.EventDate != "" {
+		d, err := time.Parse("2006-01-02", req.EventDate)
+		if err != nil {
+			return nil, model.ErrBadRequest("invalid event_date format, expected YYYY-MM-DD")
+		}
+		d = time.Date(d.Year(), d.Month(), d.Day(), a.EventDate.Hour(), a.EventDate.Minute(), a.EventDate.Second(), 0, a.EventDate.Location())
+		eventDate = &d
+	}
+
+	var ex entity.ActivityException
+	err = db.Where("activity_id = ? AND occurrence_date = ?", a.ID, occurrence).Take(&ex).Error
+	exists := err == nil
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if !exists {
+		ex = entity.ActivityException{ID: uuid.New().String(), ActivityID: a.ID, OccurrenceDate: occurrence}
+	}
+
+	ex.IsCancelled = req.IsCancelled
+	ex.Title = req.Title
+	ex.Description = req.Description
+	ex.TimeInfo = req.TimeInfo
+	ex.Location = req.Location
+	ex.EventDate = eventDate
+
+	if exists {
+		err = u.exceptionRepo.Update(db, &ex)
+	} else {
+		err = u.exceptionRepo.Create(db, &ex)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r := converter.ToActivityExceptionResponse(&ex)
+	return &r, nil
+}
+
+// DeleteException restores the occurrence on date to what the rule makes of it.
+func (u *activityUsecase) DeleteException(ctx context.Context, entityType string, id string, date string) error {
+	db := u.db.WithContext(ctx)
+
+	a, occurrence, err := u.findOccurrence(db, entityType, id, date)
+	if err != nil {
+		return err
+	}
+
+	var ex entity.ActivityException
+	if err := db.Where("activity_id = ? AND occurrence_date = ?", a.ID, occurrence).Take(&ex).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrNotFound("occurrence has no exception")
+		}
+		return err
+	}
+	return u.exceptionRepo.Delete(db, &ex)
+}
+
+// findOccurrence loads a recurring activity and checks that its rule puts an occurrence on date.
+func (u *activityUsecase) findOccurrence(db *gorm.DB, entityType string, id string, date string) (*entity.Activity, time.Time, error) {
+	var a entity.Activity
+	if err := u.repo.FindByIdAndEntityType(db, &a, id, entityType); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, time.Time{}, model.ErrNotFound("activity not found")
+		}
+		return nil, time.Time{}, err
+	}
+
+	occurrence, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, time.Time{}, model.ErrBadRequest("invalid occurrence date format, expected YYYY-MM-DD")
+	}
+
+	recurrence, err := activityRecurrence(&a)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if recurrence == nil {
+		return nil, time.Time{}, model.ErrBadRequest("activity does not recur")
+	}
+	if !calendar.Occurs(recurrence, a.EventDate, occurrence) {
+		return nil, time.Time{}, model.ErrBadRequest("activity does not occur on " + date)
+	}
+	return &a, occurrence, nil
+}
+
+func (u *activityUsecase) getOccurrences(entityType string, req *model.ListRequest, window *model.DateRange) ([]model.ActivityResponse, *model.PageMetadata, error) {
+	from, to := window.From, window.To
+	switch days := int(to.Sub(from).Hours()/24) + 1; {
+	case days < 1:
+		return nil, nil, model.ErrBadRequest("to must not be before from")
+	case days > activityMaxWindowDays:
+		return nil, nil, model.ErrBadRequest("the window covers at most 366 days")
+	}
+	if req.Page < 0 || req.Size < 0 || req.Size > repository.MaxPageSize {
+		return nil, nil, model.ErrBadRequest("page must be positive and size between 1 and 100")
+	}
+	end := to.AddDate(0, 0, 1)
+
+	// One-off activities in the window, and recurring ones that started before its end.
+	var items []entity.Activity
+	query := u.db.Where("entity_type = ?", entityType).Where("is_active = ?", true).
+		Where("(recurrence_rule = '' AND pawukon_rule = '' AND event_date >= ? AND event_date < ?) OR ((recurrence_rule <> '' OR pawukon_rule <> '') AND event_date < ?)", from, end, end)
+	if _, err := u.repo.FindPage(query, &items, activityListSpec, &model.ListRequest{Search: req.Search, Filters: req.Filters}); err != nil {
+		return nil, nil, err
+	}
+
+	var ids []string
+	for _, item := range items {
+		if item.RecurrenceRule != "" || item.PawukonRule != "" {
+			ids = append(ids, item.ID)
+		}
+	}
+	exceptions := map[string]map[string]*entity.ActivityException{}
+	if len(ids) > 0 {
+		var rows []entity.ActivityException
+		if err := u.db.Where("activity_id IN ?", ids).
+			Where("(occurrence_date >= ? AND occurrence_date < ?) OR (event_date >= ? AND event_date < ?)", from, end, from, end).
+			Find(&rows).Error; err != nil {
+			return nil, nil, err
+		}
+		for i := range rows {
+			if exceptions[rows[i].ActivityID] == nil {
+				exceptions[rows[i].ActivityID] = map[string]*entity.ActivityException{}
+			}
+			exceptions[rows[i].ActivityID][rows[i].OccurrenceDate.Format("2006-01-02")] = &rows[i]
+		}
+	}
+
+	inWindow := func(t time.Time) bool { return !t.Before(from) && t.Before(end) }
+	occurrences := make([]model.ActivityResponse, 0, len(items))
+	for i := range items {
+		a := &items[i]
+		recurrence, err := activityRecurrence(a)
+		if err != nil {
+			continue
+		}
+		if recurrence == nil {
+			occurrences = append(occurrences, converter.ToActivityResponse(a))
+			continue
+		}
+
+		for _, date := range recurrence.Between(a.EventDate, from, to) {
+			ex := exceptions[a.ID][date.Format("2006-01-02")]
+			if ex != nil && (ex.IsCancelled || ex.EventDate != nil && !inWindow(*ex.EventDate)) {
+				continue
+			}
+			occurrences = append(occurrences, converter.ToActivityOccurrenceResponse(a, date, ex))
+		}
+		// Occurrences moved into the window from a date outside it.
+		for _, ex := range exceptions[a.ID] {
+			if !ex.IsCancelled && !inWindow(ex.OccurrenceDate) && ex.EventDate != nil && inWindow(*ex.EventDate) &&
+				calendar.Occurs(recurrence, a.EventDate, ex.OccurrenceDate) {
+				occurrences = append(occurrences, converter.ToActivityOccurrenceResponse(a, ex.OccurrenceDate, ex))
+			}
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		if !occurrences[i].EventDate.Equal(occurrences[j].EventDate) {
+			return occurrences[i].EventDate.Before(occurrences[j].EventDate)
+		}
+		return occurrences[i].OrderIndex < occurrences[j].OrderIndex
+	})
+
+	if req.Page == 0 && req.Size == 0 {
+		return occurrences, nil, nil
+	}
+	size := req.Size
+	if size == 0 {
+		size = repository.DefaultPageSize
+	}
+	paging := &model.PageMetadata{
+		Page:      max(req.Page, 1),
+		Size:      size,
+		TotalItem: int64(len(occurrences)),
+		TotalPage: int64((len(occurrences) + size - 1) / size),
+	}
+	first := min((paging.Page-1)*size, len(occurrences))
+	return occurrences[first:min(first+size, len(occurrences))], paging, nil
+}
+
+// activityRecurrence returns the rule of a recurring activity, or nil for a one-off one.
+func activityRecurrence(a *entity.Activity) (calendar.Recurrence, error) {
+	switch {
+	case a.RecurrenceRule != "":
+		rule, err := calendar.ParseRRule(a.RecurrenceRule)
+		if err != nil {
+			return nil, model.ErrBadRequest("invalid recurrence_rule: " + err.Error())
+		}
+		return rule, nil
+	case a.PawukonRule != "":
+		day, err := calendar.ParsePawukonDay(a.PawukonRule)
+		if err != nil {
+			return nil, model.ErrBadRequest("invalid pawukon_rule: " + err.Error())
+		}
+		return day, nil
+	}
+	return nil, nil
+}
+
+// validateRecurrence checks the rules of an activity. A Pawukon rule must name the day the activity
+// starts on, so the first occurrence is the event date itself.
+func validateRecurrence(recurrenceRule string, pawukonRule string, eventDate time.Time) error {
+	if recurrenceRule != "" && pawukonRule != "" {
+		return model.ErrBadRequest("an activity recurs by either recurrence_rule or pawukon_rule, not both")
+	}
+
+	recurrence, err := activityRecurrence(&entity.Activity{RecurrenceRule: recurrenceRule, PawukonRule: pawukonRule})
+	if err != nil {
+		return err
+	}
+	if day, ok := recurrence.(calendar.PawukonDay); ok && calendar.PawukonOf(eventDate) != day {
+		return model.ErrBadRequest("event_date falls on " + calendar.PawukonOf(eventDate).String() + ", not " + day.String())
+	}
+	return nil
 }
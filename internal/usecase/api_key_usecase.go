@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/model/converter"
+	"pura-agung-kertajaya-backend/internal/repository"
+	"pura-agung-kertajaya-backend/internal/util"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// APIKeyLastUsedInterval bounds how often last_used_at is written for a busy key.
+const APIKeyLastUsedInterval = time.Minute
+
+type APIKeyUseCase interface {
+	GetAll(ctx context.Context) ([]model.APIKeyResponse, error)
+	GetByID(ctx context.Context, id string) (*model.APIKeyResponse, error)
+	Create(ctx context.Context, createdBy string, req *model.CreateAPIKeyRequest) (*model.APIKeySecretResponse, error)
+	Update(ctx context.Context, id string, req *model.UpdateAPIKeyRequest) (*model.APIKeyResponse, error)
+	Rotate(ctx context.Context, id string) (*model.APIKeySecretResponse, error)
+	Revoke(ctx context.Context, id string) error
+	Authenticate(ctx context.Context, key string) (*model.APIKeyAuth, error)
+}
+
+type apiKeyUseCase struct {
+	DB               *gorm.DB
+	Validate         *validator.Validate
+	APIKeyRepository *repository.APIKeyRepository
+	Now              func() time.Time
+}
+
+func NewAPIKeyUseCase(db *gorm.DB, validate *validator.Validate, apiKeyRepository *repository.APIKeyRepository) APIKeyUseCase {
+	return &apiKeyUseCase{
+		DB:               db,
+		Validate:         validate,
+		APIKeyRepository: apiKeyRepository,
+		Now:              time.Now,
+	}
+}
+
+func (c *apiKeyUseCase) GetAll(ctx context.Context) ([]model.APIKeyResponse, error) {
+	var keys []entity.APIKey
+	if err := c.APIKeyRepository.FindAllWithScopes(c.DB.WithContext(ctx), &keys); err != nil {
+		return nil, err
+	}
+	return converter.ToAPIKeyResponses(keys), nil
+}
+
+func (c *apiKeyUseCase) GetByID(ctx context.Context, id string) (*model.APIKeyResponse, error) {
+	var key entity.APIKey
+	if err := c.APIKeyRepository.FindByIdWithScopes(c.DB.WithContext(ctx), &key, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("api key not found")
+		}
+		return nil, err
+	}
+	return converter.ToAPIKeyResponse(&key), nil
+}
+
+func (c *apiKeyUseCase) Create(ctx context.Context, createdBy string, req *model.CreateAPIKeyRequest) (*model.APIKeySecretResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	permissions, err := normalizePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, prefix, err := util.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key := entity.APIKey{
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   util.HashAPIKey(plain),
+		CreatedBy: createdBy,
+	}
+
+	if err := c.APIKeyRepository.Create(tx, &key); err != nil {
+		return nil, err
+	}
+
+	key.Scopes = toAPIKeyScopes(key.ID, req.EntityTypes, permissions)
+	if err := c.APIKeyRepository.ReplaceScopes(tx, key.ID, key.Scopes); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return &model.APIKeySecretResponse{
+		APIKey: converter.ToAPIKeyResponse(&key),
+		Key:    plain,
+	}, nil
+}
+
+func (c *apiKeyUseCase) Update(ctx context.Context, id string, req *model.UpdateAPIKeyRequest) (*model.APIKeyResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	permissions, err := normalizePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := c.findActive(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Name = req.Name
+	if err := c.APIKeyRepository.UpdateWithoutScopes(tx, key); err != nil {
+		return nil, err
+	}
+
+	key.Scopes = toAPIKeyScopes(key.ID, req.EntityTypes, permissions)
+	if err := c.APIKeyRepository.ReplaceScopes(tx, key.ID, key.Scopes); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return converter.ToAPIKeyResponse(key), nil
+}
+
+// Rotate replaces the secret of a key while keeping its label and scopes.
+// The previous secret stops working immediately.
+func (c *apiKeyUseCase) Rotate(ctx context.Context, id string) (*model.APIKeySecretResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	key, err := c.findActive(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, prefix, err := util.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key.Prefix = prefix
+	key.KeyHash = util.HashAPIKey(plain)
+	key.LastUsedAt = nil
+
+	if err := c.APIKeyRepository.UpdateWithoutScopes(tx, key); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return &model.APIKeySecretResponse{
+		APIKey: converter.ToAPIKeyResponse(key),
+		Key:    plain,
+	}, nil
+}
+
+// Revoke disables a key for good. The record is kept so its history stays visible; revoking twice is a no-op.
+func (c *apiKeyUseCase) Revoke(ctx context.Context, id string) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var key entity.APIKey
+	if err := c.APIKeyRepository.FindById(tx, &key, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrNotFound("api key not found")
+		}
+		return err
+	}
+
+	if key.RevokedAt != nil {
+		return nil
+	}
+
+	now := c.Now()
+	key.RevokedAt = &now
+	if err := c.APIKeyRepository.Update(tx, &key); err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (c *apiKeyUseCase) Authenticate(ctx context.Context, plain string) (*model.APIKeyAuth, error) {
+	db := c.DB.WithContext(ctx)
+
+	var key entity.APIKey
+	if err := c.APIKeyRepository.FindActiveByHash(db, &key, util.HashAPIKey(plain)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrUnauthorized("Invalid API key")
+		}
+		return nil, err
+	}
+
+	if err := c.APIKeyRepository.TouchLastUsed(db, key.ID, c.Now(), APIKeyLastUsedInterval); err != nil {
+		return nil, err
+	}
+
+	return &model.APIKeyAuth{
+		ID:     key.ID,
+		Name:   key.Name,
+		Grants: converter.ToAPIKeyGrants(key.Scopes),
+	}, nil
+}
+
+func (c *apiKeyUseCase) findActive(tx *gorm.DB, id string) (*entity.APIKey, error) {
+	var key entity.APIKey
+	if err := c.APIKeyRepository.FindByIdWithScopes(tx, &key, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("api key not found")
+		}
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return nil, model.ErrBadRequest("api key has been revoked")
+	}
+	return &key, nil
+}
+
+// toAPIKeyScopes grants every permission on every listed entity.
+func toAPIKeyScopes(keyID string, entityTypes []string, permissions []string) []entity.APIKeyScope {
+	seen := make(map[string]bool, len(entityTypes))
+	scopes := make([]entity.APIKeyScope, 0, len(entityTypes)*len(permissions))
+	for _, entityType := range entityTypes {
+		if seen[entityType] {
+			continue
+		}
+		seen[entityType] = true
+		for _, permission := range permissions {
+			scopes = append(scopes, entity.APIKeyScope{APIKeyID: keyID, EntityType: entityType, Permission: permission})
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/model/converter"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const maxRelatedArticles = 12
+
+// GetRelated recommends further reading for a published article: the other visible articles of the
+// entity sharing its tags or category, best match first.
+func (u *articleUsecase) GetRelated(entityType string, slug string, limit int) ([]model.ArticleResponse, error) {
+	if limit < 1 || limit > maxRelatedArticles {
+		return nil, model.ErrBadRequest(fmt.Sprintf("limit must be between 1 and %d", maxRelatedArticles))
+	}
+
+	now := time.Now()
+
+	var article entity.Article
+	if err := u.db.Preload("Tags").
+		Scopes(visibleAt(now)).
+		Where("entity_type = ?", entityType).
+		Where("slug = ?", slug).
+		Take(&article).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("article not found")
+		}
+		return nil, err
+	}
+
+	var related []entity.Article
+	if err := u.tagRepo.FindRelated(preloadArticleRelations(u.db).Scopes(visibleAt(now)), &related, &article, limit); err != nil {
+		return nil, err
+	}
+
+	return converter.ToPublicArticleResponses(related), nil
+}
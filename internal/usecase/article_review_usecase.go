@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/model/converter"
+	"pura-agung-kertajaya-backend/internal/util"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetReviewQueue lists the entity's articles waiting for review, oldest submission first.
+func (u *articleUsecase) GetReviewQueue(ctx context.Context, entityType string) ([]model.ArticleResponse, error) {
+	var articles []entity.Article
+
+	query := u.db.WithContext(ctx).Preload("Category").
+		Where("entity_type = ? AND status = ?", entityType, entity.ArticleStatusInReview).
+		Order("submitted_at ASC")
+
+	if err := u.repo.FindAll(query, &articles); err != nil {
+		return nil, err
+	}
+
+	return converter.ToArticleResponses(articles), nil
+}
+
+func (u *articleUsecase) SubmitForReview(ctx context.Context, entityType string, id string) (*model.ArticleResponse, error) {
+	return u.review(ctx, entityType, id, entity.ArticleStatusDraft, "only draft articles can be submitted for review", func(article *entity.Article, actorID *string, now time.Time) error {
+		article.Status = entity.ArticleStatusInReview
+		article.ReviewComment = ""
+		article.SubmittedBy = actorID
+		article.SubmittedAt = &now
+		article.ReviewedBy = nil
+		article.ReviewedAt = nil
+		return nil
+	})
+}
+
+// ApproveReview publishes the article, or schedules it when its publish date is still ahead.
+func (u *articleUsecase) ApproveReview(ctx context.Context, entityType string, id string) (*model.ArticleResponse, error) {
+	return u.review(ctx, entityType, id, entity.ArticleStatusInReview, "article is not waiting for review", func(article *entity.Article, actorID *string, now time.Time) error {
+		article.Status, article.PublishedAt = scheduleStatus(entity.ArticleStatusPublished, article.PublishedAt, now)
+		if err := checkExpiry(article.PublishedAt, article.ExpiresAt); err != nil {
+			return err
+		}
+		article.ReviewComment = ""
+		article.ReviewedBy = actorID
+		article.ReviewedAt = &now
+		return nil
+	})
+}
+
+// RejectReview sends the article back to draft with the reviewer's comment for the author.
+func (u *articleUsecase) RejectReview(ctx context.Context, entityType string, id string, req *model.RejectArticleRequest) (*model.ArticleResponse, error) {
+	if err := u.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	return u.review(ctx, entityType, id, entity.ArticleStatusInReview, "article is not waiting for review", func(article *entity.Article, actorID *string, now time.Time) error {
+		article.Status = entity.ArticleStatusDraft
+		article.ReviewComment = req.Comment
+		article.ReviewedBy = actorID
+		article.ReviewedAt = &now
+		return nil
+	})
+}
+
+// review applies a workflow transition to an article that must currently be in the given status.
+// The transition is recorded as a revision like any other save.
+func (u *articleUsecase) review(ctx context.Context, entityType string, id string, from entity.ArticleStatus, conflict string, apply func(article *entity.Article, actorID *string, now time.Time) error) (*model.ArticleResponse, error) {
+	tx := u.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var article entity.Article
+	if err := u.repo.FindByIdAndEntityType(tx.Clauses(clause.Locking{Strength: "UPDATE"}), &article, id, entityType); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("article not found")
+		}
+		return nil, err
+	}
+
+	if article.Status != from {
+		return nil, model.ErrConflict(conflict)
+	}
+
+	if err := u.ensureBaselineRevision(tx, &article); err != nil {
+		return nil, err
+	}
+
+	var actorID *string
+	if actor := util.AuditActorFromContext(ctx); actor != nil && actor.ID != "" {
+		actorID = &actor.ID
+	}
+
+	if err := apply(&article, actorID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Update(tx, &article); err != nil {
+		return nil, err
+	}
+
+	if err := u.saveRevision(ctx, tx, &article, nil); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	resp := converter.ToArticleResponse(&article)
+	return &resp, nil
+}
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/model/converter"
+	"pura-agung-kertajaya-backend/internal/util"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (u *articleUsecase) GetRevisions(ctx context.Context, entityType string, id string) ([]model.ArticleRevisionSummaryResponse, error) {
+	db := u.db.WithContext(ctx)
+
+	if err := u.checkArticle(db, entityType, id); err != nil {
+		return nil, err
+	}
+
+	var revisions []entity.ArticleRevision
+	if err := u.revisionRepo.FindByArticleID(db, &revisions, id); err != nil {
+		return nil, err
+	}
+
+	return converter.ToArticleRevisionSummaryResponses(revisions), nil
+}
+
+func (u *articleUsecase) GetRevision(ctx context.Context, entityType string, id string, version int) (*model.ArticleRevisionResponse, error) {
+	db := u.db.WithContext(ctx)
+
+	if err := u.checkArticle(db, entityType, id); err != nil {
+		return nil, err
+	}
+
+	revision, err := u.findRevision(db, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := converter.ToArticleRevisionResponse(revision)
+	return &resp, nil
+}
+
+func (u *articleUsecase) DiffRevisions(ctx context.Context, entityType string, id string, from int, to int) (*model.ArticleRevisionDiffResponse, error) {
+	if from < 1 || to < 1 {
+		return nil, model.ErrBadRequest("from and to must be revision versions")
+	}
+
+	db := u.db.WithContext(ctx)
+
+	if err := u.checkArticle(db, entityType, id); err != nil {
+		return nil, err
+	}
+
+	before, err := u.findRevision(db, id, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := u.findRevision(db, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]model.ArticleRevisionChange{}
+	beforeFields, afterFields := revisionFields(before), revisionFields(after)
+	for name, oldValue := range beforeFields {
+		newValue := afterFields[name]
+		if sameJSON(oldValue, newValue) {
+			continue
+		}
+		changes[name] = model.ArticleRevisionChange{Before: oldValue, After: newValue}
+	}
+
+	resp := &model.ArticleRevisionDiffResponse{
+		ArticleID: id,
+		From:      from,
+		To:        to,
+		Changes:   changes,
+	}
+	if before.Content != after.Content {
+		resp.ContentDiff = converter.ToDiffLineResponses(util.DiffLines(before.Content, after.Content))
+	}
+
+	return resp, nil
+}
+
+// RestoreRevision copies the content of an older revision back onto the article and records the
+// result as a new revision. Status, featured flag and publish date stay as they are, so restoring
+// never publishes or unpublishes an article. Restoring changes live content in place, so a published
+// or scheduled article can only be restored by a caller who may publish it.
+func (u *articleUsecase) RestoreRevision(ctx context.Context, entityType string, id string, version int, canPublish bool) (*model.ArticleResponse, error) {
+	tx := u.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var article entity.Article
+	if err := u.repo.FindByIdAndEntityType(tx.Clauses(clause.Locking{Strength: "UPDATE"}), &article, id, entityType); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("article not found")
+		}
+		return nil, err
+	}
+	if !canPublish && (article.Status == entity.ArticleStatusPublished || article.Status == entity.ArticleStatusScheduled) {
+		return nil, model.ErrForbidden("You do not have permission to change a published article")
+	}
+
+	revision, err := u.findRevision(tx, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if article.Title != revision.Title {
+		finalSlug, err := u.uniqueSlug(tx, entityType, revision.Title, id)
+		if err != nil {
+			return nil, err
+		}
+		article.Slug = finalSlug
+	}
+
+	article.CategoryID = revision.CategoryID
+	if article.CategoryID != nil {
+		count, err := u.repo.CountReference(tx.Where("entity_type = ?", entityType), &entity.Category{}, "id", *article.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			article.CategoryID = nil
+		}
+	}
+
+	article.Title = revision.Title
+	article.AuthorName = revision.AuthorName
+	article.AuthorRole = revision.AuthorRole
+	article.Excerpt = revision.Excerpt
+	article.Content = revision.Content
+	article.Images = revision.Images
+	if err := u.renderContent(&article, revision.ContentFormat); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Update(tx, &article); err != nil {
+		return nil, err
+	}
+
+	if err := u.saveRevision(ctx, tx, &article, &version); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	resp := converter.ToArticleResponse(&article)
+	return &resp, nil
+}
+
+// checkArticle reports a missing article, or one belonging to another entity, as not found.
+func (u *articleUsecase) checkArticle(db *gorm.DB, entityType string, id string) error {
+	count, err := u.repo.CountReference(db.Where("entity_type = ?", entityType), &entity.Article{}, "id", id)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.ErrNotFound("article not found")
+	}
+	return nil
+}
+
+func (u *articleUsecase) findRevision(db *gorm.DB, id string, version int) (*entity.ArticleRevision, error) {
+	var revision entity.ArticleRevision
+	if err := u.revisionRepo.FindByVersion(db, &revision, id, version); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("article revision not found")
+		}
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// ensureBaselineRevision snapshots an article saved before revisions were recorded, so its first
+// edit does not lose the text it had.
+func (u *articleUsecase) ensureBaselineRevision(tx *gorm.DB, article *entity.Article) error {
+	latest, err := u.revisionRepo.LatestVersion(tx, article.ID)
+	if err != nil || latest > 0 {
+		return err
+	}
+
+	revision := newArticleRevision(article, 1)
+	return u.revisionRepo.Create(tx, &revision)
+}
+
+// saveRevision records the article as saved by the current actor.
+func (u *articleUsecase) saveRevision(ctx context.Context, tx *gorm.DB, article *entity.Article, restoredFrom *int) error {
+	latest, err := u.revisionRepo.LatestVersion(tx, article.ID)
+	if err != nil {
+		return err
+	}
+
+	revision := newArticleRevision(article, latest+1)
+	revision.RestoredFrom = restoredFrom
+	if actor := util.AuditActorFromContext(ctx); actor != nil && actor.ID != "" {
+		revision.CreatedBy = &actor.ID
+	}
+
+	return u.revisionRepo.Create(tx, &revision)
+}
+
+func newArticleRevision(article *entity.Article, version int) entity.ArticleRevision {
+	return entity.ArticleRevision{
+		ArticleID:     article.ID,
+		Version:       version,
+		CategoryID:    article.CategoryID,
+		Title:         article.Title,
+		Slug:          article.Slug,
+		AuthorName:    article.AuthorName,
+		AuthorRole:    article.AuthorRole,
+		Excerpt:       article.Excerpt,
+		Content:       article.Content,
+		ContentFormat: article.ContentFormat,
+		Images:        article.Images,
+		Status:        article.Status,
+		IsFeatured:    article.IsFeatured,
+		PublishedAt:   article.PublishedAt,
+		ExpiresAt:     article.ExpiresAt,
+	}
+}
+
+func revisionFields(r *entity.ArticleRevision) map[string]any {
+	return map[string]any{
+		"category_id":  r.CategoryID,
+		"title":        r.Title,
+		"slug":         r.Slug,
+		"author_name":  r.AuthorName,
+		"author_role":  r.AuthorRole,
+		"excerpt":      r.Excerpt,
+		"images":       r.Images,
+		"status":       r.Status,
+		"is_featured":  r.IsFeatured,
+		"published_at": r.PublishedAt,
+		"expires_at":   r.ExpiresAt,
+	}
+}
+
+func sameJSON(a any, b any) bool {
+	left, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	right, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(left) == string(right)
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ApplySchedule archives articles whose expiry has passed and publishes scheduled articles that are
+// due. Every article is checked again under a row lock before it changes, so several instances
+// applying the schedule at the same time never transition an article twice.
+func (u *articleUsecase) ApplySchedule(ctx context.Context, now time.Time) (*model.ArticleScheduleResult, error) {
+	result := &model.ArticleScheduleResult{}
+
+	expiring := []entity.ArticleStatus{entity.ArticleStatusScheduled, entity.ArticleStatusPublished}
+	archived, err := u.transitionDue(ctx, entity.ArticleStatusArchived, "status IN ? AND expires_at <= ?", expiring, now)
+	result.Archived = archived
+	if err != nil {
+		return result, err
+	}
+
+	published, err := u.transitionDue(ctx, entity.ArticleStatusPublished, "status = ? AND published_at <= ?", entity.ArticleStatusScheduled, now)
+	result.Published = published
+	return result, err
+}
+
+func (u *articleUsecase) transitionDue(ctx context.Context, to entity.ArticleStatus, query string, args ...any) (int, error) {
+	var ids []string
+	if err := u.db.WithContext(ctx).Model(&entity.Article{}).Where(query, args...).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, id := range ids {
+		changed, err := u.transition(ctx, id, to, query, args...)
+		if err != nil {
+			return count, err
+		}
+		if changed {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// transition reports false when the article no longer matches the query, typically because
+// another instance has already handled it.
+func (u *articleUsecase) transition(ctx context.Context, id string, to entity.ArticleStatus, query string, args ...any) (bool, error) {
+	tx := u.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var article entity.Article
+	if err := u.repo.FindById(tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(query, args...), &article, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if err := u.ensureBaselineRevision(tx, &article); err != nil {
+		return false, err
+	}
+
+	article.Status = to
+	if err := u.repo.Update(tx, &article); err != nil {
+		return false, err
+	}
+
+	if err := u.saveRevision(ctx, tx, &article, nil); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// visibleAt limits a query to the articles visitors may see at the given time. It does not rely on
+// the scheduler having run, so a scheduled article never shows up early.
+func visibleAt(now time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("status = ?", entity.ArticleStatusPublished).
+			Where("published_at IS NULL OR published_at <= ?", now).
+			Where("expires_at IS NULL OR expires_at > ?", now)
+	}
+}
+
+// scheduleStatus turns a publish request into SCHEDULED while its publish date is still ahead, and
+// back into PUBLISHED once it is not. A publish request without a date publishes now.
+func scheduleStatus(status entity.ArticleStatus, publishedAt *time.Time, now time.Time) (entity.ArticleStatus, *time.Time) {
+	if status != entity.ArticleStatusPublished && status != entity.ArticleStatusScheduled {
+		return status, publishedAt
+	}
+
+	if publishedAt == nil {
+		publishedAt = &now
+	}
+	if publishedAt.After(now) {
+		return entity.ArticleStatusScheduled, publishedAt
+	}
+	return entity.ArticleStatusPublished, publishedAt
+}
+
+func checkExpiry(publishedAt *time.Time, expiresAt *time.Time) error {
+	if publishedAt != nil && expiresAt != nil && !expiresAt.After(*publishedAt) {
+		return model.ErrBadRequest("expires_at must be after published_at")
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"pura-agung-kertajaya-backend/internal/entity"
@@ -8,76 +9,156 @@ import (
 	"pura-agung-kertajaya-backend/internal/model/converter"
 	"pura-agung-kertajaya-backend/internal/repository"
 	"pura-agung-kertajaya-backend/internal/util"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gosimple/slug"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ArticleUsecase interface {
-	GetAll(filter string) ([]model.ArticleResponse, error)
-	GetPublic(limit int) ([]model.ArticleResponse, error)
-	GetByID(id string) (*model.ArticleResponse, error)
-	GetBySlug(slug string) (*model.ArticleResponse, error)
-	Create(req model.CreateArticleRequest) (*model.ArticleResponse, error)
-	Update(id string, req model.UpdateArticleRequest) (*model.ArticleResponse, error)
-	Delete(id string) error
+	GetAll(entityType string, req *model.ListRequest) ([]model.ArticleResponse, *model.PageMetadata, error)
+	GetPublic(entityType string, req *model.ListRequest) ([]model.ArticleResponse, *model.PageMetadata, error)
+	GetByID(entityType string, id string) (*model.ArticleResponse, error)
+	GetBySlug(entityType string, slug string) (*model.ArticleResponse, error)
+	Create(ctx context.Context, entityType string, req model.CreateArticleRequest) (*model.ArticleResponse, error)
+	Update(ctx context.Context, entityType string, id string, req model.UpdateArticleRequest) (*model.ArticleResponse, error)
+	Delete(ctx context.Context, entityType string, id string) error
+	GetRevisions(ctx context.Context, entityType string, id string) ([]model.ArticleRevisionSummaryResponse, error)
+	GetRevision(ctx context.Context, entityType string, id string, version int) (*model.ArticleRevisionResponse, error)
+	DiffRevisions(ctx context.Context, entityType string, id string, from int, to int) (*model.ArticleRevisionDiffResponse, error)
+	RestoreRevision(ctx context.Context, entityType string, id string, version int, canPublish bool) (*model.ArticleResponse, error)
+	ApplySchedule(ctx context.Context, now time.Time) (*model.ArticleScheduleResult, error)
+	GetReviewQueue(ctx context.Context, entityType string) ([]model.ArticleResponse, error)
+	SubmitForReview(ctx context.Context, entityType string, id string) (*model.ArticleResponse, error)
+	ApproveReview(ctx context.Context, entityType string, id string) (*model.ArticleResponse, error)
+	RejectReview(ctx context.Context, entityType string, id string, req *model.RejectArticleRequest) (*model.ArticleResponse, error)
+	GetRelated(entityType string, slug string, limit int) ([]model.ArticleResponse, error)
 }
 
 type articleUsecase struct {
-	db       *gorm.DB
-	repo     *repository.Repository[entity.Article]
-	validate *validator.Validate
+	db           *gorm.DB
+	repo         *repository.Repository[entity.Article]
+	revisionRepo *repository.ArticleRevisionRepository
+	tagRepo      *repository.ArticleTagRepository
+	validate     *validator.Validate
+	siteURL      *util.SiteURLUtil
 }
 
-func NewArticleUsecase(db *gorm.DB, validate *validator.Validate) ArticleUsecase {
+func NewArticleUsecase(db *gorm.DB, validate *validator.Validate, siteURL *util.SiteURLUtil) ArticleUsecase {
 	return &articleUsecase{
-		db:       db,
-		repo:     &repository.Repository[entity.Article]{DB: db},
-		validate: validate,
+		db:           db,
+		repo:         &repository.Repository[entity.Article]{DB: db},
+		revisionRepo: &repository.ArticleRevisionRepository{},
+		tagRepo:      &repository.ArticleTagRepository{},
+		validate:     validate,
+		siteURL:      siteURL,
 	}
 }
 
-func (u *articleUsecase) GetPublic(limit int) ([]model.ArticleResponse, error) {
-	var articles []entity.Article
+var articleListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"title":        "title",
+		"status":       "status",
+		"is_featured":  "is_featured",
+		"published_at": "published_at",
+		"created_at":   "created_at",
+		"updated_at":   "updated_at",
+	},
+	Filters: map[string]string{
+		"status":      "status",
+		"category_id": "category_id",
+		"is_featured": "is_featured",
+	},
+	Search:      []string{"title"},
+	DefaultSort: "-created_at",
+	DefaultSize: repository.DefaultPageSize,
+	Preload:     preloadArticleRelations,
+}
 
-	query := u.db.Preload("Category").
-		Where("status = ?", entity.ArticleStatusPublished).
-		Order("is_featured DESC, published_at DESC")
+var publicArticleListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"title":        "title",
+		"is_featured":  "is_featured",
+		"published_at": "published_at",
+	},
+	Filters: map[string]string{
+		"category_id": "category_id",
+		"is_featured": "is_featured",
+	},
+	Search:      []string{"title"},
+	DefaultSort: "-is_featured,-published_at",
+	DefaultSize: repository.DefaultPageSize,
+	Preload:     preloadArticleRelations,
+}
 
-	if limit > 0 {
-		query = query.Limit(limit)
-	}
+func preloadArticleRelations(db *gorm.DB) *gorm.DB {
+	return db.Preload("Category").Preload("Tags")
+}
 
-	if err := u.repo.FindAll(query, &articles); err != nil {
-		return nil, err
+// renderContent stores the sanitized HTML of the article body written in format, with its plain text,
+// word count and reading time. An empty format is HTML.
+func (u *articleUsecase) renderContent(article *entity.Article, format string) error {
+	if format == "" {
+		format = util.ContentFormatHTML
+	}
+	rendered, err := util.RenderContent(format, article.Content, u.siteURL.AssetURL)
+	if err != nil {
+		return model.ErrBadRequest(err.Error())
 	}
 
-	return converter.ToArticleResponses(articles), nil
+	article.ContentFormat = format
+	article.ContentHTML = rendered.HTML
+	article.ContentText = rendered.Text
+	article.WordCount = rendered.WordCount
+	article.ReadingTimeMinutes = rendered.ReadingTimeMinutes
+	return nil
 }
 
-func (u *articleUsecase) GetAll(filter string) ([]model.ArticleResponse, error) {
+// tagFilter keeps the articles carrying one of the comma separated tag slugs of the tag filter.
+func (u *articleUsecase) tagFilter(entityType string, req *model.ListRequest) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		tag := req.Filters["tag"]
+		if tag == "" {
+			return db
+		}
+		return db.Where("id IN (?)", u.tagRepo.TaggedWith(u.db, entityType, strings.Split(tag, ",")))
+	}
+}
+
+func (u *articleUsecase) GetPublic(entityType string, req *model.ListRequest) ([]model.ArticleResponse, *model.PageMetadata, error) {
 	var articles []entity.Article
 
-	query := u.db.Preload("Category").Order("created_at DESC")
+	query := u.db.Where("entity_type = ?", entityType).Scopes(visibleAt(time.Now()), u.tagFilter(entityType, req))
 
-	if filter != "" {
-		query = query.Where("title LIKE ?", "%"+filter+"%")
+	paging, err := u.repo.FindPage(query, &articles, publicArticleListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if err := u.repo.FindAll(query, &articles); err != nil {
-		return nil, err
+	return converter.ToPublicArticleResponses(articles), paging, nil
+}
+
+func (u *articleUsecase) GetAll(entityType string, req *model.ListRequest) ([]model.ArticleResponse, *model.PageMetadata, error) {
+	var articles []entity.Article
+
+	query := u.db.Where("entity_type = ?", entityType).Scopes(u.tagFilter(entityType, req))
+
+	paging, err := u.repo.FindPage(query, &articles, articleListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return converter.ToArticleResponses(articles), nil
+	return converter.ToArticleResponses(articles), paging, nil
 }
 
-func (u *articleUsecase) GetByID(id string) (*model.ArticleResponse, error) {
+func (u *articleUsecase) GetByID(entityType string, id string) (*model.ArticleResponse, error) {
 	var article entity.Article
-	query := u.db.Preload("Category").Where("id = ?", id)
 
-	if err := query.First(&article).Error; err != nil {
+	if err := u.repo.FindByIdAndEntityType(preloadArticleRelations(u.db), &article, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("article not found")
 		}
@@ -88,11 +169,13 @@ func (u *articleUsecase) GetByID(id string) (*model.ArticleResponse, error) {
 	return &resp, nil
 }
 
-func (u *articleUsecase) GetBySlug(slug string) (*model.ArticleResponse, error) {
+func (u *articleUsecase) GetBySlug(entityType string, slug string) (*model.ArticleResponse, error) {
 	var article entity.Article
 
-	if err := u.db.Preload("Category").
-		Where("slug = ? AND status = ?", slug, entity.ArticleStatusPublished).
+	if err := preloadArticleRelations(u.db).
+		Scopes(visibleAt(time.Now())).
+		Where("entity_type = ?", entityType).
+		Where("slug = ?", slug).
 		First(&article).Error; err != nil {
 
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -101,42 +184,48 @@ func (u *articleUsecase) GetBySlug(slug string) (*model.ArticleResponse, error)
 		return nil, err
 	}
 
-	resp := converter.ToArticleResponse(&article)
+	resp := converter.ToPublicArticleResponse(&article)
 	return &resp, nil
 }
 
-func (u *articleUsecase) Create(req model.CreateArticleRequest) (*model.ArticleResponse, error) {
+func (u *articleUsecase) Create(ctx context.Context, entityType string, req model.CreateArticleRequest) (*model.ArticleResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 
-	baseSlug := slug.Make(req.Title)
-	finalSlug := baseSlug
-	counter := 1
-	for {
-		count, err := u.repo.CountBySlug(u.db, finalSlug)
-		if err != nil {
-			return nil, err
-		}
-		if count == 0 {
-			break
-		}
-		finalSlug = fmt.Sprintf("%s-%d", baseSlug, counter)
-		counter++
+	status, pubTime := scheduleStatus(entity.ArticleStatus(req.Status), req.PublishedAt, time.Now())
+	if err := checkExpiry(pubTime, req.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	finalSlug, err := u.uniqueSlug(tx, entityType, req.Title, "")
+	if err != nil {
+		return nil, err
 	}
 
 	var catID *string
 	if req.CategoryID != "" {
+		if err := u.checkCategory(tx, entityType, req.CategoryID); err != nil {
+			return nil, err
+		}
 		catID = &req.CategoryID
 	}
 
-	pubTime := req.PublishedAt
-	if req.Status == "PUBLISHED" && pubTime == nil {
-		now := time.Now()
-		pubTime = &now
+	tags, err := u.findTags(tx, entityType, req.TagIDs)
+	if err != nil {
+		return nil, err
 	}
 
 	article := entity.Article{
+		EntityType:  entityType,
 		CategoryID:  catID,
 		Title:       req.Title,
 		Slug:        finalSlug,
@@ -145,12 +234,36 @@ func (u *articleUsecase) Create(req model.CreateArticleRequest) (*model.ArticleR
 		Excerpt:     req.Excerpt,
 		Content:     req.Content,
 		Images:      util.ImageMap(req.Images),
-		Status:      entity.ArticleStatus(req.Status),
+		Status:      status,
 		IsFeatured:  req.IsFeatured,
 		PublishedAt: pubTime,
+		ExpiresAt:   req.ExpiresAt,
+
+		MetaTitle:       req.MetaTitle,
+		MetaDescription: req.MetaDescription,
+		CanonicalURL:    req.CanonicalURL,
+		OGImageVariant:  req.OGImageVariant,
+	}
+	if err := u.renderContent(&article, req.ContentFormat); err != nil {
+		return nil, err
 	}
 
-	if err := u.repo.Create(u.db, &article); err != nil {
+	if err := u.repo.Create(tx, &article); err != nil {
+		return nil, err
+	}
+
+	if len(tags) > 0 {
+		if err := u.tagRepo.ReplaceTags(tx, article.ID, tags); err != nil {
+			return nil, err
+		}
+		article.Tags = tags
+	}
+
+	if err := u.saveRevision(ctx, tx, &article, nil); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
 		return nil, err
 	}
 
@@ -158,33 +271,35 @@ func (u *articleUsecase) Create(req model.CreateArticleRequest) (*model.ArticleR
 	return &resp, nil
 }
 
-func (u *articleUsecase) Update(id string, req model.UpdateArticleRequest) (*model.ArticleResponse, error) {
+func (u *articleUsecase) Update(ctx context.Context, entityType string, id string, req model.UpdateArticleRequest) (*model.ArticleResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
+
+	tx := db.Begin()
+	defer tx.Rollback()
 
 	var article entity.Article
-	if err := u.repo.FindById(u.db, &article, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(tx.Clauses(clause.Locking{Strength: "UPDATE"}), &article, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("article not found")
 		}
 		return nil, err
 	}
 
+	if err := u.ensureBaselineRevision(tx, &article); err != nil {
+		return nil, err
+	}
+
 	if article.Title != req.Title {
-		baseSlug := slug.Make(req.Title)
-		finalSlug := baseSlug
-		counter := 1
-		for {
-			count, err := u.repo.CountBySlugIgnoringID(u.db, finalSlug, id)
-			if err != nil {
-				return nil, err
-			}
-			if count == 0 {
-				break
-			}
-			finalSlug = fmt.Sprintf("%s-%d", baseSlug, counter)
-			counter++
+		finalSlug, err := u.uniqueSlug(tx, entityType, req.Title, id)
+		if err != nil {
+			return nil, err
 		}
 		article.Slug = finalSlug
 	}
@@ -195,10 +310,26 @@ func (u *articleUsecase) Update(id string, req model.UpdateArticleRequest) (*mod
 	article.Excerpt = req.Excerpt
 	article.Content = req.Content
 	article.Images = util.ImageMap(req.Images)
-	article.Status = entity.ArticleStatus(req.Status)
 	article.IsFeatured = req.IsFeatured
+	article.MetaTitle = req.MetaTitle
+	article.MetaDescription = req.MetaDescription
+	article.CanonicalURL = req.CanonicalURL
+	article.OGImageVariant = req.OGImageVariant
+
+	format := req.ContentFormat
+	if format == "" {
+		format = article.ContentFormat
+	}
+	if err := u.renderContent(&article, format); err != nil {
+		return nil, err
+	}
 
 	if req.CategoryID != "" {
+		if article.CategoryID == nil || *article.CategoryID != req.CategoryID {
+			if err := u.checkCategory(tx, entityType, req.CategoryID); err != nil {
+				return nil, err
+			}
+		}
 		article.CategoryID = &req.CategoryID
 	} else {
 		article.CategoryID = nil
@@ -208,7 +339,42 @@ func (u *articleUsecase) Update(id string, req model.UpdateArticleRequest) (*mod
 		article.PublishedAt = req.PublishedAt
 	}
 
-	if err := u.repo.Update(u.db, &article); err != nil {
+	status := entity.ArticleStatus(req.Status)
+	if status == entity.ArticleStatusInReview && article.Status != entity.ArticleStatusInReview {
+		return nil, model.ErrBadRequest("submit the article to send it for review")
+	}
+
+	article.Status, article.PublishedAt = scheduleStatus(status, article.PublishedAt, time.Now())
+	article.ExpiresAt = req.ExpiresAt
+	if err := checkExpiry(article.PublishedAt, article.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	var tags []entity.Tag
+	if req.TagIDs != nil {
+		found, err := u.findTags(tx, entityType, req.TagIDs)
+		if err != nil {
+			return nil, err
+		}
+		tags = found
+	}
+
+	if err := u.repo.Update(tx, &article); err != nil {
+		return nil, err
+	}
+
+	if req.TagIDs != nil {
+		if err := u.tagRepo.ReplaceTags(tx, article.ID, tags); err != nil {
+			return nil, err
+		}
+		article.Tags = tags
+	}
+
+	if err := u.saveRevision(ctx, tx, &article, nil); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
 		return nil, err
 	}
 
@@ -216,13 +382,72 @@ func (u *articleUsecase) Update(id string, req model.UpdateArticleRequest) (*mod
 	return &resp, nil
 }
 
-func (u *articleUsecase) Delete(id string) error {
-	exists, err := u.repo.CountById(u.db, id)
+func (u *articleUsecase) Delete(ctx context.Context, entityType string, id string) error {
+	db := u.db.WithContext(ctx)
+
+	var article entity.Article
+	if err := u.repo.FindByIdAndEntityType(db, &article, id, entityType); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrNotFound("article not found")
+		}
+		return err
+	}
+	return u.repo.Delete(db, &article)
+}
+
+// checkCategory rejects a category that does not exist in the article's entity.
+func (u *articleUsecase) checkCategory(db *gorm.DB, entityType string, categoryID string) error {
+	count, err := u.repo.CountReference(db.Where("entity_type = ?", entityType), &entity.Category{}, "id", categoryID)
 	if err != nil {
 		return err
 	}
-	if exists == 0 {
-		return model.ErrNotFound("article not found")
+	if count == 0 {
+		return model.ErrBadRequest("category not found")
+	}
+	return nil
+}
+
+// findTags loads the tags with the given ids, rejecting any id that is not a tag of the entity.
+func (u *articleUsecase) findTags(db *gorm.DB, entityType string, tagIDs []string) ([]entity.Tag, error) {
+	ids := slices.Clone(tagIDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	var tags []entity.Tag
+	if len(ids) == 0 {
+		return tags, nil
+	}
+	if err := db.Where("entity_type = ? AND id IN ?", entityType, ids).Order("name ASC").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	if len(tags) != len(ids) {
+		return nil, model.ErrBadRequest("tag not found")
+	}
+	return tags, nil
+}
+
+// uniqueSlug derives the slug from the title and appends a counter while it is taken by another
+// article of the same entity.
+func (u *articleUsecase) uniqueSlug(db *gorm.DB, entityType string, title string, ignoreID string) (string, error) {
+	baseSlug := slug.Make(title)
+	finalSlug := baseSlug
+	counter := 1
+	for {
+		var count int64
+		var err error
+		scoped := db.Where("entity_type = ?", entityType)
+		if ignoreID == "" {
+			count, err = u.repo.CountBySlug(scoped, finalSlug)
+		} else {
+			count, err = u.repo.CountBySlugIgnoringID(scoped, finalSlug, ignoreID)
+		}
+		if err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return finalSlug, nil
+		}
+		finalSlug = fmt.Sprintf("%s-%d", baseSlug, counter)
+		counter++
 	}
-	return u.repo.Delete(u.db, &entity.Article{ID: id})
 }
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/model/converter"
+	"pura-agung-kertajaya-backend/internal/repository"
+	"pura-agung-kertajaya-backend/internal/util"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxViewStatsDays   = 365
+	maxPopularArticles = 20
+)
+
+type ArticleViewUsecase interface {
+	RecordView(ctx context.Context, articleID string, ip string, userAgent string) error
+	FlushViews(ctx context.Context) (int64, error)
+	GetPopular(entityType string, days int, limit int) ([]model.PopularArticleResponse, error)
+	GetStats(ctx context.Context, entityType string, id string, days int) (*model.ArticleViewStatsResponse, error)
+}
+
+type articleViewUsecase struct {
+	db          *gorm.DB
+	repo        *repository.ArticleViewRepository
+	articleRepo *repository.Repository[entity.Article]
+	viewCounter *util.ViewCounterUtil
+}
+
+func NewArticleViewUsecase(db *gorm.DB, viewCounter *util.ViewCounterUtil) ArticleViewUsecase {
+	return &articleViewUsecase{
+		db:          db,
+		repo:        &repository.ArticleViewRepository{},
+		articleRepo: &repository.Repository[entity.Article]{DB: db},
+		viewCounter: viewCounter,
+	}
+}
+
+func (u *articleViewUsecase) RecordView(ctx context.Context, articleID string, ip string, userAgent string) error {
+	_, err := u.viewCounter.Record(ctx, articleID, ip, userAgent)
+	return err
+}
+
+// FlushViews moves the views buffered in Redis into the daily counts and returns how many it moved.
+// Views of articles deleted in the meantime are dropped.
+func (u *articleViewUsecase) FlushViews(ctx context.Context) (int64, error) {
+	var flushed int64
+
+	err := u.viewCounter.Drain(ctx, func(views []util.ArticleDayViews) error {
+		if len(views) == 0 {
+			return nil
+		}
+
+		ids := make([]string, 0, len(views))
+		for _, v := range views {
+			ids = append(ids, v.ArticleID)
+		}
+
+		return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var existing []string
+			if err := tx.Model(&entity.Article{}).Where("id IN ?", ids).Pluck("id", &existing).Error; err != nil {
+				return err
+			}
+			known := make(map[string]bool, len(existing))
+			for _, id := range existing {
+				known[id] = true
+			}
+
+			flushed = 0
+			for _, v := range views {
+				if !known[v.ArticleID] {
+					continue
+				}
+				if err := u.repo.AddViews(tx, v.ArticleID, v.Date, v.Views); err != nil {
+					return err
+				}
+				flushed += v.Views
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return flushed, nil
+}
+
+// GetPopular lists the visible articles of the entity most viewed over the last days.
+func (u *articleViewUsecase) GetPopular(entityType string, days int, limit int) ([]model.PopularArticleResponse, error) {
+	if days < 1 || days > maxViewStatsDays {
+		return nil, model.ErrBadRequest(fmt.Sprintf("days must be between 1 and %d", maxViewStatsDays))
+	}
+	if limit < 1 || limit > maxPopularArticles {
+		return nil, model.ErrBadRequest(fmt.Sprintf("limit must be between 1 and %d", maxPopularArticles))
+	}
+
+	now := time.Now()
+
+	var counts []repository.ArticleViewCount
+	query := u.db.Scopes(visibleAt(now)).Where("entity_type = ?", entityType)
+	if err := u.repo.FindPopular(query, &counts, viewDay(now, days), limit); err != nil {
+		return nil, err
+	}
+	if len(counts) == 0 {
+		return []model.PopularArticleResponse{}, nil
+	}
+
+	ids := make([]string, len(counts))
+	for i, c := range counts {
+		ids[i] = c.ArticleID
+	}
+
+	var articles []entity.Article
+	if err := preloadArticleRelations(u.db).Where("id IN ?", ids).Find(&articles).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entity.Article, len(articles))
+	for i := range articles {
+		byID[articles[i].ID] = &articles[i]
+	}
+
+	responses := make([]model.PopularArticleResponse, 0, len(counts))
+	for _, c := range counts {
+		article, ok := byID[c.ArticleID]
+		if !ok {
+			continue
+		}
+		responses = append(responses, model.PopularArticleResponse{
+			ArticleResponse: converter.ToPublicArticleResponse(article),
+			Views:           c.Views,
+		})
+	}
+	return responses, nil
+}
+
+// GetStats reports the article's views overall and per day over the last days, today included.
+// Views still buffered in Redis show up after the next flush.
+func (u *articleViewUsecase) GetStats(ctx context.Context, entityType string, id string, days int) (*model.ArticleViewStatsResponse, error) {
+	if days < 1 || days > maxViewStatsDays {
+		return nil, model.ErrBadRequest(fmt.Sprintf("days must be between 1 and %d", maxViewStatsDays))
+	}
+
+	db := u.db.WithContext(ctx)
+
+	var article entity.Article
+	if err := u.articleRepo.FindByIdAndEntityType(db, &article, id, entityType); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("article not found")
+		}
+		return nil, err
+	}
+
+	total, err := u.repo.TotalViews(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	since := viewDay(time.Now(), days)
+	var views []entity.ArticleView
+	if err := u.repo.FindDaily(db, &views, id, since); err != nil {
+		return nil, err
+	}
+
+	perDay := make(map[string]int64, len(views))
+	for _, v := range views {
+		perDay[v.ViewDate.Format(time.DateOnly)] += v.Views
+	}
+
+	stats := &model.ArticleViewStatsResponse{
+		ArticleID:  id,
+		TotalViews: total,
+		Days:       days,
+		Daily:      make([]model.ArticleDailyViewsResponse, days),
+	}
+	for i := range days {
+		day := since.AddDate(0, 0, i).Format(time.DateOnly)
+		stats.Daily[i] = model.ArticleDailyViewsResponse{Date: day, Views: perDay[day]}
+		stats.PeriodViews += perDay[day]
+	}
+	return stats, nil
+}
+
+// viewDay returns the first (UTC) day of a period of days ending today, the day views are counted by.
+func viewDay(now time.Time, days int) time.Time {
+	return now.UTC().Truncate(24*time.Hour).AddDate(0, 0, 1-days)
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/model/converter"
+	"pura-agung-kertajaya-backend/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type AuditLogUseCase interface {
+	Search(ctx context.Context, req *model.SearchAuditLogRequest) ([]model.AuditLogResponse, int64, error)
+}
+
+type auditLogUseCase struct {
+	DB                 *gorm.DB
+	Validate           *validator.Validate
+	AuditLogRepository *repository.AuditLogRepository
+}
+
+func NewAuditLogUseCase(db *gorm.DB, validate *validator.Validate, auditLogRepository *repository.AuditLogRepository) AuditLogUseCase {
+	return &auditLogUseCase{
+		DB:                 db,
+		Validate:           validate,
+		AuditLogRepository: auditLogRepository,
+	}
+}
+
+func (c *auditLogUseCase) Search(ctx context.Context, req *model.SearchAuditLogRequest) ([]model.AuditLogResponse, int64, error) {
+	if err := c.Validate.Struct(req); err != nil {
+		return nil, 0, err
+	}
+
+	if req.From != nil && req.To != nil && req.From.After(*req.To) {
+		return nil, 0, model.ErrBadRequest("from must not be after to")
+	}
+
+	logs, total, err := c.AuditLogRepository.Search(c.DB.WithContext(ctx), req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return converter.ToAuditLogResponses(logs), total, nil
+}
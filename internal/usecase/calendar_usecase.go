@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"pura-agung-kertajaya-backend/internal/calendar"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/model/converter"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// calendarDefaultDays is the length of the range returned when no end date is asked for.
+	calendarDefaultDays = 31
+	calendarMaxDays     = 366
+)
+
+type CalendarUsecase interface {
+	GetRange(req *model.PublicCalendarRequest) ([]model.CalendarDayResponse, error)
+}
+
+type calendarUsecase struct {
+	validate *validator.Validate
+	odalan   map[string]calendar.PawukonDay
+	now      func() time.Time
+}
+
+// NewCalendarUsecase takes the Pawukon day of the odalan of every entity's temple, if it has one.
+func NewCalendarUsecase(validate *validator.Validate, odalan map[string]calendar.PawukonDay) CalendarUsecase {
+	return &calendarUsecase{
+		validate: validate,
+		odalan:   odalan,
+		now:      time.Now,
+	}
+}
+
+// GetRange computes every day from req.From to req.To. Without dates it starts today and covers a month.
+func (u *calendarUsecase) GetRange(req *model.PublicCalendarRequest) ([]model.CalendarDayResponse, error) {
+	if err := u.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	from, _ := time.Parse("2006-01-02", req.From)
+	if req.From == "" {
+		today := u.now().In(calendar.Location)
+		from = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	to, _ := time.Parse("2006-01-02", req.To)
+	if req.To == "" {
+		to = from.AddDate(0, 0, calendarDefaultDays-1)
+	}
+
+	switch span := int(to.Sub(from).Hours()/24) + 1; {
+	case span < 1:
+		return nil, model.ErrBadRequest("to must not be before from")
+	case span > calendarMaxDays:
+		return nil, model.ErrBadRequest("the calendar covers at most 366 days at a time")
+	}
+
+	days := calendar.Range(from, to)
+	odalan, hasOdalan := u.odalan[req.EntityType]
+	responses := make([]model.CalendarDayResponse, len(days))
+	for i := range days {
+		if hasOdalan && days[i].Pawukon == odalan {
+			days[i].HolyDays = append(days[i].HolyDays, "Piodalan")
+		}
+		responses[i] = converter.ToCalendarDayResponse(&days[i])
+	}
+	return responses, nil
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"pura-agung-kertajaya-backend/internal/entity"
@@ -14,11 +15,11 @@ import (
 )
 
 type CategoryUsecase interface {
-	GetAll() ([]model.CategoryResponse, error)
-	GetByID(id string) (*model.CategoryResponse, error)
-	Create(req model.CreateCategoryRequest) (*model.CategoryResponse, error)
-	Update(id string, req model.UpdateCategoryRequest) (*model.CategoryResponse, error)
-	Delete(id string) error
+	GetAll(entityType string, req *model.ListRequest) ([]model.CategoryResponse, *model.PageMetadata, error)
+	GetByID(entityType string, id string) (*model.CategoryResponse, error)
+	Create(ctx context.Context, entityType string, req model.CreateCategoryRequest) (*model.CategoryResponse, error)
+	Update(ctx context.Context, entityType string, id string, req model.UpdateCategoryRequest) (*model.CategoryResponse, error)
+	Delete(ctx context.Context, entityType string, id string) error
 }
 
 type categoryUsecase struct {
@@ -35,21 +36,31 @@ func NewCategoryUsecase(db *gorm.DB, validate *validator.Validate) CategoryUseca
 	}
 }
 
-func (u *categoryUsecase) GetAll() ([]model.CategoryResponse, error) {
+var categoryListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"name": "name",
+		"slug": "slug",
+	},
+	Search:      []string{"name"},
+	DefaultSort: "name",
+}
+
+func (u *categoryUsecase) GetAll(entityType string, req *model.ListRequest) ([]model.CategoryResponse, *model.PageMetadata, error) {
 	var items []entity.Category
 
-	query := u.db.Order("name ASC")
+	query := u.db.Where("entity_type = ?", entityType)
 
-	if err := u.repo.FindAll(query, &items); err != nil {
-		return nil, err
+	paging, err := u.repo.FindPage(query, &items, categoryListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return converter.ToCategoryResponses(items), nil
+	return converter.ToCategoryResponses(items), paging, nil
 }
 
-func (u *categoryUsecase) GetByID(id string) (*model.CategoryResponse, error) {
+func (u *categoryUsecase) GetByID(entityType string, id string) (*model.CategoryResponse, error) {
 	var c entity.Category
-	if err := u.repo.FindById(u.db, &c, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(u.db, &c, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("category not found")
 		}
@@ -59,17 +70,22 @@ func (u *categoryUsecase) GetByID(id string) (*model.CategoryResponse, error) {
 	return &r, nil
 }
 
-func (u *categoryUsecase) Create(req model.CreateCategoryRequest) (*model.CategoryResponse, error) {
+func (u *categoryUsecase) Create(ctx context.Context, entityType string, req model.CreateCategoryRequest) (*model.CategoryResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 
 	baseSlug := slug.Make(req.Name)
 	finalSlug := baseSlug
 	counter := 1
 
 	for {
-		count, err := u.repo.CountBySlug(u.db, finalSlug)
+		count, err := u.repo.CountBySlug(db.Where("entity_type = ?", entityType), finalSlug)
 		if err != nil {
 			return nil, err
 		}
@@ -82,11 +98,12 @@ func (u *categoryUsecase) Create(req model.CreateCategoryRequest) (*model.Catego
 	}
 
 	c := entity.Category{
-		Name: req.Name,
-		Slug: finalSlug,
+		EntityType: entityType,
+		Name:       req.Name,
+		Slug:       finalSlug,
 	}
 
-	if err := u.repo.Create(u.db, &c); err != nil {
+	if err := u.repo.Create(db, &c); err != nil {
 		return nil, err
 	}
 
@@ -94,13 +111,18 @@ func (u *categoryUsecase) Create(req model.CreateCategoryRequest) (*model.Catego
 	return &r, nil
 }
 
-func (u *categoryUsecase) Update(id string, req model.UpdateCategoryRequest) (*model.CategoryResponse, error) {
+func (u *categoryUsecase) Update(ctx context.Context, entityType string, id string, req model.UpdateCategoryRequest) (*model.CategoryResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 
 	var c entity.Category
-	if err := u.repo.FindById(u.db, &c, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &c, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("category not found")
 		}
@@ -113,7 +135,7 @@ func (u *categoryUsecase) Update(id string, req model.UpdateCategoryRequest) (*m
 		counter := 1
 
 		for {
-			count, err := u.repo.CountBySlugIgnoringID(u.db, finalSlug, id)
+			count, err := u.repo.CountBySlugIgnoringID(db.Where("entity_type = ?", entityType), finalSlug, id)
 
 			if err != nil {
 				return nil, err
@@ -130,7 +152,7 @@ func (u *categoryUsecase) Update(id string, req model.UpdateCategoryRequest) (*m
 
 	c.Name = req.Name
 
-	if err := u.repo.Update(u.db, &c); err != nil {
+	if err := u.repo.Update(db, &c); err != nil {
 		return nil, err
 	}
 
@@ -138,16 +160,18 @@ func (u *categoryUsecase) Update(id string, req model.UpdateCategoryRequest) (*m
 	return &r, nil
 }
 
-func (u *categoryUsecase) Delete(id string) error {
-	exists, err := u.repo.CountById(u.db, id)
-	if err != nil {
+func (u *categoryUsecase) Delete(ctx context.Context, entityType string, id string) error {
+	db := u.db.WithContext(ctx)
+
+	var c entity.Category
+	if err := u.repo.FindByIdAndEntityType(db, &c, id, entityType); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrNotFound("category not found")
+		}
 		return err
 	}
-	if exists == 0 {
-		return model.ErrNotFound("category not found")
-	}
 
-	totalUsed, err := u.repo.CountReference(u.db, &entity.Article{}, "category_id", id)
+	totalUsed, err := u.repo.CountReference(db, &entity.Article{}, "category_id", id)
 	if err != nil {
 		return err
 	}
@@ -156,5 +180,5 @@ func (u *categoryUsecase) Delete(id string) error {
 		return model.ErrConflict("category is currently in use")
 	}
 
-	return u.repo.Delete(u.db, &entity.Category{ID: id})
+	return u.repo.Delete(db, &c)
 }
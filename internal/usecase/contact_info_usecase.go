@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"pura-agung-kertajaya-backend/internal/entity"
 	"pura-agung-kertajaya-backend/internal/model"
@@ -13,11 +14,11 @@ import (
 )
 
 type ContactInfoUsecase interface {
-	GetAll(entityType string) ([]model.ContactInfoResponse, error)
-	GetByID(id string) (*model.ContactInfoResponse, error)
-	Create(req model.CreateContactInfoRequest) (*model.ContactInfoResponse, error)
-	Update(id string, req model.UpdateContactInfoRequest) (*model.ContactInfoResponse, error)
-	Delete(id string) error
+	GetAll(entityType string, req *model.ListRequest) ([]model.ContactInfoResponse, *model.PageMetadata, error)
+	GetByID(entityType string, id string) (*model.ContactInfoResponse, error)
+	Create(ctx context.Context, entityType string, req model.CreateContactInfoRequest) (*model.ContactInfoResponse, error)
+	Update(ctx context.Context, entityType string, id string, req model.UpdateContactInfoRequest) (*model.ContactInfoResponse, error)
+	Delete(ctx context.Context, entityType string, id string) error
 }
 
 type contactInfoUsecase struct {
@@ -34,20 +35,33 @@ func NewContactInfoUsecase(db *gorm.DB, validate *validator.Validate) ContactInf
 	}
 }
 
-func (u *contactInfoUsecase) GetAll(entityType string) ([]model.ContactInfoResponse, error) {
+var contactInfoListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	DefaultSort: "created_at",
+}
+
+func (u *contactInfoUsecase) GetAll(entityType string, req *model.ListRequest) ([]model.ContactInfoResponse, *model.PageMetadata, error) {
 	var items []entity.ContactInfo
+
 	if entityType == "" {
 		entityType = "pura"
 	}
-	if err := u.db.Where("entity_type = ?", entityType).Order("created_at ASC").Find(&items).Error; err != nil {
-		return nil, err
+	query := u.db.Where("entity_type = ?", entityType)
+
+	paging, err := u.repo.FindPage(query, &items, contactInfoListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
-	return converter.ToContactInfoResponses(items), nil
+
+	return converter.ToContactInfoResponses(items), paging, nil
 }
 
-func (u *contactInfoUsecase) GetByID(id string) (*model.ContactInfoResponse, error) {
+func (u *contactInfoUsecase) GetByID(entityType string, id string) (*model.ContactInfoResponse, error) {
 	var e entity.ContactInfo
-	if err := u.repo.FindById(u.db, &e, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(u.db, &e, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("contact info not found")
 		}
@@ -57,14 +71,19 @@ func (u *contactInfoUsecase) GetByID(id string) (*model.ContactInfoResponse, err
 	return &r, nil
 }
 
-func (u *contactInfoUsecase) Create(req model.CreateContactInfoRequest) (*model.ContactInfoResponse, error) {
+func (u *contactInfoUsecase) Create(ctx context.Context, entityType string, req model.CreateContactInfoRequest) (*model.ContactInfoResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 
 	e := entity.ContactInfo{
 		ID:            uuid.New().String(),
-		EntityType:    req.EntityType,
+		EntityType:    entityType,
 		Address:       req.Address,
 		Phone:         req.Phone,
 		Email:         req.Email,
@@ -72,7 +91,7 @@ func (u *contactInfoUsecase) Create(req model.CreateContactInfoRequest) (*model.
 		MapEmbedURL:   req.MapEmbedURL,
 	}
 
-	if err := u.repo.Create(u.db, &e); err != nil {
+	if err := u.repo.Create(db, &e); err != nil {
 		return nil, err
 	}
 
@@ -80,13 +99,18 @@ func (u *contactInfoUsecase) Create(req model.CreateContactInfoRequest) (*model.
 	return &r, nil
 }
 
-func (u *contactInfoUsecase) Update(id string, req model.UpdateContactInfoRequest) (*model.ContactInfoResponse, error) {
+func (u *contactInfoUsecase) Update(ctx context.Context, entityType string, id string, req model.UpdateContactInfoRequest) (*model.ContactInfoResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 
 	var e entity.ContactInfo
-	if err := u.repo.FindById(u.db, &e, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &e, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("contact info not found")
 		}
@@ -99,7 +123,7 @@ func (u *contactInfoUsecase) Update(id string, req model.UpdateContactInfoReques
 	e.VisitingHours = req.VisitingHours
 	e.MapEmbedURL = req.MapEmbedURL
 
-	if err := u.repo.Update(u.db, &e); err != nil {
+	if err := u.repo.Update(db, &e); err != nil {
 		return nil, err
 	}
 
@@ -107,13 +131,15 @@ func (u *contactInfoUsecase) Update(id string, req model.UpdateContactInfoReques
 	return &r, nil
 }
 
-func (u *contactInfoUsecase) Delete(id string) error {
+func (u *contactInfoUsecase) Delete(ctx context.Context, entityType string, id string) error {
+	db := u.db.WithContext(ctx)
+
 	var e entity.ContactInfo
-	if err := u.repo.FindById(u.db, &e, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &e, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return model.ErrNotFound("contact info not found")
 		}
 		return err
 	}
-	return u.repo.Delete(u.db, &e)
+	return u.repo.Delete(db, &e)
 }
@@ -0,0 +1,12 @@
+package usecase
+
+import "pura-agung-kertajaya-backend/internal/model"
+
+// checkEntityScope rejects payloads that try to write a record into an entity other than the caller's scope.
+// Create requests require entity_type; on update it may be left out, but never names another entity.
+func checkEntityScope(scope string, requested string) error {
+	if requested != "" && requested != scope {
+		return model.ErrForbidden("You do not have access to this entity")
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"pura-agung-kertajaya-backend/internal/entity"
 	"pura-agung-kertajaya-backend/internal/model"
@@ -14,12 +15,12 @@ import (
 )
 
 type FacilityUsecase interface {
-	GetAll(entityType string) ([]model.FacilityResponse, error)
-	GetPublic(entityType string) ([]model.FacilityResponse, error)
-	GetByID(id string) (*model.FacilityResponse, error)
-	Create(entityType string, req model.CreateFacilityRequest) (*model.FacilityResponse, error)
-	Update(id string, req model.UpdateFacilityRequest) (*model.FacilityResponse, error)
-	Delete(id string) error
+	GetAll(entityType string, req *model.ListRequest) ([]model.FacilityResponse, *model.PageMetadata, error)
+	GetPublic(entityType string, req *model.ListRequest) ([]model.FacilityResponse, *model.PageMetadata, error)
+	GetByID(entityType string, id string) (*model.FacilityResponse, error)
+	Create(ctx context.Context, entityType string, req model.CreateFacilityRequest) (*model.FacilityResponse, error)
+	Update(ctx context.Context, entityType string, id string, req model.UpdateFacilityRequest) (*model.FacilityResponse, error)
+	Delete(ctx context.Context, entityType string, id string) error
 }
 
 type facilityUsecase struct {
@@ -36,31 +37,48 @@ func NewFacilityUsecase(db *gorm.DB, validate *validator.Validate) FacilityUseca
 	}
 }
 
-func (u *facilityUsecase) GetAll(entityType string) ([]model.FacilityResponse, error) {
+var facilityListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"name":        "name",
+		"order_index": "order_index",
+		"created_at":  "created_at",
+	},
+	Filters: map[string]string{
+		"is_active": "is_active",
+	},
+	Search:      []string{"name"},
+	DefaultSort: "order_index",
+}
+
+func (u *facilityUsecase) GetAll(entityType string, req *model.ListRequest) ([]model.FacilityResponse, *model.PageMetadata, error) {
 	var items []entity.Facility
 
-	query := u.db.Where("entity_type = ?", entityType).Order("order_index ASC")
-	if err := u.repo.FindAll(query, &items); err != nil {
-		return nil, err
+	query := u.db.Where("entity_type = ?", entityType)
+
+	paging, err := u.repo.FindPage(query, &items, facilityListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return converter.ToFacilityResponses(items), nil
+	return converter.ToFacilityResponses(items), paging, nil
 }
 
-func (u *facilityUsecase) GetPublic(entityType string) ([]model.FacilityResponse, error) {
+func (u *facilityUsecase) GetPublic(entityType string, req *model.ListRequest) ([]model.FacilityResponse, *model.PageMetadata, error) {
 	var items []entity.Facility
 
-	query := u.db.Where("entity_type = ?", entityType).Where("is_active = ?", true).Order("order_index ASC")
-	if err := u.repo.FindAll(query, &items); err != nil {
-		return nil, err
+	query := u.db.Where("entity_type = ?", entityType).Where("is_active = ?", true)
+
+	paging, err := u.repo.FindPage(query, &items, facilityListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return converter.ToFacilityResponses(items), nil
+	return converter.ToFacilityResponses(items), paging, nil
 }
 
-func (u *facilityUsecase) GetByID(id string) (*model.FacilityResponse, error) {
+func (u *facilityUsecase) GetByID(entityType string, id string) (*model.FacilityResponse, error) {
 	var f entity.Facility
-	if err := u.repo.FindById(u.db, &f, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(u.db, &f, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("facility not found")
 		}
@@ -70,10 +88,15 @@ func (u *facilityUsecase) GetByID(id string) (*model.FacilityResponse, error) {
 	return &r, nil
 }
 
-func (u *facilityUsecase) Create(entityType string, req model.CreateFacilityRequest) (*model.FacilityResponse, error) {
+func (u *facilityUsecase) Create(ctx context.Context, entityType string, req model.CreateFacilityRequest) (*model.FacilityResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 	f := entity.Facility{
 		ID:          uuid.New().String(),
 		EntityType:  entityType,
@@ -83,19 +106,24 @@ func (u *facilityUsecase) Create(entityType string, req model.CreateFacilityRequ
 		OrderIndex:  req.OrderIndex,
 		IsActive:    req.IsActive,
 	}
-	if err := u.repo.Create(u.db, &f); err != nil {
+	if err := u.repo.Create(db, &f); err != nil {
 		return nil, err
 	}
 	r := converter.ToFacilityResponse(f)
 	return &r, nil
 }
 
-func (u *facilityUsecase) Update(id string, req model.UpdateFacilityRequest) (*model.FacilityResponse, error) {
+func (u *facilityUsecase) Update(ctx context.Context, entityType string, id string, req model.UpdateFacilityRequest) (*model.FacilityResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 	var f entity.Facility
-	if err := u.repo.FindById(u.db, &f, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &f, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("facility not found")
 		}
@@ -108,20 +136,22 @@ func (u *facilityUsecase) Update(id string, req model.UpdateFacilityRequest) (*m
 	f.OrderIndex = req.OrderIndex
 	f.IsActive = req.IsActive
 
-	if err := u.repo.Update(u.db, &f); err != nil {
+	if err := u.repo.Update(db, &f); err != nil {
 		return nil, err
 	}
 	r := converter.ToFacilityResponse(f)
 	return &r, nil
 }
 
-func (u *facilityUsecase) Delete(id string) error {
+func (u *facilityUsecase) Delete(ctx context.Context, entityType string, id string) error {
+	db := u.db.WithContext(ctx)
+
 	var f entity.Facility
-	if err := u.repo.FindById(u.db, &f, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &f, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return model.ErrNotFound("facility not found")
 		}
 		return err
 	}
-	return u.repo.Delete(u.db, &f)
+	return u.repo.Delete(db, &f)
 }
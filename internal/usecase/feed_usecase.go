@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"errors"
+	"net/http"
+	"pura-agung-kertajaya-backend/internal/calendar"
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/repository"
+	"pura-agung-kertajaya-backend/internal/util"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	feedSize     = 20
+	feedLanguage = "id"
+
+	// The activity calendar covers a year of occurrences, starting a month back.
+	activityCalendarPastDays = 30
+	// activityDefaultDuration is the length of an activity whose time info gives no end time.
+	activityDefaultDuration = time.Hour
+	activityUIDDomain       = "pura-agung-kertajaya"
+)
+
+// activityTimePattern finds times such as "08:00" or "19.30" in the free text time info of activities.
+var activityTimePattern = regexp.MustCompile(`(?:^|[^0-9])([01]?[0-9]|2[0-3])[.:]([0-5][0-9])(?:[^0-9]|$)`)
+
+type FeedUsecase interface {
+	GetArticleFeed(entityType string, categorySlug string) (*model.Feed, error)
+	GetActivityCalendar(entityType string) (*model.ICalendar, error)
+	GetActivityEvent(entityType string, id string, date string) (*model.ICalendar, error)
+}
+
+type feedUsecase struct {
+	db                  *gorm.DB
+	categoryRepo        *repository.Repository[entity.Category]
+	articleUsecase      ArticleUsecase
+	activityUsecase     ActivityUsecase
+	siteIdentityUsecase SiteIdentityUsecase
+	siteURL             *util.SiteURLUtil
+}
+
+func NewFeedUsecase(db *gorm.DB, articleUsecase ArticleUsecase, activityUsecase ActivityUsecase, siteIdentityUsecase SiteIdentityUsecase, siteURL *util.SiteURLUtil) FeedUsecase {
+	return &feedUsecase{
+		db:                  db,
+		categoryRepo:        &repository.Repository[entity.Category]{DB: db},
+		articleUsecase:      articleUsecase,
+		activityUsecase:     activityUsecase,
+		siteIdentityUsecase: siteIdentityUsecase,
+		siteURL:             siteURL,
+	}
+}
+
+// GetArticleFeed lists the latest published articles of the entity, optionally of one category,
+// newest first.
+func (u *feedUsecase) GetArticleFeed(entityType string, categorySlug string) (*model.Feed, error) {
+	if err := u.checkSite(entityType); err != nil {
+		return nil, err
+	}
+
+	feed := &model.Feed{
+		Title:    entityType,
+		Link:     u.siteURL.BaseURL(entityType),
+		Language: feedLanguage,
+	}
+
+	identity, err := u.siteIdentity(entityType)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		feed.Title = identity.SiteName
+		feed.Description = identity.Tagline
+	}
+
+	req := &model.ListRequest{Size: feedSize, Sort: "-published_at", Filters: map[string]string{}}
+	if categorySlug != "" {
+		var category entity.Category
+		if err := u.categoryRepo.FindBySlug(u.db.Where("entity_type = ?", entityType), &category, categorySlug); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, model.ErrNotFound("category not found")
+			}
+			return nil, err
+		}
+		req.Filters["category_id"] = category.ID
+		feed.Title += " - " + category.Name
+	}
+
+	articles, _, err := u.articleUsecase.GetPublic(entityType, req)
+	if err != nil {
+		return nil, err
+	}
+
+	feed.Items = make([]model.FeedItem, 0, len(articles))
+	for _, a := range articles {
+		item := model.FeedItem{
+			ID:          "urn:uuid:" + a.ID,
+			Title:       a.Title,
+			Link:        u.siteURL.ArticleURL(entityType, a.Slug),
+			Summary:     a.Excerpt,
+			ContentHTML: a.ContentHTML,
+			Author:      a.AuthorName,
+			ImageURL:    u.siteURL.AssetURL(feedImage(a.Images)),
+			Published:   a.CreatedAt,
+			Updated:     a.UpdatedAt,
+		}
+		if a.PublishedAt != nil {
+			item.Published = *a.PublishedAt
+		}
+		if a.Category != nil {
+			item.Categories = append(item.Categories, a.Category.Name)
+		}
+		for _, tag := range a.Tags {
+			item.Categories = append(item.Categories, tag.Name)
+		}
+
+		if item.Updated.After(feed.Updated) {
+			feed.Updated = item.Updated
+		}
+		if item.Published.After(feed.Updated) {
+			feed.Updated = item.Published
+		}
+		feed.Items = append(feed.Items, item)
+	}
+
+	return feed, nil
+}
+
+// GetActivityCalendar lists the occurrences of the entity's active activities from a month ago to a
+// year ahead.
+func (u *feedUsecase) GetActivityCalendar(entityType string) (*model.ICalendar, error) {
+	if err := u.checkSite(entityType); err != nil {
+		return nil, err
+	}
+
+	cal, err := u.newActivityCalendar(entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	activities, _, err := u.activityUsecase.GetPublic(entityType, &model.ListRequest{}, activityCalendarWindow(calendar.Today()))
+	if err != nil {
+		return nil, err
+	}
+	u.addActivityEvents(cal, activities)
+	return cal, nil
+}
+
+// GetActivityEvent holds a single active activity. A recurring activity comes with its occurrences
+// over the calendar's year, or with only the one on date when it is given.
+func (u *feedUsecase) GetActivityEvent(entityType string, id string, date string) (*model.ICalendar, error) {
+	if err := u.checkSite(entityType); err != nil {
+		return nil, err
+	}
+
+	activity, err := u.activityUsecase.GetByID(entityType, id)
+	if err != nil {
+		return nil, err
+	}
+	if !activity.IsActive {
+		return nil, model.ErrNotFound("activity not found")
+	}
+
+	cal, err := u.newActivityCalendar(entityType)
+	if err != nil {
+		return nil, err
+	}
+	if activity.RecurrenceRule == "" && activity.PawukonRule == "" {
+		u.addActivityEvents(cal, []model.ActivityResponse{*activity})
+		return cal, nil
+	}
+
+	anchor := calendar.Today()
+	if date != "" {
+		if anchor, err = time.Parse("2006-01-02", date); err != nil {
+			return nil, model.ErrBadRequest("invalid date format, expected YYYY-MM-DD")
+		}
+	}
+	req := &model.ListRequest{Filters: map[string]string{"id": activity.ID}}
+	occurrences, _, err := u.activityUsecase.GetPublic(entityType, req, activityCalendarWindow(anchor))
+	if err != nil {
+		return nil, err
+	}
+	if date != "" {
+		var matching []model.ActivityResponse
+		for _, o := range occurrences {
+			if o.OccurrenceDate == date {
+				matching = append(matching, o)
+			}
+		}
+		if len(matching) == 0 {
+			return nil, model.ErrNotFound("activity does not occur on " + date)
+		}
+		occurrences = matching
+	}
+	u.addActivityEvents(cal, occurrences)
+	return cal, nil
+}
+
+// checkSite keeps feeds to the entities that have a public site, like their sitemaps.
+func (u *feedUsecase) checkSite(entityType string) error {
+	if !model.IsEntityType(entityType) {
+		return model.ErrBadRequest("invalid entity_type")
+	}
+	if !u.siteURL.HasSite(entityType) {
+		return model.ErrNotFound("feed not found")
+	}
+	return nil
+}
+
+func (u *feedUsecase) newActivityCalendar(entityType string) (*model.ICalendar, error) {
+	cal := &model.ICalendar{
+		Name: entityType,
+		URL:  u.siteURL.PageURL(entityType, u.siteURL.ActivityPath),
+	}
+
+	identity, err := u.siteIdentity(entityType)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		cal.Name = identity.SiteName
+		cal.Description = identity.Tagline
+	}
+	return cal, nil
+}
+
+// addActivityEvents turns activities, or occurrences of recurring ones, into events. An activity
+// whose time info holds a time starts then, otherwise it lasts the whole day.
+func (u *feedUsecase) addActivityEvents(cal *model.ICalendar, activities []model.ActivityResponse) {
+	for _, a := range activities {
+		event := model.ICalendarEvent{
+			UID:         a.ID,
+			Summary:     a.Title,
+			Description: a.Description,
+			Location:    a.Location,
+			URL:         cal.URL,
+			Updated:     a.UpdatedAt,
+		}
+		if a.OccurrenceDate != "" {
+			event.UID += "-" + strings.ReplaceAll(a.OccurrenceDate, "-", "")
+		}
+		event.UID += "@" + activityUIDDomain
+		if a.TimeInfo != "" {
+			event.Description = strings.TrimSpace(event.Description + "\n\nWaktu: " + a.TimeInfo)
+		}
+
+		day := time.Date(a.EventDate.Year(), a.EventDate.Month(), a.EventDate.Day(), 0, 0, 0, 0, time.UTC)
+		if start, end, ok := activityTimes(a.TimeInfo); ok {
+			event.Start = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, calendar.Location).Add(start)
+			event.End = event.Start.Add(end - start)
+		} else {
+			event.Start, event.End, event.AllDay = day, day.AddDate(0, 0, 1), true
+		}
+
+		if event.Updated.After(cal.Updated) {
+			cal.Updated = event.Updated
+		}
+		cal.Events = append(cal.Events, event)
+	}
+}
+
+// activityTimes reads the start time of an activity, and its end when a second, later time follows,
+// as offsets from midnight.
+func activityTimes(timeInfo string) (time.Duration, time.Duration, bool) {
+	var times []time.Duration
+	for _, m := range activityTimePattern.FindAllStringSubmatch(timeInfo, 2) {
+		hour, _ := strconv.Atoi(m[1])
+		minute, _ := strconv.Atoi(m[2])
+		times = append(times, time.Duration(hour)*time.Hour+time.Duration(minute)*time.Minute)
+	}
+	switch {
+	case len(times) == 0:
+		return 0, 0, false
+	case len(times) == 2 && times[1] > times[0]:
+		return times[0], times[1], true
+	}
+	return times[0], times[0] + activityDefaultDuration, true
+}
+
+// activityCalendarWindow is the year of occurrences the activity calendar covers around anchor.
+func activityCalendarWindow(anchor time.Time) *model.DateRange {
+	from := anchor.AddDate(0, 0, -activityCalendarPastDays)
+	return &model.DateRange{From: from, To: from.AddDate(0, 0, activityMaxWindowDays-1)}
+}
+
+// siteIdentity returns the identity of the entity's site, or nil when it has none yet.
+func (u *feedUsecase) siteIdentity(entityType string) (*model.SiteIdentityResponse, error) {
+	identity, err := u.siteIdentityUsecase.GetPublic(entityType)
+	if err != nil {
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return identity, nil
+}
+
+// feedImage picks the image variant best suited to feed readers.
+func feedImage(images model.ImageVariants) string {
+	for _, variant := range []string{images.Lg, images.Xl, images.Md, images.Fhd, images.TwoXl, images.Sm, images.Xs} {
+		if variant != "" {
+			return variant
+		}
+	}
+	return ""
+}
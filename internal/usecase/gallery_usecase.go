@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"pura-agung-kertajaya-backend/internal/entity"
 	"pura-agung-kertajaya-backend/internal/model"
@@ -14,12 +15,12 @@ import (
 )
 
 type GalleryUsecase interface {
-	GetAll(entityType string) ([]model.GalleryResponse, error)
-	GetPublic(entityType string) ([]model.GalleryResponse, error)
-	GetByID(id string) (*model.GalleryResponse, error)
-	Create(entityType string, req model.CreateGalleryRequest) (*model.GalleryResponse, error)
-	Update(id string, req model.UpdateGalleryRequest) (*model.GalleryResponse, error)
-	Delete(id string) error
+	GetAll(entityType string, req *model.ListRequest) ([]model.GalleryResponse, *model.PageMetadata, error)
+	GetPublic(entityType string, req *model.ListRequest) ([]model.GalleryResponse, *model.PageMetadata, error)
+	GetByID(entityType string, id string) (*model.GalleryResponse, error)
+	Create(ctx context.Context, entityType string, req model.CreateGalleryRequest) (*model.GalleryResponse, error)
+	Update(ctx context.Context, entityType string, id string, req model.UpdateGalleryRequest) (*model.GalleryResponse, error)
+	Delete(ctx context.Context, entityType string, id string) error
 }
 
 type galleryUsecase struct {
@@ -36,33 +37,48 @@ func NewGalleryUsecase(db *gorm.DB, validate *validator.Validate) GalleryUsecase
 	}
 }
 
-func (u *galleryUsecase) GetAll(entityType string) ([]model.GalleryResponse, error) {
+var galleryListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"title":       "title",
+		"order_index": "order_index",
+		"created_at":  "created_at",
+	},
+	Filters: map[string]string{
+		"is_active": "is_active",
+	},
+	Search:      []string{"title"},
+	DefaultSort: "order_index",
+}
+
+func (u *galleryUsecase) GetAll(entityType string, req *model.ListRequest) ([]model.GalleryResponse, *model.PageMetadata, error) {
 	var items []entity.Gallery
 
-	query := u.db.Where("entity_type = ?", entityType).Order("order_index ASC")
+	query := u.db.Where("entity_type = ?", entityType)
 
-	if err := u.repo.FindAll(query, &items); err != nil {
-		return nil, err
+	paging, err := u.repo.FindPage(query, &items, galleryListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return converter.ToGalleryResponses(items), nil
+	return converter.ToGalleryResponses(items), paging, nil
 }
 
-func (u *galleryUsecase) GetPublic(entityType string) ([]model.GalleryResponse, error) {
+func (u *galleryUsecase) GetPublic(entityType string, req *model.ListRequest) ([]model.GalleryResponse, *model.PageMetadata, error) {
 	var items []entity.Gallery
 
-	query := u.db.Where("entity_type = ?", entityType).Where("is_active = ?", true).Order("order_index ASC")
+	query := u.db.Where("entity_type = ?", entityType).Where("is_active = ?", true)
 
-	if err := u.repo.FindAll(query, &items); err != nil {
-		return nil, err
+	paging, err := u.repo.FindPage(query, &items, galleryListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return converter.ToGalleryResponses(items), nil
+	return converter.ToGalleryResponses(items), paging, nil
 }
 
-func (u *galleryUsecase) GetByID(id string) (*model.GalleryResponse, error) {
+func (u *galleryUsecase) GetByID(entityType string, id string) (*model.GalleryResponse, error) {
 	var g entity.Gallery
-	if err := u.repo.FindById(u.db, &g, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(u.db, &g, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("gallery not found")
 		}
@@ -72,10 +88,15 @@ func (u *galleryUsecase) GetByID(id string) (*model.GalleryResponse, error) {
 	return &r, nil
 }
 
-func (u *galleryUsecase) Create(entityType string, req model.CreateGalleryRequest) (*model.GalleryResponse, error) {
+func (u *galleryUsecase) Create(ctx context.Context, entityType string, req model.CreateGalleryRequest) (*model.GalleryResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 	g := entity.Gallery{
 		ID:          uuid.New().String(),
 		EntityType:  entityType,
@@ -85,19 +106,24 @@ func (u *galleryUsecase) Create(entityType string, req model.CreateGalleryReques
 		OrderIndex:  req.OrderIndex,
 		IsActive:    req.IsActive,
 	}
-	if err := u.repo.Create(u.db, &g); err != nil {
+	if err := u.repo.Create(db, &g); err != nil {
 		return nil, err
 	}
 	r := converter.ToGalleryResponse(&g)
 	return &r, nil
 }
 
-func (u *galleryUsecase) Update(id string, req model.UpdateGalleryRequest) (*model.GalleryResponse, error) {
+func (u *galleryUsecase) Update(ctx context.Context, entityType string, id string, req model.UpdateGalleryRequest) (*model.GalleryResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 	var g entity.Gallery
-	if err := u.repo.FindById(u.db, &g, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &g, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("gallery not found")
 		}
@@ -110,20 +136,22 @@ func (u *galleryUsecase) Update(id string, req model.UpdateGalleryRequest) (*mod
 	g.OrderIndex = req.OrderIndex
 	g.IsActive = req.IsActive
 
-	if err := u.repo.Update(u.db, &g); err != nil {
+	if err := u.repo.Update(db, &g); err != nil {
 		return nil, err
 	}
 	r := converter.ToGalleryResponse(&g)
 	return &r, nil
 }
 
-func (u *galleryUsecase) Delete(id string) error {
+func (u *galleryUsecase) Delete(ctx context.Context, entityType string, id string) error {
+	db := u.db.WithContext(ctx)
+
 	var g entity.Gallery
-	if err := u.repo.FindById(u.db, &g, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &g, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return model.ErrNotFound("gallery not found")
 		}
 		return err
 	}
-	return u.repo.Delete(u.db, &g)
+	return u.repo.Delete(db, &g)
 }
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"pura-agung-kertajaya-backend/internal/entity"
 	"pura-agung-kertajaya-backend/internal/model"
@@ -14,12 +15,12 @@ import (
 )
 
 type HeroSlideUsecase interface {
-	GetAll(entityType string) ([]model.HeroSlideResponse, error)
-	GetPublic(entityType string) ([]model.HeroSlideResponse, error)
-	GetByID(id string) (*model.HeroSlideResponse, error)
-	Create(entityType string, req model.HeroSlideRequest) (*model.HeroSlideResponse, error)
-	Update(id string, req model.HeroSlideRequest) (*model.HeroSlideResponse, error)
-	Delete(id string) error
+	GetAll(entityType string, req *model.ListRequest) ([]model.HeroSlideResponse, *model.PageMetadata, error)
+	GetPublic(entityType string, req *model.ListRequest) ([]model.HeroSlideResponse, *model.PageMetadata, error)
+	GetByID(entityType string, id string) (*model.HeroSlideResponse, error)
+	Create(ctx context.Context, entityType string, req model.HeroSlideRequest) (*model.HeroSlideResponse, error)
+	Update(ctx context.Context, entityType string, id string, req model.HeroSlideRequest) (*model.HeroSlideResponse, error)
+	Delete(ctx context.Context, entityType string, id string) error
 }
 
 type heroSlideUsecase struct {
@@ -36,43 +37,60 @@ func NewHeroSlideUsecase(db *gorm.DB, validate *validator.Validate) HeroSlideUse
 	}
 }
 
-func (u *heroSlideUsecase) GetAll(entityType string) ([]model.HeroSlideResponse, error) {
+var heroSlideListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"order_index": "order_index",
+		"created_at":  "created_at",
+	},
+	Filters: map[string]string{
+		"is_active": "is_active",
+	},
+	DefaultSort: "order_index",
+}
+
+func (u *heroSlideUsecase) GetAll(entityType string, req *model.ListRequest) ([]model.HeroSlideResponse, *model.PageMetadata, error) {
 	var slides []entity.HeroSlide
-	query := u.db.Order("order_index ASC")
+
+	query := u.db
 	if entityType != "" {
 		query = query.Where("entity_type = ?", entityType)
 	}
 
-	if err := u.repo.FindAll(query, &slides); err != nil {
-		return nil, err
+	paging, err := u.repo.FindPage(query, &slides, heroSlideListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
+
 	responses := make([]model.HeroSlideResponse, 0, len(slides))
 	for _, s := range slides {
 		responses = append(responses, converter.ToHeroSlideResponse(s))
 	}
-	return responses, nil
+	return responses, paging, nil
 }
 
-func (u *heroSlideUsecase) GetPublic(entityType string) ([]model.HeroSlideResponse, error) {
+func (u *heroSlideUsecase) GetPublic(entityType string, req *model.ListRequest) ([]model.HeroSlideResponse, *model.PageMetadata, error) {
 	var slides []entity.HeroSlide
-	query := u.db.Where("is_active = ?", true).Order("order_index ASC")
+
+	query := u.db.Where("is_active = ?", true)
 	if entityType != "" {
 		query = query.Where("entity_type = ?", entityType)
 	}
 
-	if err := u.repo.FindAll(query, &slides); err != nil {
-		return nil, err
+	paging, err := u.repo.FindPage(query, &slides, heroSlideListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
+
 	responses := make([]model.HeroSlideResponse, 0, len(slides))
 	for _, s := range slides {
 		responses = append(responses, converter.ToHeroSlideResponse(s))
 	}
-	return responses, nil
+	return responses, paging, nil
 }
 
-func (u *heroSlideUsecase) GetByID(id string) (*model.HeroSlideResponse, error) {
+func (u *heroSlideUsecase) GetByID(entityType string, id string) (*model.HeroSlideResponse, error) {
 	var s entity.HeroSlide
-	if err := u.repo.FindById(u.db, &s, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(u.db, &s, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("hero slide not found")
 		}
@@ -82,10 +100,15 @@ func (u *heroSlideUsecase) GetByID(id string) (*model.HeroSlideResponse, error)
 	return &resp, nil
 }
 
-func (u *heroSlideUsecase) Create(entityType string, req model.HeroSlideRequest) (*model.HeroSlideResponse, error) {
+func (u *heroSlideUsecase) Create(ctx context.Context, entityType string, req model.HeroSlideRequest) (*model.HeroSlideResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 
 	s := entity.HeroSlide{
 		ID:         uuid.New().String(),
@@ -95,7 +118,7 @@ func (u *heroSlideUsecase) Create(entityType string, req model.HeroSlideRequest)
 		IsActive:   req.IsActive,
 	}
 
-	if err := u.repo.Create(u.db, &s); err != nil {
+	if err := u.repo.Create(db, &s); err != nil {
 		return nil, err
 	}
 
@@ -103,25 +126,29 @@ func (u *heroSlideUsecase) Create(entityType string, req model.HeroSlideRequest)
 	return &resp, nil
 }
 
-func (u *heroSlideUsecase) Update(id string, req model.HeroSlideRequest) (*model.HeroSlideResponse, error) {
+func (u *heroSlideUsecase) Update(ctx context.Context, entityType string, id string, req model.HeroSlideRequest) (*model.HeroSlideResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 
 	var s entity.HeroSlide
-	if err := u.repo.FindById(u.db, &s, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &s, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("hero slide not found")
 		}
 		return nil, err
 	}
 
-	s.EntityType = req.EntityType
 	s.Images = util.ImageMap(req.Images)
 	s.OrderIndex = req.OrderIndex
 	s.IsActive = req.IsActive
 
-	if err := u.repo.Update(u.db, &s); err != nil {
+	if err := u.repo.Update(db, &s); err != nil {
 		return nil, err
 	}
 
@@ -129,13 +156,15 @@ func (u *heroSlideUsecase) Update(id string, req model.HeroSlideRequest) (*model
 	return &resp, nil
 }
 
-func (u *heroSlideUsecase) Delete(id string) error {
+func (u *heroSlideUsecase) Delete(ctx context.Context, entityType string, id string) error {
+	db := u.db.WithContext(ctx)
+
 	var s entity.HeroSlide
-	if err := u.repo.FindById(u.db, &s, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &s, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return model.ErrNotFound("hero slide not found")
 		}
 		return err
 	}
-	return u.repo.Delete(u.db, &s)
+	return u.repo.Delete(db, &s)
 }
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"pura-agung-kertajaya-backend/internal/model"
 
 	"github.com/stretchr/testify/mock"
@@ -8,47 +9,47 @@ import (
 
 type AboutUsecaseMock struct{ mock.Mock }
 
-func (m *AboutUsecaseMock) GetAll(entityType string) ([]model.AboutSectionResponse, error) {
-	args := m.Called(entityType)
+func (m *AboutUsecaseMock) GetAll(entityType string, req *model.ListRequest) ([]model.AboutSectionResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.AboutSectionResponse), args.Error(1)
+	return args.Get(0).([]model.AboutSectionResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *AboutUsecaseMock) GetPublic(entityType string) ([]model.AboutSectionResponse, error) {
-	args := m.Called(entityType)
+func (m *AboutUsecaseMock) GetPublic(entityType string, req *model.ListRequest) ([]model.AboutSectionResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.AboutSectionResponse), args.Error(1)
+	return args.Get(0).([]model.AboutSectionResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *AboutUsecaseMock) GetByID(id string) (*model.AboutSectionResponse, error) {
-	args := m.Called(id)
+func (m *AboutUsecaseMock) GetByID(entityType string, id string) (*model.AboutSectionResponse, error) {
+	args := m.Called(entityType, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.AboutSectionResponse), args.Error(1)
 }
 
-func (m *AboutUsecaseMock) Create(req model.AboutSectionRequest) (*model.AboutSectionResponse, error) {
-	args := m.Called(req)
+func (m *AboutUsecaseMock) Create(ctx context.Context, entityType string, req model.AboutSectionRequest) (*model.AboutSectionResponse, error) {
+	args := m.Called(ctx, entityType, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.AboutSectionResponse), args.Error(1)
 }
 
-func (m *AboutUsecaseMock) Update(id string, req model.AboutSectionRequest) (*model.AboutSectionResponse, error) {
-	args := m.Called(id, req)
+func (m *AboutUsecaseMock) Update(ctx context.Context, entityType string, id string, req model.AboutSectionRequest) (*model.AboutSectionResponse, error) {
+	args := m.Called(ctx, entityType, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.AboutSectionResponse), args.Error(1)
 }
 
-func (m *AboutUsecaseMock) Delete(id string) error {
-	args := m.Called(id)
+func (m *AboutUsecaseMock) Delete(ctx context.Context, entityType string, id string) error {
+	args := m.Called(ctx, entityType, id)
 	return args.Error(0)
 }
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"pura-agung-kertajaya-backend/internal/model"
 
 	"github.com/stretchr/testify/mock"
@@ -8,47 +9,68 @@ import (
 
 type ActivityUsecaseMock struct{ mock.Mock }
 
-func (m *ActivityUsecaseMock) GetAll(entityType string) ([]model.ActivityResponse, error) {
-	args := m.Called(entityType)
+func (m *ActivityUsecaseMock) GetAll(entityType string, req *model.ListRequest) ([]model.ActivityResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.ActivityResponse), args.Error(1)
+	return args.Get(0).([]model.ActivityResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *ActivityUsecaseMock) GetPublic(entityType string) ([]model.ActivityResponse, error) {
-	args := m.Called(entityType)
+func (m *ActivityUsecaseMock) GetPublic(entityType string, req *model.ListRequest, window *model.DateRange) ([]model.ActivityResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req, window)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.ActivityResponse), args.Error(1)
+	return args.Get(0).([]model.ActivityResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *ActivityUsecaseMock) GetByID(id string) (*model.ActivityResponse, error) {
-	args := m.Called(id)
+func (m *ActivityUsecaseMock) GetByID(entityType string, id string) (*model.ActivityResponse, error) {
+	args := m.Called(entityType, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.ActivityResponse), args.Error(1)
 }
 
-func (m *ActivityUsecaseMock) Create(entityType string, req model.CreateActivityRequest) (*model.ActivityResponse, error) {
-	args := m.Called(entityType, req)
+func (m *ActivityUsecaseMock) Create(ctx context.Context, entityType string, req model.CreateActivityRequest) (*model.ActivityResponse, error) {
+	args := m.Called(ctx, entityType, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.ActivityResponse), args.Error(1)
 }
 
-func (m *ActivityUsecaseMock) Update(id string, req model.UpdateActivityRequest) (*model.ActivityResponse, error) {
-	args := m.Called(id, req)
+func (m *ActivityUsecaseMock) Update(ctx context.Context, entityType string, id string, req model.UpdateActivityRequest) (*model.ActivityResponse, error) {
+	args := m.Called(ctx, entityType, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.ActivityResponse), args.Error(1)
 }
 
-func (m *ActivityUsecaseMock) Delete(id string) error {
-	args := m.Called(id)
+func (m *ActivityUsecaseMock) Delete(ctx context.Context, entityType string, id string) error {
+	args := m.Called(ctx, entityType, id)
+	return args.Error(0)
+}
+
+func (m *ActivityUsecaseMock) GetExceptions(entityType string, id string) ([]model.ActivityExceptionResponse, error) {
+	args := m.Called(entityType, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.ActivityExceptionResponse), args.Error(1)
+}
+
+func (m *ActivityUsecaseMock) SaveException(ctx context.Context, entityType string, id string, date string, req model.ActivityExceptionRequest) (*model.ActivityExceptionResponse, error) {
+	args := m.Called(ctx, entityType, id, date, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ActivityExceptionResponse), args.Error(1)
+}
+
+func (m *ActivityUsecaseMock) DeleteException(ctx context.Context, entityType string, id string, date string) error {
+	args := m.Called(ctx, entityType, id, date)
 	return args.Error(0)
 }
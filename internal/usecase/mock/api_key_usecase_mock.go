@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"pura-agung-kertajaya-backend/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type APIKeyUsecaseMock struct {
+	mock.Mock
+}
+
+func (m *APIKeyUsecaseMock) GetAll(ctx context.Context) ([]model.APIKeyResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.APIKeyResponse), args.Error(1)
+}
+
+func (m *APIKeyUsecaseMock) GetByID(ctx context.Context, id string) (*model.APIKeyResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIKeyResponse), args.Error(1)
+}
+
+func (m *APIKeyUsecaseMock) Create(ctx context.Context, createdBy string, req *model.CreateAPIKeyRequest) (*model.APIKeySecretResponse, error) {
+	args := m.Called(ctx, createdBy, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIKeySecretResponse), args.Error(1)
+}
+
+func (m *APIKeyUsecaseMock) Update(ctx context.Context, id string, req *model.UpdateAPIKeyRequest) (*model.APIKeyResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIKeyResponse), args.Error(1)
+}
+
+func (m *APIKeyUsecaseMock) Rotate(ctx context.Context, id string) (*model.APIKeySecretResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIKeySecretResponse), args.Error(1)
+}
+
+func (m *APIKeyUsecaseMock) Revoke(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *APIKeyUsecaseMock) Authenticate(ctx context.Context, key string) (*model.APIKeyAuth, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIKeyAuth), args.Error(1)
+}
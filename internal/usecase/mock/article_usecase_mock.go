@@ -1,7 +1,9 @@
 package usecase
 
 import (
+	"context"
 	"pura-agung-kertajaya-backend/internal/model"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,55 +12,135 @@ type ArticleUsecaseMock struct {
 	mock.Mock
 }
 
-func (m *ArticleUsecaseMock) GetAll(filter string) ([]model.ArticleResponse, error) {
-	args := m.Called(filter)
+func (m *ArticleUsecaseMock) GetAll(entityType string, req *model.ListRequest) ([]model.ArticleResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
+	if args.Get(0) == nil {
+		return nil, pageMetadata(args, 1), args.Error(2)
+	}
+	return args.Get(0).([]model.ArticleResponse), pageMetadata(args, 1), args.Error(2)
+}
+
+func (m *ArticleUsecaseMock) GetPublic(entityType string, req *model.ListRequest) ([]model.ArticleResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
+	if args.Get(0) == nil {
+		return nil, pageMetadata(args, 1), args.Error(2)
+	}
+	return args.Get(0).([]model.ArticleResponse), pageMetadata(args, 1), args.Error(2)
+}
+
+func (m *ArticleUsecaseMock) GetByID(entityType string, id string) (*model.ArticleResponse, error) {
+	args := m.Called(entityType, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]model.ArticleResponse), args.Error(1)
+	return args.Get(0).(*model.ArticleResponse), args.Error(1)
 }
 
-func (m *ArticleUsecaseMock) GetPublic(limit int) ([]model.ArticleResponse, error) {
-	args := m.Called(limit)
+func (m *ArticleUsecaseMock) GetBySlug(entityType string, slug string) (*model.ArticleResponse, error) {
+	args := m.Called(entityType, slug)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]model.ArticleResponse), args.Error(1)
+	return args.Get(0).(*model.ArticleResponse), args.Error(1)
+}
+
+func (m *ArticleUsecaseMock) Create(ctx context.Context, entityType string, req model.CreateArticleRequest) (*model.ArticleResponse, error) {
+	args := m.Called(ctx, entityType, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ArticleResponse), args.Error(1)
+}
+
+func (m *ArticleUsecaseMock) Update(ctx context.Context, entityType string, id string, req model.UpdateArticleRequest) (*model.ArticleResponse, error) {
+	args := m.Called(ctx, entityType, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ArticleResponse), args.Error(1)
+}
+
+func (m *ArticleUsecaseMock) Delete(ctx context.Context, entityType string, id string) error {
+	args := m.Called(ctx, entityType, id)
+	return args.Error(0)
+}
+
+func (m *ArticleUsecaseMock) GetRevisions(ctx context.Context, entityType string, id string) ([]model.ArticleRevisionSummaryResponse, error) {
+	args := m.Called(ctx, entityType, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.ArticleRevisionSummaryResponse), args.Error(1)
+}
+
+func (m *ArticleUsecaseMock) GetRevision(ctx context.Context, entityType string, id string, version int) (*model.ArticleRevisionResponse, error) {
+	args := m.Called(ctx, entityType, id, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ArticleRevisionResponse), args.Error(1)
 }
 
-func (m *ArticleUsecaseMock) GetByID(id string) (*model.ArticleResponse, error) {
-	args := m.Called(id)
+func (m *ArticleUsecaseMock) DiffRevisions(ctx context.Context, entityType string, id string, from int, to int) (*model.ArticleRevisionDiffResponse, error) {
+	args := m.Called(ctx, entityType, id, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ArticleRevisionDiffResponse), args.Error(1)
+}
+
+func (m *ArticleUsecaseMock) RestoreRevision(ctx context.Context, entityType string, id string, version int, canPublish bool) (*model.ArticleResponse, error) {
+	args := m.Called(ctx, entityType, id, version, canPublish)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.ArticleResponse), args.Error(1)
 }
 
-func (m *ArticleUsecaseMock) GetBySlug(slug string) (*model.ArticleResponse, error) {
-	args := m.Called(slug)
+func (m *ArticleUsecaseMock) ApplySchedule(ctx context.Context, now time.Time) (*model.ArticleScheduleResult, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ArticleScheduleResult), args.Error(1)
+}
+
+func (m *ArticleUsecaseMock) GetReviewQueue(ctx context.Context, entityType string) ([]model.ArticleResponse, error) {
+	args := m.Called(ctx, entityType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.ArticleResponse), args.Error(1)
+}
+
+func (m *ArticleUsecaseMock) SubmitForReview(ctx context.Context, entityType string, id string) (*model.ArticleResponse, error) {
+	args := m.Called(ctx, entityType, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.ArticleResponse), args.Error(1)
 }
 
-func (m *ArticleUsecaseMock) Create(req model.CreateArticleRequest) (*model.ArticleResponse, error) {
-	args := m.Called(req)
+func (m *ArticleUsecaseMock) ApproveReview(ctx context.Context, entityType string, id string) (*model.ArticleResponse, error) {
+	args := m.Called(ctx, entityType, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.ArticleResponse), args.Error(1)
 }
 
-func (m *ArticleUsecaseMock) Update(id string, req model.UpdateArticleRequest) (*model.ArticleResponse, error) {
-	args := m.Called(id, req)
+func (m *ArticleUsecaseMock) RejectReview(ctx context.Context, entityType string, id string, req *model.RejectArticleRequest) (*model.ArticleResponse, error) {
+	args := m.Called(ctx, entityType, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.ArticleResponse), args.Error(1)
 }
 
-func (m *ArticleUsecaseMock) Delete(id string) error {
-	args := m.Called(id)
-	return args.Error(0)
+func (m *ArticleUsecaseMock) GetRelated(entityType string, slug string, limit int) ([]model.ArticleResponse, error) {
+	args := m.Called(entityType, slug, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.ArticleResponse), args.Error(1)
 }
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"pura-agung-kertajaya-backend/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ArticleViewUsecaseMock struct {
+	mock.Mock
+}
+
+func (m *ArticleViewUsecaseMock) RecordView(ctx context.Context, articleID string, ip string, userAgent string) error {
+	args := m.Called(ctx, articleID, ip, userAgent)
+	return args.Error(0)
+}
+
+func (m *ArticleViewUsecaseMock) FlushViews(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *ArticleViewUsecaseMock) GetPopular(entityType string, days int, limit int) ([]model.PopularArticleResponse, error) {
+	args := m.Called(entityType, days, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.PopularArticleResponse), args.Error(1)
+}
+
+func (m *ArticleViewUsecaseMock) GetStats(ctx context.Context, entityType string, id string, days int) (*model.ArticleViewStatsResponse, error) {
+	args := m.Called(ctx, entityType, id, days)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ArticleViewStatsResponse), args.Error(1)
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+	"pura-agung-kertajaya-backend/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AuditLogUsecaseMock struct {
+	mock.Mock
+}
+
+func (m *AuditLogUsecaseMock) Search(ctx context.Context, req *model.SearchAuditLogRequest) ([]model.AuditLogResponse, int64, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]model.AuditLogResponse), args.Get(1).(int64), args.Error(2)
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"pura-agung-kertajaya-backend/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CalendarUsecaseMock struct {
+	mock.Mock
+}
+
+func (m *CalendarUsecaseMock) GetRange(req *model.PublicCalendarRequest) ([]model.CalendarDayResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.CalendarDayResponse), args.Error(1)
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"pura-agung-kertajaya-backend/internal/model"
 
 	"github.com/stretchr/testify/mock"
@@ -10,39 +11,39 @@ type CategoryUsecaseMock struct {
 	mock.Mock
 }
 
-func (m *CategoryUsecaseMock) GetAll() ([]model.CategoryResponse, error) {
-	args := m.Called()
+func (m *CategoryUsecaseMock) GetAll(entityType string, req *model.ListRequest) ([]model.CategoryResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.CategoryResponse), args.Error(1)
+	return args.Get(0).([]model.CategoryResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *CategoryUsecaseMock) GetByID(id string) (*model.CategoryResponse, error) {
-	args := m.Called(id)
+func (m *CategoryUsecaseMock) GetByID(entityType string, id string) (*model.CategoryResponse, error) {
+	args := m.Called(entityType, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.CategoryResponse), args.Error(1)
 }
 
-func (m *CategoryUsecaseMock) Create(req model.CreateCategoryRequest) (*model.CategoryResponse, error) {
-	args := m.Called(req)
+func (m *CategoryUsecaseMock) Create(ctx context.Context, entityType string, req model.CreateCategoryRequest) (*model.CategoryResponse, error) {
+	args := m.Called(ctx, entityType, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.CategoryResponse), args.Error(1)
 }
 
-func (m *CategoryUsecaseMock) Update(id string, req model.UpdateCategoryRequest) (*model.CategoryResponse, error) {
-	args := m.Called(id, req)
+func (m *CategoryUsecaseMock) Update(ctx context.Context, entityType string, id string, req model.UpdateCategoryRequest) (*model.CategoryResponse, error) {
+	args := m.Called(ctx, entityType, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.CategoryResponse), args.Error(1)
 }
 
-func (m *CategoryUsecaseMock) Delete(id string) error {
-	args := m.Called(id)
+func (m *CategoryUsecaseMock) Delete(ctx context.Context, entityType string, id string) error {
+	args := m.Called(ctx, entityType, id)
 	return args.Error(0)
 }
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"pura-agung-kertajaya-backend/internal/model"
 
 	"github.com/stretchr/testify/mock"
@@ -8,39 +9,39 @@ import (
 
 type ContactInfoUsecaseMock struct{ mock.Mock }
 
-func (m *ContactInfoUsecaseMock) GetAll(entityType string) ([]model.ContactInfoResponse, error) {
-	args := m.Called(entityType)
+func (m *ContactInfoUsecaseMock) GetAll(entityType string, req *model.ListRequest) ([]model.ContactInfoResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.ContactInfoResponse), args.Error(1)
+	return args.Get(0).([]model.ContactInfoResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *ContactInfoUsecaseMock) GetByID(id string) (*model.ContactInfoResponse, error) {
-	args := m.Called(id)
+func (m *ContactInfoUsecaseMock) GetByID(entityType string, id string) (*model.ContactInfoResponse, error) {
+	args := m.Called(entityType, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.ContactInfoResponse), args.Error(1)
 }
 
-func (m *ContactInfoUsecaseMock) Create(req model.CreateContactInfoRequest) (*model.ContactInfoResponse, error) {
-	args := m.Called(req)
+func (m *ContactInfoUsecaseMock) Create(ctx context.Context, entityType string, req model.CreateContactInfoRequest) (*model.ContactInfoResponse, error) {
+	args := m.Called(ctx, entityType, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.ContactInfoResponse), args.Error(1)
 }
 
-func (m *ContactInfoUsecaseMock) Update(id string, req model.UpdateContactInfoRequest) (*model.ContactInfoResponse, error) {
-	args := m.Called(id, req)
+func (m *ContactInfoUsecaseMock) Update(ctx context.Context, entityType string, id string, req model.UpdateContactInfoRequest) (*model.ContactInfoResponse, error) {
+	args := m.Called(ctx, entityType, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.ContactInfoResponse), args.Error(1)
 }
 
-func (m *ContactInfoUsecaseMock) Delete(id string) error {
-	args := m.Called(id)
+func (m *ContactInfoUsecaseMock) Delete(ctx context.Context, entityType string, id string) error {
+	args := m.Called(ctx, entityType, id)
 	return args.Error(0)
 }
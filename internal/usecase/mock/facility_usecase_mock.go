@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"pura-agung-kertajaya-backend/internal/model"
 
 	"github.com/stretchr/testify/mock"
@@ -8,47 +9,47 @@ import (
 
 type FacilityUsecaseMock struct{ mock.Mock }
 
-func (m *FacilityUsecaseMock) GetAll(entityType string) ([]model.FacilityResponse, error) {
-	args := m.Called(entityType)
+func (m *FacilityUsecaseMock) GetAll(entityType string, req *model.ListRequest) ([]model.FacilityResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.FacilityResponse), args.Error(1)
+	return args.Get(0).([]model.FacilityResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *FacilityUsecaseMock) GetPublic(entityType string) ([]model.FacilityResponse, error) {
-	args := m.Called(entityType)
+func (m *FacilityUsecaseMock) GetPublic(entityType string, req *model.ListRequest) ([]model.FacilityResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.FacilityResponse), args.Error(1)
+	return args.Get(0).([]model.FacilityResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *FacilityUsecaseMock) GetByID(id string) (*model.FacilityResponse, error) {
-	args := m.Called(id)
+func (m *FacilityUsecaseMock) GetByID(entityType string, id string) (*model.FacilityResponse, error) {
+	args := m.Called(entityType, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.FacilityResponse), args.Error(1)
 }
 
-func (m *FacilityUsecaseMock) Create(entityType string, req model.CreateFacilityRequest) (*model.FacilityResponse, error) {
-	args := m.Called(entityType, req)
+func (m *FacilityUsecaseMock) Create(ctx context.Context, entityType string, req model.CreateFacilityRequest) (*model.FacilityResponse, error) {
+	args := m.Called(ctx, entityType, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.FacilityResponse), args.Error(1)
 }
 
-func (m *FacilityUsecaseMock) Update(id string, req model.UpdateFacilityRequest) (*model.FacilityResponse, error) {
-	args := m.Called(id, req)
+func (m *FacilityUsecaseMock) Update(ctx context.Context, entityType string, id string, req model.UpdateFacilityRequest) (*model.FacilityResponse, error) {
+	args := m.Called(ctx, entityType, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.FacilityResponse), args.Error(1)
 }
 
-func (m *FacilityUsecaseMock) Delete(id string) error {
-	args := m.Called(id)
+func (m *FacilityUsecaseMock) Delete(ctx context.Context, entityType string, id string) error {
+	args := m.Called(ctx, entityType, id)
 	return args.Error(0)
 }
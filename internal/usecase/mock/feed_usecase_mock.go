@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"pura-agung-kertajaya-backend/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FeedUsecaseMock struct {
+	mock.Mock
+}
+
+func (m *FeedUsecaseMock) GetArticleFeed(entityType string, categorySlug string) (*model.Feed, error) {
+	args := m.Called(entityType, categorySlug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Feed), args.Error(1)
+}
+
+func (m *FeedUsecaseMock) GetActivityCalendar(entityType string) (*model.ICalendar, error) {
+	args := m.Called(entityType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ICalendar), args.Error(1)
+}
+
+func (m *FeedUsecaseMock) GetActivityEvent(entityType string, id string, date string) (*model.ICalendar, error) {
+	args := m.Called(entityType, id, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ICalendar), args.Error(1)
+}
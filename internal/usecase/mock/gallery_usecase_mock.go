@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"pura-agung-kertajaya-backend/internal/model"
 
 	"github.com/stretchr/testify/mock"
@@ -10,47 +11,47 @@ type GalleryUsecaseMock struct {
 	mock.Mock
 }
 
-func (m *GalleryUsecaseMock) GetAll(entityType string) ([]model.GalleryResponse, error) {
-	args := m.Called(entityType)
+func (m *GalleryUsecaseMock) GetAll(entityType string, req *model.ListRequest) ([]model.GalleryResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.GalleryResponse), args.Error(1)
+	return args.Get(0).([]model.GalleryResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *GalleryUsecaseMock) GetPublic(entityType string) ([]model.GalleryResponse, error) {
-	args := m.Called(entityType)
+func (m *GalleryUsecaseMock) GetPublic(entityType string, req *model.ListRequest) ([]model.GalleryResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.GalleryResponse), args.Error(1)
+	return args.Get(0).([]model.GalleryResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *GalleryUsecaseMock) GetByID(id string) (*model.GalleryResponse, error) {
-	args := m.Called(id)
+func (m *GalleryUsecaseMock) GetByID(entityType string, id string) (*model.GalleryResponse, error) {
+	args := m.Called(entityType, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.GalleryResponse), args.Error(1)
 }
 
-func (m *GalleryUsecaseMock) Create(entityType string, req model.CreateGalleryRequest) (*model.GalleryResponse, error) {
-	args := m.Called(entityType, req)
+func (m *GalleryUsecaseMock) Create(ctx context.Context, entityType string, req model.CreateGalleryRequest) (*model.GalleryResponse, error) {
+	args := m.Called(ctx, entityType, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.GalleryResponse), args.Error(1)
 }
 
-func (m *GalleryUsecaseMock) Update(id string, req model.UpdateGalleryRequest) (*model.GalleryResponse, error) {
-	args := m.Called(id, req)
+func (m *GalleryUsecaseMock) Update(ctx context.Context, entityType string, id string, req model.UpdateGalleryRequest) (*model.GalleryResponse, error) {
+	args := m.Called(ctx, entityType, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.GalleryResponse), args.Error(1)
 }
 
-func (m *GalleryUsecaseMock) Delete(id string) error {
-	args := m.Called(id)
+func (m *GalleryUsecaseMock) Delete(ctx context.Context, entityType string, id string) error {
+	args := m.Called(ctx, entityType, id)
 	return args.Error(0)
 }
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"pura-agung-kertajaya-backend/internal/model"
 
 	"github.com/stretchr/testify/mock"
@@ -10,47 +11,47 @@ type HeroSlideUsecaseMock struct {
 	mock.Mock
 }
 
-func (m *HeroSlideUsecaseMock) GetAll(entityType string) ([]model.HeroSlideResponse, error) {
-	args := m.Called(entityType)
+func (m *HeroSlideUsecaseMock) GetAll(entityType string, req *model.ListRequest) ([]model.HeroSlideResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.HeroSlideResponse), args.Error(1)
+	return args.Get(0).([]model.HeroSlideResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *HeroSlideUsecaseMock) GetPublic(entityType string) ([]model.HeroSlideResponse, error) {
-	args := m.Called(entityType)
+func (m *HeroSlideUsecaseMock) GetPublic(entityType string, req *model.ListRequest) ([]model.HeroSlideResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.HeroSlideResponse), args.Error(1)
+	return args.Get(0).([]model.HeroSlideResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *HeroSlideUsecaseMock) GetByID(id string) (*model.HeroSlideResponse, error) {
-	args := m.Called(id)
+func (m *HeroSlideUsecaseMock) GetByID(entityType string, id string) (*model.HeroSlideResponse, error) {
+	args := m.Called(entityType, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.HeroSlideResponse), args.Error(1)
 }
 
-func (m *HeroSlideUsecaseMock) Create(entityType string, req model.HeroSlideRequest) (*model.HeroSlideResponse, error) {
-	args := m.Called(entityType, req)
+func (m *HeroSlideUsecaseMock) Create(ctx context.Context, entityType string, req model.HeroSlideRequest) (*model.HeroSlideResponse, error) {
+	args := m.Called(ctx, entityType, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.HeroSlideResponse), args.Error(1)
 }
 
-func (m *HeroSlideUsecaseMock) Update(id string, req model.HeroSlideRequest) (*model.HeroSlideResponse, error) {
-	args := m.Called(id, req)
+func (m *HeroSlideUsecaseMock) Update(ctx context.Context, entityType string, id string, req model.HeroSlideRequest) (*model.HeroSlideResponse, error) {
+	args := m.Called(ctx, entityType, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.HeroSlideResponse), args.Error(1)
 }
 
-func (m *HeroSlideUsecaseMock) Delete(id string) error {
-	args := m.Called(id)
+func (m *HeroSlideUsecaseMock) Delete(ctx context.Context, entityType string, id string) error {
+	args := m.Called(ctx, entityType, id)
 	return args.Error(0)
 }
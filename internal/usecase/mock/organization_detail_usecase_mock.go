@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"pura-agung-kertajaya-backend/internal/model"
 
 	"github.com/stretchr/testify/mock"
@@ -18,8 +19,8 @@ func (m *OrganizationDetailUsecaseMock) GetByEntityType(entityType string) (*mod
 	return args.Get(0).(*model.OrganizationDetailResponse), args.Error(1)
 }
 
-func (m *OrganizationDetailUsecaseMock) Update(entityType string, req model.UpdateOrganizationDetailRequest) (*model.OrganizationDetailResponse, error) {
-	args := m.Called(entityType, req)
+func (m *OrganizationDetailUsecaseMock) Update(ctx context.Context, entityType string, req model.UpdateOrganizationDetailRequest) (*model.OrganizationDetailResponse, error) {
+	args := m.Called(ctx, entityType, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"pura-agung-kertajaya-backend/internal/model"
 
 	"github.com/stretchr/testify/mock"
@@ -10,47 +11,47 @@ type OrganizationMemberUsecaseMock struct {
 	mock.Mock
 }
 
-func (m *OrganizationMemberUsecaseMock) GetAll(entityType string) ([]model.OrganizationResponse, error) {
-	args := m.Called(entityType)
+func (m *OrganizationMemberUsecaseMock) GetAll(entityType string, req *model.ListRequest) ([]model.OrganizationResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.OrganizationResponse), args.Error(1)
+	return args.Get(0).([]model.OrganizationResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *OrganizationMemberUsecaseMock) GetPublic(entityType string) ([]model.OrganizationResponse, error) {
-	args := m.Called(entityType)
+func (m *OrganizationMemberUsecaseMock) GetPublic(entityType string, req *model.ListRequest) ([]model.OrganizationResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.OrganizationResponse), args.Error(1)
+	return args.Get(0).([]model.OrganizationResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *OrganizationMemberUsecaseMock) GetByID(id string) (*model.OrganizationResponse, error) {
-	args := m.Called(id)
+func (m *OrganizationMemberUsecaseMock) GetByID(entityType string, id string) (*model.OrganizationResponse, error) {
+	args := m.Called(entityType, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.OrganizationResponse), args.Error(1)
 }
 
-func (m *OrganizationMemberUsecaseMock) Create(entityType string, req model.CreateOrganizationRequest) (*model.OrganizationResponse, error) {
-	args := m.Called(entityType, req)
+func (m *OrganizationMemberUsecaseMock) Create(ctx context.Context, entityType string, req model.CreateOrganizationRequest) (*model.OrganizationResponse, error) {
+	args := m.Called(ctx, entityType, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.OrganizationResponse), args.Error(1)
 }
 
-func (m *OrganizationMemberUsecaseMock) Update(id string, req model.UpdateOrganizationRequest) (*model.OrganizationResponse, error) {
-	args := m.Called(id, req)
+func (m *OrganizationMemberUsecaseMock) Update(ctx context.Context, entityType string, id string, req model.UpdateOrganizationRequest) (*model.OrganizationResponse, error) {
+	args := m.Called(ctx, entityType, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.OrganizationResponse), args.Error(1)
 }
 
-func (m *OrganizationMemberUsecaseMock) Delete(id string) error {
-	args := m.Called(id)
+func (m *OrganizationMemberUsecaseMock) Delete(ctx context.Context, entityType string, id string) error {
+	args := m.Called(ctx, entityType, id)
 	return args.Error(0)
 }
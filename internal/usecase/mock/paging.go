@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"pura-agung-kertajaya-backend/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// pageMetadata reads optional paging metadata from a mocked return value.
+func pageMetadata(args mock.Arguments, index int) *model.PageMetadata {
+	if paging, ok := args.Get(index).(*model.PageMetadata); ok {
+		return paging
+	}
+	return nil
+}
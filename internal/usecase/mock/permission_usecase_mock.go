@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"pura-agung-kertajaya-backend/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type PermissionUsecaseMock struct {
+	mock.Mock
+}
+
+func (m *PermissionUsecaseMock) GetRoles(ctx context.Context) ([]model.RoleResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.RoleResponse), args.Error(1)
+}
+
+func (m *PermissionUsecaseMock) GetRoleByID(ctx context.Context, id string) (*model.RoleResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RoleResponse), args.Error(1)
+}
+
+func (m *PermissionUsecaseMock) CreateRole(ctx context.Context, req *model.CreateRoleRequest) (*model.RoleResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RoleResponse), args.Error(1)
+}
+
+func (m *PermissionUsecaseMock) UpdateRole(ctx context.Context, id string, req *model.UpdateRoleRequest) (*model.RoleResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RoleResponse), args.Error(1)
+}
+
+func (m *PermissionUsecaseMock) DeleteRole(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *PermissionUsecaseMock) GetUserRoles(ctx context.Context, userID string) ([]model.RoleAssignmentResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.RoleAssignmentResponse), args.Error(1)
+}
+
+func (m *PermissionUsecaseMock) SetUserRoles(ctx context.Context, userID string, req *model.SetUserRolesRequest) ([]model.RoleAssignmentResponse, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.RoleAssignmentResponse), args.Error(1)
+}
+
+func (m *PermissionUsecaseMock) GetGrants(ctx context.Context, userID string) (model.PermissionGrants, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(model.PermissionGrants), args.Error(1)
+}
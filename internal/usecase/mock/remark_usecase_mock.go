@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"pura-agung-kertajaya-backend/internal/model"
 
 	"github.com/stretchr/testify/mock"
@@ -10,47 +11,47 @@ type RemarkUsecaseMock struct {
 	mock.Mock
 }
 
-func (m *RemarkUsecaseMock) GetAll(entityType string) ([]model.RemarkResponse, error) {
-	args := m.Called(entityType)
+func (m *RemarkUsecaseMock) GetAll(entityType string, req *model.ListRequest) ([]model.RemarkResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.RemarkResponse), args.Error(1)
+	return args.Get(0).([]model.RemarkResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *RemarkUsecaseMock) GetPublic(entityType string) ([]model.RemarkResponse, error) {
-	args := m.Called(entityType)
+func (m *RemarkUsecaseMock) GetPublic(entityType string, req *model.ListRequest) ([]model.RemarkResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.RemarkResponse), args.Error(1)
+	return args.Get(0).([]model.RemarkResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *RemarkUsecaseMock) GetByID(id string) (*model.RemarkResponse, error) {
-	args := m.Called(id)
+func (m *RemarkUsecaseMock) GetByID(entityType string, id string) (*model.RemarkResponse, error) {
+	args := m.Called(entityType, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.RemarkResponse), args.Error(1)
 }
 
-func (m *RemarkUsecaseMock) Create(entityType string, req model.CreateRemarkRequest) (*model.RemarkResponse, error) {
-	args := m.Called(entityType, req)
+func (m *RemarkUsecaseMock) Create(ctx context.Context, entityType string, req model.CreateRemarkRequest) (*model.RemarkResponse, error) {
+	args := m.Called(ctx, entityType, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.RemarkResponse), args.Error(1)
 }
 
-func (m *RemarkUsecaseMock) Update(id string, req model.UpdateRemarkRequest) (*model.RemarkResponse, error) {
-	args := m.Called(id, req)
+func (m *RemarkUsecaseMock) Update(ctx context.Context, entityType string, id string, req model.UpdateRemarkRequest) (*model.RemarkResponse, error) {
+	args := m.Called(ctx, entityType, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.RemarkResponse), args.Error(1)
 }
 
-func (m *RemarkUsecaseMock) Delete(id string) error {
-	args := m.Called(id)
+func (m *RemarkUsecaseMock) Delete(ctx context.Context, entityType string, id string) error {
+	args := m.Called(ctx, entityType, id)
 	return args.Error(0)
 }
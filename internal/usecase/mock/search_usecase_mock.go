@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+	"pura-agung-kertajaya-backend/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SearchUsecaseMock struct {
+	mock.Mock
+}
+
+func (m *SearchUsecaseMock) Search(ctx context.Context, req *model.PublicSearchRequest) ([]model.SearchResultResponse, int64, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]model.SearchResultResponse), args.Get(1).(int64), args.Error(2)
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"pura-agung-kertajaya-backend/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SEOUsecaseMock struct {
+	mock.Mock
+}
+
+func (m *SEOUsecaseMock) Resolve(entityType string, path string) (*model.SEOMetadataResponse, error) {
+	args := m.Called(entityType, path)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SEOMetadataResponse), args.Error(1)
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"pura-agung-kertajaya-backend/internal/model"
 
 	"github.com/stretchr/testify/mock"
@@ -8,12 +9,12 @@ import (
 
 type SiteIdentityUsecaseMock struct{ mock.Mock }
 
-func (m *SiteIdentityUsecaseMock) GetAll(entityType string) ([]model.SiteIdentityResponse, error) {
-	args := m.Called(entityType)
+func (m *SiteIdentityUsecaseMock) GetAll(entityType string, req *model.ListRequest) ([]model.SiteIdentityResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.SiteIdentityResponse), args.Error(1)
+	return args.Get(0).([]model.SiteIdentityResponse), pageMetadata(args, 1), args.Error(2)
 }
 
 func (m *SiteIdentityUsecaseMock) GetPublic(entityType string) (*model.SiteIdentityResponse, error) {
@@ -24,31 +25,31 @@ func (m *SiteIdentityUsecaseMock) GetPublic(entityType string) (*model.SiteIdent
 	return args.Get(0).(*model.SiteIdentityResponse), args.Error(1)
 }
 
-func (m *SiteIdentityUsecaseMock) GetByID(id string) (*model.SiteIdentityResponse, error) {
-	args := m.Called(id)
+func (m *SiteIdentityUsecaseMock) GetByID(entityType string, id string) (*model.SiteIdentityResponse, error) {
+	args := m.Called(entityType, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.SiteIdentityResponse), args.Error(1)
 }
 
-func (m *SiteIdentityUsecaseMock) Create(entityType string, req model.SiteIdentityRequest) (*model.SiteIdentityResponse, error) {
-	args := m.Called(entityType, req)
+func (m *SiteIdentityUsecaseMock) Create(ctx context.Context, entityType string, req model.SiteIdentityRequest) (*model.SiteIdentityResponse, error) {
+	args := m.Called(ctx, entityType, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.SiteIdentityResponse), args.Error(1)
 }
 
-func (m *SiteIdentityUsecaseMock) Update(id string, req model.SiteIdentityRequest) (*model.SiteIdentityResponse, error) {
-	args := m.Called(id, req)
+func (m *SiteIdentityUsecaseMock) Update(ctx context.Context, entityType string, id string, req model.SiteIdentityRequest) (*model.SiteIdentityResponse, error) {
+	args := m.Called(ctx, entityType, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.SiteIdentityResponse), args.Error(1)
 }
 
-func (m *SiteIdentityUsecaseMock) Delete(id string) error {
-	args := m.Called(id)
+func (m *SiteIdentityUsecaseMock) Delete(ctx context.Context, entityType string, id string) error {
+	args := m.Called(ctx, entityType, id)
 	return args.Error(0)
 }
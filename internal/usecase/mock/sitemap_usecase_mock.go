@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"pura-agung-kertajaya-backend/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SitemapUsecaseMock struct {
+	mock.Mock
+}
+
+func (m *SitemapUsecaseMock) GetIndex() ([]model.SitemapIndexEntry, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SitemapIndexEntry), args.Error(1)
+}
+
+func (m *SitemapUsecaseMock) GetSitemap(entityType string) (*model.Sitemap, error) {
+	args := m.Called(entityType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Sitemap), args.Error(1)
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"pura-agung-kertajaya-backend/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type TagUsecaseMock struct {
+	mock.Mock
+}
+
+func (m *TagUsecaseMock) GetAll(entityType string, req *model.ListRequest) ([]model.TagResponse, *model.PageMetadata, error) {
+	args := m.Called(entityType, req)
+	if args.Get(0) == nil {
+		return nil, pageMetadata(args, 1), args.Error(2)
+	}
+	return args.Get(0).([]model.TagResponse), pageMetadata(args, 1), args.Error(2)
+}
+
+func (m *TagUsecaseMock) GetByID(entityType string, id string) (*model.TagResponse, error) {
+	args := m.Called(entityType, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TagResponse), args.Error(1)
+}
+
+func (m *TagUsecaseMock) Create(ctx context.Context, entityType string, req model.CreateTagRequest) (*model.TagResponse, error) {
+	args := m.Called(ctx, entityType, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TagResponse), args.Error(1)
+}
+
+func (m *TagUsecaseMock) Update(ctx context.Context, entityType string, id string, req model.UpdateTagRequest) (*model.TagResponse, error) {
+	args := m.Called(ctx, entityType, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TagResponse), args.Error(1)
+}
+
+func (m *TagUsecaseMock) Delete(ctx context.Context, entityType string, id string) error {
+	args := m.Called(ctx, entityType, id)
+	return args.Error(0)
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"pura-agung-kertajaya-backend/internal/model"
 
 	"github.com/stretchr/testify/mock"
@@ -10,20 +11,20 @@ type TestimonialUsecaseMock struct {
 	mock.Mock
 }
 
-func (m *TestimonialUsecaseMock) GetAll() ([]model.TestimonialResponse, error) {
-	args := m.Called()
+func (m *TestimonialUsecaseMock) GetAll(req *model.ListRequest) ([]model.TestimonialResponse, *model.PageMetadata, error) {
+	args := m.Called(req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.TestimonialResponse), args.Error(1)
+	return args.Get(0).([]model.TestimonialResponse), pageMetadata(args, 1), args.Error(2)
 }
 
-func (m *TestimonialUsecaseMock) GetPublic() ([]model.TestimonialResponse, error) {
-	args := m.Called()
+func (m *TestimonialUsecaseMock) GetPublic(req *model.ListRequest) ([]model.TestimonialResponse, *model.PageMetadata, error) {
+	args := m.Called(req)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pageMetadata(args, 1), args.Error(2)
 	}
-	return args.Get(0).([]model.TestimonialResponse), args.Error(1)
+	return args.Get(0).([]model.TestimonialResponse), pageMetadata(args, 1), args.Error(2)
 }
 
 func (m *TestimonialUsecaseMock) GetByID(id string) (*model.TestimonialResponse, error) {
@@ -34,23 +35,23 @@ func (m *TestimonialUsecaseMock) GetByID(id string) (*model.TestimonialResponse,
 	return args.Get(0).(*model.TestimonialResponse), args.Error(1)
 }
 
-func (m *TestimonialUsecaseMock) Create(req model.TestimonialRequest) (*model.TestimonialResponse, error) {
-	args := m.Called(req)
+func (m *TestimonialUsecaseMock) Create(ctx context.Context, req model.TestimonialRequest) (*model.TestimonialResponse, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.TestimonialResponse), args.Error(1)
 }
 
-func (m *TestimonialUsecaseMock) Update(id string, req model.TestimonialRequest) (*model.TestimonialResponse, error) {
-	args := m.Called(id, req)
+func (m *TestimonialUsecaseMock) Update(ctx context.Context, id string, req model.TestimonialRequest) (*model.TestimonialResponse, error) {
+	args := m.Called(ctx, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.TestimonialResponse), args.Error(1)
 }
 
-func (m *TestimonialUsecaseMock) Delete(id string) error {
-	args := m.Called(id)
+func (m *TestimonialUsecaseMock) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
@@ -11,12 +11,28 @@ type UserUsecaseMock struct {
 	mock.Mock
 }
 
-func (m *UserUsecaseMock) Login(ctx context.Context, req *model.LoginUserRequest) (*model.UserResponse, string, error) {
+func (m *UserUsecaseMock) Login(ctx context.Context, req *model.LoginUserRequest) (*model.LoginResult, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
-		return nil, "", args.Error(2)
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.LoginResult), args.Error(1)
+}
+
+func (m *UserUsecaseMock) VerifyTwoFactorLogin(ctx context.Context, req *model.VerifyTwoFactorLoginRequest) (*model.UserResponse, *model.TokenPair, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).(*model.UserResponse), args.String(1), args.Error(2)
+	return args.Get(0).(*model.UserResponse), args.Get(1).(*model.TokenPair), args.Error(2)
+}
+
+func (m *UserUsecaseMock) Refresh(ctx context.Context, req *model.RefreshTokenRequest) (*model.UserResponse, *model.TokenPair, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*model.UserResponse), args.Get(1).(*model.TokenPair), args.Error(2)
 }
 
 func (m *UserUsecaseMock) Current(ctx context.Context, userID string) (*model.UserResponse, error) {
@@ -35,7 +51,122 @@ func (m *UserUsecaseMock) UpdateProfile(ctx context.Context, userID string, req
 	return args.Get(0).(*model.UserResponse), args.Error(1)
 }
 
-func (m *UserUsecaseMock) Logout(ctx context.Context, tokenString string) error {
-	args := m.Called(ctx, tokenString)
+func (m *UserUsecaseMock) Logout(ctx context.Context, req *model.LogoutRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *UserUsecaseMock) ForgotPassword(ctx context.Context, req *model.ForgotPasswordRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *UserUsecaseMock) CompletePasswordReset(ctx context.Context, req *model.CompletePasswordResetRequest) error {
+	args := m.Called(ctx, req)
 	return args.Error(0)
 }
+
+func (m *UserUsecaseMock) GetAll(ctx context.Context, req *model.ListRequest) ([]model.UserResponse, *model.PageMetadata, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, pageMetadata(args, 1), args.Error(2)
+	}
+	return args.Get(0).([]model.UserResponse), pageMetadata(args, 1), args.Error(2)
+}
+
+func (m *UserUsecaseMock) GetByID(ctx context.Context, id string) (*model.UserResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.UserResponse), args.Error(1)
+}
+
+func (m *UserUsecaseMock) Create(ctx context.Context, req *model.CreateUserRequest) (*model.UserResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.UserResponse), args.Error(1)
+}
+
+func (m *UserUsecaseMock) UpdateRole(ctx context.Context, actorID string, id string, req *model.UpdateUserRoleRequest) (*model.UserResponse, error) {
+	args := m.Called(ctx, actorID, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.UserResponse), args.Error(1)
+}
+
+func (m *UserUsecaseMock) UpdateStatus(ctx context.Context, actorID string, id string, req *model.UpdateUserStatusRequest) (*model.UserResponse, error) {
+	args := m.Called(ctx, actorID, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.UserResponse), args.Error(1)
+}
+
+func (m *UserUsecaseMock) ResetPassword(ctx context.Context, id string, req *model.ResetUserPasswordRequest) error {
+	args := m.Called(ctx, id, req)
+	return args.Error(0)
+}
+
+func (m *UserUsecaseMock) ListSessions(ctx context.Context, userID string, currentSessionID string) ([]model.SessionResponse, error) {
+	args := m.Called(ctx, userID, currentSessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SessionResponse), args.Error(1)
+}
+
+func (m *UserUsecaseMock) RevokeSession(ctx context.Context, userID string, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *UserUsecaseMock) RevokeAllSessions(ctx context.Context, userID string, exceptSessionID string) error {
+	args := m.Called(ctx, userID, exceptSessionID)
+	return args.Error(0)
+}
+
+func (m *UserUsecaseMock) SetupTwoFactor(ctx context.Context, userID string) (*model.TwoFactorSetupResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TwoFactorSetupResponse), args.Error(1)
+}
+
+func (m *UserUsecaseMock) EnableTwoFactor(ctx context.Context, userID string, req *model.TwoFactorCodeRequest) (*model.RecoveryCodesResponse, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RecoveryCodesResponse), args.Error(1)
+}
+
+func (m *UserUsecaseMock) DisableTwoFactor(ctx context.Context, userID string, req *model.DisableTwoFactorRequest) error {
+	args := m.Called(ctx, userID, req)
+	return args.Error(0)
+}
+
+func (m *UserUsecaseMock) RegenerateRecoveryCodes(ctx context.Context, userID string, req *model.TwoFactorCodeRequest) (*model.RecoveryCodesResponse, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RecoveryCodesResponse), args.Error(1)
+}
+
+func (m *UserUsecaseMock) ResetTwoFactor(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *UserUsecaseMock) GetLoginAttempts(ctx context.Context, req *model.SearchLoginAttemptRequest) ([]model.LoginAttemptResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.LoginAttemptResponse), args.Error(1)
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"pura-agung-kertajaya-backend/internal/entity"
 	"pura-agung-kertajaya-backend/internal/model"
@@ -14,7 +15,7 @@ import (
 
 type OrganizationDetailUsecase interface {
 	GetByEntityType(entityType string) (*model.OrganizationDetailResponse, error)
-	Update(entityType string, req model.UpdateOrganizationDetailRequest) (*model.OrganizationDetailResponse, error)
+	Update(ctx context.Context, entityType string, req model.UpdateOrganizationDetailRequest) (*model.OrganizationDetailResponse, error)
 }
 
 type organizationDetailUsecase struct {
@@ -57,14 +58,16 @@ func (u *organizationDetailUsecase) GetByEntityType(entityType string) (*model.O
 	return &response, nil
 }
 
-func (u *organizationDetailUsecase) Update(entityType string, req model.UpdateOrganizationDetailRequest) (*model.OrganizationDetailResponse, error) {
+func (u *organizationDetailUsecase) Update(ctx context.Context, entityType string, req model.UpdateOrganizationDetailRequest) (*model.OrganizationDetailResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
 
 	var detail entity.OrganizationDetail
 
-	err := u.db.Where("entity_type = ?", entityType).First(&detail).Error
+	err := db.Where("entity_type = ?", entityType).First(&detail).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		detail = entity.OrganizationDetail{
@@ -80,7 +83,7 @@ func (u *organizationDetailUsecase) Update(entityType string, req model.UpdateOr
 			StructureImageURL:     req.StructureImageURL,
 		}
 
-		if err := u.repo.Create(u.db, &detail); err != nil {
+		if err := u.repo.Create(db, &detail); err != nil {
 			return nil, err
 		}
 	} else if err != nil {
@@ -95,7 +98,7 @@ func (u *organizationDetailUsecase) Update(entityType string, req model.UpdateOr
 		detail.RulesImageURL = req.RulesImageURL
 		detail.StructureImageURL = req.StructureImageURL
 
-		if err := u.repo.Update(u.db, &detail); err != nil {
+		if err := u.repo.Update(db, &detail); err != nil {
 			return nil, err
 		}
 	}
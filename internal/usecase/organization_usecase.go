@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"pura-agung-kertajaya-backend/internal/entity"
 	"pura-agung-kertajaya-backend/internal/model"
@@ -13,12 +14,12 @@ import (
 )
 
 type OrganizationUsecase interface {
-	GetAll(entityType string) ([]model.OrganizationResponse, error)
-	GetPublic(entityType string) ([]model.OrganizationResponse, error)
-	GetByID(id string) (*model.OrganizationResponse, error)
-	Create(entityType string, req model.CreateOrganizationRequest) (*model.OrganizationResponse, error)
-	Update(id string, req model.UpdateOrganizationRequest) (*model.OrganizationResponse, error)
-	Delete(id string) error
+	GetAll(entityType string, req *model.ListRequest) ([]model.OrganizationResponse, *model.PageMetadata, error)
+	GetPublic(entityType string, req *model.ListRequest) ([]model.OrganizationResponse, *model.PageMetadata, error)
+	GetByID(entityType string, id string) (*model.OrganizationResponse, error)
+	Create(ctx context.Context, entityType string, req model.CreateOrganizationRequest) (*model.OrganizationResponse, error)
+	Update(ctx context.Context, entityType string, id string, req model.UpdateOrganizationRequest) (*model.OrganizationResponse, error)
+	Delete(ctx context.Context, entityType string, id string) error
 }
 
 type organizationUsecase struct {
@@ -35,34 +36,51 @@ func NewOrganizationUsecase(db *gorm.DB, validate *validator.Validate) Organizat
 	}
 }
 
-func (u *organizationUsecase) GetAll(entityType string) ([]model.OrganizationResponse, error) {
+var organizationListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"name":           "name",
+		"position":       "position",
+		"position_order": "position_order",
+		"order_index":    "order_index",
+		"created_at":     "created_at",
+	},
+	Filters: map[string]string{
+		"is_active": "is_active",
+		"position":  "position",
+	},
+	Search:      []string{"name", "position"},
+	DefaultSort: "position_order,order_index",
+}
+
+func (u *organizationUsecase) GetAll(entityType string, req *model.ListRequest) ([]model.OrganizationResponse, *model.PageMetadata, error) {
 	var items []entity.OrganizationMember
 
-	query := u.db.Where("entity_type = ?", entityType).Order("position_order ASC, order_index ASC")
+	query := u.db.Where("entity_type = ?", entityType)
 
-	if err := u.repo.FindAll(query, &items); err != nil {
-		return nil, err
+	paging, err := u.repo.FindPage(query, &items, organizationListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return converter.ToOrganizationResponses(items), nil
+	return converter.ToOrganizationResponses(items), paging, nil
 }
 
-func (u *organizationUsecase) GetPublic(entityType string) ([]model.OrganizationResponse, error) {
+func (u *organizationUsecase) GetPublic(entityType string, req *model.ListRequest) ([]model.OrganizationResponse, *model.PageMetadata, error) {
 	var items []entity.OrganizationMember
-	query := u.db.Where("entity_type = ?", entityType).
-		Where("is_active = ?", true).
-		Order("position_order ASC, order_index ASC")
 
-	if err := u.repo.FindAll(query, &items); err != nil {
-		return nil, err
+	query := u.db.Where("entity_type = ?", entityType).Where("is_active = ?", true)
+
+	paging, err := u.repo.FindPage(query, &items, organizationListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return converter.ToOrganizationResponses(items), nil
+	return converter.ToOrganizationResponses(items), paging, nil
 }
 
-func (u *organizationUsecase) GetByID(id string) (*model.OrganizationResponse, error) {
+func (u *organizationUsecase) GetByID(entityType string, id string) (*model.OrganizationResponse, error) {
 	var m entity.OrganizationMember
-	if err := u.repo.FindById(u.db, &m, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(u.db, &m, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("organization member not found")
 		}
@@ -72,10 +90,15 @@ func (u *organizationUsecase) GetByID(id string) (*model.OrganizationResponse, e
 	return &r, nil
 }
 
-func (u *organizationUsecase) Create(entityType string, req model.CreateOrganizationRequest) (*model.OrganizationResponse, error) {
+func (u *organizationUsecase) Create(ctx context.Context, entityType string, req model.CreateOrganizationRequest) (*model.OrganizationResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 	m := entity.OrganizationMember{
 		ID:            uuid.New().String(),
 		EntityType:    entityType,
@@ -85,19 +108,24 @@ func (u *organizationUsecase) Create(entityType string, req model.CreateOrganiza
 		OrderIndex:    req.OrderIndex,
 		IsActive:      req.IsActive,
 	}
-	if err := u.repo.Create(u.db, &m); err != nil {
+	if err := u.repo.Create(db, &m); err != nil {
 		return nil, err
 	}
 	r := converter.ToOrganizationResponse(&m)
 	return &r, nil
 }
 
-func (u *organizationUsecase) Update(id string, req model.UpdateOrganizationRequest) (*model.OrganizationResponse, error) {
+func (u *organizationUsecase) Update(ctx context.Context, entityType string, id string, req model.UpdateOrganizationRequest) (*model.OrganizationResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 	var m entity.OrganizationMember
-	if err := u.repo.FindById(u.db, &m, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &m, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("organization member not found")
 		}
@@ -110,20 +138,22 @@ func (u *organizationUsecase) Update(id string, req model.UpdateOrganizationRequ
 	m.OrderIndex = req.OrderIndex
 	m.IsActive = req.IsActive
 
-	if err := u.repo.Update(u.db, &m); err != nil {
+	if err := u.repo.Update(db, &m); err != nil {
 		return nil, err
 	}
 	r := converter.ToOrganizationResponse(&m)
 	return &r, nil
 }
 
-func (u *organizationUsecase) Delete(id string) error {
+func (u *organizationUsecase) Delete(ctx context.Context, entityType string, id string) error {
+	db := u.db.WithContext(ctx)
+
 	var m entity.OrganizationMember
-	if err := u.repo.FindById(u.db, &m, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &m, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return model.ErrNotFound("organization member not found")
 		}
 		return err
 	}
-	return u.repo.Delete(u.db, &m)
+	return u.repo.Delete(db, &m)
 }
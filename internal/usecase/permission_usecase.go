@@ -0,0 +1,316 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/model/converter"
+	"pura-agung-kertajaya-backend/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type PermissionUseCase interface {
+	GetRoles(ctx context.Context) ([]model.RoleResponse, error)
+	GetRoleByID(ctx context.Context, id string) (*model.RoleResponse, error)
+	CreateRole(ctx context.Context, req *model.CreateRoleRequest) (*model.RoleResponse, error)
+	UpdateRole(ctx context.Context, id string, req *model.UpdateRoleRequest) (*model.RoleResponse, error)
+	DeleteRole(ctx context.Context, id string) error
+
+	GetUserRoles(ctx context.Context, userID string) ([]model.RoleAssignmentResponse, error)
+	SetUserRoles(ctx context.Context, userID string, req *model.SetUserRolesRequest) ([]model.RoleAssignmentResponse, error)
+	GetGrants(ctx context.Context, userID string) (model.PermissionGrants, error)
+}
+
+type permissionUseCase struct {
+	DB                   *gorm.DB
+	Validate             *validator.Validate
+	UserRepository       *repository.UserRepository
+	RoleRepository       *repository.RoleRepository
+	AssignmentRepository *repository.UserRoleAssignmentRepository
+}
+
+func NewPermissionUseCase(
+	db *gorm.DB,
+	validate *validator.Validate,
+	userRepository *repository.UserRepository,
+	roleRepository *repository.RoleRepository,
+	assignmentRepository *repository.UserRoleAssignmentRepository,
+) PermissionUseCase {
+	return &permissionUseCase{
+		DB:                   db,
+		Validate:             validate,
+		UserRepository:       userRepository,
+		RoleRepository:       roleRepository,
+		AssignmentRepository: assignmentRepository,
+	}
+}
+
+func (c *permissionUseCase) GetRoles(ctx context.Context) ([]model.RoleResponse, error) {
+	var roles []entity.Role
+	if err := c.RoleRepository.FindAllWithPermissions(c.DB.WithContext(ctx), &roles); err != nil {
+		return nil, err
+	}
+	return converter.ToRoleResponses(roles), nil
+}
+
+func (c *permissionUseCase) GetRoleByID(ctx context.Context, id string) (*model.RoleResponse, error) {
+	var role entity.Role
+	if err := c.RoleRepository.FindByIdWithPermissions(c.DB.WithContext(ctx), &role, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("role not found")
+		}
+		return nil, err
+	}
+	res := converter.ToRoleResponse(&role)
+	return &res, nil
+}
+
+func (c *permissionUseCase) CreateRole(ctx context.Context, req *model.CreateRoleRequest) (*model.RoleResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	permissions, err := normalizePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := c.RoleRepository.CountByNameIgnoringID(tx, req.Name, "")
+	if err != nil {
+		return nil, err
+	}
+	if total > 0 {
+		return nil, model.ErrConflict("role name already exists")
+	}
+
+	role := entity.Role{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := c.RoleRepository.Create(tx, &role); err != nil {
+		return nil, err
+	}
+
+	if err := c.RoleRepository.ReplacePermissions(tx, role.ID, permissions); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	role.Permissions = toRolePermissions(role.ID, permissions)
+	res := converter.ToRoleResponse(&role)
+	return &res, nil
+}
+
+func (c *permissionUseCase) UpdateRole(ctx context.Context, id string, req *model.UpdateRoleRequest) (*model.RoleResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	permissions, err := normalizePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	var role entity.Role
+	if err := c.RoleRepository.FindById(tx, &role, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("role not found")
+		}
+		return nil, err
+	}
+
+	if role.IsSystem && role.Name != req.Name {
+		return nil, model.ErrBadRequest("built-in roles cannot be renamed")
+	}
+
+	total, err := c.RoleRepository.CountByNameIgnoringID(tx, req.Name, role.ID)
+	if err != nil {
+		return nil, err
+	}
+	if total > 0 {
+		return nil, model.ErrConflict("role name already exists")
+	}
+
+	role.Name = req.Name
+	role.Description = req.Description
+
+	if err := c.RoleRepository.Update(tx, &role); err != nil {
+		return nil, err
+	}
+
+	if err := c.RoleRepository.ReplacePermissions(tx, role.ID, permissions); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	role.Permissions = toRolePermissions(role.ID, permissions)
+	res := converter.ToRoleResponse(&role)
+	return &res, nil
+}
+
+func (c *permissionUseCase) DeleteRole(ctx context.Context, id string) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var role entity.Role
+	if err := c.RoleRepository.FindById(tx, &role, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrNotFound("role not found")
+		}
+		return err
+	}
+
+	if role.IsSystem {
+		return model.ErrBadRequest("built-in roles cannot be deleted")
+	}
+
+	total, err := c.RoleRepository.CountReference(tx, new(entity.UserRoleAssignment), "role_id", role.ID)
+	if err != nil {
+		return err
+	}
+	if total > 0 {
+		return model.ErrConflict("role is still assigned to users")
+	}
+
+	if err := c.RoleRepository.Delete(tx, &role); err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (c *permissionUseCase) GetUserRoles(ctx context.Context, userID string) ([]model.RoleAssignmentResponse, error) {
+	db := c.DB.WithContext(ctx)
+
+	total, err := c.UserRepository.CountById(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	if total == 0 {
+		return nil, model.ErrNotFound("user not found")
+	}
+
+	var assignments []entity.UserRoleAssignment
+	if err := c.AssignmentRepository.FindByUserID(db, &assignments, userID); err != nil {
+		return nil, err
+	}
+	return converter.ToRoleAssignmentResponses(assignments), nil
+}
+
+// SetUserRoles replaces every role assignment of the user with the requested ones.
+func (c *permissionUseCase) SetUserRoles(ctx context.Context, userID string, req *model.SetUserRolesRequest) ([]model.RoleAssignmentResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	total, err := c.UserRepository.CountById(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if total == 0 {
+		return nil, model.ErrNotFound("user not found")
+	}
+
+	seen := make(map[model.RoleAssignmentRequest]bool)
+	roleIDs := make(map[string]bool)
+	var assignments []entity.UserRoleAssignment
+	for _, a := range req.Assignments {
+		if seen[a] {
+			continue
+		}
+		seen[a] = true
+		roleIDs[a.RoleID] = true
+		assignments = append(assignments, entity.UserRoleAssignment{
+			UserID:     userID,
+			RoleID:     a.RoleID,
+			EntityType: a.EntityType,
+		})
+	}
+
+	if len(roleIDs) > 0 {
+		ids := make([]string, 0, len(roleIDs))
+		for id := range roleIDs {
+			ids = append(ids, id)
+		}
+		found, err := c.RoleRepository.CountByIds(tx, ids)
+		if err != nil {
+			return nil, err
+		}
+		if found != int64(len(ids)) {
+			return nil, model.ErrBadRequest("one or more roles do not exist")
+		}
+	}
+
+	if err := c.AssignmentRepository.DeleteByUserID(tx, userID); err != nil {
+		return nil, err
+	}
+
+	for i := range assignments {
+		if err := c.AssignmentRepository.Create(tx, &assignments[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	var saved []entity.UserRoleAssignment
+	if err := c.AssignmentRepository.FindByUserID(tx, &saved, userID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return converter.ToRoleAssignmentResponses(saved), nil
+}
+
+func (c *permissionUseCase) GetGrants(ctx context.Context, userID string) (model.PermissionGrants, error) {
+	grants, err := c.AssignmentRepository.FindGrants(c.DB.WithContext(ctx), userID)
+	if err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+// normalizePermissions rejects permissions outside the catalog and drops duplicates.
+func normalizePermissions(permissions []string) ([]string, error) {
+	seen := make(map[string]bool, len(permissions))
+	result := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		if !model.IsPermission(p) {
+			return nil, model.ErrBadRequest("unknown permission: " + p)
+		}
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+func toRolePermissions(roleID string, permissions []string) []entity.RolePermission {
+	rows := make([]entity.RolePermission, 0, len(permissions))
+	for _, p := range permissions {
+		rows = append(rows, entity.RolePermission{RoleID: roleID, Permission: p})
+	}
+	return rows
+}
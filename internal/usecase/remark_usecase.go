@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"pura-agung-kertajaya-backend/internal/entity"
 	"pura-agung-kertajaya-backend/internal/model"
@@ -13,12 +14,12 @@ import (
 )
 
 type RemarkUsecase interface {
-	GetAll(entityType string) ([]model.RemarkResponse, error)
-	GetPublic(entityType string) ([]model.RemarkResponse, error)
-	GetByID(id string) (*model.RemarkResponse, error)
-	Create(entityType string, req model.CreateRemarkRequest) (*model.RemarkResponse, error)
-	Update(id string, req model.UpdateRemarkRequest) (*model.RemarkResponse, error)
-	Delete(id string) error
+	GetAll(entityType string, req *model.ListRequest) ([]model.RemarkResponse, *model.PageMetadata, error)
+	GetPublic(entityType string, req *model.ListRequest) ([]model.RemarkResponse, *model.PageMetadata, error)
+	GetByID(entityType string, id string) (*model.RemarkResponse, error)
+	Create(ctx context.Context, entityType string, req model.CreateRemarkRequest) (*model.RemarkResponse, error)
+	Update(ctx context.Context, entityType string, id string, req model.UpdateRemarkRequest) (*model.RemarkResponse, error)
+	Delete(ctx context.Context, entityType string, id string) error
 }
 
 type remarkUsecase struct {
@@ -35,33 +36,49 @@ func NewRemarkUsecase(db *gorm.DB, validate *validator.Validate) RemarkUsecase {
 	}
 }
 
-func (u *remarkUsecase) GetAll(entityType string) ([]model.RemarkResponse, error) {
+var remarkListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"name":        "name",
+		"position":    "position",
+		"order_index": "order_index",
+		"created_at":  "created_at",
+	},
+	Filters: map[string]string{
+		"is_active": "is_active",
+	},
+	Search:      []string{"name", "position"},
+	DefaultSort: "order_index",
+}
+
+func (u *remarkUsecase) GetAll(entityType string, req *model.ListRequest) ([]model.RemarkResponse, *model.PageMetadata, error) {
 	var remarks []entity.Remark
 
-	query := u.db.Where("entity_type = ?", entityType).Order("order_index ASC")
+	query := u.db.Where("entity_type = ?", entityType)
 
-	if err := u.repo.FindAll(query, &remarks); err != nil {
-		return nil, err
+	paging, err := u.repo.FindPage(query, &remarks, remarkListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return converter.ToRemarkResponses(remarks), nil
+	return converter.ToRemarkResponses(remarks), paging, nil
 }
 
-func (u *remarkUsecase) GetPublic(entityType string) ([]model.RemarkResponse, error) {
+func (u *remarkUsecase) GetPublic(entityType string, req *model.ListRequest) ([]model.RemarkResponse, *model.PageMetadata, error) {
 	var remarks []entity.Remark
 
-	query := u.db.Where("is_active = ? AND entity_type = ?", true, entityType).
-		Order("order_index ASC")
+	query := u.db.Where("is_active = ? AND entity_type = ?", true, entityType)
 
-	if err := u.repo.FindAll(query, &remarks); err != nil {
-		return nil, err
+	paging, err := u.repo.FindPage(query, &remarks, remarkListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
-	return converter.ToRemarkResponses(remarks), nil
+
+	return converter.ToRemarkResponses(remarks), paging, nil
 }
 
-func (u *remarkUsecase) GetByID(id string) (*model.RemarkResponse, error) {
+func (u *remarkUsecase) GetByID(entityType string, id string) (*model.RemarkResponse, error) {
 	var r entity.Remark
-	if err := u.repo.FindById(u.db, &r, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(u.db, &r, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("remark not found")
 		}
@@ -72,10 +89,15 @@ func (u *remarkUsecase) GetByID(id string) (*model.RemarkResponse, error) {
 	return &response, nil
 }
 
-func (u *remarkUsecase) Create(entityType string, req model.CreateRemarkRequest) (*model.RemarkResponse, error) {
+func (u *remarkUsecase) Create(ctx context.Context, entityType string, req model.CreateRemarkRequest) (*model.RemarkResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 
 	r := entity.Remark{
 		ID:         uuid.New().String(),
@@ -92,7 +114,7 @@ func (u *remarkUsecase) Create(entityType string, req model.CreateRemarkRequest)
 		r.OrderIndex = 1
 	}
 
-	if err := u.repo.Create(u.db, &r); err != nil {
+	if err := u.repo.Create(db, &r); err != nil {
 		return nil, err
 	}
 
@@ -100,13 +122,18 @@ func (u *remarkUsecase) Create(entityType string, req model.CreateRemarkRequest)
 	return &response, nil
 }
 
-func (u *remarkUsecase) Update(id string, req model.UpdateRemarkRequest) (*model.RemarkResponse, error) {
+func (u *remarkUsecase) Update(ctx context.Context, entityType string, id string, req model.UpdateRemarkRequest) (*model.RemarkResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 
 	var r entity.Remark
-	if err := u.repo.FindById(u.db, &r, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &r, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("remark not found")
 		}
@@ -120,7 +147,7 @@ func (u *remarkUsecase) Update(id string, req model.UpdateRemarkRequest) (*model
 	r.OrderIndex = req.OrderIndex
 	r.IsActive = req.IsActive
 
-	if err := u.repo.Update(u.db, &r); err != nil {
+	if err := u.repo.Update(db, &r); err != nil {
 		return nil, err
 	}
 
@@ -128,13 +155,15 @@ func (u *remarkUsecase) Update(id string, req model.UpdateRemarkRequest) (*model
 	return &response, nil
 }
 
-func (u *remarkUsecase) Delete(id string) error {
+func (u *remarkUsecase) Delete(ctx context.Context, entityType string, id string) error {
+	db := u.db.WithContext(ctx)
+
 	var r entity.Remark
-	if err := u.repo.FindById(u.db, &r, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &r, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return model.ErrNotFound("remark not found")
 		}
 		return err
 	}
-	return u.repo.Delete(u.db, &r)
+	return u.repo.Delete(db, &r)
 }
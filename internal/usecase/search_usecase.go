@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/model/converter"
+	"pura-agung-kertajaya-backend/internal/repository"
+	"pura-agung-kertajaya-backend/internal/util"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// searchSnippetWidth is the approximate length, in characters, of the highlighted snippet of a hit.
+const searchSnippetWidth = 160
+
+type SearchUsecase interface {
+	Search(ctx context.Context, req *model.PublicSearchRequest) ([]model.SearchResultResponse, int64, error)
+}
+
+type searchUsecase struct {
+	repo     repository.SearchRepository
+	validate *validator.Validate
+	now      func() time.Time
+}
+
+func NewSearchUsecase(repo repository.SearchRepository, validate *validator.Validate) SearchUsecase {
+	return &searchUsecase{
+		repo:     repo,
+		validate: validate,
+		now:      time.Now,
+	}
+}
+
+func (u *searchUsecase) Search(ctx context.Context, req *model.PublicSearchRequest) ([]model.SearchResultResponse, int64, error) {
+	if err := u.validate.Struct(req); err != nil {
+		return nil, 0, err
+	}
+
+	terms := util.SearchTerms(req.Query)
+	if len(terms) == 0 {
+		return nil, 0, model.ErrBadRequest("q must contain at least one letter or digit")
+	}
+
+	documents, total, err := u.repo.Search(ctx, req, u.now())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]model.SearchResultResponse, len(documents))
+	for i := range documents {
+		results[i] = converter.ToSearchResultResponse(&documents[i], util.HighlightSnippet(documents[i].Body, terms, searchSnippetWidth))
+	}
+	return results, total, nil
+}
@@ -0,0 +1,389 @@
+package usecase
+
+import (
+	"errors"
+	"net/http"
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/repository"
+	"pura-agung-kertajaya-backend/internal/util"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	seoLocale     = "id_ID"
+	seoMaxEvents  = 20
+	schemaContext = "https://schema.org"
+)
+
+// ogImagePreference is the order image variants are tried in when none was chosen. Social cards are
+// shown at about 1200px wide.
+var ogImagePreference = []string{"xl", "lg", "2xl", "fhd", "md"}
+
+type SEOUsecase interface {
+	Resolve(entityType string, path string) (*model.SEOMetadataResponse, error)
+}
+
+type seoUsecase struct {
+	db                  *gorm.DB
+	categoryRepo        *repository.Repository[entity.Category]
+	articleUsecase      ArticleUsecase
+	siteIdentityUsecase SiteIdentityUsecase
+	siteURL             *util.SiteURLUtil
+}
+
+func NewSEOUsecase(db *gorm.DB, articleUsecase ArticleUsecase, siteIdentityUsecase SiteIdentityUsecase, siteURL *util.SiteURLUtil) SEOUsecase {
+	return &seoUsecase{
+		db:                  db,
+		categoryRepo:        &repository.Repository[entity.Category]{DB: db},
+		articleUsecase:      articleUsecase,
+		siteIdentityUsecase: siteIdentityUsecase,
+		siteURL:             siteURL,
+	}
+}
+
+// Resolve builds the metadata of the public page at path: an article, a category, the article listing
+// or one of the static pages. Anything the page does not set falls back to the site identity.
+func (u *seoUsecase) Resolve(entityType string, path string) (*model.SEOMetadataResponse, error) {
+	if !u.siteURL.HasSite(entityType) {
+		return nil, model.ErrNotFound("site not found")
+	}
+
+	site, err := u.siteIdentityUsecase.GetPublic(entityType)
+	if err != nil {
+		var e *model.ResponseError
+		if !errors.As(err, &e) || e.Code != http.StatusNotFound {
+			return nil, err
+		}
+	}
+
+	path = normalizeSEOPath(path)
+	meta := &model.SEOMetadataResponse{
+		Path:   path,
+		Type:   "website",
+		Locale: seoLocale,
+		JSONLD: []map[string]any{},
+	}
+	var organization map[string]any
+	if site != nil {
+		meta.SiteName = site.SiteName
+		meta.Description = site.MetaDescription
+		if meta.Description == "" {
+			meta.Description = site.Tagline
+		}
+		meta.Image = u.siteImage(site)
+
+		organization = u.organizationLD(entityType, site)
+		meta.JSONLD = append(meta.JSONLD, withSchemaContext(organization))
+	}
+
+	articlePath := normalizeSEOPath(u.siteURL.ArticlePath)
+	categoryPath := normalizeSEOPath(u.siteURL.CategoryPath)
+
+	switch {
+	case strings.HasPrefix(path, articlePath+"/") && !strings.Contains(strings.TrimPrefix(path, articlePath+"/"), "/"):
+		article, err := u.articleUsecase.GetBySlug(entityType, strings.TrimPrefix(path, articlePath+"/"))
+		if err != nil {
+			return nil, err
+		}
+		u.applyArticle(meta, entityType, article, organization)
+
+	case strings.HasPrefix(path, categoryPath+"/") && !strings.Contains(strings.TrimPrefix(path, categoryPath+"/"), "/"):
+		var category entity.Category
+		if err := u.categoryRepo.FindBySlug(u.db.Where("entity_type = ?", entityType), &category, strings.TrimPrefix(path, categoryPath+"/")); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, model.ErrNotFound("category not found")
+			}
+			return nil, err
+		}
+		meta.Title = withSiteName(category.Name, meta.SiteName)
+		meta.CanonicalURL = u.siteURL.CategoryURL(entityType, category.Slug)
+
+	case path == "/":
+		meta.Title = meta.SiteName
+		meta.CanonicalURL = u.siteURL.PageURL(entityType, path)
+		if site != nil {
+			if site.MetaTitle != "" {
+				meta.Title = site.MetaTitle
+			}
+			if site.CanonicalURL != "" {
+				meta.CanonicalURL = site.CanonicalURL
+			}
+		}
+
+	case path == articlePath || u.isPage(path):
+		meta.Title = withSiteName(pageTitle(path), meta.SiteName)
+		meta.CanonicalURL = u.siteURL.PageURL(entityType, path)
+
+		if path == normalizeSEOPath(u.siteURL.ActivityPath) {
+			events, err := u.eventsLD(entityType, organization)
+			if err != nil {
+				return nil, err
+			}
+			meta.JSONLD = append(meta.JSONLD, events...)
+		}
+
+	default:
+		return nil, model.ErrNotFound("page not found")
+	}
+
+	if meta.Image != nil && meta.Image.Alt == "" {
+		meta.Image.Alt = meta.Title
+	}
+	u.applySocialTags(meta)
+	return meta, nil
+}
+
+func (u *seoUsecase) applyArticle(meta *model.SEOMetadataResponse, entityType string, article *model.ArticleResponse, organization map[string]any) {
+	meta.Type = "article"
+	meta.Title = article.MetaTitle
+	if meta.Title == "" {
+		meta.Title = withSiteName(article.Title, meta.SiteName)
+	}
+	meta.Description = article.MetaDescription
+	if meta.Description == "" {
+		meta.Description = article.Excerpt
+	}
+	meta.CanonicalURL = article.CanonicalURL
+	if meta.CanonicalURL == "" {
+		meta.CanonicalURL = u.siteURL.ArticleURL(entityType, article.Slug)
+	}
+	if url, variant := ogImage(article.Images, article.OGImageVariant); url != "" {
+		meta.Image = &model.SEOImageResponse{URL: u.siteURL.AssetURL(url), Width: util.PresetWidth(variant), Alt: article.Title}
+	}
+
+	published := article.CreatedAt
+	if article.PublishedAt != nil {
+		published = *article.PublishedAt
+	}
+
+	ld := map[string]any{
+		"@context":         schemaContext,
+		"@type":            "Article",
+		"headline":         article.Title,
+		"description":      meta.Description,
+		"url":              meta.CanonicalURL,
+		"inLanguage":       feedLanguage,
+		"datePublished":    published.Format(time.RFC3339),
+		"dateModified":     article.UpdatedAt.Format(time.RFC3339),
+		"mainEntityOfPage": map[string]any{"@type": "WebPage", "@id": meta.CanonicalURL},
+	}
+	if article.AuthorName != "" {
+		author := map[string]any{"@type": "Person", "name": article.AuthorName}
+		if article.AuthorRole != "" {
+			author["jobTitle"] = article.AuthorRole
+		}
+		ld["author"] = author
+	}
+	if organization != nil {
+		ld["publisher"] = organization
+	}
+	if meta.Image != nil {
+		ld["image"] = []string{meta.Image.URL}
+	}
+	if article.Category != nil {
+		ld["articleSection"] = article.Category.Name
+	}
+	if len(article.Tags) > 0 {
+		keywords := make([]string, 0, len(article.Tags))
+		for _, tag := range article.Tags {
+			keywords = append(keywords, tag.Name)
+		}
+		ld["keywords"] = strings.Join(keywords, ", ")
+	}
+	meta.JSONLD = append(meta.JSONLD, ld)
+
+	meta.OpenGraph = map[string]string{
+		"article:published_time": published.Format(time.RFC3339),
+		"article:modified_time":  article.UpdatedAt.Format(time.RFC3339),
+	}
+	if article.Category != nil {
+		meta.OpenGraph["article:section"] = article.Category.Name
+	}
+	if article.AuthorName != "" {
+		meta.OpenGraph["article:author"] = article.AuthorName
+	}
+}
+
+// applySocialTags fills the Open Graph and Twitter card tags from the resolved metadata.
+func (u *seoUsecase) applySocialTags(meta *model.SEOMetadataResponse) {
+	if meta.OpenGraph == nil {
+		meta.OpenGraph = map[string]string{}
+	}
+	meta.OpenGraph["og:type"] = meta.Type
+	meta.OpenGraph["og:title"] = meta.Title
+	meta.OpenGraph["og:url"] = meta.CanonicalURL
+	meta.OpenGraph["og:locale"] = meta.Locale
+	if meta.Description != "" {
+		meta.OpenGraph["og:description"] = meta.Description
+	}
+	if meta.SiteName != "" {
+		meta.OpenGraph["og:site_name"] = meta.SiteName
+	}
+
+	meta.Twitter = map[string]string{
+		"twitter:card":  "summary",
+		"twitter:title": meta.Title,
+	}
+	if meta.Description != "" {
+		meta.Twitter["twitter:description"] = meta.Description
+	}
+
+	if meta.Image != nil {
+		meta.OpenGraph["og:image"] = meta.Image.URL
+		meta.OpenGraph["og:image:alt"] = meta.Image.Alt
+		if meta.Image.Width > 0 {
+			meta.OpenGraph["og:image:width"] = strconv.Itoa(meta.Image.Width)
+		}
+		meta.Twitter["twitter:card"] = "summary_large_image"
+		meta.Twitter["twitter:image"] = meta.Image.URL
+	}
+}
+
+// siteImage is the site's share image: its chosen Open Graph variant, else the logo.
+func (u *seoUsecase) siteImage(site *model.SiteIdentityResponse) *model.SEOImageResponse {
+	if url, variant := ogImage(site.OGImages, site.OGImageVariant); url != "" {
+		return &model.SEOImageResponse{URL: u.siteURL.AssetURL(url), Width: util.PresetWidth(variant)}
+	}
+	if site.LogoURL != "" {
+		return &model.SEOImageResponse{URL: u.siteURL.AssetURL(site.LogoURL)}
+	}
+	return nil
+}
+
+func (u *seoUsecase) organizationLD(entityType string, site *model.SiteIdentityResponse) map[string]any {
+	organization := map[string]any{
+		"@type": "Organization",
+		"name":  site.SiteName,
+		"url":   u.siteURL.PageURL(entityType, "/"),
+	}
+	if site.LogoURL != "" {
+		organization["logo"] = u.siteURL.AssetURL(site.LogoURL)
+	}
+	if site.Tagline != "" {
+		organization["description"] = site.Tagline
+	}
+	return organization
+}
+
+// eventsLD describes the upcoming active activities of the entity, soonest first.
+func (u *seoUsecase) eventsLD(entityType string, organizer map[string]any) ([]map[string]any, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var activities []entity.Activity
+	if err := u.db.
+		Where("entity_type = ?", entityType).
+		Where("is_active = ?", true).
+		Where("event_date >= ?", today).
+		Order("event_date ASC").
+		Order("order_index ASC").
+		Limit(seoMaxEvents).
+		Find(&activities).Error; err != nil {
+		return nil, err
+	}
+
+	url := u.siteURL.PageURL(entityType, u.siteURL.ActivityPath)
+	events := make([]map[string]any, 0, len(activities))
+	for _, a := range activities {
+		event := map[string]any{
+			"@context":            schemaContext,
+			"@type":               "Event",
+			"name":                a.Title,
+			"description":         a.Description,
+			"startDate":           a.EventDate.Format(time.DateOnly),
+			"eventStatus":         schemaContext + "/EventScheduled",
+			"eventAttendanceMode": schemaContext + "/OfflineEventAttendanceMode",
+			"url":                 url,
+		}
+		if a.Location != "" {
+			event["location"] = map[string]any{"@type": "Place", "name": a.Location, "address": a.Location}
+		}
+		if organizer != nil {
+			event["organizer"] = organizer
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (u *seoUsecase) isPage(path string) bool {
+	for _, page := range u.siteURL.Pages {
+		if normalizeSEOPath(page) == path {
+			return true
+		}
+	}
+	return false
+}
+
+// ogImage picks the chosen variant, else the first available of ogImagePreference. It returns the
+// image and the name of its variant.
+func ogImage(images model.ImageVariants, variant string) (string, string) {
+	if variant != "" {
+		if url := imageVariant(images, variant); url != "" {
+			return url, variant
+		}
+	}
+	for _, name := range ogImagePreference {
+		if url := imageVariant(images, name); url != "" {
+			return url, name
+		}
+	}
+	return "", ""
+}
+
+func imageVariant(images model.ImageVariants, name string) string {
+	switch name {
+	case "sm":
+		return images.Sm
+	case "md":
+		return images.Md
+	case "lg":
+		return images.Lg
+	case "xl":
+		return images.Xl
+	case "2xl":
+		return images.TwoXl
+	case "fhd":
+		return images.Fhd
+	}
+	return ""
+}
+
+// normalizeSEOPath drops the query, fragment and trailing slash and makes the path absolute.
+func normalizeSEOPath(path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	return "/" + strings.Trim(path, "/")
+}
+
+// pageTitle turns the last segment of a path such as /struktur-organisasi into "Struktur Organisasi".
+func pageTitle(path string) string {
+	segment := path[strings.LastIndex(path, "/")+1:]
+	words := strings.Fields(strings.ReplaceAll(segment, "-", " "))
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+func withSiteName(title string, siteName string) string {
+	if siteName == "" || title == siteName {
+		return title
+	}
+	return title + " | " + siteName
+}
+
+func withSchemaContext(doc map[string]any) map[string]any {
+	out := make(map[string]any, len(doc)+1)
+	out["@context"] = schemaContext
+	for k, v := range doc {
+		out[k] = v
+	}
+	return out
+}
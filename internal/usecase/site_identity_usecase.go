@@ -1,11 +1,13 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"pura-agung-kertajaya-backend/internal/entity"
 	"pura-agung-kertajaya-backend/internal/model"
 	"pura-agung-kertajaya-backend/internal/model/converter"
 	"pura-agung-kertajaya-backend/internal/repository"
+	"pura-agung-kertajaya-backend/internal/util"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -13,12 +15,12 @@ import (
 )
 
 type SiteIdentityUsecase interface {
-	GetAll(entityType string) ([]model.SiteIdentityResponse, error)
+	GetAll(entityType string, req *model.ListRequest) ([]model.SiteIdentityResponse, *model.PageMetadata, error)
 	GetPublic(entityType string) (*model.SiteIdentityResponse, error)
-	GetByID(id string) (*model.SiteIdentityResponse, error)
-	Create(entityType string, req model.SiteIdentityRequest) (*model.SiteIdentityResponse, error)
-	Update(id string, req model.SiteIdentityRequest) (*model.SiteIdentityResponse, error)
-	Delete(id string) error
+	GetByID(entityType string, id string) (*model.SiteIdentityResponse, error)
+	Create(ctx context.Context, entityType string, req model.SiteIdentityRequest) (*model.SiteIdentityResponse, error)
+	Update(ctx context.Context, entityType string, id string, req model.SiteIdentityRequest) (*model.SiteIdentityResponse, error)
+	Delete(ctx context.Context, entityType string, id string) error
 }
 
 type siteIdentityUsecase struct {
@@ -35,21 +37,32 @@ func NewSiteIdentityUsecase(db *gorm.DB, validate *validator.Validate) SiteIdent
 	}
 }
 
-func (u *siteIdentityUsecase) GetAll(entityType string) ([]model.SiteIdentityResponse, error) {
+var siteIdentityListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"site_name":  "site_name",
+		"created_at": "created_at",
+	},
+	DefaultSort: "created_at",
+}
+
+func (u *siteIdentityUsecase) GetAll(entityType string, req *model.ListRequest) ([]model.SiteIdentityResponse, *model.PageMetadata, error) {
 	var items []entity.SiteIdentity
-	query := u.db.Order("created_at ASC")
+
+	query := u.db
 	if entityType != "" {
 		query = query.Where("entity_type = ?", entityType)
 	}
 
-	if err := u.repo.FindAll(query, &items); err != nil {
-		return nil, err
+	paging, err := u.repo.FindPage(query, &items, siteIdentityListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
+
 	resp := make([]model.SiteIdentityResponse, 0, len(items))
 	for _, e := range items {
 		resp = append(resp, converter.ToSiteIdentityResponse(e))
 	}
-	return resp, nil
+	return resp, paging, nil
 }
 
 func (u *siteIdentityUsecase) GetPublic(entityType string) (*model.SiteIdentityResponse, error) {
@@ -70,9 +83,9 @@ func (u *siteIdentityUsecase) GetPublic(entityType string) (*model.SiteIdentityR
 	return &r, nil
 }
 
-func (u *siteIdentityUsecase) GetByID(id string) (*model.SiteIdentityResponse, error) {
+func (u *siteIdentityUsecase) GetByID(entityType string, id string) (*model.SiteIdentityResponse, error) {
 	var e entity.SiteIdentity
-	if err := u.repo.FindById(u.db, &e, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(u.db, &e, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("site identity not found")
 		}
@@ -82,10 +95,15 @@ func (u *siteIdentityUsecase) GetByID(id string) (*model.SiteIdentityResponse, e
 	return &r, nil
 }
 
-func (u *siteIdentityUsecase) Create(entityType string, req model.SiteIdentityRequest) (*model.SiteIdentityResponse, error) {
+func (u *siteIdentityUsecase) Create(ctx context.Context, entityType string, req model.SiteIdentityRequest) (*model.SiteIdentityResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 	e := entity.SiteIdentity{
 		ID:                  uuid.New().String(),
 		EntityType:          entityType,
@@ -96,27 +114,36 @@ func (u *siteIdentityUsecase) Create(entityType string, req model.SiteIdentityRe
 		PrimaryButtonLink:   req.PrimaryButtonLink,
 		SecondaryButtonText: req.SecondaryButtonText,
 		SecondaryButtonLink: req.SecondaryButtonLink,
+		MetaTitle:           req.MetaTitle,
+		MetaDescription:     req.MetaDescription,
+		CanonicalURL:        req.CanonicalURL,
+		OGImages:            util.ImageMap(req.OGImages),
+		OGImageVariant:      req.OGImageVariant,
 	}
-	if err := u.repo.Create(u.db, &e); err != nil {
+	if err := u.repo.Create(db, &e); err != nil {
 		return nil, err
 	}
 	r := converter.ToSiteIdentityResponse(e)
 	return &r, nil
 }
 
-func (u *siteIdentityUsecase) Update(id string, req model.SiteIdentityRequest) (*model.SiteIdentityResponse, error) {
+func (u *siteIdentityUsecase) Update(ctx context.Context, entityType string, id string, req model.SiteIdentityRequest) (*model.SiteIdentityResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
 	var e entity.SiteIdentity
-	if err := u.repo.FindById(u.db, &e, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &e, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("site identity not found")
 		}
 		return nil, err
 	}
 
-	e.EntityType = req.EntityType
 	e.SiteName = req.SiteName
 	e.LogoURL = req.LogoURL
 	e.Tagline = req.Tagline
@@ -124,21 +151,28 @@ func (u *siteIdentityUsecase) Update(id string, req model.SiteIdentityRequest) (
 	e.PrimaryButtonLink = req.PrimaryButtonLink
 	e.SecondaryButtonText = req.SecondaryButtonText
 	e.SecondaryButtonLink = req.SecondaryButtonLink
+	e.MetaTitle = req.MetaTitle
+	e.MetaDescription = req.MetaDescription
+	e.CanonicalURL = req.CanonicalURL
+	e.OGImages = util.ImageMap(req.OGImages)
+	e.OGImageVariant = req.OGImageVariant
 
-	if err := u.repo.Update(u.db, &e); err != nil {
+	if err := u.repo.Update(db, &e); err != nil {
 		return nil, err
 	}
 	r := converter.ToSiteIdentityResponse(e)
 	return &r, nil
 }
 
-func (u *siteIdentityUsecase) Delete(id string) error {
+func (u *siteIdentityUsecase) Delete(ctx context.Context, entityType string, id string) error {
+	db := u.db.WithContext(ctx)
+
 	var e entity.SiteIdentity
-	if err := u.repo.FindById(u.db, &e, id); err != nil {
+	if err := u.repo.FindByIdAndEntityType(db, &e, id, entityType); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return model.ErrNotFound("site identity not found")
 		}
 		return err
 	}
-	return u.repo.Delete(u.db, &e)
+	return u.repo.Delete(db, &e)
 }
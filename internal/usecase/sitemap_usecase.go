@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/util"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SitemapUsecase interface {
+	GetIndex() ([]model.SitemapIndexEntry, error)
+	GetSitemap(entityType string) (*model.Sitemap, error)
+}
+
+type sitemapUsecase struct {
+	db      *gorm.DB
+	siteURL *util.SiteURLUtil
+}
+
+func NewSitemapUsecase(db *gorm.DB, siteURL *util.SiteURLUtil) SitemapUsecase {
+	return &sitemapUsecase{
+		db:      db,
+		siteURL: siteURL,
+	}
+}
+
+type sitemapSlug struct {
+	Slug      string
+	UpdatedAt time.Time
+}
+
+type sitemapEntityUpdate struct {
+	EntityType string
+	UpdatedAt  time.Time
+}
+
+// GetIndex lists the entities with a public site, each with the time its content last changed.
+func (u *sitemapUsecase) GetIndex() ([]model.SitemapIndexEntry, error) {
+	now := time.Now()
+
+	var articles, categories []sitemapEntityUpdate
+	if err := u.db.Model(&entity.Article{}).
+		Select("entity_type, MAX(updated_at) AS updated_at").
+		Scopes(visibleAt(now)).
+		Group("entity_type").
+		Scan(&articles).Error; err != nil {
+		return nil, err
+	}
+	if err := u.db.Model(&entity.Category{}).
+		Select("entity_type, MAX(updated_at) AS updated_at").
+		Group("entity_type").
+		Scan(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	updated := make(map[string]time.Time)
+	for _, row := range append(articles, categories...) {
+		if row.UpdatedAt.After(updated[row.EntityType]) {
+			updated[row.EntityType] = row.UpdatedAt
+		}
+	}
+
+	entries := make([]model.SitemapIndexEntry, 0, len(model.EntityTypes))
+	for _, entityType := range model.EntityTypes {
+		if !u.siteURL.HasSite(entityType) {
+			continue
+		}
+		entry := model.SitemapIndexEntry{EntityType: entityType}
+		if t, ok := updated[entityType]; ok {
+			entry.LastMod = &t
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// GetSitemap lists the static pages of the entity's site, the categories with published articles and
+// the published articles themselves, most recently changed first.
+func (u *sitemapUsecase) GetSitemap(entityType string) (*model.Sitemap, error) {
+	if !u.siteURL.HasSite(entityType) {
+		return nil, model.ErrNotFound("sitemap not found")
+	}
+
+	now := time.Now()
+	visibleArticles := u.db.Model(&entity.Article{}).
+		Where("entity_type = ?", entityType).
+		Scopes(visibleAt(now)).
+		Session(&gorm.Session{})
+
+	var categories []sitemapSlug
+	if err := u.db.Model(&entity.Category{}).
+		Select("slug, updated_at").
+		Where("entity_type = ?", entityType).
+		Where("id IN (?)", visibleArticles.Select("category_id")).
+		Order("slug ASC").
+		Scan(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	pages := len(u.siteURL.Pages) + 1
+	limit := max(util.MaxSitemapURLs-pages-len(categories), 0)
+
+	var articles []sitemapSlug
+	if err := visibleArticles.
+		Select("slug, updated_at").
+		Order("updated_at DESC").
+		Limit(limit).
+		Scan(&articles).Error; err != nil {
+		return nil, err
+	}
+
+	sitemap := &model.Sitemap{
+		EntityType: entityType,
+		URLs:       make([]model.SitemapURL, 0, pages+len(categories)+len(articles)),
+	}
+
+	var newestArticle *time.Time
+	if len(articles) > 0 {
+		newestArticle = &articles[0].UpdatedAt
+	}
+	for _, page := range u.siteURL.Pages {
+		url := model.SitemapURL{Loc: u.siteURL.PageURL(entityType, page)}
+		if page == "/" {
+			url.LastMod = newestArticle
+		}
+		sitemap.URLs = append(sitemap.URLs, url)
+	}
+	sitemap.URLs = append(sitemap.URLs, model.SitemapURL{Loc: u.siteURL.PageURL(entityType, u.siteURL.ArticlePath), LastMod: newestArticle})
+
+	for _, c := range categories {
+		sitemap.URLs = append(sitemap.URLs, model.SitemapURL{Loc: u.siteURL.CategoryURL(entityType, c.Slug), LastMod: &c.UpdatedAt})
+	}
+	for _, a := range articles {
+		sitemap.URLs = append(sitemap.URLs, model.SitemapURL{Loc: u.siteURL.ArticleURL(entityType, a.Slug), LastMod: &a.UpdatedAt})
+	}
+
+	for _, url := range sitemap.URLs {
+		if url.LastMod != nil && url.LastMod.After(sitemap.Updated) {
+			sitemap.Updated = *url.LastMod
+		}
+	}
+	return sitemap, nil
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/model/converter"
+	"pura-agung-kertajaya-backend/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gosimple/slug"
+	"gorm.io/gorm"
+)
+
+type TagUsecase interface {
+	GetAll(entityType string, req *model.ListRequest) ([]model.TagResponse, *model.PageMetadata, error)
+	GetByID(entityType string, id string) (*model.TagResponse, error)
+	Create(ctx context.Context, entityType string, req model.CreateTagRequest) (*model.TagResponse, error)
+	Update(ctx context.Context, entityType string, id string, req model.UpdateTagRequest) (*model.TagResponse, error)
+	Delete(ctx context.Context, entityType string, id string) error
+}
+
+type tagUsecase struct {
+	db             *gorm.DB
+	repo           *repository.Repository[entity.Tag]
+	articleTagRepo *repository.ArticleTagRepository
+	validate       *validator.Validate
+}
+
+func NewTagUsecase(db *gorm.DB, validate *validator.Validate) TagUsecase {
+	return &tagUsecase{
+		db:             db,
+		repo:           &repository.Repository[entity.Tag]{DB: db},
+		articleTagRepo: &repository.ArticleTagRepository{},
+		validate:       validate,
+	}
+}
+
+var tagListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"name": "name",
+		"slug": "slug",
+	},
+	Search:      []string{"name"},
+	DefaultSort: "name",
+}
+
+func (u *tagUsecase) GetAll(entityType string, req *model.ListRequest) ([]model.TagResponse, *model.PageMetadata, error) {
+	var items []entity.Tag
+
+	query := u.db.Where("entity_type = ?", entityType)
+
+	paging, err := u.repo.FindPage(query, &items, tagListSpec, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return converter.ToTagResponses(items), paging, nil
+}
+
+func (u *tagUsecase) GetByID(entityType string, id string) (*model.TagResponse, error) {
+	var t entity.Tag
+	if err := u.repo.FindByIdAndEntityType(u.db, &t, id, entityType); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("tag not found")
+		}
+		return nil, err
+	}
+	r := converter.ToTagResponse(&t)
+	return &r, nil
+}
+
+func (u *tagUsecase) Create(ctx context.Context, entityType string, req model.CreateTagRequest) (*model.TagResponse, error) {
+	db := u.db.WithContext(ctx)
+
+	if err := u.validate.Struct(req); err != nil {
+		return nil, err
+	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
+
+	finalSlug, err := u.uniqueSlug(db, entityType, req.Name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	t := entity.Tag{
+		EntityType: entityType,
+		Name:       req.Name,
+		Slug:       finalSlug,
+	}
+
+	if err := u.repo.Create(db, &t); err != nil {
+		return nil, err
+	}
+
+	r := converter.ToTagResponse(&t)
+	return &r, nil
+}
+
+func (u *tagUsecase) Update(ctx context.Context, entityType string, id string, req model.UpdateTagRequest) (*model.TagResponse, error) {
+	db := u.db.WithContext(ctx)
+
+	if err := u.validate.Struct(req); err != nil {
+		return nil, err
+	}
+	if err := checkEntityScope(entityType, req.EntityType); err != nil {
+		return nil, err
+	}
+
+	var t entity.Tag
+	if err := u.repo.FindByIdAndEntityType(db, &t, id, entityType); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("tag not found")
+		}
+		return nil, err
+	}
+
+	if t.Name != req.Name {
+		finalSlug, err := u.uniqueSlug(db, entityType, req.Name, id)
+		if err != nil {
+			return nil, err
+		}
+		t.Slug = finalSlug
+	}
+
+	t.Name = req.Name
+
+	if err := u.repo.Update(db, &t); err != nil {
+		return nil, err
+	}
+
+	r := converter.ToTagResponse(&t)
+	return &r, nil
+}
+
+// Delete removes the tag from every article carrying it.
+func (u *tagUsecase) Delete(ctx context.Context, entityType string, id string) error {
+	tx := u.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var t entity.Tag
+	if err := u.repo.FindByIdAndEntityType(tx, &t, id, entityType); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrNotFound("tag not found")
+		}
+		return err
+	}
+
+	if err := u.articleTagRepo.DeleteByTagID(tx, id); err != nil {
+		return err
+	}
+	if err := u.repo.Delete(tx, &t); err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (u *tagUsecase) uniqueSlug(db *gorm.DB, entityType string, name string, ignoreID string) (string, error) {
+	baseSlug := slug.Make(name)
+	finalSlug := baseSlug
+	counter := 1
+	for {
+		var count int64
+		var err error
+		scoped := db.Where("entity_type = ?", entityType)
+		if ignoreID == "" {
+			count, err = u.repo.CountBySlug(scoped, finalSlug)
+		} else {
+			count, err = u.repo.CountBySlugIgnoringID(scoped, finalSlug, ignoreID)
+		}
+		if err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return finalSlug, nil
+		}
+		finalSlug = fmt.Sprintf("%s-%d", baseSlug, counter)
+		counter++
+	}
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"pura-agung-kertajaya-backend/internal/entity"
 	"pura-agung-kertajaya-backend/internal/model"
@@ -12,12 +13,12 @@ import (
 )
 
 type TestimonialUsecase interface {
-	GetAll() ([]model.TestimonialResponse, error)
-	GetPublic() ([]model.TestimonialResponse, error)
+	GetAll(req *model.ListRequest) ([]model.TestimonialResponse, *model.PageMetadata, error)
+	GetPublic(req *model.ListRequest) ([]model.TestimonialResponse, *model.PageMetadata, error)
 	GetByID(id string) (*model.TestimonialResponse, error)
-	Create(req model.TestimonialRequest) (*model.TestimonialResponse, error)
-	Update(id string, req model.TestimonialRequest) (*model.TestimonialResponse, error)
-	Delete(id string) error
+	Create(ctx context.Context, req model.TestimonialRequest) (*model.TestimonialResponse, error)
+	Update(ctx context.Context, id string, req model.TestimonialRequest) (*model.TestimonialResponse, error)
+	Delete(ctx context.Context, id string) error
 }
 
 type testimonialUsecase struct {
@@ -34,28 +35,45 @@ func NewTestimonialUsecase(db *gorm.DB, validate *validator.Validate) Testimonia
 	}
 }
 
-func (u *testimonialUsecase) GetAll() ([]model.TestimonialResponse, error) {
+var testimonialListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"name":        "name",
+		"rating":      "rating",
+		"order_index": "order_index",
+		"created_at":  "created_at",
+	},
+	Filters: map[string]string{
+		"is_active": "is_active",
+		"rating":    "rating",
+	},
+	Search:      []string{"name"},
+	DefaultSort: "order_index",
+}
+
+func (u *testimonialUsecase) GetAll(req *model.ListRequest) ([]model.TestimonialResponse, *model.PageMetadata, error) {
 	var testimonials []entity.Testimonial
 
-	query := u.db.Order("order_index ASC")
+	query := u.db
 
-	if err := u.repo.FindAll(query, &testimonials); err != nil {
-		return nil, err
+	paging, err := u.repo.FindPage(query, &testimonials, testimonialListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return converter.ToTestimonialResponses(testimonials), nil
+	return converter.ToTestimonialResponses(testimonials), paging, nil
 }
 
-func (u *testimonialUsecase) GetPublic() ([]model.TestimonialResponse, error) {
+func (u *testimonialUsecase) GetPublic(req *model.ListRequest) ([]model.TestimonialResponse, *model.PageMetadata, error) {
 	var testimonials []entity.Testimonial
 
-	query := u.db.Where("is_active = ?", true).Order("order_index ASC")
+	query := u.db.Where("is_active = ?", true)
 
-	if err := u.repo.FindAll(query, &testimonials); err != nil {
-		return nil, err
+	paging, err := u.repo.FindPage(query, &testimonials, testimonialListSpec, req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return converter.ToTestimonialResponses(testimonials), nil
+	return converter.ToTestimonialResponses(testimonials), paging, nil
 }
 
 func (u *testimonialUsecase) GetByID(id string) (*model.TestimonialResponse, error) {
@@ -71,7 +89,9 @@ func (u *testimonialUsecase) GetByID(id string) (*model.TestimonialResponse, err
 	return &response, nil
 }
 
-func (u *testimonialUsecase) Create(req model.TestimonialRequest) (*model.TestimonialResponse, error) {
+func (u *testimonialUsecase) Create(ctx context.Context, req model.TestimonialRequest) (*model.TestimonialResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
@@ -85,7 +105,7 @@ func (u *testimonialUsecase) Create(req model.TestimonialRequest) (*model.Testim
 		OrderIndex: req.OrderIndex,
 	}
 
-	if err := u.repo.Create(u.db, &t); err != nil {
+	if err := u.repo.Create(db, &t); err != nil {
 		return nil, err
 	}
 
@@ -93,13 +113,15 @@ func (u *testimonialUsecase) Create(req model.TestimonialRequest) (*model.Testim
 	return &response, nil
 }
 
-func (u *testimonialUsecase) Update(id string, req model.TestimonialRequest) (*model.TestimonialResponse, error) {
+func (u *testimonialUsecase) Update(ctx context.Context, id string, req model.TestimonialRequest) (*model.TestimonialResponse, error) {
+	db := u.db.WithContext(ctx)
+
 	if err := u.validate.Struct(req); err != nil {
 		return nil, err
 	}
 
 	var t entity.Testimonial
-	if err := u.repo.FindById(u.db, &t, id); err != nil {
+	if err := u.repo.FindById(db, &t, id); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, model.ErrNotFound("testimonial not found")
 		}
@@ -113,7 +135,7 @@ func (u *testimonialUsecase) Update(id string, req model.TestimonialRequest) (*m
 	t.IsActive = req.IsActive
 	t.OrderIndex = req.OrderIndex
 
-	if err := u.repo.Update(u.db, &t); err != nil {
+	if err := u.repo.Update(db, &t); err != nil {
 		return nil, err
 	}
 
@@ -121,13 +143,15 @@ func (u *testimonialUsecase) Update(id string, req model.TestimonialRequest) (*m
 	return &response, nil
 }
 
-func (u *testimonialUsecase) Delete(id string) error {
+func (u *testimonialUsecase) Delete(ctx context.Context, id string) error {
+	db := u.db.WithContext(ctx)
+
 	var t entity.Testimonial
-	if err := u.repo.FindById(u.db, &t, id); err != nil {
+	if err := u.repo.FindById(db, &t, id); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return model.ErrNotFound("testimonial not found")
 		}
 		return err
 	}
-	return u.repo.Delete(u.db, &t)
+	return u.repo.Delete(db, &t)
 }
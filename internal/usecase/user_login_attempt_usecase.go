@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/model/converter"
+)
+
+const defaultLoginAttemptLimit = 50
+
+// dummyPasswordHash is compared against when the email is unknown, see Login.
+var dummyPasswordHash = []byte("$2a$10$6SB8CBWTIRPSYDl7voE9LemhJ6exyfrjYnfXJ/.OebEExuxLlxavS")
+
+func (c *userUseCase) GetLoginAttempts(ctx context.Context, req *model.SearchLoginAttemptRequest) ([]model.LoginAttemptResponse, error) {
+	if err := c.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultLoginAttemptLimit
+	}
+
+	query := c.DB.WithContext(ctx).Order("created_at DESC").Limit(limit)
+
+	if req.Email != "" {
+		query = query.Where("email = ?", req.Email)
+	}
+	if req.UserID != "" {
+		query = query.Where("user_id = ?", req.UserID)
+	}
+	if req.IP != "" {
+		query = query.Where("ip = ?", req.IP)
+	}
+	if req.Result != "" {
+		query = query.Where("result = ?", req.Result)
+	}
+
+	var attempts []entity.LoginAttempt
+	if err := c.LoginAttemptRepository.FindAll(query, &attempts); err != nil {
+		return nil, err
+	}
+
+	return converter.ToLoginAttemptResponses(attempts), nil
+}
+
+// checkLockout refuses the attempt while the email is locked. The same answer is given for
+// emails that have no account, so a lockout never confirms that an account exists.
+func (c *userUseCase) checkLockout(ctx context.Context, attempt *entity.LoginAttempt) error {
+	lockedFor, err := c.LockoutUtil.LockedFor(ctx, attempt.Email)
+	if err != nil {
+		return err
+	}
+	if lockedFor == 0 {
+		return nil
+	}
+
+	attempt.Result = entity.LoginAttemptLocked
+	c.recordLoginAttempt(ctx, attempt)
+	return errTooManyLoginAttempts()
+}
+
+func (c *userUseCase) loginFailed(ctx context.Context, attempt *entity.LoginAttempt, result entity.LoginAttemptResult, message string) error {
+	attempt.Result = result
+	c.recordLoginAttempt(ctx, attempt)
+
+	lockout, err := c.LockoutUtil.RegisterFailure(ctx, attempt.Email)
+	if err != nil {
+		return err
+	}
+	if lockout > 0 {
+		return errTooManyLoginAttempts()
+	}
+
+	return model.ErrUnauthorized(message)
+}
+
+func (c *userUseCase) loginSucceeded(ctx context.Context, attempt *entity.LoginAttempt) error {
+	attempt.Result = entity.LoginAttemptSuccess
+	c.recordLoginAttempt(ctx, attempt)
+
+	return c.LockoutUtil.Reset(ctx, attempt.Email)
+}
+
+// recordLoginAttempt writes the history row outside of any login transaction.
+// A failure to record is logged but never blocks the login itself.
+func (c *userUseCase) recordLoginAttempt(ctx context.Context, attempt *entity.LoginAttempt) {
+	if err := c.LoginAttemptRepository.Create(c.DB.WithContext(ctx), attempt); err != nil {
+		c.LoginAttemptRepository.Log.WithError(err).WithField("email", attempt.Email).Warn("Failed to record login attempt")
+	}
+}
+
+func errTooManyLoginAttempts() error {
+	return model.ErrTooManyRequests("Too many failed login attempts, please try again later")
+}
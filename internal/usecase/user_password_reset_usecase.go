@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/util"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// passwordResetMailTimeout bounds the delivery of a reset email, which outlives the request.
+const passwordResetMailTimeout = time.Minute
+
+// ForgotPassword emails a reset link when the email belongs to an active account.
+// It succeeds either way so the endpoint cannot be used to discover accounts; the email is sent in
+// the background so that the response does not take longer for existing accounts either.
+func (c *userUseCase) ForgotPassword(ctx context.Context, req *model.ForgotPasswordRequest) error {
+	if err := c.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if !c.RecaptchaUtil.Verify(ctx, req.RecaptchaToken) {
+		return model.ErrForbidden("ReCAPTCHA verification failed")
+	}
+
+	var user entity.User
+	if err := c.UserRepository.FindByEmail(c.DB.WithContext(ctx), &user, req.Email); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if !user.IsActive {
+		return nil
+	}
+
+	token, err := c.TokenUtil.CreatePasswordResetToken(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), passwordResetMailTimeout)
+		defer cancel()
+
+		if err := c.MailUtil.SendPasswordReset(ctx, user.Email, user.Name, token, util.PasswordResetTTL); err != nil {
+			c.UserRepository.Log.WithError(err).WithField("user_id", user.ID).Error("Failed to send password reset email")
+		}
+	}()
+
+	return nil
+}
+
+func (c *userUseCase) CompletePasswordReset(ctx context.Context, req *model.CompletePasswordResetRequest) error {
+	if err := c.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	userID, err := c.TokenUtil.ConsumePasswordResetToken(ctx, req.Token)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidPasswordReset) {
+			return errInvalidPasswordReset()
+		}
+		return err
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var user entity.User
+	if err := c.UserRepository.FindById(tx, &user, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errInvalidPasswordReset()
+		}
+		return err
+	}
+
+	if !user.IsActive {
+		return errInvalidPasswordReset()
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.Password = string(hashed)
+
+	if err := c.UserRepository.Update(tx, &user); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	if err := c.TokenUtil.RevokeUserSessions(ctx, user.ID, ""); err != nil {
+		return err
+	}
+
+	return c.LockoutUtil.Reset(ctx, user.Email)
+}
+
+func errInvalidPasswordReset() error {
+	return model.ErrBadRequest("Password reset link is invalid or has expired")
+}
@@ -0,0 +1,330 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/model/converter"
+	"pura-agung-kertajaya-backend/internal/util"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func (c *userUseCase) VerifyTwoFactorLogin(ctx context.Context, req *model.VerifyTwoFactorLoginRequest) (*model.UserResponse, *model.TokenPair, error) {
+	if err := c.Validate.Struct(req); err != nil {
+		return nil, nil, err
+	}
+
+	auth, err := c.TokenUtil.GetLoginChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidLoginChallenge) {
+			return nil, nil, model.ErrUnauthorized("Login challenge is invalid or has expired")
+		}
+		return nil, nil, err
+	}
+
+	attempt := &entity.LoginAttempt{
+		Email:     auth.Email,
+		UserID:    &auth.ID,
+		IP:        req.IP,
+		UserAgent: req.UserAgent,
+	}
+
+	if err := c.checkLockout(ctx, attempt); err != nil {
+		_ = c.TokenUtil.DeleteLoginChallenge(ctx, req.ChallengeToken)
+		return nil, nil, err
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var user entity.User
+	if err := c.UserRepository.FindById(tx, &user, auth.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			_ = c.TokenUtil.DeleteLoginChallenge(ctx, req.ChallengeToken)
+			return nil, nil, model.ErrUnauthorized("Login challenge is invalid or has expired")
+		}
+		return nil, nil, err
+	}
+
+	if !user.IsActive || !user.TwoFactorEnabled {
+		_ = c.TokenUtil.DeleteLoginChallenge(ctx, req.ChallengeToken)
+		return nil, nil, model.ErrUnauthorized("Login challenge is invalid or has expired")
+	}
+
+	valid, err := c.verifySecondFactor(ctx, tx, &user, req.Code)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !valid {
+		if err := c.TokenUtil.FailLoginChallenge(ctx, req.ChallengeToken); err != nil {
+			return nil, nil, err
+		}
+
+		err := c.loginFailed(ctx, attempt, entity.LoginAttemptInvalidSecondFactor, "Invalid authentication code")
+		var e *model.ResponseError
+		if errors.As(err, &e) && e.Code == http.StatusTooManyRequests {
+			_ = c.TokenUtil.DeleteLoginChallenge(ctx, req.ChallengeToken)
+		}
+		return nil, nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, nil, err
+	}
+
+	if err := c.TokenUtil.DeleteLoginChallenge(ctx, req.ChallengeToken); err != nil {
+		return nil, nil, err
+	}
+
+	token, err := c.TokenUtil.CreateToken(ctx, &model.Auth{
+		ID:    user.ID,
+		Role:  user.Role,
+		Email: user.Email,
+	}, &model.SessionDevice{
+		IP:        req.IP,
+		UserAgent: req.UserAgent,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := c.loginSucceeded(ctx, attempt); err != nil {
+		return nil, nil, err
+	}
+
+	return converter.UserToResponse(&user), token, nil
+}
+
+func (c *userUseCase) SetupTwoFactor(ctx context.Context, userID string) (*model.TwoFactorSetupResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var user entity.User
+	if err := c.UserRepository.FindById(tx, &user, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("user not found")
+		}
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, model.ErrConflict("two-factor authentication is already enabled")
+	}
+
+	secret, err := c.TOTPUtil.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	user.TwoFactorSecret = secret
+
+	if err := c.UserRepository.Update(tx, &user); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return &model.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: c.TOTPUtil.ProvisioningURI(user.Email, secret),
+	}, nil
+}
+
+func (c *userUseCase) EnableTwoFactor(ctx context.Context, userID string, req *model.TwoFactorCodeRequest) (*model.RecoveryCodesResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	var user entity.User
+	if err := c.UserRepository.FindById(tx, &user, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("user not found")
+		}
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, model.ErrConflict("two-factor authentication is already enabled")
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, model.ErrBadRequest("two-factor setup has not been started")
+	}
+
+	valid, err := c.TOTPUtil.Validate(ctx, user.ID, user.TwoFactorSecret, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, model.ErrBadRequest("Invalid authentication code")
+	}
+
+	user.TwoFactorEnabled = true
+
+	if err := c.UserRepository.Update(tx, &user); err != nil {
+		return nil, err
+	}
+
+	codes, err := c.replaceRecoveryCodes(tx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return &model.RecoveryCodesResponse{Codes: codes}, nil
+}
+
+func (c *userUseCase) DisableTwoFactor(ctx context.Context, userID string, req *model.DisableTwoFactorRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	var user entity.User
+	if err := c.UserRepository.FindById(tx, &user, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrNotFound("user not found")
+		}
+		return err
+	}
+
+	if !user.TwoFactorEnabled {
+		return model.ErrBadRequest("two-factor authentication is not enabled")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return model.ErrBadRequest("Invalid password or authentication code")
+	}
+
+	valid, err := c.verifySecondFactor(ctx, tx, &user, req.Code)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return model.ErrBadRequest("Invalid password or authentication code")
+	}
+
+	if err := c.clearTwoFactor(tx, &user); err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (c *userUseCase) RegenerateRecoveryCodes(ctx context.Context, userID string, req *model.TwoFactorCodeRequest) (*model.RecoveryCodesResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	var user entity.User
+	if err := c.UserRepository.FindById(tx, &user, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("user not found")
+		}
+		return nil, err
+	}
+
+	if !user.TwoFactorEnabled {
+		return nil, model.ErrBadRequest("two-factor authentication is not enabled")
+	}
+
+	valid, err := c.TOTPUtil.Validate(ctx, user.ID, user.TwoFactorSecret, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, model.ErrBadRequest("Invalid authentication code")
+	}
+
+	codes, err := c.replaceRecoveryCodes(tx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return &model.RecoveryCodesResponse{Codes: codes}, nil
+}
+
+func (c *userUseCase) ResetTwoFactor(ctx context.Context, id string) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var user entity.User
+	if err := c.UserRepository.FindById(tx, &user, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrNotFound("user not found")
+		}
+		return err
+	}
+
+	if err := c.clearTwoFactor(tx, &user); err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// verifySecondFactor accepts either a current TOTP code or one of the user's unused recovery codes.
+func (c *userUseCase) verifySecondFactor(ctx context.Context, tx *gorm.DB, user *entity.User, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+
+	if len(code) == util.TOTPDigits && strings.Trim(code, "0123456789") == "" {
+		return c.TOTPUtil.Validate(ctx, user.ID, user.TwoFactorSecret, code)
+	}
+
+	return c.RecoveryCodeRepository.MarkUsed(tx, user.ID, util.HashRecoveryCode(code), time.Now())
+}
+
+func (c *userUseCase) replaceRecoveryCodes(tx *gorm.DB, userID string) ([]string, error) {
+	if err := c.RecoveryCodeRepository.DeleteByUserID(tx, userID); err != nil {
+		return nil, err
+	}
+
+	codes, err := util.GenerateRecoveryCodes(util.RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range codes {
+		recoveryCode := entity.UserRecoveryCode{
+			UserID:   userID,
+			CodeHash: util.HashRecoveryCode(code),
+		}
+		if err := c.RecoveryCodeRepository.Create(tx, &recoveryCode); err != nil {
+			return nil, err
+		}
+	}
+
+	return codes, nil
+}
+
+func (c *userUseCase) clearTwoFactor(tx *gorm.DB, user *entity.User) error {
+	user.TwoFactorEnabled = false
+	user.TwoFactorSecret = ""
+
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		return err
+	}
+
+	return c.RecoveryCodeRepository.DeleteByUserID(tx, user.ID)
+}
@@ -15,84 +15,248 @@ import (
 )
 
 type UserUseCase interface {
-	Login(ctx context.Context, req *model.LoginUserRequest) (*model.UserResponse, string, error)
+	Login(ctx context.Context, req *model.LoginUserRequest) (*model.LoginResult, error)
+	VerifyTwoFactorLogin(ctx context.Context, req *model.VerifyTwoFactorLoginRequest) (*model.UserResponse, *model.TokenPair, error)
+	Refresh(ctx context.Context, req *model.RefreshTokenRequest) (*model.UserResponse, *model.TokenPair, error)
 	Current(ctx context.Context, userID string) (*model.UserResponse, error)
 	UpdateProfile(ctx context.Context, userID string, req *model.UpdateUserRequest) (*model.UserResponse, error)
-	Logout(ctx context.Context, tokenString string) error
+	Logout(ctx context.Context, req *model.LogoutRequest) error
+	ForgotPassword(ctx context.Context, req *model.ForgotPasswordRequest) error
+	CompletePasswordReset(ctx context.Context, req *model.CompletePasswordResetRequest) error
+	ListSessions(ctx context.Context, userID string, currentSessionID string) ([]model.SessionResponse, error)
+	RevokeSession(ctx context.Context, userID string, sessionID string) error
+	RevokeAllSessions(ctx context.Context, userID string, exceptSessionID string) error
+
+	GetAll(ctx context.Context, req *model.ListRequest) ([]model.UserResponse, *model.PageMetadata, error)
+	GetByID(ctx context.Context, id string) (*model.UserResponse, error)
+	Create(ctx context.Context, req *model.CreateUserRequest) (*model.UserResponse, error)
+	UpdateRole(ctx context.Context, actorID string, id string, req *model.UpdateUserRoleRequest) (*model.UserResponse, error)
+	UpdateStatus(ctx context.Context, actorID string, id string, req *model.UpdateUserStatusRequest) (*model.UserResponse, error)
+	ResetPassword(ctx context.Context, id string, req *model.ResetUserPasswordRequest) error
+
+	SetupTwoFactor(ctx context.Context, userID string) (*model.TwoFactorSetupResponse, error)
+	EnableTwoFactor(ctx context.Context, userID string, req *model.TwoFactorCodeRequest) (*model.RecoveryCodesResponse, error)
+	DisableTwoFactor(ctx context.Context, userID string, req *model.DisableTwoFactorRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, userID string, req *model.TwoFactorCodeRequest) (*model.RecoveryCodesResponse, error)
+	ResetTwoFactor(ctx context.Context, id string) error
+
+	GetLoginAttempts(ctx context.Context, req *model.SearchLoginAttemptRequest) ([]model.LoginAttemptResponse, error)
 }
 
 type userUseCase struct {
-	DB             *gorm.DB
-	Validate       *validator.Validate
-	UserRepository *repository.UserRepository
-	TokenUtil      *util.TokenUtil
-	RecaptchaUtil  *util.RecaptchaUtil
+	DB                     *gorm.DB
+	Validate               *validator.Validate
+	UserRepository         *repository.UserRepository
+	RecoveryCodeRepository *repository.UserRecoveryCodeRepository
+	LoginAttemptRepository *repository.LoginAttemptRepository
+	AssignmentRepository   *repository.UserRoleAssignmentRepository
+	TokenUtil              *util.TokenUtil
+	RecaptchaUtil          *util.RecaptchaUtil
+	TOTPUtil               *util.TOTPUtil
+	LockoutUtil            *util.LockoutUtil
+	MailUtil               *util.MailUtil
 }
 
 func NewUserUseCase(
 	db *gorm.DB,
 	validate *validator.Validate,
 	userRepository *repository.UserRepository,
+	recoveryCodeRepository *repository.UserRecoveryCodeRepository,
+	loginAttemptRepository *repository.LoginAttemptRepository,
+	assignmentRepository *repository.UserRoleAssignmentRepository,
 	tokenUtil *util.TokenUtil,
 	recaptchaUtil *util.RecaptchaUtil,
+	totpUtil *util.TOTPUtil,
+	lockoutUtil *util.LockoutUtil,
+	mailUtil *util.MailUtil,
 ) UserUseCase {
 	return &userUseCase{
-		DB:             db,
-		Validate:       validate,
-		UserRepository: userRepository,
-		TokenUtil:      tokenUtil,
-		RecaptchaUtil:  recaptchaUtil,
+		DB:                     db,
+		Validate:               validate,
+		UserRepository:         userRepository,
+		RecoveryCodeRepository: recoveryCodeRepository,
+		LoginAttemptRepository: loginAttemptRepository,
+		AssignmentRepository:   assignmentRepository,
+		TokenUtil:              tokenUtil,
+		RecaptchaUtil:          recaptchaUtil,
+		TOTPUtil:               totpUtil,
+		LockoutUtil:            lockoutUtil,
+		MailUtil:               mailUtil,
 	}
 }
 
-func (c *userUseCase) Login(ctx context.Context, req *model.LoginUserRequest) (*model.UserResponse, string, error) {
-	tx := c.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
-
+func (c *userUseCase) Login(ctx context.Context, req *model.LoginUserRequest) (*model.LoginResult, error) {
 	if err := c.Validate.Struct(req); err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
 	if !c.RecaptchaUtil.Verify(ctx, req.RecaptchaToken) {
-		return nil, "", model.ErrForbidden("ReCAPTCHA verification failed")
+		return nil, model.ErrForbidden("ReCAPTCHA verification failed")
+	}
+
+	attempt := &entity.LoginAttempt{
+		Email:     req.Email,
+		IP:        req.IP,
+		UserAgent: req.UserAgent,
+	}
+
+	if err := c.checkLockout(ctx, attempt); err != nil {
+		return nil, err
 	}
 
 	var user entity.User
-	if err := c.UserRepository.FindByEmail(tx, &user, req.Email); err != nil {
-		return nil, "", model.ErrUnauthorized("Invalid email or password")
+	if err := c.UserRepository.FindByEmail(c.DB.WithContext(ctx), &user, req.Email); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		// Spend the same bcrypt time as for a real account so timing does not reveal which emails exist.
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(req.Password))
+		return nil, c.loginFailed(ctx, attempt, entity.LoginAttemptInvalidCredentials, "Invalid email or password")
 	}
+	attempt.UserID = &user.ID
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return nil, "", model.ErrUnauthorized("Invalid email or password")
+		return nil, c.loginFailed(ctx, attempt, entity.LoginAttemptInvalidCredentials, "Invalid email or password")
 	}
 
-	token, _, err := c.TokenUtil.CreateToken(ctx, &model.Auth{
+	if !user.IsActive {
+		attempt.Result = entity.LoginAttemptDisabled
+		c.recordLoginAttempt(ctx, attempt)
+		return nil, model.ErrForbidden("Account is disabled")
+	}
+
+	if user.TwoFactorEnabled {
+		challenge, err := c.TokenUtil.CreateLoginChallenge(ctx, &model.Auth{
+			ID:    user.ID,
+			Role:  user.Role,
+			Email: user.Email,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &model.LoginResult{
+			Challenge: &model.TwoFactorChallengeResponse{
+				TwoFactorRequired: true,
+				ChallengeToken:    challenge,
+				ExpiresIn:         int(util.LoginChallengeTTL.Seconds()),
+			},
+		}, nil
+	}
+
+	token, err := c.TokenUtil.CreateToken(ctx, &model.Auth{
 		ID:    user.ID,
 		Role:  user.Role,
 		Email: req.Email,
+	}, &model.SessionDevice{
+		IP:        req.IP,
+		UserAgent: req.UserAgent,
 	})
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		return nil, "", err
+	if err := c.loginSucceeded(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	return &model.LoginResult{
+		User:  converter.UserToResponse(&user),
+		Token: token,
+	}, nil
+}
+
+func (c *userUseCase) Refresh(ctx context.Context, req *model.RefreshTokenRequest) (*model.UserResponse, *model.TokenPair, error) {
+	auth, err := c.TokenUtil.ConsumeRefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, util.ErrRefreshTokenReused) {
+			return nil, nil, model.ErrUnauthorized("Session has been revoked, please log in again")
+		}
+		if errors.Is(err, util.ErrInvalidRefreshToken) {
+			return nil, nil, model.ErrUnauthorized("Invalid or expired refresh token")
+		}
+		return nil, nil, err
+	}
+
+	var user entity.User
+	if err := c.UserRepository.FindById(c.DB.WithContext(ctx), &user, auth.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			_ = c.TokenUtil.RevokeFamily(ctx, auth.FamilyID)
+			return nil, nil, model.ErrUnauthorized("Invalid or expired refresh token")
+		}
+		return nil, nil, err
+	}
+
+	if !user.IsActive {
+		_ = c.TokenUtil.RevokeFamily(ctx, auth.FamilyID)
+		return nil, nil, model.ErrForbidden("Account is disabled")
+	}
+
+	token, err := c.TokenUtil.IssueToken(ctx, &model.Auth{
+		ID:       user.ID,
+		Role:     user.Role,
+		Email:    user.Email,
+		FamilyID: auth.FamilyID,
+	}, &model.SessionDevice{
+		IP:        req.IP,
+		UserAgent: req.UserAgent,
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
 	return converter.UserToResponse(&user), token, nil
 }
 
-func (c *userUseCase) Logout(ctx context.Context, tokenString string) error {
-	_, jti, err := c.TokenUtil.ParseToken(ctx, tokenString)
+// Logout revokes the session family of the refresh token, or of the access token when there is no
+// refresh token.
+func (c *userUseCase) Logout(ctx context.Context, req *model.LogoutRequest) error {
+	if req.RefreshToken != "" {
+		err := c.TokenUtil.RevokeRefreshFamily(ctx, req.RefreshToken)
+		if errors.Is(err, util.ErrInvalidRefreshToken) {
+			return model.ErrUnauthorized("Invalid or expired refresh token")
+		}
+		return err
+	}
+
+	auth, jti, err := c.TokenUtil.ParseToken(ctx, req.AccessToken)
 	if err != nil {
 		return model.ErrUnauthorized("Invalid or expired token")
 	}
 
-	if err := c.TokenUtil.RevokeToken(ctx, jti); err != nil {
+	if auth.FamilyID == "" {
+		return c.TokenUtil.RevokeToken(ctx, jti)
+	}
+
+	return c.TokenUtil.RevokeFamily(ctx, auth.FamilyID)
+}
+
+func (c *userUseCase) ListSessions(ctx context.Context, userID string, currentSessionID string) ([]model.SessionResponse, error) {
+	sessions, err := c.TokenUtil.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
+	}
+	return sessions, nil
+}
+
+func (c *userUseCase) RevokeSession(ctx context.Context, userID string, sessionID string) error {
+	owned, err := c.TokenUtil.HasSession(ctx, userID, sessionID)
+	if err != nil {
 		return err
 	}
+	if !owned {
+		return model.ErrNotFound("session not found")
+	}
+
+	return c.TokenUtil.RevokeFamily(ctx, sessionID)
+}
 
-	return nil
+func (c *userUseCase) RevokeAllSessions(ctx context.Context, userID string, exceptSessionID string) error {
+	return c.TokenUtil.RevokeUserSessions(ctx, userID, exceptSessionID)
 }
 
 func (c *userUseCase) Current(ctx context.Context, userID string) (*model.UserResponse, error) {
@@ -142,5 +306,227 @@ func (c *userUseCase) UpdateProfile(ctx context.Context, userID string, req *mod
 		return nil, err
 	}
 
+	if req.Password != "" {
+		if err := c.TokenUtil.RevokeUserSessions(ctx, user.ID, req.SessionID); err != nil {
+			return nil, err
+		}
+	}
+
 	return converter.UserToResponse(&user), nil
 }
+
+var userListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"name":       "name",
+		"email":      "email",
+		"role":       "role",
+		"created_at": "created_at",
+	},
+	Filters: map[string]string{
+		"role":      "role",
+		"is_active": "is_active",
+	},
+	Search:      []string{"name", "email"},
+	DefaultSort: "role,name",
+}
+
+func (c *userUseCase) GetAll(ctx context.Context, req *model.ListRequest) ([]model.UserResponse, *model.PageMetadata, error) {
+	var users []entity.User
+
+	paging, err := c.UserRepository.FindPage(c.DB.WithContext(ctx), &users, userListSpec, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	return converter.UserToResponses(users), paging, nil
+}
+
+func (c *userUseCase) GetByID(ctx context.Context, id string) (*model.UserResponse, error) {
+	var user entity.User
+	if err := c.UserRepository.FindById(c.DB.WithContext(ctx), &user, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("user not found")
+		}
+		return nil, err
+	}
+	return converter.UserToResponse(&user), nil
+}
+
+func (c *userUseCase) Create(ctx context.Context, req *model.CreateUserRequest) (*model.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	total, err := c.UserRepository.CountByEmail(tx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if total > 0 {
+		return nil, model.ErrConflict("email already registered")
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := entity.User{
+		Name:     req.Name,
+		Email:    req.Email,
+		Password: string(hashed),
+		Role:     req.Role,
+		IsActive: true,
+	}
+
+	if err := c.UserRepository.Create(tx, &user); err != nil {
+		return nil, err
+	}
+
+	if err := c.assignDefaultRole(tx, &user); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return converter.UserToResponse(&user), nil
+}
+
+func (c *userUseCase) UpdateRole(ctx context.Context, actorID string, id string, req *model.UpdateUserRoleRequest) (*model.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	if actorID == id && req.Role != "super" {
+		return nil, model.ErrBadRequest("you cannot remove your own super role")
+	}
+
+	var user entity.User
+	if err := c.UserRepository.FindById(tx, &user, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("user not found")
+		}
+		return nil, err
+	}
+
+	previousRole := user.Role
+	roleChanged := previousRole != req.Role
+	user.Role = req.Role
+
+	if err := c.UserRepository.Update(tx, &user); err != nil {
+		return nil, err
+	}
+
+	if roleChanged {
+		if err := c.revokeDefaultRole(tx, user.ID, previousRole); err != nil {
+			return nil, err
+		}
+		if err := c.assignDefaultRole(tx, &user); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	if roleChanged {
+		if err := c.TokenUtil.RevokeUserSessions(ctx, user.ID, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return converter.UserToResponse(&user), nil
+}
+
+func (c *userUseCase) UpdateStatus(ctx context.Context, actorID string, id string, req *model.UpdateUserStatusRequest) (*model.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if actorID == id && !req.IsActive {
+		return nil, model.ErrBadRequest("you cannot disable your own account")
+	}
+
+	var user entity.User
+	if err := c.UserRepository.FindById(tx, &user, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotFound("user not found")
+		}
+		return nil, err
+	}
+
+	user.IsActive = req.IsActive
+
+	if err := c.UserRepository.Update(tx, &user); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		if err := c.TokenUtil.RevokeUserSessions(ctx, user.ID, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return converter.UserToResponse(&user), nil
+}
+
+func (c *userUseCase) ResetPassword(ctx context.Context, id string, req *model.ResetUserPasswordRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	var user entity.User
+	if err := c.UserRepository.FindById(tx, &user, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrNotFound("user not found")
+		}
+		return err
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.Password = string(hashed)
+
+	if err := c.UserRepository.Update(tx, &user); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	return c.TokenUtil.RevokeUserSessions(ctx, user.ID, "")
+}
+
+// assignDefaultRole gives an entity admin the editor role on the entity named by their account role,
+// so accounts created through the user endpoints keep working without a separate role assignment.
+func (c *userUseCase) assignDefaultRole(tx *gorm.DB, user *entity.User) error {
+	if !model.IsEntityType(user.Role) {
+		return nil
+	}
+	return c.AssignmentRepository.AssignByRoleName(tx, user.ID, entity.RoleEditor, user.Role)
+}
+
+// revokeDefaultRole takes back the editor role assignDefaultRole gave for a former account role, so a
+// moved or promoted admin loses the rights on their old entity.
+func (c *userUseCase) revokeDefaultRole(tx *gorm.DB, userID string, role string) error {
+	if !model.IsEntityType(role) {
+		return nil
+	}
+	return c.AssignmentRepository.RevokeByRoleName(tx, userID, entity.RoleEditor, role)
+}
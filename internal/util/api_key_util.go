@@ -0,0 +1,34 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	APIKeyPrefix       = "pak_"
+	APIKeyDisplayChars = 12
+)
+
+// GenerateAPIKey returns a new random key and the prefix shown in the CMS to identify it.
+func GenerateAPIKey() (key string, prefix string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	key = APIKeyPrefix + strings.ToLower(base32NoPadding.EncodeToString(b))
+	return key, key[:APIKeyDisplayChars], nil
+}
+
+// HashAPIKey hashes a key for storage and lookup. Keys are long random strings,
+// so a fast hash is enough and keeps authentication cheap on every request.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
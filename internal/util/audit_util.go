@@ -0,0 +1,21 @@
+package util
+
+import (
+	"context"
+	"pura-agung-kertajaya-backend/internal/model"
+)
+
+type auditActorKey struct{}
+
+func WithAuditActor(ctx context.Context, actor *model.AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// AuditActorFromContext returns nil for work that was not started by an authenticated request.
+func AuditActorFromContext(ctx context.Context) *model.AuditActor {
+	if ctx == nil {
+		return nil
+	}
+	actor, _ := ctx.Value(auditActorKey{}).(*model.AuditActor)
+	return actor
+}
@@ -0,0 +1,176 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	ContentFormatHTML     = "html"
+	ContentFormatMarkdown = "markdown"
+	ContentFormatBlocks   = "blocks"
+)
+
+// readingWordsPerMinute is the reading speed the reading time is estimated with.
+const readingWordsPerMinute = 200
+
+// ContentBlock is one block of an article body in the blocks format. Text is inline Markdown.
+type ContentBlock struct {
+	Type    string `json:"type"`
+	Text    string `json:"text,omitempty"`
+	Level   int    `json:"level,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Alt     string `json:"alt,omitempty"`
+	Caption string `json:"caption,omitempty"`
+	Cite    string `json:"cite,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// RenderedContent is an article body rendered to sanitized HTML, with its plain text for search.
+type RenderedContent struct {
+	HTML               string
+	Text               string
+	WordCount          int
+	ReadingTimeMinutes int
+}
+
+// RenderContent renders an article body to sanitized HTML and measures it. Image keys of blocks are
+// made absolute with assetURL. An empty format is taken as HTML.
+func RenderContent(format string, source string, assetURL func(string) string) (*RenderedContent, error) {
+	var rendered string
+	switch format {
+	case "", ContentFormatHTML:
+		rendered = source
+	case ContentFormatMarkdown:
+		rendered = RenderMarkdown(source)
+	case ContentFormatBlocks:
+		var blocks []ContentBlock
+		if err := json.Unmarshal([]byte(source), &blocks); err != nil {
+			return nil, errors.New("content must be a JSON array of blocks")
+		}
+		var err error
+		if rendered, err = RenderBlocks(blocks, assetURL); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown content format %q", format)
+	}
+
+	safe := SanitizeHTML(rendered)
+	text := PlainText(safe)
+	words := len(strings.Fields(text))
+	minutes := 0
+	if words > 0 {
+		minutes = (words + readingWordsPerMinute - 1) / readingWordsPerMinute
+	}
+	return &RenderedContent{HTML: safe, Text: text, WordCount: words, ReadingTimeMinutes: minutes}, nil
+}
+
+// RenderBlocks renders paragraph, heading, image, quote and embed blocks. It does not sanitize.
+func RenderBlocks(blocks []ContentBlock, assetURL func(string) string) (string, error) {
+	var b strings.Builder
+	for i, block := range blocks {
+		switch block.Type {
+		case "paragraph":
+			b.WriteString("<p>" + renderInline(block.Text) + "</p>\n")
+
+		case "heading":
+			level := block.Level
+			if level == 0 {
+				level = 2
+			}
+			if level < 1 || level > 6 {
+				return "", fmt.Errorf("block %d: heading level must be between 1 and 6", i+1)
+			}
+			fmt.Fprintf(&b, "<h%d>%s</h%d>\n", level, renderInline(block.Text), level)
+
+		case "image":
+			if block.Key == "" {
+				return "", fmt.Errorf("block %d: image needs the key of an uploaded file", i+1)
+			}
+			b.WriteString(`<figure><img src="` + html.EscapeString(assetURL(block.Key)) + `" alt="` + html.EscapeString(block.Alt) + `" loading="lazy">`)
+			if block.Caption != "" {
+				b.WriteString("<figcaption>" + renderInline(block.Caption) + "</figcaption>")
+			}
+			b.WriteString("</figure>\n")
+
+		case "quote":
+			b.WriteString("<blockquote><p>" + renderInline(block.Text) + "</p>")
+			if block.Cite != "" {
+				b.WriteString("<cite>" + html.EscapeString(block.Cite) + "</cite>")
+			}
+			b.WriteString("</blockquote>\n")
+
+		case "embed":
+			src, ok := EmbedPlayerURL(block.URL)
+			if !ok {
+				return "", fmt.Errorf("block %d: only YouTube, Vimeo and Google Maps can be embedded", i+1)
+			}
+			b.WriteString(`<figure><iframe src="` + html.EscapeString(src) + `" title="` + html.EscapeString(block.Caption) + `" loading="lazy" allowfullscreen></iframe>`)
+			if block.Caption != "" {
+				b.WriteString("<figcaption>" + renderInline(block.Caption) + "</figcaption>")
+			}
+			b.WriteString("</figure>\n")
+
+		default:
+			return "", fmt.Errorf("block %d: unknown block type %q", i+1, block.Type)
+		}
+	}
+	return b.String(), nil
+}
+
+// EmbedPlayerURL turns the address of a YouTube video, a Vimeo video or a Google Maps embed into the
+// address of its embeddable player.
+func EmbedPlayerURL(raw string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", false
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	path := strings.Trim(u.Path, "/")
+
+	var id string
+	switch host {
+	case "youtube.com", "m.youtube.com", "youtube-nocookie.com":
+		switch {
+		case path == "watch":
+			id = u.Query().Get("v")
+		case strings.HasPrefix(path, "embed/"), strings.HasPrefix(path, "shorts/"), strings.HasPrefix(path, "live/"):
+			id = path[strings.Index(path, "/")+1:]
+		}
+		if validEmbedID(id) {
+			return "https://www.youtube-nocookie.com/embed/" + id, true
+		}
+	case "youtu.be":
+		if validEmbedID(path) {
+			return "https://www.youtube-nocookie.com/embed/" + path, true
+		}
+	case "vimeo.com", "player.vimeo.com":
+		id = path[strings.LastIndex(path, "/")+1:]
+		if validEmbedID(id) {
+			return "https://player.vimeo.com/video/" + id, true
+		}
+	case "google.com":
+		if path == "maps/embed" && u.RawQuery != "" {
+			return "https://www.google.com/maps/embed?" + u.RawQuery, true
+		}
+	}
+	return "", false
+}
+
+func validEmbedID(id string) bool {
+	if id == "" || utf8.RuneCountInString(id) > 64 {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
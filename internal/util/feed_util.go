@@ -0,0 +1,274 @@
+package util
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"mime"
+	"path"
+	"pura-agung-kertajaya-backend/internal/model"
+	"strings"
+	"time"
+)
+
+const (
+	ContentTypeRSS      = "application/rss+xml; charset=utf-8"
+	ContentTypeAtom     = "application/atom+xml; charset=utf-8"
+	ContentTypeJSONFeed = "application/feed+json; charset=utf-8"
+)
+
+type rssFeed struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	AtomNS    string     `xml:"xmlns:atom,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	DCNS      string     `xml:"xmlns:dc,attr"`
+	MediaNS   string     `xml:"xmlns:media,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language,omitempty"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Self          atomLink  `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Creator     string        `xml:"dc:creator,omitempty"`
+	Categories  []string      `xml:"category"`
+	Description string        `xml:"description"`
+	Content     string        `xml:"content:encoded,omitempty"`
+	Media       *rssMedia     `xml:"media:content,omitempty"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssMedia struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr,omitempty"`
+	Medium string `xml:"medium,attr"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length int    `xml:"length,attr"`
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Lang     string      `xml:"xml:lang,attr,omitempty"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published,omitempty"`
+	Author     atomPerson     `xml:"author"`
+	Links      []atomLink     `xml:"link"`
+	Categories []atomCategory `xml:"category"`
+	Summary    *atomText      `xml:"summary,omitempty"`
+	Content    *atomText      `xml:"content,omitempty"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	FeedURL     string         `json:"feed_url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Language    string         `json:"language,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url,omitempty"`
+	Title         string           `json:"title,omitempty"`
+	ContentHTML   string           `json:"content_html,omitempty"`
+	Summary       string           `json:"summary,omitempty"`
+	Image         string           `json:"image,omitempty"`
+	DatePublished string           `json:"date_published,omitempty"`
+	DateModified  string           `json:"date_modified,omitempty"`
+	Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// RenderRSS renders the feed as RSS 2.0; selfURL is the address the feed is served from.
+func RenderRSS(feed *model.Feed, selfURL string) ([]byte, error) {
+	items := make([]rssItem, 0, len(feed.Items))
+	for _, it := range feed.Items {
+		item := rssItem{
+			Title:       it.Title,
+			Link:        it.Link,
+			GUID:        rssGUID{IsPermaLink: false, Value: it.ID},
+			PubDate:     it.Published.UTC().Format(time.RFC1123Z),
+			Creator:     it.Author,
+			Categories:  it.Categories,
+			Description: it.Summary,
+			Content:     it.ContentHTML,
+		}
+		if it.ImageURL != "" {
+			imageType := imageContentType(it.ImageURL)
+			item.Media = &rssMedia{URL: it.ImageURL, Type: imageType, Medium: "image"}
+			item.Enclosure = &rssEnclosure{URL: it.ImageURL, Type: imageType}
+		}
+		items = append(items, item)
+	}
+
+	doc := rssFeed{
+		Version:   "2.0",
+		AtomNS:    "http://www.w3.org/2005/Atom",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		DCNS:      "http://purl.org/dc/elements/1.1/",
+		MediaNS:   "http://search.yahoo.com/mrss/",
+		Channel: rssChannel{
+			Title:       feed.Title,
+			Link:        feed.Link,
+			Description: feed.Description,
+			Language:    feed.Language,
+			Self:        atomLink{Href: selfURL, Rel: "self", Type: "application/rss+xml"},
+			Items:       items,
+		},
+	}
+	if !feed.Updated.IsZero() {
+		doc.Channel.LastBuildDate = feed.Updated.UTC().Format(time.RFC1123Z)
+	}
+	return marshalXML(doc)
+}
+
+// RenderAtom renders the feed as Atom 1.0; selfURL is the address the feed is served from.
+func RenderAtom(feed *model.Feed, selfURL string) ([]byte, error) {
+	entries := make([]atomEntry, 0, len(feed.Items))
+	for _, it := range feed.Items {
+		author := it.Author
+		if author == "" {
+			author = feed.Title
+		}
+
+		entry := atomEntry{
+			ID:        it.ID,
+			Title:     it.Title,
+			Updated:   it.Updated.UTC().Format(time.RFC3339),
+			Published: it.Published.UTC().Format(time.RFC3339),
+			Author:    atomPerson{Name: author},
+			Links:     []atomLink{{Href: it.Link, Rel: "alternate", Type: "text/html"}},
+		}
+		for _, category := range it.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: category})
+		}
+		if it.ImageURL != "" {
+			entry.Links = append(entry.Links, atomLink{Href: it.ImageURL, Rel: "enclosure", Type: imageContentType(it.ImageURL)})
+		}
+		if it.Summary != "" {
+			entry.Summary = &atomText{Type: "text", Body: it.Summary}
+		}
+		if it.ContentHTML != "" {
+			entry.Content = &atomText{Type: "html", Body: it.ContentHTML}
+		}
+		entries = append(entries, entry)
+	}
+
+	doc := atomFeed{
+		Lang:     feed.Language,
+		ID:       selfURL,
+		Title:    feed.Title,
+		Subtitle: feed.Description,
+		Updated:  feed.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: selfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: feed.Link, Rel: "alternate", Type: "text/html"},
+		},
+		Entries: entries,
+	}
+	return marshalXML(doc)
+}
+
+// RenderJSONFeed renders the feed as JSON Feed 1.1; selfURL is the address the feed is served from.
+func RenderJSONFeed(feed *model.Feed, selfURL string) ([]byte, error) {
+	items := make([]jsonFeedItem, 0, len(feed.Items))
+	for _, it := range feed.Items {
+		item := jsonFeedItem{
+			ID:            it.ID,
+			URL:           it.Link,
+			Title:         it.Title,
+			ContentHTML:   it.ContentHTML,
+			Summary:       it.Summary,
+			Image:         it.ImageURL,
+			DatePublished: it.Published.UTC().Format(time.RFC3339),
+			DateModified:  it.Updated.UTC().Format(time.RFC3339),
+			Tags:          it.Categories,
+		}
+		if it.Author != "" {
+			item.Authors = []jsonFeedAuthor{{Name: it.Author}}
+		}
+		items = append(items, item)
+	}
+
+	return json.Marshal(jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       feed.Title,
+		HomePageURL: feed.Link,
+		FeedURL:     selfURL,
+		Description: feed.Description,
+		Language:    feed.Language,
+		Items:       items,
+	})
+}
+
+func marshalXML(doc any) ([]byte, error) {
+	body, err := xml.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+func imageContentType(imageURL string) string {
+	ext := path.Ext(strings.SplitN(imageURL, "?", 2)[0])
+	if contentType := mime.TypeByExtension(strings.ToLower(ext)); contentType != "" {
+		return contentType
+	}
+	return "image/webp"
+}
@@ -0,0 +1,197 @@
+package util
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedElements maps every element kept by SanitizeHTML to the attributes it may carry.
+var allowedElements = map[string][]string{
+	"p": nil, "br": nil, "hr": nil, "span": nil, "div": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"strong": nil, "b": nil, "em": nil, "i": nil, "u": nil, "s": nil, "del": nil, "ins": nil,
+	"sub": nil, "sup": nil, "mark": nil, "small": nil, "code": nil, "pre": nil, "kbd": nil,
+	"blockquote": {"cite"}, "q": {"cite"}, "cite": nil,
+	"ul": nil, "ol": {"start"}, "li": nil,
+	"a":      {"href", "title"},
+	"img":    {"src", "alt", "title", "width", "height", "loading"},
+	"figure": nil, "figcaption": nil,
+	"table": nil, "thead": nil, "tbody": nil, "tfoot": nil, "tr": nil,
+	"th": {"colspan", "rowspan", "scope"}, "td": {"colspan", "rowspan"},
+	"iframe": {"src", "title", "width", "height", "allowfullscreen", "loading"},
+}
+
+// droppedElements are removed together with everything inside them. Other unknown elements are
+// unwrapped and keep their text.
+var droppedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "object": true, "embed": true,
+	"applet": true, "svg": true, "math": true, "form": true, "input": true, "button": true,
+	"select": true, "textarea": true, "head": true, "title": true, "meta": true, "link": true, "base": true,
+}
+
+var voidElements = map[string]bool{"br": true, "hr": true, "img": true}
+
+// embedHosts are the sites an iframe may point at, each with the path its player lives under.
+var embedHosts = map[string]string{
+	"www.youtube.com":          "/embed/",
+	"www.youtube-nocookie.com": "/embed/",
+	"player.vimeo.com":         "/video/",
+	"www.google.com":           "/maps/embed",
+}
+
+// SanitizeHTML keeps only the allowlisted elements and attributes of an HTML fragment. Links may use
+// http, https, mailto and tel or be relative, images http or https, and iframes only the embed players
+// of embedHosts. Links get rel="noopener noreferrer nofollow".
+func SanitizeHTML(fragment string) string {
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), context)
+	if err != nil {
+		return html.EscapeString(fragment)
+	}
+
+	var b strings.Builder
+	for _, n := range nodes {
+		sanitizeNode(&b, n)
+	}
+	return b.String()
+}
+
+func sanitizeNode(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(html.EscapeString(n.Data))
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	tag := strings.ToLower(n.Data)
+	if droppedElements[tag] {
+		return
+	}
+	allowed, ok := allowedElements[tag]
+	if !ok {
+		sanitizeChildren(b, n)
+		return
+	}
+
+	attrs := sanitizeAttributes(tag, n.Attr, allowed)
+	if tag == "iframe" && attrs == nil {
+		return
+	}
+
+	b.WriteString("<" + tag)
+	for _, a := range attrs {
+		b.WriteString(" " + a.Key)
+		if a.Val != "" || a.Key != "allowfullscreen" {
+			b.WriteString(`="` + html.EscapeString(a.Val) + `"`)
+		}
+	}
+	b.WriteString(">")
+	if voidElements[tag] {
+		return
+	}
+	if tag != "iframe" {
+		sanitizeChildren(b, n)
+	}
+	b.WriteString("</" + tag + ">")
+}
+
+func sanitizeChildren(b *strings.Builder, n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sanitizeNode(b, c)
+	}
+}
+
+// sanitizeAttributes keeps the allowed attributes with a safe value. It returns nil for an iframe
+// whose source is not an allowed player.
+func sanitizeAttributes(tag string, attrs []html.Attribute, allowed []string) []html.Attribute {
+	kept := make([]html.Attribute, 0, len(attrs)+1)
+	for _, a := range attrs {
+		key := strings.ToLower(a.Key)
+		if a.Namespace != "" || !slices.Contains(allowed, key) {
+			continue
+		}
+		val := strings.TrimSpace(a.Val)
+
+		switch key {
+		case "href":
+			if !safeURL(val, "http", "https", "mailto", "tel") {
+				continue
+			}
+		case "src":
+			if tag == "iframe" && !embedURL(val) {
+				return nil
+			}
+			if !safeURL(val, "http", "https") {
+				continue
+			}
+		case "cite":
+			if !safeURL(val, "http", "https") {
+				continue
+			}
+		case "width", "height", "colspan", "rowspan", "start":
+			if !digits(val) {
+				continue
+			}
+		}
+		kept = append(kept, html.Attribute{Key: key, Val: val})
+	}
+
+	switch tag {
+	case "a":
+		kept = append(kept, html.Attribute{Key: "rel", Val: "noopener noreferrer nofollow"})
+	case "iframe":
+		if !hasAttribute(kept, "src") {
+			return nil
+		}
+	}
+	return kept
+}
+
+// safeURL accepts relative addresses and absolute ones using one of the schemes.
+func safeURL(raw string, schemes ...string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" {
+		return u.Host == "" && !strings.HasPrefix(raw, "//") && !strings.Contains(raw, "\\")
+	}
+	return slices.Contains(schemes, strings.ToLower(u.Scheme))
+}
+
+func embedURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+	prefix, ok := embedHosts[strings.ToLower(u.Host)]
+	return ok && strings.HasPrefix(u.Path, prefix)
+}
+
+func digits(s string) bool {
+	if s == "" || len(s) > 5 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func hasAttribute(attrs []html.Attribute, key string) bool {
+	for _, a := range attrs {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
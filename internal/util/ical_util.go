@@ -0,0 +1,107 @@
+package util
+
+import (
+	"pura-agung-kertajaya-backend/internal/calendar"
+	"pura-agung-kertajaya-backend/internal/model"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	ContentTypeICalendar = "text/calendar; charset=utf-8"
+
+	// ICalendarTimezone is the zone timed events are written in.
+	ICalendarTimezone = "Asia/Makassar"
+
+	icalProductID    = "-//Pura Agung Kertajaya//Activities//ID"
+	icalLineOctets   = 75
+	icalDateFormat   = "20060102"
+	icalLocalFormat  = "20060102T150405"
+	icalUTCFormat    = "20060102T150405Z"
+	icalFoldedIndent = "\r\n "
+)
+
+var icalTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// RenderICalendar writes the calendar as an iCalendar stream. Timed events are written in
+// Asia/Makassar, which has kept UTC+8 without daylight saving since 1932.
+func RenderICalendar(cal *model.ICalendar) []byte {
+	w := &icalWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", icalProductID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	if cal.Name != "" {
+		w.line("X-WR-CALNAME", icalText(cal.Name))
+	}
+	if cal.Description != "" {
+		w.line("X-WR-CALDESC", icalText(cal.Description))
+	}
+	w.line("X-WR-TIMEZONE", ICalendarTimezone)
+
+	w.line("BEGIN", "VTIMEZONE")
+	w.line("TZID", ICalendarTimezone)
+	w.line("BEGIN", "STANDARD")
+	w.line("DTSTART", "19700101T000000")
+	w.line("TZOFFSETFROM", "+0800")
+	w.line("TZOFFSETTO", "+0800")
+	w.line("TZNAME", "WITA")
+	w.line("END", "STANDARD")
+	w.line("END", "VTIMEZONE")
+
+	for _, e := range cal.Events {
+		w.line("BEGIN", "VEVENT")
+		w.line("UID", e.UID)
+		w.line("DTSTAMP", e.Updated.UTC().Format(icalUTCFormat))
+		if e.AllDay {
+			w.line("DTSTART;VALUE=DATE", e.Start.Format(icalDateFormat))
+			w.line("DTEND;VALUE=DATE", e.End.Format(icalDateFormat))
+		} else {
+			w.line("DTSTART;TZID="+ICalendarTimezone, e.Start.In(calendar.Location).Format(icalLocalFormat))
+			w.line("DTEND;TZID="+ICalendarTimezone, e.End.In(calendar.Location).Format(icalLocalFormat))
+		}
+		w.line("SUMMARY", icalText(e.Summary))
+		if e.Description != "" {
+			w.line("DESCRIPTION", icalText(e.Description))
+		}
+		if e.Location != "" {
+			w.line("LOCATION", icalText(e.Location))
+		}
+		if e.URL != "" {
+			w.line("URL", e.URL)
+		}
+		w.line("LAST-MODIFIED", e.Updated.UTC().Format(icalUTCFormat))
+		w.line("END", "VEVENT")
+	}
+
+	w.line("END", "VCALENDAR")
+	return []byte(w.String())
+}
+
+func icalText(s string) string {
+	return icalTextEscaper.Replace(s)
+}
+
+// icalWriter writes content lines ending in CRLF, folded so that no line exceeds 75 octets.
+type icalWriter struct {
+	strings.Builder
+}
+
+func (w *icalWriter) line(name string, value string) {
+	s := name + ":" + value
+	limit := icalLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString(icalFoldedIndent)
+		s = s[cut:]
+		// The space that starts a folded line counts towards its length.
+		limit = icalLineOctets - 1
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
@@ -39,6 +39,16 @@ var AllPresets = []ImagePreset{
 	PresetFullHD,
 }
 
+// PresetWidth returns the width of the named preset, or 0 when there is no such preset.
+func PresetWidth(name string) int {
+	for _, p := range AllPresets {
+		if p.Name == name {
+			return p.Width
+		}
+	}
+	return 0
+}
+
 type ProcessCallback func(presetName string, data []byte) error
 
 func ProcessAndHandleImage(r io.Reader, presets []ImagePreset, onProcessed ProcessCallback) error {
@@ -0,0 +1,104 @@
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	LockoutFreeAttempts = 3
+	LockoutBaseDuration = 30 * time.Second
+	LockoutMaxDuration  = 15 * time.Minute
+	LockoutWindow       = 24 * time.Hour
+)
+
+// LockoutUtil tracks failed logins per email, independent of whether the account exists.
+// After LockoutFreeAttempts failures every further failure locks the email for a doubling period.
+type LockoutUtil struct {
+	Redis *redis.Client
+	Now   func() time.Time
+}
+
+func NewLockoutUtil(redisClient *redis.Client) *LockoutUtil {
+	return &LockoutUtil{
+		Redis: redisClient,
+		Now:   time.Now,
+	}
+}
+
+// LockedFor returns how long the email stays locked, or zero when it may try again.
+func (l *LockoutUtil) LockedFor(ctx context.Context, email string) (time.Duration, error) {
+	until, err := l.Redis.HGet(ctx, lockoutKey(email), "locked_until").Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	remaining := time.Unix(until, 0).Sub(l.Now())
+	if remaining <= 0 {
+		return 0, nil
+	}
+	return remaining, nil
+}
+
+// RegisterFailure counts a failed attempt and returns the lockout it triggered, if any.
+func (l *LockoutUtil) RegisterFailure(ctx context.Context, email string) (time.Duration, error) {
+	key := lockoutKey(email)
+
+	var failures *redis.IntCmd
+	_, err := l.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		failures = pipe.HIncrBy(ctx, key, "failures", 1)
+		pipe.Expire(ctx, key, LockoutWindow)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	lockout := LockoutDuration(failures.Val())
+	if lockout == 0 {
+		return 0, nil
+	}
+
+	lockedUntil := l.Now().Add(lockout).Unix()
+	if err := l.Redis.HSet(ctx, key, "locked_until", strconv.FormatInt(lockedUntil, 10)).Err(); err != nil {
+		return 0, err
+	}
+
+	return lockout, nil
+}
+
+func (l *LockoutUtil) Reset(ctx context.Context, email string) error {
+	return l.Redis.Del(ctx, lockoutKey(email)).Err()
+}
+
+// LockoutDuration returns the lockout that follows the given number of consecutive failures.
+func LockoutDuration(failures int64) time.Duration {
+	over := failures - LockoutFreeAttempts
+	if over <= 0 {
+		return 0
+	}
+
+	lockout := LockoutBaseDuration
+	for i := int64(1); i < over; i++ {
+		lockout *= 2
+		if lockout >= LockoutMaxDuration {
+			return LockoutMaxDuration
+		}
+	}
+	return lockout
+}
+
+func lockoutKey(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return "login_failures:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,177 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+)
+
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers a single plain-text email.
+type Mailer interface {
+	Send(ctx context.Context, mail *Mail) error
+}
+
+type SMTPMailer struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, mail *Mail) error {
+	addr := net.JoinHostPort(m.Host, strconv.Itoa(m.Port))
+
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	return smtp.SendMail(addr, auth, m.From, []string{mail.To}, buildMessage(m.From, mail))
+}
+
+// MemoryMailer keeps sent mail in memory; used by tests.
+type MemoryMailer struct {
+	mu   sync.Mutex
+	Sent []Mail
+}
+
+func (m *MemoryMailer) Send(ctx context.Context, mail *Mail) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Sent = append(m.Sent, *mail)
+	return nil
+}
+
+func (m *MemoryMailer) Last() *Mail {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.Sent) == 0 {
+		return nil
+	}
+	last := m.Sent[len(m.Sent)-1]
+	return &last
+}
+
+// FileMailer writes every mail as an .eml file so it can be opened locally during development.
+type FileMailer struct {
+	Dir  string
+	From string
+}
+
+func (m *FileMailer) Send(ctx context.Context, mail *Mail) error {
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405"), uuid.New().String())
+	return os.WriteFile(filepath.Join(m.Dir, name), buildMessage(m.From, mail), 0o600)
+}
+
+func buildMessage(from string, mail *Mail) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + mail.To + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", mail.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(mail.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// MailUtil renders the application's transactional emails and hands them to a Mailer.
+type MailUtil struct {
+	Mailer           Mailer
+	AppName          string
+	PasswordResetURL string
+}
+
+// NewMailUtil builds the mailer named by mail.driver. The file and memory drivers only ever run when
+// named, so a missing or mistyped driver fails instead of quietly keeping reset links on disk.
+func NewMailUtil(v *viper.Viper) (*MailUtil, error) {
+	from := v.GetString("mail.from")
+
+	var mailer Mailer
+	switch driver := v.GetString("mail.driver"); driver {
+	case "smtp":
+		if v.GetString("mail.smtp.host") == "" || from == "" {
+			return nil, errors.New("the smtp mail driver needs mail.smtp.host and mail.from")
+		}
+		mailer = &SMTPMailer{
+			Host:     v.GetString("mail.smtp.host"),
+			Port:     v.GetInt("mail.smtp.port"),
+			Username: v.GetString("mail.smtp.username"),
+			Password: v.GetString("mail.smtp.password"),
+			From:     from,
+		}
+	case "memory":
+		mailer = &MemoryMailer{}
+	case "file":
+		dir := v.GetString("mail.file_dir")
+		if dir == "" {
+			dir = filepath.Join(os.TempDir(), "pura-agung-kertajaya-mail")
+		}
+		mailer = &FileMailer{Dir: dir, From: from}
+	default:
+		return nil, fmt.Errorf("unknown mail.driver %q, expected smtp, file or memory", driver)
+	}
+
+	appName := v.GetString("app.name")
+	if appName == "" {
+		appName = DefaultTOTPIssuer
+	}
+
+	return &MailUtil{
+		Mailer:           mailer,
+		AppName:          appName,
+		PasswordResetURL: v.GetString("password_reset.url"),
+	}, nil
+}
+
+func (m *MailUtil) SendPasswordReset(ctx context.Context, to string, name string, token string, ttl time.Duration) error {
+	link := m.PasswordResetURL
+	if u, err := url.Parse(link); err == nil && link != "" {
+		query := u.Query()
+		query.Set("token", token)
+		u.RawQuery = query.Encode()
+		link = u.String()
+	} else {
+		link = token
+	}
+
+	body := fmt.Sprintf(
+		"Hello %s,\n\n"+
+			"We received a request to reset the password of your %s CMS account.\n"+
+			"Open the link below to choose a new password. The link can be used once and expires in %d minutes.\n\n"+
+			"%s\n\n"+
+			"If you did not request this, you can ignore this email; your password stays the same.\n",
+		name, m.AppName, int(ttl.Minutes()), link,
+	)
+
+	return m.Mailer.Send(ctx, &Mail{
+		To:      to,
+		Subject: m.AppName + " password reset",
+		Body:    body,
+	})
+}
@@ -0,0 +1,230 @@
+package util
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	mdHeading     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdRule        = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	mdBullet      = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	mdOrdered     = regexp.MustCompile(`^\s*(\d{1,9})[.)]\s+(.*)$`)
+	mdFence       = regexp.MustCompile("^\\s*(```|~~~)")
+	mdCodeSpan    = regexp.MustCompile("`([^`]+)`")
+	mdImage       = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+&#34;([^)]*?)&#34;)?\)`)
+	mdLink        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+&#34;([^)]*?)&#34;)?\)`)
+	mdAutolink    = regexp.MustCompile(`&lt;(https?://[^\s&]+)&gt;`)
+	mdStrongEmph  = regexp.MustCompile(`\*\*\*(\S(?:.*?\S)?)\*\*\*`)
+	mdStrong      = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	mdEmphasis    = regexp.MustCompile(`\*(\S(?:[^*]*?\S)?)\*|(^|[^\w])_(\S(?:[^_]*?\S)?)_([^\w]|$)`)
+	mdStrike      = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	mdPlaceholder = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// RenderMarkdown renders the common subset of Markdown: headings, paragraphs, block quotes, bullet and
+// numbered lists, fenced code, rules, and inline emphasis, code, links and images. Raw HTML is
+// escaped rather than passed through. NUL characters are replaced, as CommonMark asks.
+func RenderMarkdown(source string) string {
+	source = strings.ReplaceAll(source, "\x00", "\uFFFD")
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	var b strings.Builder
+	renderMarkdownBlocks(&b, lines)
+	return b.String()
+}
+
+func renderMarkdownBlocks(b *strings.Builder, lines []string) {
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + renderParagraphLines(paragraph) + "</p>\n")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+
+		case mdFence.MatchString(line):
+			flush()
+			fence := mdFence.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			b.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case mdHeading.MatchString(line):
+			flush()
+			m := mdHeading.FindStringSubmatch(line)
+			level := len(m[1])
+			fmt.Fprintf(b, "<h%d>%s</h%d>\n", level, renderInline(m[2]), level)
+
+		case mdRule.MatchString(line):
+			flush()
+			b.WriteString("<hr>\n")
+
+		case strings.HasPrefix(strings.TrimSpace(line), ">"):
+			flush()
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				text := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(text, " "))
+			}
+			i--
+			b.WriteString("<blockquote>\n")
+			renderMarkdownBlocks(b, quoted)
+			b.WriteString("</blockquote>\n")
+
+		case mdBullet.MatchString(line) || mdOrdered.MatchString(line):
+			flush()
+			i = renderMarkdownList(b, lines, i) - 1
+
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+}
+
+// renderMarkdownList renders the list starting at lines[start] and returns the index of the first line
+// after it. Indented lines continue the item above them; nested lists are flattened into it.
+func renderMarkdownList(b *strings.Builder, lines []string, start int) int {
+	ordered := mdOrdered.MatchString(lines[start])
+	marker := mdBullet
+	tag := "ul"
+	if ordered {
+		marker, tag = mdOrdered, "ol"
+	}
+
+	b.WriteString("<" + tag)
+	if ordered {
+		if n, _ := strconv.Atoi(mdOrdered.FindStringSubmatch(lines[start])[1]); n > 1 {
+			fmt.Fprintf(b, ` start="%d"`, n)
+		}
+	}
+	b.WriteString(">\n")
+
+	var item []string
+	flush := func() {
+		if len(item) > 0 {
+			b.WriteString("<li>" + renderParagraphLines(item) + "</li>\n")
+			item = nil
+		}
+	}
+
+	i := start
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if m := marker.FindStringSubmatch(line); m != nil && !startsIndented(line) {
+			flush()
+			item = append(item, m[len(m)-1])
+			continue
+		}
+		if strings.TrimSpace(line) == "" || !startsIndented(line) && (mdBullet.MatchString(line) || mdOrdered.MatchString(line) || mdHeading.MatchString(line)) {
+			break
+		}
+		if !startsIndented(line) && len(item) == 0 {
+			break
+		}
+		if m := mdBullet.FindStringSubmatch(line); m != nil {
+			line = m[1]
+		} else if m := mdOrdered.FindStringSubmatch(line); m != nil {
+			line = m[2]
+		}
+		item = append(item, strings.TrimSpace(line))
+	}
+	flush()
+
+	b.WriteString("</" + tag + ">\n")
+	return i
+}
+
+func startsIndented(line string) bool {
+	return strings.HasPrefix(line, "  ") || strings.HasPrefix(line, "\t")
+}
+
+// renderParagraphLines joins the lines of a paragraph; a line ending in two spaces or a backslash
+// breaks the line.
+func renderParagraphLines(lines []string) string {
+	parts := make([]string, len(lines))
+	for i, line := range lines {
+		hardBreak := i < len(lines)-1 && (strings.HasSuffix(line, "  ") || strings.HasSuffix(line, "\\"))
+		line = renderInline(strings.TrimRight(strings.TrimSpace(line), "\\"))
+		if hardBreak {
+			line += "<br>"
+		}
+		parts[i] = line
+	}
+	return strings.Join(parts, "\n")
+}
+
+// renderInline escapes text and renders its inline Markdown. NUL characters mark the spans held
+// aside, so any in the text itself, such as from the blocks of a JSON body, are replaced first.
+func renderInline(text string) string {
+	text = strings.ReplaceAll(text, "\x00", "\uFFFD")
+
+	var spans []string
+	hold := func(span string) string {
+		spans = append(spans, span)
+		return fmt.Sprintf("\x00%d\x00", len(spans)-1)
+	}
+
+	text = mdCodeSpan.ReplaceAllStringFunc(text, func(s string) string {
+		return hold("<code>" + html.EscapeString(mdCodeSpan.FindStringSubmatch(s)[1]) + "</code>")
+	})
+
+	// Images and links are held aside like code, so that emphasis never reaches into a URL or alt text.
+	text = html.EscapeString(text)
+	text = mdImage.ReplaceAllStringFunc(text, func(s string) string {
+		m := mdImage.FindStringSubmatch(s)
+		img := `<img src="` + m[2] + `" alt="` + m[1] + `"`
+		if m[3] != "" {
+			img += ` title="` + m[3] + `"`
+		}
+		return hold(img + ">")
+	})
+	text = mdLink.ReplaceAllStringFunc(text, func(s string) string {
+		m := mdLink.FindStringSubmatch(s)
+		link := `<a href="` + m[2] + `"`
+		if m[3] != "" {
+			link += ` title="` + m[3] + `"`
+		}
+		return hold(link + ">" + renderEmphasis(m[1]) + "</a>")
+	})
+	text = mdAutolink.ReplaceAllStringFunc(text, func(s string) string {
+		url := mdAutolink.FindStringSubmatch(s)[1]
+		return hold(`<a href="` + url + `">` + url + `</a>`)
+	})
+	text = renderEmphasis(text)
+
+	// A span can only hold spans held before it, such as code in a link label, so restoring them in
+	// order leaves each one whole by the time a later span or the text refers to it.
+	restore := func(s string, held int) string {
+		return mdPlaceholder.ReplaceAllStringFunc(s, func(marker string) string {
+			n, err := strconv.Atoi(mdPlaceholder.FindStringSubmatch(marker)[1])
+			if err != nil || n >= held {
+				return ""
+			}
+			return spans[n]
+		})
+	}
+	for i := range spans {
+		spans[i] = restore(spans[i], i)
+	}
+	return restore(text, len(spans))
+}
+
+func renderEmphasis(text string) string {
+	text = mdStrongEmph.ReplaceAllString(text, "<em><strong>$1</strong></em>")
+	text = mdStrong.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = mdEmphasis.ReplaceAllString(text, "$2<em>$1$3</em>$4")
+	return mdStrike.ReplaceAllString(text, "<del>$1</del>")
+}
@@ -0,0 +1,127 @@
+package util
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// maxSearchTerms bounds how many words of a query are used, so a pasted paragraph cannot blow up the
+// full-text expression.
+const maxSearchTerms = 10
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// SearchTerms splits a free text query into lower-case words. Punctuation, including full-text
+// operators such as + - * " ( ), is dropped and duplicates are removed.
+func SearchTerms(query string) []string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool, len(words))
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		terms = append(terms, word)
+		if len(terms) == maxSearchTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// PlainText strips HTML tags and entities from text and collapses whitespace.
+func PlainText(text string) string {
+	text = htmlTagPattern.ReplaceAllString(text, " ")
+	return strings.Join(strings.Fields(html.UnescapeString(text)), " ")
+}
+
+// HighlightSnippet returns about width characters of text around the first word starting with one of
+// the terms. The result is HTML escaped and every matching word is wrapped in <mark>. Text cut at
+// either end is marked with an ellipsis.
+func HighlightSnippet(text string, terms []string, width int) string {
+	runes := []rune(PlainText(text))
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	termRunes := make([][]rune, 0, len(terms))
+	for _, term := range terms {
+		if term != "" {
+			termRunes = append(termRunes, []rune(strings.ToLower(term)))
+		}
+	}
+
+	start := 0
+	for i := range lower {
+		if matchWordAt(lower, i, termRunes) > 0 {
+			// Leave some leading context before the first hit.
+			start = max(i-width/3, 0)
+			break
+		}
+	}
+	end := min(start+width, len(runes))
+	if end == len(runes) {
+		start = max(end-width, 0)
+	}
+
+	// Do not cut words in half.
+	for start > 0 && start < end && !unicode.IsSpace(runes[start-1]) {
+		start++
+	}
+	for end < len(runes) && end > start && !unicode.IsSpace(runes[end]) {
+		end--
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("… ")
+	}
+	plainFrom := start
+	for i := start; i < end; i++ {
+		n := matchWordAt(lower[:end], i, termRunes)
+		if n == 0 {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[plainFrom:i])))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(string(runes[i : i+n])))
+		b.WriteString("</mark>")
+		i += n - 1
+		plainFrom = i + 1
+	}
+	b.WriteString(html.EscapeString(string(runes[plainFrom:end])))
+	if end < len(runes) {
+		b.WriteString(" …")
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// matchWordAt reports the length of the word starting at i when it begins with one of the terms, or
+// zero when there is no word start or no match at i.
+func matchWordAt(text []rune, i int, terms [][]rune) int {
+	if !isWordRune(text[i]) || (i > 0 && isWordRune(text[i-1])) {
+		return 0
+	}
+	for _, term := range terms {
+		if len(term) > len(text)-i || string(text[i:i+len(term)]) != string(term) {
+			continue
+		}
+		n := len(term)
+		for i+n < len(text) && isWordRune(text[i+n]) {
+			n++
+		}
+		return n
+	}
+	return 0
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,97 @@
+package util
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	DefaultArticlePath  = "/articles"
+	DefaultCategoryPath = "/categories"
+	DefaultActivityPath = "/activities"
+)
+
+// DefaultSitePages are the static sections every public site has.
+var DefaultSitePages = []string{"/", "/about", "/activities", "/gallery", "/facilities", "/organization", "/contact"}
+
+// SiteURLUtil builds absolute links into the public site of every entity and to uploaded files.
+type SiteURLUtil struct {
+	BaseURLs     map[string]string
+	ArticlePath  string
+	CategoryPath string
+	ActivityPath string
+	Pages        []string
+	AssetBaseURL string
+}
+
+func NewSiteURLUtil(v *viper.Viper) *SiteURLUtil {
+	articlePath := v.GetString("site.article_path")
+	if articlePath == "" {
+		articlePath = DefaultArticlePath
+	}
+	categoryPath := v.GetString("site.category_path")
+	if categoryPath == "" {
+		categoryPath = DefaultCategoryPath
+	}
+	activityPath := v.GetString("site.activity_path")
+	if activityPath == "" {
+		activityPath = DefaultActivityPath
+	}
+	pages := v.GetStringSlice("site.pages")
+	if len(pages) == 0 {
+		pages = DefaultSitePages
+	}
+
+	return &SiteURLUtil{
+		BaseURLs:     v.GetStringMapString("site.base_url"),
+		ArticlePath:  articlePath,
+		CategoryPath: categoryPath,
+		ActivityPath: activityPath,
+		Pages:        pages,
+		AssetBaseURL: v.GetString("cloudflare_r2.public_url"),
+	}
+}
+
+// HasSite tells whether a public site is configured for the entity.
+func (s *SiteURLUtil) HasSite(entityType string) bool {
+	return s.BaseURL(entityType) != ""
+}
+
+// BaseURL returns the root of the entity's public site without a trailing slash.
+func (s *SiteURLUtil) BaseURL(entityType string) string {
+	return strings.TrimRight(s.BaseURLs[entityType], "/")
+}
+
+func (s *SiteURLUtil) ArticleURL(entityType string, slug string) string {
+	return strings.TrimSuffix(s.PageURL(entityType, s.ArticlePath), "/") + "/" + url.PathEscape(slug)
+}
+
+func (s *SiteURLUtil) CategoryURL(entityType string, slug string) string {
+	return strings.TrimSuffix(s.PageURL(entityType, s.CategoryPath), "/") + "/" + url.PathEscape(slug)
+}
+
+// PageURL returns the absolute address of a path on the entity's site. The root path keeps its slash.
+func (s *SiteURLUtil) PageURL(entityType string, path string) string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return s.BaseURL(entityType) + "/"
+	}
+	return s.BaseURL(entityType) + "/" + path
+}
+
+// AssetURL makes a stored file reference absolute. References may already be absolute, protocol
+// relative, or a key relative to the public storage URL.
+func (s *SiteURLUtil) AssetURL(ref string) string {
+	switch {
+	case ref == "":
+		return ""
+	case strings.HasPrefix(ref, "//"):
+		return "https:" + ref
+	case strings.HasPrefix(ref, "http://"), strings.HasPrefix(ref, "https://"):
+		return ref
+	default:
+		return strings.TrimRight(s.AssetBaseURL, "/") + "/" + strings.TrimLeft(ref, "/")
+	}
+}
@@ -0,0 +1,55 @@
+package util
+
+import (
+	"encoding/xml"
+	"pura-agung-kertajaya-backend/internal/model"
+	"time"
+)
+
+const (
+	ContentTypeXML       = "application/xml; charset=utf-8"
+	MaxSitemapURLs       = 50000
+	sitemapNamespace     = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	sitemapLastModFormat = time.RFC3339
+)
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapLoc `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapLoc `xml:"sitemap"`
+}
+
+type sitemapLoc struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+func RenderSitemap(urls []model.SitemapURL) ([]byte, error) {
+	doc := sitemapURLSet{XMLNS: sitemapNamespace, URLs: make([]sitemapLoc, 0, len(urls))}
+	for _, u := range urls {
+		doc.URLs = append(doc.URLs, sitemapLoc{Loc: u.Loc, LastMod: sitemapLastMod(u.LastMod)})
+	}
+	return marshalXML(doc)
+}
+
+// RenderSitemapIndex renders an index of sitemaps; loc returns the address of an entity's sitemap.
+func RenderSitemapIndex(entries []model.SitemapIndexEntry, loc func(entityType string) string) ([]byte, error) {
+	doc := sitemapIndex{XMLNS: sitemapNamespace, Sitemaps: make([]sitemapLoc, 0, len(entries))}
+	for _, e := range entries {
+		doc.Sitemaps = append(doc.Sitemaps, sitemapLoc{Loc: loc(e.EntityType), LastMod: sitemapLastMod(e.LastMod)})
+	}
+	return marshalXML(doc)
+}
+
+func sitemapLastMod(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(sitemapLastModFormat)
+}
@@ -2,8 +2,13 @@ package util
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"pura-agung-kertajaya-backend/internal/model"
+	"sort"
 	"strconv"
 	"time"
 
@@ -12,53 +17,217 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 7 * 24 * time.Hour
+
+	LoginChallengeTTL         = 5 * time.Minute
+	MaxLoginChallengeAttempts = 5
+
+	PasswordResetTTL = 30 * time.Minute
+)
+
+var (
+	ErrInvalidRefreshToken   = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused    = errors.New("refresh token reuse detected")
+	ErrInvalidLoginChallenge = errors.New("invalid or expired login challenge")
+	ErrInvalidPasswordReset  = errors.New("invalid or expired password reset token")
+)
+
+// consumeRefreshTokenScript reads a refresh token and marks it used in one step, so of two refreshes
+// racing with the same token only one sees it unused. It returns the token's fields with used
+// already counted, or nothing for an unknown token.
+var consumeRefreshTokenScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return {}
+end
+redis.call('HINCRBY', KEYS[1], 'used', 1)
+return redis.call('HGETALL', KEYS[1])
+`)
+
+// failLoginChallengeScript counts a wrong code and drops the challenge on the last attempt. It only
+// touches a live challenge, so a late attempt never brings an expired one back without a TTL.
+var failLoginChallengeScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], 'user_id') == 0 then
+	return 0
+end
+local attempts = redis.call('HINCRBY', KEYS[1], 'attempts', 1)
+if attempts >= tonumber(ARGV[1]) then
+	redis.call('DEL', KEYS[1])
+end
+return attempts
+`)
+
 type TokenUtil struct {
-	SecretKey string
-	Redis     *redis.Client
+	SecretKey       string
+	Redis           *redis.Client
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
 }
 
-func NewTokenUtil(secretKey string, redisClient *redis.Client) *TokenUtil {
+func NewTokenUtil(secretKey string, redisClient *redis.Client, accessTTL time.Duration, refreshTTL time.Duration) *TokenUtil {
+	if accessTTL <= 0 {
+		accessTTL = DefaultAccessTokenTTL
+	}
+	if refreshTTL <= 0 {
+		refreshTTL = DefaultRefreshTokenTTL
+	}
+
 	return &TokenUtil{
-		SecretKey: secretKey,
-		Redis:     redisClient,
+		SecretKey:       secretKey,
+		Redis:           redisClient,
+		AccessTokenTTL:  accessTTL,
+		RefreshTokenTTL: refreshTTL,
 	}
 }
 
-func (t *TokenUtil) CreateToken(ctx context.Context, auth *model.Auth) (string, string, error) {
+// CreateToken starts a new session family and issues its first access/refresh pair.
+func (t *TokenUtil) CreateToken(ctx context.Context, auth *model.Auth, device *model.SessionDevice) (*model.TokenPair, error) {
+	familyAuth := *auth
+	familyAuth.FamilyID = uuid.New().String()
+	return t.IssueToken(ctx, &familyAuth, device)
+}
+
+// IssueToken issues an access/refresh pair inside the session family in auth.FamilyID.
+// Every key belonging to the family is tracked in family:<id> so the whole family can be revoked at once,
+// and every family of a user is indexed in user_sessions:<user_id>.
+func (t *TokenUtil) IssueToken(ctx context.Context, auth *model.Auth, device *model.SessionDevice) (*model.TokenPair, error) {
 	jti := uuid.New().String()
+	now := time.Now()
 
-	exp := time.Now().Add(24 * time.Hour)
 	claims := jwt.MapClaims{
 		"id":   auth.ID,
 		"role": auth.Role,
-		"exp":  exp.Unix(),
+		"exp":  now.Add(t.AccessTokenTTL).Unix(),
 		"jti":  jti,
+		"fid":  auth.FamilyID,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	signedToken, err := token.SignedString([]byte(t.SecretKey))
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 
-	key := "session:" + jti
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	sessionKey := "session:" + jti
+	refreshKey := refreshTokenKey(refreshToken)
+	familyKey := "family:" + auth.FamilyID
+	familyInfoKey := "family_info:" + auth.FamilyID
+	userSessionsKey := "user_sessions:" + auth.ID
 
-	data := map[string]string{
+	sessionData := map[string]string{
 		"user_id":   auth.ID,
 		"email":     auth.Email,
 		"role":      auth.Role,
-		"issued_at": strconv.FormatInt(time.Now().Unix(), 10),
+		"family_id": auth.FamilyID,
+		"issued_at": strconv.FormatInt(now.Unix(), 10),
 	}
 
-	if err = t.Redis.HSet(ctx, key, data).Err(); err != nil {
-		return "", "", err
+	refreshData := map[string]string{
+		"user_id":    auth.ID,
+		"email":      auth.Email,
+		"role":       auth.Role,
+		"family_id":  auth.FamilyID,
+		"access_jti": jti,
+		"used":       "0",
 	}
 
-	if err = t.Redis.Expire(ctx, key, 24*time.Hour).Err(); err != nil {
-		return "", "", err
+	familyInfo := map[string]string{
+		"user_id":      auth.ID,
+		"last_seen_at": strconv.FormatInt(now.Unix(), 10),
+	}
+	if device != nil {
+		familyInfo["ip"] = device.IP
+		familyInfo["user_agent"] = device.UserAgent
+	}
+
+	_, err = t.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, sessionKey, sessionData)
+		pipe.Expire(ctx, sessionKey, t.AccessTokenTTL)
+		pipe.HSet(ctx, refreshKey, refreshData)
+		pipe.Expire(ctx, refreshKey, t.RefreshTokenTTL)
+		pipe.SAdd(ctx, familyKey, sessionKey, refreshKey)
+		pipe.Expire(ctx, familyKey, t.RefreshTokenTTL)
+		pipe.HSet(ctx, familyInfoKey, familyInfo)
+		pipe.HSetNX(ctx, familyInfoKey, "issued_at", strconv.FormatInt(now.Unix(), 10))
+		pipe.Expire(ctx, familyInfoKey, t.RefreshTokenTTL)
+		pipe.SAdd(ctx, userSessionsKey, auth.FamilyID)
+		pipe.Expire(ctx, userSessionsKey, t.RefreshTokenTTL)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return signedToken, jti, nil
+	return &model.TokenPair{
+		AccessToken:      signedToken,
+		RefreshToken:     refreshToken,
+		AccessExpiresIn:  t.AccessTokenTTL,
+		RefreshExpiresIn: t.RefreshTokenTTL,
+	}, nil
+}
+
+// ConsumeRefreshToken marks a refresh token as used and returns the session it belongs to.
+// Presenting a refresh token that was already rotated revokes the whole family.
+func (t *TokenUtil) ConsumeRefreshToken(ctx context.Context, refreshToken string) (*model.Auth, error) {
+	if refreshToken == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	fields, err := consumeRefreshTokenScript.Run(ctx, t.Redis, []string{refreshTokenKey(refreshToken)}).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	data := make(map[string]string, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		data[fields[i]] = fields[i+1]
+	}
+	familyID := data["family_id"]
+
+	if data["used"] != "1" {
+		if err := t.RevokeFamily(ctx, familyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	if jti := data["access_jti"]; jti != "" {
+		if err := t.RevokeToken(ctx, jti); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.Auth{
+		ID:       data["user_id"],
+		Role:     data["role"],
+		Email:    data["email"],
+		FamilyID: familyID,
+	}, nil
+}
+
+// RevokeRefreshFamily revokes the session family a refresh token belongs to without consuming it.
+func (t *TokenUtil) RevokeRefreshFamily(ctx context.Context, refreshToken string) error {
+	if refreshToken == "" {
+		return ErrInvalidRefreshToken
+	}
+
+	familyID, err := t.Redis.HGet(ctx, refreshTokenKey(refreshToken), "family_id").Result()
+	if errors.Is(err, redis.Nil) || err == nil && familyID == "" {
+		return ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return err
+	}
+	return t.RevokeFamily(ctx, familyID)
 }
 
 func (t *TokenUtil) ParseToken(ctx context.Context, jwtToken string) (*model.Auth, string, error) {
@@ -77,6 +246,7 @@ func (t *TokenUtil) ParseToken(ctx context.Context, jwtToken string) (*model.Aut
 	jti, _ := claims["jti"].(string)
 	id, _ := claims["id"].(string)
 	role, _ := claims["role"].(string)
+	familyID, _ := claims["fid"].(string)
 
 	key := "session:" + jti
 	exists, err := t.Redis.Exists(ctx, key).Result()
@@ -88,11 +258,247 @@ func (t *TokenUtil) ParseToken(ctx context.Context, jwtToken string) (*model.Aut
 	}
 
 	return &model.Auth{
-		ID:   id,
-		Role: role,
+		ID:       id,
+		Role:     role,
+		FamilyID: familyID,
 	}, jti, nil
 }
 
 func (t *TokenUtil) RevokeToken(ctx context.Context, jti string) error {
 	return t.Redis.Del(ctx, "session:"+jti).Err()
 }
+
+// RevokeFamily deletes every access session and refresh token issued in a session family.
+func (t *TokenUtil) RevokeFamily(ctx context.Context, familyID string) error {
+	if familyID == "" {
+		return nil
+	}
+
+	familyKey := "family:" + familyID
+	familyInfoKey := "family_info:" + familyID
+
+	keys, err := t.Redis.SMembers(ctx, familyKey).Result()
+	if err != nil {
+		return err
+	}
+
+	userID, err := t.Redis.HGet(ctx, familyInfoKey, "user_id").Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	_, err = t.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, append(keys, familyKey, familyInfoKey)...)
+		if userID != "" {
+			pipe.SRem(ctx, "user_sessions:"+userID, familyID)
+		}
+		return nil
+	})
+	return err
+}
+
+// ListSessions returns the live session families of a user, pruning index entries that already expired.
+func (t *TokenUtil) ListSessions(ctx context.Context, userID string) ([]model.SessionResponse, error) {
+	userSessionsKey := "user_sessions:" + userID
+
+	familyIDs, err := t.Redis.SMembers(ctx, userSessionsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]model.SessionResponse, 0, len(familyIDs))
+	for _, familyID := range familyIDs {
+		info, err := t.Redis.HGetAll(ctx, "family_info:"+familyID).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(info) == 0 {
+			if err := t.Redis.SRem(ctx, userSessionsKey, familyID).Err(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		sessions = append(sessions, model.SessionResponse{
+			ID:         familyID,
+			IP:         info["ip"],
+			UserAgent:  info["user_agent"],
+			IssuedAt:   parseUnix(info["issued_at"]),
+			LastSeenAt: parseUnix(info["last_seen_at"]),
+		})
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// HasSession reports whether a session family belongs to the given user.
+func (t *TokenUtil) HasSession(ctx context.Context, userID string, familyID string) (bool, error) {
+	return t.Redis.SIsMember(ctx, "user_sessions:"+userID, familyID).Result()
+}
+
+// RevokeUserSessions revokes every session family of a user except exceptFamilyID (which may be empty).
+func (t *TokenUtil) RevokeUserSessions(ctx context.Context, userID string, exceptFamilyID string) error {
+	familyIDs, err := t.Redis.SMembers(ctx, "user_sessions:"+userID).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, familyID := range familyIDs {
+		if familyID == exceptFamilyID {
+			continue
+		}
+		if err := t.RevokeFamily(ctx, familyID); err != nil {
+			return err
+		}
+		// Families whose info already expired are no longer resolvable to a user, so drop them explicitly.
+		if err := t.Redis.SRem(ctx, "user_sessions:"+userID, familyID).Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateLoginChallenge stores a short-lived challenge for a user who passed the password step
+// but still has to present a second factor.
+func (t *TokenUtil) CreateLoginChallenge(ctx context.Context, auth *model.Auth) (string, error) {
+	challenge, err := generateRefreshToken()
+	if err != nil {
+		return "", err
+	}
+
+	key := loginChallengeKey(challenge)
+
+	_, err = t.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, map[string]string{
+			"user_id":  auth.ID,
+			"email":    auth.Email,
+			"role":     auth.Role,
+			"attempts": "0",
+		})
+		pipe.Expire(ctx, key, LoginChallengeTTL)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return challenge, nil
+}
+
+func (t *TokenUtil) GetLoginChallenge(ctx context.Context, challenge string) (*model.Auth, error) {
+	if challenge == "" {
+		return nil, ErrInvalidLoginChallenge
+	}
+
+	data, err := t.Redis.HGetAll(ctx, loginChallengeKey(challenge)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if data["user_id"] == "" {
+		return nil, ErrInvalidLoginChallenge
+	}
+
+	return &model.Auth{
+		ID:    data["user_id"],
+		Role:  data["role"],
+		Email: data["email"],
+	}, nil
+}
+
+// FailLoginChallenge records a wrong code and drops the challenge once too many attempts were made.
+func (t *TokenUtil) FailLoginChallenge(ctx context.Context, challenge string) error {
+	keys := []string{loginChallengeKey(challenge)}
+	return failLoginChallengeScript.Run(ctx, t.Redis, keys, MaxLoginChallengeAttempts).Err()
+}
+
+func (t *TokenUtil) DeleteLoginChallenge(ctx context.Context, challenge string) error {
+	return t.Redis.Del(ctx, loginChallengeKey(challenge)).Err()
+}
+
+// CreatePasswordResetToken issues a single-use reset token. Issuing a new one invalidates the previous token of the user.
+func (t *TokenUtil) CreatePasswordResetToken(ctx context.Context, userID string) (string, error) {
+	token, err := generateRefreshToken()
+	if err != nil {
+		return "", err
+	}
+
+	key := passwordResetKey(token)
+	userKey := "password_reset_user:" + userID
+
+	previous, err := t.Redis.Get(ctx, userKey).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return "", err
+	}
+
+	_, err = t.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if previous != "" {
+			pipe.Del(ctx, previous)
+		}
+		pipe.Set(ctx, key, userID, PasswordResetTTL)
+		pipe.Set(ctx, userKey, key, PasswordResetTTL)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// ConsumePasswordResetToken atomically deletes the token and returns the user it was issued for.
+func (t *TokenUtil) ConsumePasswordResetToken(ctx context.Context, token string) (string, error) {
+	if token == "" {
+		return "", ErrInvalidPasswordReset
+	}
+
+	userID, err := t.Redis.GetDel(ctx, passwordResetKey(token)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", ErrInvalidPasswordReset
+		}
+		return "", err
+	}
+
+	if err := t.Redis.Del(ctx, "password_reset_user:"+userID).Err(); err != nil {
+		return "", err
+	}
+
+	return userID, nil
+}
+
+func generateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func refreshTokenKey(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return "refresh:" + hex.EncodeToString(sum[:])
+}
+
+func loginChallengeKey(challenge string) string {
+	sum := sha256.Sum256([]byte(challenge))
+	return "login_challenge:" + hex.EncodeToString(sum[:])
+}
+
+func passwordResetKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "password_reset:" + hex.EncodeToString(sum[:])
+}
+
+func parseUnix(value string) time.Time {
+	sec, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
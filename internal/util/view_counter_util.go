@@ -0,0 +1,180 @@
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	viewPendingKey  = "article_views:pending"
+	viewFlushPrefix = "article_views:flushing:"
+	viewSeenPrefix  = "article_views:seen:"
+
+	// viewFlushLease is how long a drain may take before its views are taken for lost, when the
+	// process died between taking them out of the buffer and storing them.
+	viewFlushLease = 10 * time.Minute
+)
+
+// recoverViewsScript moves the views of an abandoned flush back into the buffer. It runs atomically,
+// so racing drains recover a flush only once.
+var recoverViewsScript = redis.NewScript(`
+local fields = redis.call('HGETALL', KEYS[1])
+for i = 1, #fields, 2 do
+	redis.call('HINCRBY', KEYS[2], fields[i], fields[i + 1])
+end
+redis.call('DEL', KEYS[1])
+return #fields / 2
+`)
+
+// takeViewsScript moves the buffer to a flush key, or returns 0 when no views are buffered.
+var takeViewsScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('RENAME', KEYS[1], KEYS[2])
+return 1
+`)
+
+var botUserAgentMarkers = []string{"bot", "crawl", "spider", "slurp", "preview", "curl", "wget", "python-requests", "go-http-client"}
+
+// ArticleDayViews is the number of views an article got on one (UTC) day.
+type ArticleDayViews struct {
+	ArticleID string
+	Date      time.Time
+	Views     int64
+}
+
+// ViewCounterUtil buffers article views in Redis until they are flushed to the database.
+// A visitor counts once per article per day. Visitors are only known by a hash of their address,
+// user agent, the article and the day, kept until the day is over; nothing else about them is stored.
+type ViewCounterUtil struct {
+	Redis *redis.Client
+	Now   func() time.Time
+}
+
+func NewViewCounterUtil(redisClient *redis.Client) *ViewCounterUtil {
+	return &ViewCounterUtil{
+		Redis: redisClient,
+		Now:   time.Now,
+	}
+}
+
+// Record counts a view of the article and reports whether it was counted. Repeated views by the
+// same visitor on the same day and views by crawlers are not.
+func (v *ViewCounterUtil) Record(ctx context.Context, articleID string, ip string, userAgent string) (bool, error) {
+	if IsBotUserAgent(userAgent) {
+		return false, nil
+	}
+
+	now := v.Now().UTC()
+	day := now.Format(time.DateOnly)
+	endOfDay := now.Truncate(24 * time.Hour).Add(24 * time.Hour)
+
+	first, err := v.Redis.SetNX(ctx, viewSeenKey(day, articleID, ip, userAgent), 1, endOfDay.Sub(now)).Result()
+	if err != nil || !first {
+		return false, err
+	}
+
+	if err := v.Redis.HIncrBy(ctx, viewPendingKey, articleID+"|"+day, 1).Err(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Drain hands the buffered views to apply and forgets them once apply succeeds. When apply fails the
+// views go back into the buffer for the next flush, and views left behind by a drain that never
+// finished are recovered once its lease is over. Concurrent drains never see the same views.
+func (v *ViewCounterUtil) Drain(ctx context.Context, apply func([]ArticleDayViews) error) error {
+	if err := v.recoverAbandoned(ctx); err != nil {
+		return err
+	}
+
+	flushKey := viewFlushPrefix + strconv.FormatInt(v.Now().Unix(), 10) + ":" + uuid.New().String()
+	taken, err := takeViewsScript.Run(ctx, v.Redis, []string{viewPendingKey, flushKey}).Int()
+	if err != nil {
+		return err
+	}
+	if taken == 0 {
+		return nil
+	}
+
+	fields, err := v.Redis.HGetAll(ctx, flushKey).Result()
+	if err != nil {
+		return err
+	}
+
+	views := make([]ArticleDayViews, 0, len(fields))
+	for field, value := range fields {
+		articleID, day, ok := strings.Cut(field, "|")
+		date, dateErr := time.Parse(time.DateOnly, day)
+		count, countErr := strconv.ParseInt(value, 10, 64)
+		if !ok || dateErr != nil || countErr != nil {
+			continue
+		}
+		views = append(views, ArticleDayViews{ArticleID: articleID, Date: date, Views: count})
+	}
+
+	if err := apply(views); err != nil {
+		return errors.Join(err, v.restore(ctx, flushKey, fields))
+	}
+	return v.Redis.Del(ctx, flushKey).Err()
+}
+
+// recoverAbandoned puts back the views of flushes started longer than the lease ago. Flush keys
+// carry the time they were taken; keys without one predate that and are recovered too.
+func (v *ViewCounterUtil) recoverAbandoned(ctx context.Context) error {
+	expired := v.Now().Add(-viewFlushLease).Unix()
+
+	iter := v.Redis.Scan(ctx, 0, viewFlushPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		stamp, _, _ := strings.Cut(strings.TrimPrefix(key, viewFlushPrefix), ":")
+		if started, err := strconv.ParseInt(stamp, 10, 64); err == nil && started > expired {
+			continue
+		}
+		if err := recoverViewsScript.Run(ctx, v.Redis, []string{key, viewPendingKey}).Err(); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+func (v *ViewCounterUtil) restore(ctx context.Context, flushKey string, fields map[string]string) error {
+	_, err := v.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for field, value := range fields {
+			if count, err := strconv.ParseInt(value, 10, 64); err == nil {
+				pipe.HIncrBy(ctx, viewPendingKey, field, count)
+			}
+		}
+		pipe.Del(ctx, flushKey)
+		return nil
+	})
+	return err
+}
+
+// IsBotUserAgent tells crawlers, link previews and scripts apart from readers.
+func IsBotUserAgent(userAgent string) bool {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	if ua == "" {
+		return true
+	}
+	for _, marker := range botUserAgentMarkers {
+		if strings.Contains(ua, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func viewSeenKey(day string, articleID string, ip string, userAgent string) string {
+	sum := sha256.Sum256([]byte(day + "|" + articleID + "|" + ip + "|" + userAgent))
+	return viewSeenPrefix + hex.EncodeToString(sum[:])
+}
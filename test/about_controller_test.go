@@ -10,8 +10,10 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
 	"pura-agung-kertajaya-backend/internal/model"
 	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
 )
@@ -21,6 +23,11 @@ func setupAboutController(mockUC *usecasemock.AboutUsecaseMock) *fiber.App {
 
 	controller := httpdelivery.NewAboutController(mockUC, logger)
 
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(middleware.CtxEntityType, "pura")
+		return c.Next()
+	})
+
 	api := app.Group("/api")
 	api.Get("/about", controller.GetAll)
 	api.Get("/about/:id", controller.GetByID)
@@ -39,7 +46,7 @@ func TestAboutController_GetAllPublic_Success(t *testing.T) {
 	app := setupAboutController(mockUC)
 
 	items := []model.AboutSectionResponse{{ID: "1", EntityType: "pura", Title: "A"}, {ID: "2", EntityType: "pura", Title: "B"}}
-	mockUC.On("GetPublic", "pura").Return(items, nil)
+	mockUC.On("GetPublic", "pura", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/public/about?entity_type=pura", nil)
 	resp, _ := app.Test(req)
@@ -52,7 +59,7 @@ func TestAboutController_GetAllPublic_Error(t *testing.T) {
 	mockUC := &usecasemock.AboutUsecaseMock{}
 	app := setupAboutController(mockUC)
 
-	mockUC.On("GetPublic", "").Return(([]model.AboutSectionResponse)(nil), errors.New("db error"))
+	mockUC.On("GetPublic", "", mock.Anything).Return(([]model.AboutSectionResponse)(nil), nil, errors.New("db error"))
 	req := httptest.NewRequest("GET", "/api/public/about", nil)
 	resp, _ := app.Test(req)
 	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
@@ -67,7 +74,7 @@ func TestAboutController_GetAll_Success(t *testing.T) {
 	app := setupAboutController(mockUC)
 
 	items := []model.AboutSectionResponse{{ID: "1", EntityType: "pura", Title: "A"}, {ID: "2", EntityType: "pura", Title: "B"}}
-	mockUC.On("GetAll", "").Return(items, nil)
+	mockUC.On("GetAll", "pura", mock.Anything).Return(items, nil, nil)
 	req := httptest.NewRequest("GET", "/api/about", nil)
 	resp, _ := app.Test(req)
 	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
@@ -78,7 +85,7 @@ func TestAboutController_GetByID_Success(t *testing.T) {
 	app := setupAboutController(mockUC)
 
 	item := &model.AboutSectionResponse{ID: "x", Title: "X"}
-	mockUC.On("GetByID", "x").Return(item, nil)
+	mockUC.On("GetByID", "pura", "x").Return(item, nil)
 	req := httptest.NewRequest("GET", "/api/about/x", nil)
 	resp, _ := app.Test(req)
 	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
@@ -89,7 +96,7 @@ func TestAboutController_GetByID_NotFound(t *testing.T) {
 	app := setupAboutController(mockUC)
 
 	expectedErr := model.ErrNotFound("about section not found")
-	mockUC.On("GetByID", "missing").Return((*model.AboutSectionResponse)(nil), expectedErr)
+	mockUC.On("GetByID", "pura", "missing").Return((*model.AboutSectionResponse)(nil), expectedErr)
 
 	req := httptest.NewRequest("GET", "/api/about/missing", nil)
 	resp, _ := app.Test(req)
@@ -106,7 +113,7 @@ func TestAboutController_Create_Success(t *testing.T) {
 
 	reqBody := model.AboutSectionRequest{EntityType: "pura", Title: "T", Description: "D", IsActive: true}
 	resBody := &model.AboutSectionResponse{ID: "1", EntityType: "pura", Title: "T"}
-	mockUC.On("Create", reqBody).Return(resBody, nil)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return(resBody, nil)
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/about", bytes.NewReader(b))
 	req.Header.Set("Content-Type", "application/json")
@@ -126,7 +133,7 @@ func TestAboutController_Create_Validation_Error(t *testing.T) {
 	}
 	realValErr := validate.Struct(Dummy{})
 
-	mockUC.On("Create", reqBody).Return((*model.AboutSectionResponse)(nil), realValErr)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return((*model.AboutSectionResponse)(nil), realValErr)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/about", bytes.NewReader(b))
@@ -141,7 +148,7 @@ func TestAboutController_Update_Success(t *testing.T) {
 
 	reqBody := model.AboutSectionRequest{Title: "New", Description: "D", IsActive: true}
 	resBody := &model.AboutSectionResponse{ID: "2", Title: "New"}
-	mockUC.On("Update", "2", reqBody).Return(resBody, nil)
+	mockUC.On("Update", mock.Anything, "pura", "2", reqBody).Return(resBody, nil)
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/about/2", bytes.NewReader(b))
 	req.Header.Set("Content-Type", "application/json")
@@ -154,7 +161,7 @@ func TestAboutController_Update_NotFound(t *testing.T) {
 	app := setupAboutController(mockUC)
 
 	reqBody := model.AboutSectionRequest{Title: "N", Description: "D", IsActive: true}
-	mockUC.On("Update", "3", reqBody).Return((*model.AboutSectionResponse)(nil), model.ErrNotFound("about section not found"))
+	mockUC.On("Update", mock.Anything, "pura", "3", reqBody).Return((*model.AboutSectionResponse)(nil), model.ErrNotFound("about section not found"))
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/about/3", bytes.NewReader(b))
@@ -167,7 +174,7 @@ func TestAboutController_Delete_Success(t *testing.T) {
 	mockUC := &usecasemock.AboutUsecaseMock{}
 	app := setupAboutController(mockUC)
 
-	mockUC.On("Delete", "7").Return(nil)
+	mockUC.On("Delete", mock.Anything, "pura", "7").Return(nil)
 	req := httptest.NewRequest("DELETE", "/api/about/7", nil)
 	resp, _ := app.Test(req)
 	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
@@ -177,7 +184,7 @@ func TestAboutController_Delete_UsecaseError(t *testing.T) {
 	mockUC := &usecasemock.AboutUsecaseMock{}
 	app := setupAboutController(mockUC)
 
-	mockUC.On("Delete", "8").Return(errors.New("delete failed"))
+	mockUC.On("Delete", mock.Anything, "pura", "8").Return(errors.New("delete failed"))
 	req := httptest.NewRequest("DELETE", "/api/about/8", nil)
 	resp, _ := app.Test(req)
 	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
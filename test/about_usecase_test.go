@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"regexp"
 	"testing"
 
@@ -73,7 +74,7 @@ func TestAboutUsecase_Create_WithValues(t *testing.T) {
 		WithArgs(sqlmock.AnyArg()).
 		WillReturnRows(rowsValues)
 
-	res, err := u.Create(req)
+	res, err := u.Create(context.Background(), req.EntityType, req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
@@ -99,7 +100,7 @@ func TestAboutUsecase_GetPublic_FilterActive(t *testing.T) {
 		WithArgs("uuid-1").
 		WillReturnRows(rowsValues)
 
-	list, err := u.GetPublic("pura")
+	list, _, err := u.GetPublic("pura", &model.ListRequest{})
 
 	assert.NoError(t, err)
 	assert.Len(t, list, 1)
@@ -109,11 +110,11 @@ func TestAboutUsecase_GetPublic_FilterActive(t *testing.T) {
 func TestAboutUsecase_GetByID_NotFound(t *testing.T) {
 	u, mock := setupMockAboutUsecase(t)
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `about_section` WHERE id = ?")).
-		WithArgs("missing", 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `about_section` WHERE id = ? AND entity_type = ?")).
+		WithArgs("missing", "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.GetByID("missing")
+	res, err := u.GetByID("pura", "missing")
 	assert.Error(t, err)
 	assert.Nil(t, res)
 
@@ -128,7 +129,7 @@ func TestAboutUsecase_Update_ReplacesValues(t *testing.T) {
 	targetID := "ab-1"
 
 	req := model.AboutSectionRequest{
-		EntityType:  "yayasan",
+		EntityType:  "pura",
 		Title:       "New",
 		Description: "nd",
 		Images:      map[string]string{"lg": "https://img.com/lg.jpg"},
@@ -138,14 +139,14 @@ func TestAboutUsecase_Update_ReplacesValues(t *testing.T) {
 		},
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `about_section` WHERE id = ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `about_section` WHERE id = ? AND entity_type = ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "title", "images"}).
 			AddRow(targetID, "pura", "Old", []byte(`{}`)))
 
 	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta("UPDATE `about_section`")).
-		WithArgs("yayasan", "New", "nd", sqlmock.AnyArg(), false, sqlmock.AnyArg(), sqlmock.AnyArg(), targetID).
+		WithArgs("pura", "New", "nd", sqlmock.AnyArg(), false, sqlmock.AnyArg(), sqlmock.AnyArg(), targetID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `about_values` WHERE about_id = ?")).
@@ -158,7 +159,7 @@ func TestAboutUsecase_Update_ReplacesValues(t *testing.T) {
 	mock.ExpectCommit()
 
 	rowsResult := sqlmock.NewRows([]string{"id", "entity_type", "title", "description", "images", "is_active"}).
-		AddRow(targetID, "yayasan", "New", "nd", []byte(`{"lg":"https://img.com/lg.jpg"}`), false)
+		AddRow(targetID, "pura", "New", "nd", []byte(`{"lg":"https://img.com/lg.jpg"}`), false)
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `about_section`")).
 		WithArgs(targetID, targetID, 1).
 		WillReturnRows(rowsResult)
@@ -167,7 +168,7 @@ func TestAboutUsecase_Update_ReplacesValues(t *testing.T) {
 		WithArgs(targetID).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "about_id", "title"}).AddRow("v1", targetID, "New1"))
 
-	res, err := u.Update(targetID, req)
+	res, err := u.Update(context.Background(), "pura", targetID, req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
@@ -186,11 +187,11 @@ func TestAboutUsecase_Update_NotFound(t *testing.T) {
 		IsActive:    true,
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `about_section` WHERE id = ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `about_section` WHERE id = ? AND entity_type = ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.Update(targetID, req)
+	res, err := u.Update(context.Background(), "pura", targetID, req)
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
@@ -206,9 +207,9 @@ func TestAboutUsecase_Delete_Success(t *testing.T) {
 	u, mock := setupMockAboutUsecase(t)
 	targetID := "to-del"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `about_section` WHERE id = ?")).
-		WithArgs(targetID).
-		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `about_section` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type"}).AddRow(targetID, "pura"))
 
 	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `about_section` WHERE `about_section`.`id` = ?")).
@@ -216,7 +217,7 @@ func TestAboutUsecase_Delete_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 	assert.NoError(t, err)
 }
 
@@ -224,11 +225,11 @@ func TestAboutUsecase_Delete_NotFound(t *testing.T) {
 	u, mock := setupMockAboutUsecase(t)
 	targetID := "missing"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `about_section` WHERE id = ?")).
-		WithArgs(targetID).
-		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `about_section` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
+		WillReturnRows(sqlmock.NewRows(nil))
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 
 	assert.Error(t, err)
 	var e *model.ResponseError
@@ -236,3 +237,61 @@ func TestAboutUsecase_Delete_NotFound(t *testing.T) {
 	assert.Equal(t, 404, e.Code)
 	assert.Equal(t, "about section not found", e.Message)
 }
+
+func TestAboutUsecase_Delete_OtherEntity_NotFound(t *testing.T) {
+	u, mock := setupMockAboutUsecase(t)
+	targetID := "ab-1"
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `about_section` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "yayasan", 1).
+		WillReturnRows(sqlmock.NewRows(nil))
+
+	err := u.Delete(context.Background(), "yayasan", targetID)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 404, e.Code)
+		assert.Equal(t, "about section not found", e.Message)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAboutUsecase_Create_OtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockAboutUsecase(t)
+
+	req := model.AboutSectionRequest{
+		EntityType:  "pura",
+		Title:       "About Title",
+		Description: "About Description",
+		Images:      map[string]string{"lg": "https://img.com/lg.jpg"},
+	}
+
+	res, err := u.Create(context.Background(), "yayasan", req)
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAboutUsecase_Update_MoveToOtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockAboutUsecase(t)
+
+	req := model.AboutSectionRequest{
+		EntityType:  "yayasan",
+		Title:       "New",
+		Description: "nd",
+		Images:      map[string]string{"lg": "https://img.com/lg.jpg"},
+	}
+
+	res, err := u.Update(context.Background(), "pura", "ab-1", req)
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -6,10 +6,12 @@ import (
 	"errors"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
 	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
@@ -32,6 +34,9 @@ func setupActivityController(mockUC *usecasemock.ActivityUsecaseMock) *fiber.App
 	api.Post("/activities", controller.Create)
 	api.Put("/activities/:id", controller.Update)
 	api.Delete("/activities/:id", controller.Delete)
+	api.Get("/activities/:id/exceptions", controller.GetExceptions)
+	api.Put("/activities/:id/exceptions/:date", controller.SaveException)
+	api.Delete("/activities/:id/exceptions/:date", controller.DeleteException)
 
 	publicApi := app.Group("/api/public")
 	publicApi.Get("/activities", controller.GetAllPublic)
@@ -44,7 +49,7 @@ func TestActivityController_GetAllPublic_Success(t *testing.T) {
 	app := setupActivityController(mockUC)
 
 	items := []model.ActivityResponse{{ID: "1", Title: "A"}, {ID: "2", Title: "B"}}
-	mockUC.On("GetPublic", "").Return(items, nil)
+	mockUC.On("GetPublic", "", mock.Anything, (*model.DateRange)(nil)).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/public/activities", nil)
 	resp, _ := app.Test(req, -1)
@@ -56,7 +61,7 @@ func TestActivityController_GetAllPublic_Error(t *testing.T) {
 	mockUC := &usecasemock.ActivityUsecaseMock{}
 	app := setupActivityController(mockUC)
 
-	mockUC.On("GetPublic", "").Return(([]model.ActivityResponse)(nil), errors.New("db error"))
+	mockUC.On("GetPublic", "", mock.Anything, (*model.DateRange)(nil)).Return(([]model.ActivityResponse)(nil), nil, errors.New("db error"))
 
 	req := httptest.NewRequest("GET", "/api/public/activities", nil)
 	resp, _ := app.Test(req, -1)
@@ -72,7 +77,7 @@ func TestActivityController_GetAll_Success(t *testing.T) {
 	app := setupActivityController(mockUC)
 
 	items := []model.ActivityResponse{{ID: "1", Title: "A"}, {ID: "2", Title: "B"}}
-	mockUC.On("GetAll", "pura").Return(items, nil)
+	mockUC.On("GetAll", "pura", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/activities", nil)
 	resp, _ := app.Test(req, -1)
@@ -84,7 +89,7 @@ func TestActivityController_GetAll_Error(t *testing.T) {
 	mockUC := &usecasemock.ActivityUsecaseMock{}
 	app := setupActivityController(mockUC)
 
-	mockUC.On("GetAll", "pura").Return(nil, errors.New("db error"))
+	mockUC.On("GetAll", "pura", mock.Anything).Return(nil, nil, errors.New("db error"))
 
 	req := httptest.NewRequest("GET", "/api/activities", nil)
 	resp, _ := app.Test(req, -1)
@@ -100,7 +105,7 @@ func TestActivityController_GetByID_Success(t *testing.T) {
 	app := setupActivityController(mockUC)
 
 	item := &model.ActivityResponse{ID: "x", Title: "X"}
-	mockUC.On("GetByID", "x").Return(item, nil)
+	mockUC.On("GetByID", "pura", "x").Return(item, nil)
 
 	req := httptest.NewRequest("GET", "/api/activities/x", nil)
 	resp, _ := app.Test(req, -1)
@@ -115,7 +120,7 @@ func TestActivityController_GetByID_NotFound(t *testing.T) {
 	mockUC := &usecasemock.ActivityUsecaseMock{}
 	app := setupActivityController(mockUC)
 
-	mockUC.On("GetByID", "missing").Return((*model.ActivityResponse)(nil), model.ErrNotFound("activity not found"))
+	mockUC.On("GetByID", "pura", "missing").Return((*model.ActivityResponse)(nil), model.ErrNotFound("activity not found"))
 
 	req := httptest.NewRequest("GET", "/api/activities/missing", nil)
 	resp, _ := app.Test(req, -1)
@@ -133,7 +138,7 @@ func TestActivityController_Create_Success(t *testing.T) {
 	reqBody := model.CreateActivityRequest{EntityType: "pura", Title: "T", Description: "D", OrderIndex: 1, IsActive: true}
 	resBody := &model.ActivityResponse{ID: "1", Title: "T"}
 
-	mockUC.On("Create", "pura", reqBody).Return(resBody, nil)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return(resBody, nil)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/activities", bytes.NewReader(b))
@@ -157,7 +162,7 @@ func TestActivityController_Create_BadRequest_Date(t *testing.T) {
 	app := setupActivityController(mockUC)
 
 	reqBody := model.CreateActivityRequest{Title: "Bad Date"}
-	mockUC.On("Create", "pura", reqBody).Return((*model.ActivityResponse)(nil), model.ErrBadRequest("invalid date"))
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return((*model.ActivityResponse)(nil), model.ErrBadRequest("invalid date"))
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/activities", bytes.NewReader(b))
@@ -181,7 +186,7 @@ func TestActivityController_Create_ValidationError(t *testing.T) {
 	}
 	realValErr := validate.Struct(Dummy{})
 
-	mockUC.On("Create", "pura", reqBody).Return((*model.ActivityResponse)(nil), realValErr)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return((*model.ActivityResponse)(nil), realValErr)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/activities", bytes.NewReader(b))
@@ -197,7 +202,7 @@ func TestActivityController_Update_Success(t *testing.T) {
 	reqBody := model.UpdateActivityRequest{Title: "New", Description: "D", IsActive: true, OrderIndex: 1}
 	resBody := &model.ActivityResponse{ID: "2", Title: "New"}
 
-	mockUC.On("Update", "2", reqBody).Return(resBody, nil)
+	mockUC.On("Update", mock.Anything, "pura", "2", reqBody).Return(resBody, nil)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/activities/2", bytes.NewReader(b))
@@ -211,7 +216,7 @@ func TestActivityController_Update_NotFound(t *testing.T) {
 	app := setupActivityController(mockUC)
 
 	reqBody := model.UpdateActivityRequest{Title: "New"}
-	mockUC.On("Update", "3", reqBody).Return((*model.ActivityResponse)(nil), model.ErrNotFound("activity not found"))
+	mockUC.On("Update", mock.Anything, "pura", "3", reqBody).Return((*model.ActivityResponse)(nil), model.ErrNotFound("activity not found"))
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/activities/3", bytes.NewReader(b))
@@ -225,7 +230,7 @@ func TestActivityController_Update_BadRequest_Date(t *testing.T) {
 	app := setupActivityController(mockUC)
 
 	reqBody := model.UpdateActivityRequest{Title: "Bad Date"}
-	mockUC.On("Update", "4", reqBody).Return((*model.ActivityResponse)(nil), model.ErrBadRequest("invalid date"))
+	mockUC.On("Update", mock.Anything, "pura", "4", reqBody).Return((*model.ActivityResponse)(nil), model.ErrBadRequest("invalid date"))
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/activities/4", bytes.NewReader(b))
@@ -238,7 +243,7 @@ func TestActivityController_Delete_Success(t *testing.T) {
 	mockUC := &usecasemock.ActivityUsecaseMock{}
 	app := setupActivityController(mockUC)
 
-	mockUC.On("Delete", "7").Return(nil)
+	mockUC.On("Delete", mock.Anything, "pura", "7").Return(nil)
 
 	req := httptest.NewRequest("DELETE", "/api/activities/7", nil)
 	resp, _ := app.Test(req, -1)
@@ -249,7 +254,7 @@ func TestActivityController_Delete_NotFound(t *testing.T) {
 	mockUC := &usecasemock.ActivityUsecaseMock{}
 	app := setupActivityController(mockUC)
 
-	mockUC.On("Delete", "8").Return(model.ErrNotFound("activity not found"))
+	mockUC.On("Delete", mock.Anything, "pura", "8").Return(model.ErrNotFound("activity not found"))
 
 	req := httptest.NewRequest("DELETE", "/api/activities/8", nil)
 	resp, _ := app.Test(req, -1)
@@ -260,9 +265,77 @@ func TestActivityController_Delete_InternalError(t *testing.T) {
 	mockUC := &usecasemock.ActivityUsecaseMock{}
 	app := setupActivityController(mockUC)
 
-	mockUC.On("Delete", "9").Return(errors.New("db error"))
+	mockUC.On("Delete", mock.Anything, "pura", "9").Return(errors.New("db error"))
 
 	req := httptest.NewRequest("DELETE", "/api/activities/9", nil)
 	resp, _ := app.Test(req, -1)
 	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
 }
+
+func TestActivityController_GetAllPublic_Window(t *testing.T) {
+	mockUC := &usecasemock.ActivityUsecaseMock{}
+	app := setupActivityController(mockUC)
+
+	window := &model.DateRange{From: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 7, 31, 0, 0, 0, 0, time.UTC)}
+	mockUC.On("GetPublic", "pasraman", mock.MatchedBy(func(req *model.ListRequest) bool {
+		return len(req.Filters) == 0
+	}), window).Return([]model.ActivityResponse{{ID: "class", OccurrenceDate: "2026-07-04"}}, nil, nil)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/api/public/activities?entity_type=pasraman&from=2026-07-01", nil), -1)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestActivityController_GetAllPublic_InvalidWindow(t *testing.T) {
+	mockUC := &usecasemock.ActivityUsecaseMock{}
+	app := setupActivityController(mockUC)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/api/public/activities?from=01-07-2026", nil), -1)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUC.AssertNotCalled(t, "GetPublic")
+}
+
+func TestActivityController_SaveException_Success(t *testing.T) {
+	mockUC := &usecasemock.ActivityUsecaseMock{}
+	app := setupActivityController(mockUC)
+
+	req := model.ActivityExceptionRequest{IsCancelled: true}
+	mockUC.On("SaveException", mock.Anything, "pura", "class", "2026-07-11", req).
+		Return(&model.ActivityExceptionResponse{ID: "e1", ActivityID: "class", OccurrenceDate: "2026-07-11", IsCancelled: true}, nil)
+
+	body, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest("PUT", "/api/activities/class/exceptions/2026-07-11", bytes.NewReader(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(httpReq, -1)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestActivityController_SaveException_NotAnOccurrence(t *testing.T) {
+	mockUC := &usecasemock.ActivityUsecaseMock{}
+	app := setupActivityController(mockUC)
+
+	mockUC.On("SaveException", mock.Anything, "pura", "class", "2026-07-10", mock.Anything).
+		Return(nil, model.ErrBadRequest("activity does not occur on 2026-07-10"))
+
+	httpReq := httptest.NewRequest("PUT", "/api/activities/class/exceptions/2026-07-10", bytes.NewReader([]byte(`{"is_cancelled":true}`)))
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(httpReq, -1)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestActivityController_DeleteException_Success(t *testing.T) {
+	mockUC := &usecasemock.ActivityUsecaseMock{}
+	app := setupActivityController(mockUC)
+
+	mockUC.On("DeleteException", mock.Anything, "pura", "class", "2026-07-11").Return(nil)
+
+	resp, _ := app.Test(httptest.NewRequest("DELETE", "/api/activities/class/exceptions/2026-07-11", nil), -1)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
@@ -1,8 +1,10 @@
 package test
 
 import (
+	"context"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-playground/validator/v10"
@@ -60,11 +62,13 @@ func TestActivityUsecase_Create_Success(t *testing.T) {
 			true,
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			"",
+			"",
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	res, err := u.Create(req.EntityType, req)
+	res, err := u.Create(context.Background(), req.EntityType, req)
 	assert.NoError(t, err)
 	if assert.NotNil(t, res) {
 		assert.Equal(t, req.Title, res.Title)
@@ -75,7 +79,7 @@ func TestActivityUsecase_Create_Success(t *testing.T) {
 func TestActivityUsecase_Create_ValidationError(t *testing.T) {
 	u, _ := setupMockActivityUsecase(t)
 	req := model.CreateActivityRequest{}
-	res, err := u.Create("pura", req)
+	res, err := u.Create(context.Background(), "pura", req)
 	assert.Error(t, err)
 	assert.Nil(t, res)
 }
@@ -90,7 +94,7 @@ func TestActivityUsecase_Create_BadRequest_Date(t *testing.T) {
 		EventDate:   "invalid-date",
 		OrderIndex:  1,
 	}
-	res, err := u.Create("pura", req)
+	res, err := u.Create(context.Background(), "pura", req)
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
@@ -107,7 +111,7 @@ func TestActivityUsecase_GetAll_OrderedByIndex(t *testing.T) {
 		WithArgs("pura").
 		WillReturnRows(rows)
 
-	list, err := u.GetAll("pura")
+	list, _, err := u.GetAll("pura", &model.ListRequest{})
 	assert.NoError(t, err)
 	assert.Len(t, list, 2)
 	assert.Equal(t, "A", list[0].Title)
@@ -125,7 +129,7 @@ func TestActivityUsecase_GetPublic_FilterActiveAndOrder(t *testing.T) {
 		WithArgs("pura", true).
 		WillReturnRows(rows)
 
-	list, err := u.GetPublic("pura")
+	list, _, err := u.GetPublic("pura", &model.ListRequest{}, nil)
 	assert.NoError(t, err)
 	assert.Len(t, list, 2)
 	assert.Equal(t, "A", list[0].Title)
@@ -138,11 +142,11 @@ func TestActivityUsecase_GetByID_Success(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{"id", "title"}).AddRow(id, "Title")
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? LIMIT ?")).
-		WithArgs(id, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(id, "pura", 1).
 		WillReturnRows(rows)
 
-	res, err := u.GetByID(id)
+	res, err := u.GetByID("pura", id)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
 	assert.Equal(t, "Title", res.Title)
@@ -151,11 +155,11 @@ func TestActivityUsecase_GetByID_Success(t *testing.T) {
 func TestActivityUsecase_GetByID_NotFound(t *testing.T) {
 	u, mock := setupMockActivityUsecase(t)
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? LIMIT ?")).
-		WithArgs("missing", 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs("missing", "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.GetByID("missing")
+	res, err := u.GetByID("pura", "missing")
 	assert.Error(t, err)
 	assert.Nil(t, res)
 
@@ -180,8 +184,8 @@ func TestActivityUsecase_Update_Success(t *testing.T) {
 		IsActive:    false,
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "title"}).AddRow(targetID, "pura", "Old"))
 
 	mock.ExpectBegin()
@@ -197,12 +201,14 @@ func TestActivityUsecase_Update_Success(t *testing.T) {
 			false,
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			"",
+			"",
 			targetID,
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	res, err := u.Update(targetID, req)
+	res, err := u.Update(context.Background(), "pura", targetID, req)
 	assert.NoError(t, err)
 	if assert.NotNil(t, res) {
 		assert.Equal(t, "New", res.Title)
@@ -222,11 +228,11 @@ func TestActivityUsecase_Update_NotFound(t *testing.T) {
 		OrderIndex:  1,
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.Update(targetID, req)
+	res, err := u.Update(context.Background(), "pura", targetID, req)
 	assert.Error(t, err)
 	assert.Nil(t, res)
 
@@ -248,7 +254,7 @@ func TestActivityUsecase_Update_BadRequest_Date(t *testing.T) {
 		OrderIndex:  1,
 	}
 
-	res, err := u.Update(targetID, req)
+	res, err := u.Update(context.Background(), "pura", targetID, req)
 	assert.Error(t, err)
 	assert.Nil(t, res)
 }
@@ -257,8 +263,8 @@ func TestActivityUsecase_Delete_Success(t *testing.T) {
 	u, mock := setupMockActivityUsecase(t)
 	targetID := "to-del"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(targetID, "Del"))
 
 	mock.ExpectBegin()
@@ -267,7 +273,7 @@ func TestActivityUsecase_Delete_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 	assert.NoError(t, err)
 }
 
@@ -275,11 +281,11 @@ func TestActivityUsecase_Delete_NotFound(t *testing.T) {
 	u, mock := setupMockActivityUsecase(t)
 	targetID := "missing"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 	assert.Error(t, err)
 
 	var e *model.ResponseError
@@ -288,3 +294,170 @@ func TestActivityUsecase_Delete_NotFound(t *testing.T) {
 		assert.Equal(t, "activity not found", e.Message)
 	}
 }
+
+func TestActivityUsecase_Delete_OtherEntity_NotFound(t *testing.T) {
+	u, mock := setupMockActivityUsecase(t)
+	targetID := "act-1"
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "yayasan", 1).
+		WillReturnRows(sqlmock.NewRows(nil))
+
+	err := u.Delete(context.Background(), "yayasan", targetID)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 404, e.Code)
+		assert.Equal(t, "activity not found", e.Message)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActivityUsecase_GetPublic_ExpandsOccurrences(t *testing.T) {
+	u, mock := setupMockActivityUsecase(t)
+	from, to := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 7, 31, 0, 0, 0, 0, time.UTC)
+	end := to.AddDate(0, 0, 1)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE entity_type = ? AND is_active = ? AND ((recurrence_rule = '' AND pawukon_rule = '' AND event_date >= ? AND event_date < ?) OR ((recurrence_rule <> '' OR pawukon_rule <> '') AND event_date < ?)) ORDER BY event_date DESC,order_index ASC,id ASC")).
+		WithArgs("pasraman", true, from, end, end).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "event_date", "order_index", "time_info", "recurrence_rule", "pawukon_rule"}).
+			AddRow("once", "Rapat", time.Date(2026, 7, 15, 0, 0, 0, 0, time.UTC), 1, "", "", "").
+			AddRow("class", "Kelas Dharma", time.Date(2026, 6, 6, 9, 0, 0, 0, time.UTC), 2, "09:00", "FREQ=WEEKLY;BYDAY=SA", "").
+			AddRow("odalan", "Piodalan", time.Date(2025, 12, 24, 0, 0, 0, 0, time.UTC), 1, "", "", "Buda Kliwon Pahang"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activity_exceptions` WHERE activity_id IN (?,?) AND ((occurrence_date >= ? AND occurrence_date < ?) OR (event_date >= ? AND event_date < ?))")).
+		WithArgs("class", "odalan", from, end, from, end).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "activity_id", "occurrence_date", "is_cancelled", "title", "event_date"}).
+			AddRow("e1", "class", time.Date(2026, 7, 11, 0, 0, 0, 0, time.UTC), true, "", nil).
+			AddRow("e2", "class", time.Date(2026, 7, 18, 0, 0, 0, 0, time.UTC), false, "Kelas Dharma Khusus", nil).
+			AddRow("e3", "class", time.Date(2026, 6, 27, 0, 0, 0, 0, time.UTC), false, "", time.Date(2026, 7, 2, 9, 0, 0, 0, time.UTC)))
+
+	list, paging, err := u.GetPublic("pasraman", &model.ListRequest{}, &model.DateRange{From: from, To: to})
+
+	assert.NoError(t, err)
+	assert.Nil(t, paging)
+	var got []string
+	for _, a := range list {
+		got = append(got, a.EventDate.Format("2006-01-02")+" "+a.Title+" "+a.OccurrenceDate)
+	}
+	assert.Equal(t, []string{
+		"2026-07-02 Kelas Dharma 2026-06-27",
+		"2026-07-04 Kelas Dharma 2026-07-04",
+		"2026-07-15 Rapat ",
+		"2026-07-18 Kelas Dharma Khusus 2026-07-18",
+		"2026-07-22 Piodalan 2026-07-22",
+		"2026-07-25 Kelas Dharma 2026-07-25",
+	}, got)
+	assert.Equal(t, 9, list[1].EventDate.Hour())
+}
+
+func TestActivityUsecase_GetPublic_WindowTooLong(t *testing.T) {
+	u, _ := setupMockActivityUsecase(t)
+
+	_, _, err := u.GetPublic("pura", &model.ListRequest{}, &model.DateRange{
+		From: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	assert.EqualError(t, err, "the window covers at most 366 days")
+}
+
+func TestActivityUsecase_Create_PawukonRuleMustMatchDate(t *testing.T) {
+	u, _ := setupMockActivityUsecase(t)
+
+	req := model.CreateActivityRequest{
+		EntityType:  "pura",
+		Title:       "Piodalan",
+		Description: "Piodalan pura",
+		EventDate:   "2026-07-23",
+		OrderIndex:  1,
+		PawukonRule: "Buda Kliwon Pahang",
+	}
+	_, err := u.Create(context.Background(), "pura", req)
+	assert.EqualError(t, err, "event_date falls on Wraspati Umanis Pahang, not Buda Kliwon Pahang")
+
+	req.PawukonRule, req.RecurrenceRule = "", "FREQ=WEEKLY;BYDAY=XX"
+	_, err = u.Create(context.Background(), "pura", req)
+	assert.EqualError(t, err, `invalid recurrence_rule: invalid BYDAY "XX"`)
+}
+
+func TestActivityUsecase_SaveException_CreatesOverride(t *testing.T) {
+	u, mock := setupMockActivityUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs("class", "pasraman", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "event_date", "recurrence_rule"}).
+			AddRow("class", "pasraman", time.Date(2026, 6, 6, 9, 0, 0, 0, time.UTC), "FREQ=WEEKLY;BYDAY=SA"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activity_exceptions` WHERE activity_id = ? AND occurrence_date = ? LIMIT ?")).
+		WithArgs("class", time.Date(2026, 7, 18, 0, 0, 0, 0, time.UTC), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activity_exceptions`")).
+		WithArgs(sqlmock.AnyArg(), "class", time.Date(2026, 7, 18, 0, 0, 0, 0, time.UTC), false, "", "", "", "Wantilan",
+			time.Date(2026, 7, 19, 9, 0, 0, 0, time.UTC), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	res, err := u.SaveException(context.Background(), "pasraman", "class", "2026-07-18", model.ActivityExceptionRequest{
+		Location:  "Wantilan",
+		EventDate: "2026-07-19",
+	})
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, res) {
+		assert.Equal(t, "2026-07-18", res.OccurrenceDate)
+		assert.Equal(t, "Wantilan", res.Location)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActivityUsecase_SaveException_NotAnOccurrence(t *testing.T) {
+	u, mock := setupMockActivityUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs("class", "pasraman", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "event_date", "recurrence_rule"}).
+			AddRow("class", "pasraman", time.Date(2026, 6, 6, 9, 0, 0, 0, time.UTC), "FREQ=WEEKLY;BYDAY=SA"))
+
+	_, err := u.SaveException(context.Background(), "pasraman", "class", "2026-07-17", model.ActivityExceptionRequest{IsCancelled: true})
+
+	assert.EqualError(t, err, "activity does not occur on 2026-07-17")
+}
+
+func TestActivityUsecase_DeleteException_NotRecurring(t *testing.T) {
+	u, mock := setupMockActivityUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs("once", "pura", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "event_date"}).
+			AddRow("once", "pura", time.Date(2026, 7, 15, 0, 0, 0, 0, time.UTC)))
+
+	err := u.DeleteException(context.Background(), "pura", "once", "2026-07-15")
+
+	assert.EqualError(t, err, "activity does not recur")
+}
+
+func TestActivityUsecase_Create_OtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockActivityUsecase(t)
+
+	res, err := u.Create(context.Background(), "yayasan", model.CreateActivityRequest{EntityType: "pura", Title: "Odalan", Description: "Piodalan", EventDate: "2026-10-21", OrderIndex: 1})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActivityUsecase_Update_MoveToOtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockActivityUsecase(t)
+
+	res, err := u.Update(context.Background(), "pura", "act-1", model.UpdateActivityRequest{EntityType: "yayasan", Title: "Odalan", Description: "Piodalan", EventDate: "2026-10-21", OrderIndex: 1})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,186 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
+	"pura-agung-kertajaya-backend/internal/model"
+	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
+)
+
+func setupAPIKeyController(auth *middleware.Auth) (*fiber.App, *usecasemock.APIKeyUsecaseMock) {
+	mockUC := &usecasemock.APIKeyUsecaseMock{}
+	app, logger, _ := NewTestApp()
+
+	controller := httpdelivery.NewAPIKeyController(mockUC, logger)
+
+	authMiddleware := func(c *fiber.Ctx) error {
+		c.Locals("user", auth)
+		return c.Next()
+	}
+	superOnly := middleware.SuperAdminMiddleware()
+
+	app.Post("/api/api-keys", authMiddleware, superOnly, controller.Create)
+	app.Post("/api/api-keys/:id/_rotate", authMiddleware, superOnly, controller.Rotate)
+	app.Post("/api/api-keys/:id/_revoke", authMiddleware, superOnly, controller.Revoke)
+
+	return app, mockUC
+}
+
+// setupAPIKeyAuthApp wires the real AuthMiddleware so bearer keys are resolved through the usecase.
+func setupAPIKeyAuthApp() (*fiber.App, *usecasemock.APIKeyUsecaseMock) {
+	apiKeyUC := &usecasemock.APIKeyUsecaseMock{}
+	permissionUC := &usecasemock.PermissionUsecaseMock{}
+	app, _, _ := NewTestApp()
+
+	echoEntity := func(c *fiber.Ctx) error {
+		entityType, _ := c.Locals(middleware.CtxEntityType).(string)
+		return c.SendString(entityType)
+	}
+
+	api := app.Group("/api", middleware.AuthMiddleware(nil, apiKeyUC), middleware.EntityTypeMiddleware(permissionUC))
+	api.Get("/articles", middleware.PermissionMiddleware(model.PermissionArticlesRead), echoEntity)
+	api.Post("/articles", middleware.PermissionMiddleware(model.PermissionArticlesWrite), echoEntity)
+	api.Get("/api-keys", middleware.SuperAdminMiddleware(), echoEntity)
+	api.Get("/users/_current", middleware.UserSessionMiddleware(), echoEntity)
+
+	return app, apiKeyUC
+}
+
+func TestAPIKeyController_Create_Success(t *testing.T) {
+	app, mockUC := setupAPIKeyController(&middleware.Auth{ID: "super-1", Role: "super"})
+
+	expected := &model.CreateAPIKeyRequest{
+		Name:        "Kiosk display",
+		EntityTypes: []string{"pura"},
+		Permissions: []string{model.PermissionActivitiesRead},
+	}
+	mockUC.On("Create", mock.Anything, "super-1", expected).Return(&model.APIKeySecretResponse{
+		APIKey: &model.APIKeyResponse{ID: "key-1", Name: "Kiosk display", Prefix: "pak_abcdefgh"},
+		Key:    "pak_abcdefghsecret",
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/api-keys", strings.NewReader(`{"name":"Kiosk display","entity_types":["pura"],"permissions":["activities:read"]}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var body model.WebResponse[model.APIKeySecretResponse]
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, "pak_abcdefghsecret", body.Data.Key)
+	assert.Equal(t, "key-1", body.Data.APIKey.ID)
+	mockUC.AssertExpectations(t)
+}
+
+func TestAPIKeyController_Create_ForbiddenForNonSuper(t *testing.T) {
+	app, mockUC := setupAPIKeyController(&middleware.Auth{ID: "user-1", Role: "pura"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/api-keys", strings.NewReader(`{"name":"x"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	mockUC.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyController_Revoke_NotFound(t *testing.T) {
+	app, mockUC := setupAPIKeyController(&middleware.Auth{ID: "super-1", Role: "super"})
+	mockUC.On("Revoke", mock.Anything, "missing").Return(model.ErrNotFound("api key not found"))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/api-keys/missing/_revoke", nil)
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestAPIKeyAuth_BearerKeyUsesItsScopes(t *testing.T) {
+	app, apiKeyUC := setupAPIKeyAuthApp()
+	apiKeyUC.On("Authenticate", mock.Anything, "pak_kiosk").Return(&model.APIKeyAuth{
+		ID:     "key-1",
+		Name:   "Kiosk",
+		Grants: model.PermissionGrants{"yayasan": {model.PermissionArticlesRead}},
+	}, nil)
+
+	status, body := doAPIKeyRequest(app, http.MethodGet, "/api/articles", "pak_kiosk")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "yayasan", body)
+
+	status, _ = doAPIKeyRequest(app, http.MethodGet, "/api/articles?entity_type=pura", "pak_kiosk")
+	assert.Equal(t, http.StatusForbidden, status)
+
+	status, _ = doAPIKeyRequest(app, http.MethodPost, "/api/articles", "pak_kiosk")
+	assert.Equal(t, http.StatusForbidden, status)
+}
+
+func TestAPIKeyAuth_KeyCannotActAsUser(t *testing.T) {
+	app, apiKeyUC := setupAPIKeyAuthApp()
+	apiKeyUC.On("Authenticate", mock.Anything, "pak_kiosk").Return(&model.APIKeyAuth{
+		ID:     "key-1",
+		Grants: model.PermissionGrants{"pura": {model.PermissionArticlesRead}},
+	}, nil)
+
+	status, _ := doAPIKeyRequest(app, http.MethodGet, "/api/api-keys", "pak_kiosk")
+	assert.Equal(t, http.StatusForbidden, status)
+
+	status, _ = doAPIKeyRequest(app, http.MethodGet, "/api/users/_current", "pak_kiosk")
+	assert.Equal(t, http.StatusForbidden, status)
+}
+
+func TestAPIKeyAuth_InvalidKey(t *testing.T) {
+	app, apiKeyUC := setupAPIKeyAuthApp()
+	apiKeyUC.On("Authenticate", mock.Anything, "pak_revoked").Return(nil, model.ErrUnauthorized("Invalid API key"))
+
+	status, _ := doAPIKeyRequest(app, http.MethodGet, "/api/articles", "pak_revoked")
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, _ = doAPIKeyRequest(app, http.MethodGet, "/api/articles", "not-an-api-key")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	apiKeyUC.AssertNotCalled(t, "Authenticate", mock.Anything, "not-an-api-key")
+}
+
+func TestAuthMiddleware_OtherSchemeFallsThroughToCookie(t *testing.T) {
+	tokenUtil, _ := setupTokenUtil(t)
+	apiKeyUC := &usecasemock.APIKeyUsecaseMock{}
+	app, _, _ := NewTestApp()
+	app.Get("/api/users/_current", middleware.AuthMiddleware(tokenUtil, apiKeyUC), func(c *fiber.Ctx) error {
+		return c.SendString(middleware.GetUser(c).ID)
+	})
+
+	pair, err := tokenUtil.CreateToken(context.Background(), &model.Auth{ID: "user-1", Role: "pura"}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users/_current", nil)
+	req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+	req.AddCookie(&http.Cookie{Name: "access_token", Value: pair.AccessToken})
+	resp, _ := app.Test(req, -1)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "a proxy's Basic credentials must not shadow the session")
+	assert.Equal(t, "user-1", string(body))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/users/_current", nil)
+	req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+	resp, _ = app.Test(req, -1)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	apiKeyUC.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+}
+
+func doAPIKeyRequest(app *fiber.App, method string, target string, key string) (int, string) {
+	req := httptest.NewRequest(method, target, nil)
+	req.Header.Set("Authorization", "Bearer "+key)
+	resp, _ := app.Test(req, -1)
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
@@ -0,0 +1,220 @@
+package test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/repository"
+	"pura-agung-kertajaya-backend/internal/usecase"
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+func setupMockAPIKeyUsecase(t *testing.T) (usecase.APIKeyUseCase, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub db: %v", err)
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+
+	_, logger, _ := NewTestApp()
+	u := usecase.NewAPIKeyUseCase(gormDB, validator.New(), repository.NewAPIKeyRepository(logger))
+	return u, mock
+}
+
+func TestAPIKeyUsecase_Create_StoresHashAndScopes(t *testing.T) {
+	u, mock := setupMockAPIKeyUsecase(t)
+
+	req := &model.CreateAPIKeyRequest{
+		Name:        "Kiosk display",
+		EntityTypes: []string{"pura", "yayasan"},
+		Permissions: []string{model.PermissionActivitiesRead, model.PermissionArticlesRead},
+	}
+
+	var storedHash string
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `api_keys`")).
+		WithArgs(sqlmock.AnyArg(), "Kiosk display", sqlmock.AnyArg(), hashCapture{&storedHash}, "user-1", nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `api_key_scopes` WHERE api_key_id = ?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `api_key_scopes`")).
+		WithArgs(
+			sqlmock.AnyArg(), "pura", model.PermissionActivitiesRead,
+			sqlmock.AnyArg(), "pura", model.PermissionArticlesRead,
+			sqlmock.AnyArg(), "yayasan", model.PermissionActivitiesRead,
+			sqlmock.AnyArg(), "yayasan", model.PermissionArticlesRead,
+		).
+		WillReturnResult(sqlmock.NewResult(4, 4))
+	mock.ExpectCommit()
+
+	res, err := u.Create(context.Background(), "user-1", req)
+	assert.NoError(t, err)
+	if assert.NotNil(t, res) {
+		assert.True(t, strings.HasPrefix(res.Key, util.APIKeyPrefix))
+		assert.Equal(t, res.Key[:util.APIKeyDisplayChars], res.APIKey.Prefix)
+		assert.Equal(t, util.HashAPIKey(res.Key), storedHash)
+		assert.Equal(t, []string{"pura", "yayasan"}, res.APIKey.EntityTypes)
+		assert.Equal(t, []string{model.PermissionArticlesRead, model.PermissionActivitiesRead}, res.APIKey.Permissions)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyUsecase_Create_UnknownPermission(t *testing.T) {
+	u, mock := setupMockAPIKeyUsecase(t)
+
+	req := &model.CreateAPIKeyRequest{
+		Name:        "Frontend build",
+		EntityTypes: []string{"pura"},
+		Permissions: []string{"users:write"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	res, err := u.Create(context.Background(), "user-1", req)
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+	}
+}
+
+func TestAPIKeyUsecase_Authenticate_Success(t *testing.T) {
+	u, mock := setupMockAPIKeyUsecase(t)
+	key := util.APIKeyPrefix + "secret"
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `api_keys` WHERE key_hash = ? AND revoked_at IS NULL LIMIT ?")).
+		WithArgs(util.HashAPIKey(key), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("key-1", "Kiosk"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `api_key_scopes` WHERE `api_key_scopes`.`api_key_id` = ?")).
+		WithArgs("key-1").
+		WillReturnRows(sqlmock.NewRows([]string{"api_key_id", "entity_type", "permission"}).
+			AddRow("key-1", "pura", model.PermissionArticlesRead).
+			AddRow("key-1", "pura", model.PermissionArticlesWrite))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `api_keys` SET `last_used_at`=? WHERE id = ? AND (last_used_at IS NULL OR last_used_at < ?)")).
+		WithArgs(sqlmock.AnyArg(), "key-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	res, err := u.Authenticate(context.Background(), key)
+	assert.NoError(t, err)
+	if assert.NotNil(t, res) {
+		assert.Equal(t, "key-1", res.ID)
+		assert.True(t, res.Grants.Has("pura", model.PermissionArticlesWrite))
+		assert.False(t, res.Grants.Has("yayasan", model.PermissionArticlesRead))
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyUsecase_Authenticate_UnknownOrRevoked(t *testing.T) {
+	u, mock := setupMockAPIKeyUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `api_keys` WHERE key_hash = ? AND revoked_at IS NULL LIMIT ?")).
+		WillReturnRows(sqlmock.NewRows(nil))
+
+	res, err := u.Authenticate(context.Background(), util.APIKeyPrefix+"revoked")
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 401, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyUsecase_Rotate_ReplacesSecret(t *testing.T) {
+	u, mock := setupMockAPIKeyUsecase(t)
+
+	var storedHash string
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `api_keys` WHERE id = ? LIMIT ?")).
+		WithArgs("key-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "prefix", "key_hash"}).AddRow("key-1", "Kiosk", "pak_oldoldol", "old-hash"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `api_key_scopes` WHERE `api_key_scopes`.`api_key_id` = ?")).
+		WithArgs("key-1").
+		WillReturnRows(sqlmock.NewRows([]string{"api_key_id", "entity_type", "permission"}).AddRow("key-1", "pura", model.PermissionArticlesRead))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `api_keys`")).
+		WithArgs("Kiosk", sqlmock.AnyArg(), hashCapture{&storedHash}, sqlmock.AnyArg(), nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), "key-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	res, err := u.Rotate(context.Background(), "key-1")
+	assert.NoError(t, err)
+	if assert.NotNil(t, res) {
+		assert.NotEqual(t, "old-hash", storedHash)
+		assert.Equal(t, util.HashAPIKey(res.Key), storedHash)
+		assert.Equal(t, []string{"pura"}, res.APIKey.EntityTypes)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyUsecase_Rotate_Revoked(t *testing.T) {
+	u, mock := setupMockAPIKeyUsecase(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `api_keys` WHERE id = ? LIMIT ?")).
+		WithArgs("key-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "revoked_at"}).AddRow("key-1", time.Now()))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `api_key_scopes`")).
+		WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectRollback()
+
+	res, err := u.Rotate(context.Background(), "key-1")
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+		assert.Equal(t, "api key has been revoked", e.Message)
+	}
+}
+
+func TestAPIKeyUsecase_Revoke_NotFound(t *testing.T) {
+	u, mock := setupMockAPIKeyUsecase(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `api_keys` WHERE id = ? LIMIT ?")).
+		WithArgs("missing", 1).
+		WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectRollback()
+
+	err := u.Revoke(context.Background(), "missing")
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 404, e.Code)
+	}
+}
+
+// hashCapture matches any string argument and remembers it.
+type hashCapture struct {
+	value *string
+}
+
+func (h hashCapture) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	if ok {
+		*h.value = s
+	}
+	return ok
+}
@@ -3,11 +3,13 @@ package test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
 	"pura-agung-kertajaya-backend/internal/model"
 	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
 
@@ -18,17 +20,37 @@ import (
 )
 
 func setupArticleController(mockUC *usecasemock.ArticleUsecaseMock) *fiber.App {
+	return setupArticleControllerWithViews(mockUC, &usecasemock.ArticleViewUsecaseMock{})
+}
+
+func setupArticleControllerWithViews(mockUC *usecasemock.ArticleUsecaseMock, mockViewUC *usecasemock.ArticleViewUsecaseMock) *fiber.App {
 	app, logger, _ := NewTestApp()
 
-	controller := httpdelivery.NewArticleController(mockUC, logger)
+	controller := httpdelivery.NewArticleController(mockUC, mockViewUC, logger)
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(middleware.CtxEntityType, "pura")
+		return c.Next()
+	})
 
 	app.Get("/public/articles", controller.GetPublic)
+	app.Get("/public/articles/popular", controller.GetPopular)
 	app.Get("/public/articles/:slug", controller.GetBySlug)
+	app.Get("/public/articles/:slug/related", controller.GetRelated)
 
 	app.Get("/articles", controller.GetAll)
 	app.Post("/articles", controller.Create)
 	app.Put("/articles/:id", controller.Update)
 	app.Delete("/articles/:id", controller.Delete)
+	app.Get("/articles/:id/stats", controller.GetStats)
+	app.Get("/articles/:id/revisions", controller.GetRevisions)
+	app.Get("/articles/:id/revisions/_diff", controller.DiffRevisions)
+	app.Get("/articles/:id/revisions/:version", controller.GetRevision)
+	app.Post("/articles/:id/revisions/:version/_restore", controller.RestoreRevision)
+	app.Get("/articles/_review-queue", controller.GetReviewQueue)
+	app.Post("/articles/:id/_submit", controller.SubmitForReview)
+	app.Post("/articles/:id/_approve", controller.ApproveReview)
+	app.Post("/articles/:id/_reject", controller.RejectReview)
 
 	return app
 }
@@ -43,7 +65,7 @@ func TestArticleController_GetPublic_Success(t *testing.T) {
 		{ID: "2", Title: "Berita B", Slug: "berita-b", PublishedAt: &now},
 	}
 
-	mockUC.On("GetPublic", 5).Return(mockData, nil)
+	mockUC.On("GetPublic", "pura", mock.MatchedBy(func(req *model.ListRequest) bool { return req.Size == 5 })).Return(mockData, nil, nil)
 
 	req := httptest.NewRequest("GET", "/public/articles?limit=5", nil)
 	resp, _ := app.Test(req)
@@ -59,19 +81,78 @@ func TestArticleController_GetPublic_Success(t *testing.T) {
 
 func TestArticleController_GetBySlug_Success(t *testing.T) {
 	mockUC := &usecasemock.ArticleUsecaseMock{}
-	app := setupArticleController(mockUC)
+	mockViewUC := &usecasemock.ArticleViewUsecaseMock{}
+	app := setupArticleControllerWithViews(mockUC, mockViewUC)
 
 	slug := "upacara-besar"
 	mockData := &model.ArticleResponse{
 		ID: "1", Title: "Upacara Besar", Slug: slug,
 	}
 
-	mockUC.On("GetBySlug", slug).Return(mockData, nil)
+	mockUC.On("GetBySlug", "pura", slug).Return(mockData, nil)
+	mockViewUC.On("RecordView", mock.Anything, "1", mock.Anything, "Mozilla/5.0").Return(nil)
 
 	req := httptest.NewRequest("GET", "/public/articles/"+slug, nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0")
 	resp, _ := app.Test(req)
 
 	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockViewUC.AssertExpectations(t)
+}
+
+func TestArticleController_GetBySlug_ViewNotRecorded(t *testing.T) {
+	mockUC := &usecasemock.ArticleUsecaseMock{}
+	mockViewUC := &usecasemock.ArticleViewUsecaseMock{}
+	app := setupArticleControllerWithViews(mockUC, mockViewUC)
+
+	mockUC.On("GetBySlug", "pura", "upacara-besar").Return(&model.ArticleResponse{ID: "1"}, nil)
+	mockViewUC.On("RecordView", mock.Anything, "1", mock.Anything, mock.Anything).Return(errors.New("redis down"))
+
+	req := httptest.NewRequest("GET", "/public/articles/upacara-besar", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode, "a failed view count does not fail the request")
+}
+
+func TestArticleController_GetPopular(t *testing.T) {
+	mockUC := &usecasemock.ArticleUsecaseMock{}
+	mockViewUC := &usecasemock.ArticleViewUsecaseMock{}
+	app := setupArticleControllerWithViews(mockUC, mockViewUC)
+
+	mockViewUC.On("GetPopular", "yayasan", 7, 3).Return([]model.PopularArticleResponse{
+		{ArticleResponse: model.ArticleResponse{ID: "1", Slug: "berita-a"}, Views: 42},
+	}, nil)
+	mockViewUC.On("GetPopular", "pura", 30, 5).Return(nil, model.ErrBadRequest("limit must be between 1 and 20"))
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/public/articles/popular?entity_type=yayasan&days=7&limit=3", nil))
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data []map[string]any `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Len(t, body.Data, 1)
+	assert.Equal(t, "berita-a", body.Data[0]["slug"])
+	assert.Equal(t, float64(42), body.Data[0]["views"])
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/public/articles/popular", nil))
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUC.AssertNotCalled(t, "GetBySlug", mock.Anything, mock.Anything)
+}
+
+func TestArticleController_GetStats(t *testing.T) {
+	mockUC := &usecasemock.ArticleUsecaseMock{}
+	mockViewUC := &usecasemock.ArticleViewUsecaseMock{}
+	app := setupArticleControllerWithViews(mockUC, mockViewUC)
+
+	mockViewUC.On("GetStats", mock.Anything, "pura", "1", 7).Return(&model.ArticleViewStatsResponse{ArticleID: "1", TotalViews: 10, Days: 7}, nil)
+	mockViewUC.On("GetStats", mock.Anything, "pura", "missing", 30).Return(nil, model.ErrNotFound("article not found"))
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/articles/1/stats?days=7", nil))
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/articles/missing/stats", nil))
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
 }
 
 func TestArticleController_GetBySlug_NotFound(t *testing.T) {
@@ -81,7 +162,7 @@ func TestArticleController_GetBySlug_NotFound(t *testing.T) {
 	slug := "tidak-ada"
 	expectedErr := model.ErrNotFound("article not found")
 
-	mockUC.On("GetBySlug", slug).Return(nil, expectedErr)
+	mockUC.On("GetBySlug", "pura", slug).Return(nil, expectedErr)
 
 	req := httptest.NewRequest("GET", "/public/articles/"+slug, nil)
 	resp, _ := app.Test(req)
@@ -89,11 +170,29 @@ func TestArticleController_GetBySlug_NotFound(t *testing.T) {
 	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
 }
 
+func TestArticleController_GetRelated(t *testing.T) {
+	mockUC := &usecasemock.ArticleUsecaseMock{}
+	app := setupArticleController(mockUC)
+
+	mockUC.On("GetRelated", "yayasan", "beasiswa", 4).
+		Return([]model.ArticleResponse{{ID: "2", Slug: "beasiswa-2026"}}, nil)
+
+	req := httptest.NewRequest("GET", "/public/articles/beasiswa/related?entity_type=yayasan", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var response model.WebResponse[[]model.ArticleResponse]
+	json.NewDecoder(resp.Body).Decode(&response)
+	assert.Len(t, response.Data, 1)
+	mockUC.AssertExpectations(t)
+}
+
 func TestArticleController_GetAll_CMS(t *testing.T) {
 	mockUC := &usecasemock.ArticleUsecaseMock{}
 	app := setupArticleController(mockUC)
 
-	mockUC.On("GetAll", "").Return([]model.ArticleResponse{}, nil)
+	mockUC.On("GetAll", "pura", mock.Anything).Return([]model.ArticleResponse{}, nil, nil)
 
 	req := httptest.NewRequest("GET", "/articles", nil)
 	resp, _ := app.Test(req)
@@ -116,7 +215,7 @@ func TestArticleController_Create_Success(t *testing.T) {
 		ID: "new-id", Title: "Judul Baru", Status: "DRAFT",
 	}
 
-	mockUC.On("Create", mock.Anything).Return(mockResp, nil)
+	mockUC.On("Create", mock.Anything, "pura", mock.Anything).Return(mockResp, nil)
 
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/articles", bytes.NewReader(bodyBytes))
@@ -138,7 +237,7 @@ func TestArticleController_Create_ValidationError(t *testing.T) {
 		Field string `validate:"required"`
 	}
 	realValidationError := validate.Struct(Dummy{})
-	mockUC.On("Create", mock.Anything).Return((*model.ArticleResponse)(nil), realValidationError)
+	mockUC.On("Create", mock.Anything, "pura", mock.Anything).Return((*model.ArticleResponse)(nil), realValidationError)
 
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/articles", bytes.NewReader(bodyBytes))
@@ -153,10 +252,153 @@ func TestArticleController_Delete_Success(t *testing.T) {
 	app := setupArticleController(mockUC)
 
 	targetID := "uuid-123"
-	mockUC.On("Delete", targetID).Return(nil)
+	mockUC.On("Delete", mock.Anything, "pura", targetID).Return(nil)
 
 	req := httptest.NewRequest("DELETE", "/articles/"+targetID, nil)
 	resp, _ := app.Test(req)
 
 	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
 }
+
+func TestArticleController_GetRevisions_Success(t *testing.T) {
+	mockUC := &usecasemock.ArticleUsecaseMock{}
+	app := setupArticleController(mockUC)
+
+	mockUC.On("GetRevisions", mock.Anything, "pura", "art-1").Return([]model.ArticleRevisionSummaryResponse{
+		{Version: 2, Title: "Judul Baru"},
+		{Version: 1, Title: "Judul Lama"},
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/articles/art-1/revisions", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var response model.WebResponse[[]model.ArticleRevisionSummaryResponse]
+	json.NewDecoder(resp.Body).Decode(&response)
+	assert.Len(t, response.Data, 2)
+	mockUC.AssertExpectations(t)
+}
+
+func TestArticleController_GetRevision_InvalidVersion(t *testing.T) {
+	mockUC := &usecasemock.ArticleUsecaseMock{}
+	app := setupArticleController(mockUC)
+
+	req := httptest.NewRequest("GET", "/articles/art-1/revisions/latest", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUC.AssertNotCalled(t, "GetRevision", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestArticleController_DiffRevisions_Success(t *testing.T) {
+	mockUC := &usecasemock.ArticleUsecaseMock{}
+	app := setupArticleController(mockUC)
+
+	mockUC.On("DiffRevisions", mock.Anything, "pura", "art-1", 1, 3).Return(&model.ArticleRevisionDiffResponse{
+		ArticleID: "art-1",
+		From:      1,
+		To:        3,
+		Changes: map[string]model.ArticleRevisionChange{
+			"title": {Before: "Judul Lama", After: "Judul Baru"},
+		},
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/articles/art-1/revisions/_diff?from=1&to=3", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestArticleController_RestoreRevision_NotFound(t *testing.T) {
+	mockUC := &usecasemock.ArticleUsecaseMock{}
+	app := setupArticleController(mockUC)
+
+	mockUC.On("RestoreRevision", mock.Anything, "pura", "art-1", 9, false).Return(nil, model.ErrNotFound("article revision not found"))
+
+	req := httptest.NewRequest("POST", "/articles/art-1/revisions/9/_restore", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestArticleController_RestoreRevision_PassesPublishPermission(t *testing.T) {
+	mockUC := &usecasemock.ArticleUsecaseMock{}
+	app, logger, _ := NewTestApp()
+	controller := httpdelivery.NewArticleController(mockUC, &usecasemock.ArticleViewUsecaseMock{}, logger)
+	app.Post("/articles/:id/revisions/:version/_restore", func(c *fiber.Ctx) error {
+		c.Locals(middleware.CtxEntityType, "pura")
+		c.Locals("user", &middleware.Auth{ID: "user-1", Role: "pura", Grants: model.PermissionGrants{"pura": {model.PermissionArticlesWrite}}})
+		return c.Next()
+	}, controller.RestoreRevision)
+
+	mockUC.On("RestoreRevision", mock.Anything, "pura", "art-1", 2, false).
+		Return(nil, model.ErrForbidden("You do not have permission to change a published article"))
+
+	req := httptest.NewRequest("POST", "/articles/art-1/revisions/2/_restore", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestArticleController_SubmitForReview_Success(t *testing.T) {
+	mockUC := &usecasemock.ArticleUsecaseMock{}
+	app := setupArticleController(mockUC)
+
+	mockUC.On("SubmitForReview", mock.Anything, "pura", "art-1").Return(&model.ArticleResponse{ID: "art-1", Status: "IN_REVIEW"}, nil)
+
+	req := httptest.NewRequest("POST", "/articles/art-1/_submit", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestArticleController_ApproveReview_Conflict(t *testing.T) {
+	mockUC := &usecasemock.ArticleUsecaseMock{}
+	app := setupArticleController(mockUC)
+
+	mockUC.On("ApproveReview", mock.Anything, "pura", "art-1").Return(nil, model.ErrConflict("article is not waiting for review"))
+
+	req := httptest.NewRequest("POST", "/articles/art-1/_approve", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestArticleController_RejectReview_Success(t *testing.T) {
+	mockUC := &usecasemock.ArticleUsecaseMock{}
+	app := setupArticleController(mockUC)
+
+	reqBody := &model.RejectArticleRequest{Comment: "Judul kurang jelas"}
+	mockUC.On("RejectReview", mock.Anything, "pura", "art-1", reqBody).Return(&model.ArticleResponse{ID: "art-1", Status: "DRAFT", ReviewComment: reqBody.Comment}, nil)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/articles/art-1/_reject", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var response model.WebResponse[model.ArticleResponse]
+	json.NewDecoder(resp.Body).Decode(&response)
+	assert.Equal(t, "Judul kurang jelas", response.Data.ReviewComment)
+	mockUC.AssertExpectations(t)
+}
+
+func TestArticleController_GetReviewQueue_Success(t *testing.T) {
+	mockUC := &usecasemock.ArticleUsecaseMock{}
+	app := setupArticleController(mockUC)
+
+	mockUC.On("GetReviewQueue", mock.Anything, "pura").Return([]model.ArticleResponse{{ID: "art-1", Status: "IN_REVIEW"}}, nil)
+
+	req := httptest.NewRequest("GET", "/articles/_review-queue", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
@@ -0,0 +1,164 @@
+package test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+)
+
+func TestArticleUsecase_GetPublic_FilterByTag(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND status = ? AND (published_at IS NULL OR published_at <= ?) AND (expires_at IS NULL OR expires_at > ?) AND id IN (SELECT article_tags.article_id FROM `article_tags` JOIN tags ON tags.id = article_tags.tag_id WHERE tags.entity_type = ? AND tags.slug IN (?,?))")).
+		WithArgs("pura", entity.ArticleStatusPublished, sqlmock.AnyArg(), sqlmock.AnyArg(), "pura", "galungan", "kuningan").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE entity_type = ? AND status = ? AND (published_at IS NULL OR published_at <= ?) AND (expires_at IS NULL OR expires_at > ?) AND id IN (SELECT article_tags.article_id FROM `article_tags` JOIN tags ON tags.id = article_tags.tag_id WHERE tags.entity_type = ? AND tags.slug IN (?,?)) ORDER BY is_featured DESC,published_at DESC,id ASC LIMIT ?")).
+		WithArgs("pura", entity.ArticleStatusPublished, sqlmock.AnyArg(), sqlmock.AnyArg(), "pura", "galungan", "kuningan", 21).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "images"}).AddRow("a-1", "Galungan", []byte(`{}`)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_tags` WHERE `article_tags`.`article_id` = ?")).
+		WithArgs("a-1").
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "tag_id"}))
+
+	results, _, err := u.GetPublic("pura", &model.ListRequest{Filters: map[string]string{"tag": "galungan,kuningan"}})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_Create_WithTags(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	req := model.CreateArticleRequest{
+		EntityType: "pura",
+		TagIDs:     []string{"t2", "t1", "t2"},
+		Title:      "Hari Raya Galungan",
+		AuthorName: "Admin",
+		Content:    "Isi konten hari raya",
+		Excerpt:    "Isi konten hari raya",
+		Status:     "DRAFT",
+		Images:     map[string]string{"lg": "https://img.com/lg.jpg"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND slug = ?")).
+		WithArgs("pura", "hari-raya-galungan").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tags` WHERE entity_type = ? AND id IN (?,?) ORDER BY name ASC")).
+		WithArgs("pura", "t1", "t2").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "name", "slug"}).
+			AddRow("t1", "pura", "Galungan", "galungan").
+			AddRow("t2", "pura", "Hari Raya", "hari-raya"))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `articles`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_tags` WHERE article_id = ?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `article_tags` (`article_id`,`tag_id`) VALUES (?,?),(?,?)")).
+		WithArgs(sqlmock.AnyArg(), "t1", sqlmock.AnyArg(), "t2").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	expectArticleRevision(mock, 0)
+	mock.ExpectCommit()
+
+	created, err := u.Create(context.Background(), "pura", req)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, created) && assert.Len(t, created.Tags, 2) {
+		assert.Equal(t, "galungan", created.Tags[0].Slug)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_Create_UnknownTag(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	req := model.CreateArticleRequest{
+		EntityType: "pura",
+		TagIDs:     []string{"t1", "t-yayasan"},
+		Title:      "Hari Raya Galungan",
+		AuthorName: "Admin",
+		Content:    "Isi konten hari raya",
+		Excerpt:    "Isi konten hari raya",
+		Status:     "DRAFT",
+		Images:     map[string]string{"lg": "https://img.com/lg.jpg"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND slug = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tags` WHERE entity_type = ? AND id IN (?,?) ORDER BY name ASC")).
+		WithArgs("pura", "t-yayasan", "t1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "name", "slug"}).AddRow("t1", "pura", "Galungan", "galungan"))
+	mock.ExpectRollback()
+
+	_, err := u.Create(context.Background(), "pura", req)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+		assert.Equal(t, "tag not found", e.Message)
+	}
+}
+
+func TestArticleUsecase_GetRelated_RanksByTagsAndCategory(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE entity_type = ? AND slug = ? AND status = ? AND (published_at IS NULL OR published_at <= ?) AND (expires_at IS NULL OR expires_at > ?) LIMIT ?")).
+		WithArgs("pura", "galungan", entity.ArticleStatusPublished, sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "category_id", "title", "slug", "images"}).
+			AddRow("a-1", "pura", "c-1", "Galungan", "galungan", []byte(`{}`)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_tags` WHERE `article_tags`.`article_id` = ?")).
+		WithArgs("a-1").
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "tag_id"}).AddRow("a-1", "t1").AddRow("a-1", "t2"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tags` WHERE `tags`.`id` IN (?,?)")).
+		WithArgs("t1", "t2").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug"}).AddRow("t1", "Galungan", "galungan").AddRow("t2", "Hari Raya", "hari-raya"))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE (entity_type = ? AND id <> ?) AND (id IN (SELECT article_id FROM article_tags WHERE tag_id IN (?,?)) OR category_id = ?) AND status = ? AND (published_at IS NULL OR published_at <= ?) AND (expires_at IS NULL OR expires_at > ?) ORDER BY (SELECT COUNT(*) FROM article_tags WHERE article_tags.article_id = articles.id AND article_tags.tag_id IN (?,?)) * ? + (category_id = ?) * ? DESC, published_at DESC, id ASC LIMIT ?")).
+		WithArgs("pura", "a-1", "t1", "t2", "c-1", entity.ArticleStatusPublished, sqlmock.AnyArg(), sqlmock.AnyArg(), "t1", "t2", 2, "c-1", 1, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "slug", "images"}).
+			AddRow("a-2", "Kuningan", "kuningan", []byte(`{}`)).
+			AddRow("a-3", "Pagerwesi", "pagerwesi", []byte(`{}`)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_tags` WHERE `article_tags`.`article_id` IN (?,?)")).
+		WithArgs("a-2", "a-3").
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "tag_id"}))
+
+	related, err := u.GetRelated("pura", "galungan", 3)
+
+	assert.NoError(t, err)
+	if assert.Len(t, related, 2) {
+		assert.Equal(t, "kuningan", related[0].Slug)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_GetRelated_NoTagsOrCategory(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE entity_type = ? AND slug = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "slug", "images"}).AddRow("a-1", "pura", "sendiri", []byte(`{}`)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_tags` WHERE `article_tags`.`article_id` = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "tag_id"}))
+
+	related, err := u.GetRelated("pura", "sendiri", 4)
+
+	assert.NoError(t, err)
+	assert.Empty(t, related)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_GetRelated_InvalidLimit(t *testing.T) {
+	u, _ := setupMockArticleUsecase(t)
+
+	_, err := u.GetRelated("pura", "galungan", 50)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+	}
+}
@@ -0,0 +1,155 @@
+package test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+func expectLockedArticle(mock sqlmock.Sqlmock, id string, status string, publishedAt any) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE id = ? AND entity_type = ? LIMIT ? FOR UPDATE")).
+		WithArgs(id, "pura", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "title", "status", "images", "published_at"}).
+			AddRow(id, "pura", "Berita", status, []byte(`{}`), publishedAt))
+}
+
+func TestArticleUsecase_SubmitForReview(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+	ctx := util.WithAuditActor(context.Background(), &model.AuditActor{ID: "editor-1", Role: "yayasan"})
+
+	expectLockedArticle(mock, "art-1", "DRAFT", nil)
+	expectArticleRevision(mock, 0)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `articles` SET")).
+		WithArgs(
+			"pura", sqlmock.AnyArg(), "Berita", "", "", "", "", "", sqlmock.AnyArg(),
+			"IN_REVIEW", false, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "", "", "", "", "", "editor-1", sqlmock.AnyArg(), nil, nil,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), "art-1",
+		).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectArticleRevision(mock, 1)
+	mock.ExpectCommit()
+
+	res, err := u.SubmitForReview(ctx, "pura", "art-1")
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, res) {
+		assert.Equal(t, "IN_REVIEW", res.Status)
+		assert.Equal(t, "editor-1", *res.SubmittedBy)
+		assert.NotNil(t, res.SubmittedAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_SubmitForReview_NotDraft(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	expectLockedArticle(mock, "art-1", "PUBLISHED", time.Now())
+	mock.ExpectRollback()
+
+	_, err := u.SubmitForReview(context.Background(), "pura", "art-1")
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 409, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_ApproveReview_FutureDateIsScheduled(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+	ctx := util.WithAuditActor(context.Background(), &model.AuditActor{ID: "senior-1", Role: "yayasan"})
+
+	expectLockedArticle(mock, "art-1", "IN_REVIEW", time.Now().Add(48*time.Hour))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(version), 0) FROM `article_revisions` WHERE article_id = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `articles` SET")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectArticleRevision(mock, 2)
+	mock.ExpectCommit()
+
+	res, err := u.ApproveReview(ctx, "pura", "art-1")
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, res) {
+		assert.Equal(t, string(entity.ArticleStatusScheduled), res.Status)
+		assert.Equal(t, "senior-1", *res.ReviewedBy)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_RejectReview(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	expectLockedArticle(mock, "art-1", "IN_REVIEW", nil)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(version), 0) FROM `article_revisions` WHERE article_id = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `articles` SET")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectArticleRevision(mock, 2)
+	mock.ExpectCommit()
+
+	res, err := u.RejectReview(context.Background(), "pura", "art-1", &model.RejectArticleRequest{Comment: "Tolong lengkapi sumber foto"})
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, res) {
+		assert.Equal(t, "DRAFT", res.Status)
+		assert.Equal(t, "Tolong lengkapi sumber foto", res.ReviewComment)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_RejectReview_RequiresComment(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	_, err := u.RejectReview(context.Background(), "pura", "art-1", &model.RejectArticleRequest{})
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_Update_CannotEnterReview(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	expectLockedArticle(mock, "art-1", "DRAFT", nil)
+	expectArticleRevision(mock, 0)
+	mock.ExpectRollback()
+
+	_, err := u.Update(context.Background(), "pura", "art-1", model.UpdateArticleRequest{
+		Title:      "Berita",
+		AuthorName: "Admin",
+		Excerpt:    "Ringkasan berita",
+		Content:    "Konten berita lengkap",
+		Images:     map[string]string{"lg": "https://img.com/lg.jpg"},
+		Status:     "IN_REVIEW",
+	})
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_GetReviewQueue(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE entity_type = ? AND status = ? ORDER BY submitted_at ASC")).
+		WithArgs("pura", entity.ArticleStatusInReview).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "status", "images"}).
+			AddRow("art-1", "Berita", "IN_REVIEW", []byte(`{}`)))
+
+	res, err := u.GetReviewQueue(context.Background(), "pura")
+
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,206 @@
+package test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+var articleRevisionColumns = []string{"id", "article_id", "version", "category_id", "title", "slug", "author_name", "excerpt", "content", "images", "status", "is_featured", "created_at"}
+
+func TestArticleUsecase_Update_RecordsRevisionWithActor(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+	id := "art-1"
+
+	ctx := util.WithAuditActor(context.Background(), &model.AuditActor{ID: "user-7", Role: "pura"})
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE id = ? AND entity_type = ? LIMIT ? FOR UPDATE")).
+		WithArgs(id, "pura", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "slug", "images"}).
+			AddRow(id, "Judul Sama", "judul-sama", []byte(`{"lg":"old.jpg"}`)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(version), 0) FROM `article_revisions` WHERE article_id = ?")).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `articles`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(version), 0) FROM `article_revisions` WHERE article_id = ?")).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `article_revisions`")).
+		WithArgs(
+			sqlmock.AnyArg(),
+			id,
+			5,
+			nil,
+			"Judul Sama",
+			"judul-sama",
+			"Author",
+			"",
+			"Ringkasan baru",
+			"Konten yang diperbarui",
+			"html",
+			sqlmock.AnyArg(),
+			"DRAFT",
+			false,
+			nil,
+			nil,
+			nil,
+			"user-7",
+			sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	_, err := u.Update(ctx, "pura", id, model.UpdateArticleRequest{
+		Title:      "Judul Sama",
+		AuthorName: "Author",
+		Excerpt:    "Ringkasan baru",
+		Content:    "Konten yang diperbarui",
+		Images:     map[string]string{"lg": "https://new.jpg"},
+		Status:     "DRAFT",
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_GetRevisions_ArticleNotFound(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND id = ?")).
+		WithArgs("pura", "missing").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	_, err := u.GetRevisions(context.Background(), "pura", "missing")
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 404, e.Code)
+	}
+}
+
+func TestArticleUsecase_GetRevision_NotFound(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND id = ?")).
+		WithArgs("pura", "art-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_revisions` WHERE article_id = ? AND version = ? LIMIT ?")).
+		WithArgs("art-1", 7, 1).
+		WillReturnRows(sqlmock.NewRows(nil))
+
+	_, err := u.GetRevision(context.Background(), "pura", "art-1", 7)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 404, e.Code)
+		assert.Equal(t, "article revision not found", e.Message)
+	}
+}
+
+func TestArticleUsecase_DiffRevisions(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND id = ?")).
+		WithArgs("pura", "art-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_revisions` WHERE article_id = ? AND version = ? LIMIT ?")).
+		WithArgs("art-1", 1, 1).
+		WillReturnRows(sqlmock.NewRows(articleRevisionColumns).
+			AddRow("rev-1", "art-1", 1, nil, "Judul Lama", "judul-lama", "Admin", "Ringkas", "baris satu\nbaris dua", []byte(`{"lg":"a.jpg"}`), "DRAFT", false, time.Now()))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_revisions` WHERE article_id = ? AND version = ? LIMIT ?")).
+		WithArgs("art-1", 2, 1).
+		WillReturnRows(sqlmock.NewRows(articleRevisionColumns).
+			AddRow("rev-2", "art-1", 2, nil, "Judul Baru", "judul-baru", "Admin", "Ringkas", "baris satu\nbaris tiga", []byte(`{"lg":"a.jpg"}`), "DRAFT", false, time.Now()))
+
+	diff, err := u.DiffRevisions(context.Background(), "pura", "art-1", 1, 2)
+	assert.NoError(t, err)
+	if assert.NotNil(t, diff) {
+		assert.Len(t, diff.Changes, 2)
+		assert.Equal(t, "Judul Lama", diff.Changes["title"].Before)
+		assert.Equal(t, "judul-baru", diff.Changes["slug"].After)
+		assert.Equal(t, []model.DiffLineResponse{
+			{Op: "equal", Text: "baris satu"},
+			{Op: "delete", Text: "baris dua"},
+			{Op: "insert", Text: "baris tiga"},
+		}, diff.ContentDiff)
+	}
+}
+
+func TestArticleUsecase_DiffRevisions_InvalidVersion(t *testing.T) {
+	u, _ := setupMockArticleUsecase(t)
+
+	_, err := u.DiffRevisions(context.Background(), "pura", "art-1", 0, 2)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+	}
+}
+
+func TestArticleUsecase_RestoreRevision_CopiesContentAndRecordsRevision(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+	id := "art-1"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE id = ? AND entity_type = ? LIMIT ? FOR UPDATE")).
+		WithArgs(id, "pura", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "slug", "content", "status", "images"}).
+			AddRow(id, "Judul Baru", "judul-baru", "isi salah", "PUBLISHED", []byte(`{"lg":"b.jpg"}`)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_revisions` WHERE article_id = ? AND version = ? LIMIT ?")).
+		WithArgs(id, 1, 1).
+		WillReturnRows(sqlmock.NewRows(articleRevisionColumns).
+			AddRow("rev-1", id, 1, nil, "Judul Lama", "judul-lama", "Admin", "Ringkas", "isi benar", []byte(`{"lg":"a.jpg"}`), "DRAFT", false, time.Now()))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND (slug = ? AND id != ?)")).
+		WithArgs("pura", "judul-lama", id).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `articles`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(version), 0) FROM `article_revisions` WHERE article_id = ?")).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `article_revisions`")).
+		WithArgs(
+			sqlmock.AnyArg(), id, 4, nil, "Judul Lama", "judul-lama", "Admin", "", "Ringkas", "isi benar", "html",
+			sqlmock.AnyArg(), "PUBLISHED", false, nil, nil, 1, nil, sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	restored, err := u.RestoreRevision(context.Background(), "pura", id, 1, true)
+	assert.NoError(t, err)
+	if assert.NotNil(t, restored) {
+		assert.Equal(t, "isi benar", restored.Content)
+		assert.Equal(t, "judul-lama", restored.Slug)
+		assert.Equal(t, "PUBLISHED", restored.Status)
+		assert.Equal(t, "a.jpg", restored.Images.Lg)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_RestoreRevision_PublishedNeedsPublishPermission(t *testing.T) {
+	for _, status := range []string{"PUBLISHED", "SCHEDULED"} {
+		u, mock := setupMockArticleUsecase(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE id = ? AND entity_type = ? LIMIT ? FOR UPDATE")).
+			WithArgs("art-1", "pura", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "status"}).AddRow("art-1", "Judul Tayang", status))
+		mock.ExpectRollback()
+
+		_, err := u.RestoreRevision(context.Background(), "pura", "art-1", 1, false)
+
+		var e *model.ResponseError
+		if assert.ErrorAs(t, err, &e, status) {
+			assert.Equal(t, 403, e.Code, status)
+		}
+		assert.NoError(t, mock.ExpectationsWereMet(), status)
+	}
+}
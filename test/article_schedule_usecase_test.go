@@ -0,0 +1,162 @@
+package test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+)
+
+func TestArticleUsecase_Create_FuturePublishIsScheduled(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	publishAt := time.Now().Add(24 * time.Hour)
+	req := model.CreateArticleRequest{
+		EntityType:  "pura",
+		Title:       "Piodalan",
+		AuthorName:  "Admin",
+		Content:     "Konten piodalan",
+		Excerpt:     "Konten piodalan",
+		Status:      "PUBLISHED",
+		PublishedAt: &publishAt,
+		Images:      map[string]string{"lg": "https://img.com/lg.jpg"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND slug = ?")).
+		WithArgs("pura", "piodalan").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `articles`")).
+		WithArgs(
+			sqlmock.AnyArg(),
+			"pura",
+			sqlmock.AnyArg(),
+			req.Title,
+			"piodalan",
+			req.AuthorName,
+			"",
+			req.Excerpt,
+			req.Content,
+			sqlmock.AnyArg(),
+			"SCHEDULED",
+			false,
+			publishAt,
+			nil,
+			"html",
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			"",
+			"",
+			"",
+			"",
+			"",
+			nil,
+			nil,
+			nil,
+			nil,
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectArticleRevision(mock, 0)
+	mock.ExpectCommit()
+
+	created, err := u.Create(context.Background(), "pura", req)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, created) {
+		assert.Equal(t, "SCHEDULED", created.Status)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_Create_ExpiryBeforePublish(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	publishAt := time.Now().Add(24 * time.Hour)
+	expiresAt := publishAt.Add(-time.Hour)
+
+	_, err := u.Create(context.Background(), "pura", model.CreateArticleRequest{
+		EntityType:  "pura",
+		Title:       "Piodalan",
+		AuthorName:  "Admin",
+		Content:     "Konten piodalan",
+		Excerpt:     "Konten piodalan",
+		Status:      "PUBLISHED",
+		PublishedAt: &publishAt,
+		ExpiresAt:   &expiresAt,
+		Images:      map[string]string{"lg": "https://img.com/lg.jpg"},
+	})
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+		assert.Equal(t, "expires_at must be after published_at", e.Message)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_ApplySchedule(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `articles` WHERE status IN (?,?) AND expires_at <= ?")).
+		WithArgs(entity.ArticleStatusScheduled, entity.ArticleStatusPublished, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("art-old"))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE (status IN (?,?) AND expires_at <= ?) AND id = ? LIMIT ? FOR UPDATE")).
+		WithArgs(entity.ArticleStatusScheduled, entity.ArticleStatusPublished, now, "art-old", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "status", "images"}).
+			AddRow("art-old", "Lama", "PUBLISHED", []byte(`{}`)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(version), 0) FROM `article_revisions` WHERE article_id = ?")).
+		WithArgs("art-old").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `articles` SET")).
+		WithArgs(
+			"", sqlmock.AnyArg(), "Lama", "", "", "", "", "", sqlmock.AnyArg(),
+			"ARCHIVED", false, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "", "", "", "", "", nil, nil, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), "art-old",
+		).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectArticleRevision(mock, 2)
+	mock.ExpectCommit()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `articles` WHERE status = ? AND published_at <= ?")).
+		WithArgs(entity.ArticleStatusScheduled, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("art-due").AddRow("art-taken"))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE (status = ? AND published_at <= ?) AND id = ? LIMIT ? FOR UPDATE")).
+		WithArgs(entity.ArticleStatusScheduled, now, "art-due", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "status", "images"}).
+			AddRow("art-due", "Baru", "SCHEDULED", []byte(`{}`)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(version), 0) FROM `article_revisions` WHERE article_id = ?")).
+		WithArgs("art-due").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `articles` SET")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectArticleRevision(mock, 1)
+	mock.ExpectCommit()
+
+	// Another instance published art-taken between the listing and the lock.
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE (status = ? AND published_at <= ?) AND id = ? LIMIT ? FOR UPDATE")).
+		WithArgs(entity.ArticleStatusScheduled, now, "art-taken", 1).
+		WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectRollback()
+
+	result, err := u.ApplySchedule(context.Background(), now)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, result) {
+		assert.Equal(t, 1, result.Archived)
+		assert.Equal(t, 1, result.Published)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
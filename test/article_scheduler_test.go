@@ -0,0 +1,51 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"pura-agung-kertajaya-backend/internal/delivery/scheduler"
+	"pura-agung-kertajaya-backend/internal/model"
+	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
+)
+
+func TestArticleScheduler_RunAppliesUntilCancelled(t *testing.T) {
+	uc := new(usecasemock.ArticleUsecaseMock)
+	now := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+
+	s := scheduler.NewArticleScheduler(uc, logrus.New(), time.Millisecond)
+	s.Now = func() time.Time { return now }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uc.On("ApplySchedule", mock.Anything, now).
+		Return(nil, errors.New("db down")).Once()
+	uc.On("ApplySchedule", mock.Anything, now).
+		Return(&model.ArticleScheduleResult{Published: 1}, nil).Once().
+		Run(func(args mock.Arguments) { cancel() })
+
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after cancellation")
+	}
+	uc.AssertExpectations(t)
+}
+
+func TestNewArticleScheduler_DefaultInterval(t *testing.T) {
+	s := scheduler.NewArticleScheduler(new(usecasemock.ArticleUsecaseMock), logrus.New(), 0)
+	assert.Equal(t, scheduler.DefaultArticleScheduleInterval, s.Interval)
+}
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"regexp"
 	"testing"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"pura-agung-kertajaya-backend/internal/entity"
 	"pura-agung-kertajaya-backend/internal/model"
 	"pura-agung-kertajaya-backend/internal/usecase"
+	"pura-agung-kertajaya-backend/internal/util"
 )
 
 func setupMockArticleUsecase(t *testing.T) (usecase.ArticleUsecase, sqlmock.Sqlmock) {
@@ -30,10 +32,18 @@ func setupMockArticleUsecase(t *testing.T) (usecase.ArticleUsecase, sqlmock.Sqlm
 		t.Fatalf("failed to open gorm: %v", err)
 	}
 
-	u := usecase.NewArticleUsecase(gormDB, validator.New())
+	u := usecase.NewArticleUsecase(gormDB, validator.New(), &util.SiteURLUtil{AssetBaseURL: "https://cdn.example.com"})
 	return u, mock
 }
 
+// expectArticleRevision expects the lookup of the latest version followed by the revision insert.
+func expectArticleRevision(mock sqlmock.Sqlmock, latest int) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(version), 0) FROM `article_revisions` WHERE article_id = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(latest))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `article_revisions`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
 func TestArticleUsecase_GetPublic(t *testing.T) {
 	u, mock := setupMockArticleUsecase(t)
 
@@ -41,14 +51,25 @@ func TestArticleUsecase_GetPublic(t *testing.T) {
 		AddRow("uuid-1", "Berita 1", "PUBLISHED", time.Now(), []byte(`{"lg":"img1.jpg"}`)).
 		AddRow("uuid-2", "Berita 2", "PUBLISHED", time.Now(), []byte(`{"lg":"img2.jpg"}`))
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE status = ? ORDER BY is_featured DESC, published_at DESC LIMIT ?")).
-		WithArgs(entity.ArticleStatusPublished, 10).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND status = ? AND (published_at IS NULL OR published_at <= ?) AND (expires_at IS NULL OR expires_at > ?)")).
+		WithArgs("pura", entity.ArticleStatusPublished, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE entity_type = ? AND status = ? AND (published_at IS NULL OR published_at <= ?) AND (expires_at IS NULL OR expires_at > ?) ORDER BY is_featured DESC,published_at DESC,id ASC LIMIT ?")).
+		WithArgs("pura", entity.ArticleStatusPublished, sqlmock.AnyArg(), sqlmock.AnyArg(), 11).
 		WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_tags` WHERE `article_tags`.`article_id` IN (?,?)")).
+		WithArgs("uuid-1", "uuid-2").
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "tag_id"}))
 
-	results, err := u.GetPublic(10)
+	results, paging, err := u.GetPublic("pura", &model.ListRequest{Size: 10})
 
 	assert.NoError(t, err)
 	assert.Len(t, results, 2)
+	if assert.NotNil(t, paging) {
+		assert.Equal(t, 1, paging.Page)
+		assert.Equal(t, int64(2), paging.TotalItem)
+		assert.Empty(t, paging.NextCursor)
+	}
 
 	if len(results) > 0 {
 		assert.Equal(t, "Berita 1", results[0].Title)
@@ -61,19 +82,30 @@ func TestArticleUsecase_GetBySlug(t *testing.T) {
 
 	slug := "upacara-ngaben"
 
-	rows := sqlmock.NewRows([]string{"id", "title", "slug", "status", "images"}).
-		AddRow("uuid-1", "Upacara Ngaben", slug, "PUBLISHED", []byte(`{"lg":"img1.jpg"}`))
+	rows := sqlmock.NewRows([]string{"id", "title", "slug", "status", "images", "content", "content_format", "content_html"}).
+		AddRow("uuid-1", "Upacara Ngaben", slug, "PUBLISHED", []byte(`{"lg":"img1.jpg"}`), "Upacara **ngaben**<script>alert(1)</script>", "markdown", "<p>Upacara <strong>ngaben</strong>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n")
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE slug = ? AND status = ? ORDER BY `articles`.`id` LIMIT ?")).
-		WithArgs(slug, entity.ArticleStatusPublished, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE entity_type = ? AND slug = ? AND status = ? AND (published_at IS NULL OR published_at <= ?) AND (expires_at IS NULL OR expires_at > ?) ORDER BY `articles`.`id` LIMIT ?")).
+		WithArgs("pura", slug, entity.ArticleStatusPublished, sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
 		WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_tags` WHERE `article_tags`.`article_id` = ?")).
+		WithArgs("uuid-1").
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "tag_id"}).AddRow("uuid-1", "tag-1"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tags` WHERE `tags`.`id` = ?")).
+		WithArgs("tag-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug"}).AddRow("tag-1", "Ngaben", "ngaben"))
 
-	res, err := u.GetBySlug(slug)
+	res, err := u.GetBySlug("pura", slug)
 
 	assert.NoError(t, err)
 	if assert.NotNil(t, res) {
 		assert.Equal(t, "Upacara Ngaben", res.Title)
 		assert.Equal(t, "img1.jpg", res.Images.Lg)
+		if assert.Len(t, res.Tags, 1) {
+			assert.Equal(t, "ngaben", res.Tags[0].Slug)
+		}
+		assert.Empty(t, res.Content, "public readers get the sanitized body only")
+		assert.Equal(t, "<p>Upacara <strong>ngaben</strong>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n", res.ContentHTML)
 	}
 }
 
@@ -81,11 +113,11 @@ func TestArticleUsecase_GetBySlug_NotFound(t *testing.T) {
 	u, mock := setupMockArticleUsecase(t)
 	slug := "missing-slug"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE slug = ? AND status = ? ORDER BY `articles`.`id` LIMIT ?")).
-		WithArgs(slug, entity.ArticleStatusPublished, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE entity_type = ? AND slug = ? AND status = ? AND (published_at IS NULL OR published_at <= ?) AND (expires_at IS NULL OR expires_at > ?) ORDER BY `articles`.`id` LIMIT ?")).
+		WithArgs("pura", slug, entity.ArticleStatusPublished, sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.GetBySlug(slug)
+	res, err := u.GetBySlug("pura", slug)
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
@@ -101,11 +133,11 @@ func TestArticleUsecase_GetByID_NotFound(t *testing.T) {
 	u, mock := setupMockArticleUsecase(t)
 	id := "missing-id"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE id = ? ORDER BY `articles`.`id` LIMIT ?")).
-		WithArgs(id, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(id, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.GetByID(id)
+	res, err := u.GetByID("pura", id)
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
@@ -121,6 +153,7 @@ func TestArticleUsecase_Create_AutoSlugAndExcerpt(t *testing.T) {
 	u, mock := setupMockArticleUsecase(t)
 
 	req := model.CreateArticleRequest{
+		EntityType: "pura",
 		Title:      "Judul Berita Keren",
 		AuthorName: "Admin",
 		Content:    "Ini adalah konten yang sangat panjang sekali...",
@@ -129,14 +162,15 @@ func TestArticleUsecase_Create_AutoSlugAndExcerpt(t *testing.T) {
 		Images:     map[string]string{"lg": "https://img.com/lg.jpg"},
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE slug = ?")).
-		WithArgs("judul-berita-keren").
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND slug = ?")).
+		WithArgs("pura", "judul-berita-keren").
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
-	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `articles`")).
 		WithArgs(
 			sqlmock.AnyArg(),
+			"pura",
 			sqlmock.AnyArg(),
 			req.Title,
 			"judul-berita-keren",
@@ -148,13 +182,29 @@ func TestArticleUsecase_Create_AutoSlugAndExcerpt(t *testing.T) {
 			"PUBLISHED",
 			false,
 			sqlmock.AnyArg(),
+			nil,
+			"html",
+			sqlmock.AnyArg(),
+			req.Content,
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			"",
+			"",
+			"",
+			"",
+			"",
+			nil,
+			nil,
+			nil,
+			nil,
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectArticleRevision(mock, 0)
 	mock.ExpectCommit()
 
-	created, err := u.Create(req)
+	created, err := u.Create(context.Background(), "pura", req)
 
 	assert.NoError(t, err)
 	if assert.NotNil(t, created) {
@@ -164,10 +214,75 @@ func TestArticleUsecase_Create_AutoSlugAndExcerpt(t *testing.T) {
 	}
 }
 
+func TestArticleUsecase_Create_RendersMarkdown(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	req := model.CreateArticleRequest{
+		EntityType:    "pura",
+		Title:         "Persiapan Galungan",
+		AuthorName:    "Admin",
+		Excerpt:       "Persiapan hari raya",
+		Content:       "## Banten\n\nSiapkan **canang** sari.<script>alert(1)</script>",
+		ContentFormat: "markdown",
+		Status:        "DRAFT",
+		Images:        map[string]string{},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND slug = ?")).
+		WithArgs("pura", "persiapan-galungan").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `articles`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectArticleRevision(mock, 0)
+	mock.ExpectCommit()
+
+	created, err := u.Create(context.Background(), "pura", req)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, created) {
+		assert.Equal(t, req.Content, created.Content)
+		assert.Equal(t, "markdown", created.ContentFormat)
+		assert.Equal(t, "<h2>Banten</h2>\n<p>Siapkan <strong>canang</strong> sari.&lt;script&gt;alert(1)&lt;/script&gt;</p>\n", created.ContentHTML)
+		assert.Equal(t, 4, created.WordCount)
+		assert.Equal(t, 1, created.ReadingTimeMinutes)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_Create_InvalidBlocks(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	req := model.CreateArticleRequest{
+		EntityType:    "pura",
+		Title:         "Persiapan Galungan",
+		AuthorName:    "Admin",
+		Excerpt:       "Persiapan hari raya",
+		Content:       `[{"type": "script", "text": "alert(1)"}]`,
+		ContentFormat: "blocks",
+		Status:        "DRAFT",
+		Images:        map[string]string{},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND slug = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectRollback()
+
+	_, err := u.Create(context.Background(), "pura", req)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestArticleUsecase_Create_SlugCollision(t *testing.T) {
 	u, mock := setupMockArticleUsecase(t)
 
 	req := model.CreateArticleRequest{
+		EntityType: "pura",
 		Title:      "Berita Sama",
 		AuthorName: "Budi",
 		Content:    "Isi konten ini harus cukup panjang ya",
@@ -176,18 +291,19 @@ func TestArticleUsecase_Create_SlugCollision(t *testing.T) {
 		Images:     map[string]string{"lg": "https://img.com/lg.jpg"},
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE slug = ?")).
-		WithArgs("berita-sama").
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND slug = ?")).
+		WithArgs("pura", "berita-sama").
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE slug = ?")).
-		WithArgs("berita-sama-1").
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND slug = ?")).
+		WithArgs("pura", "berita-sama-1").
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
-	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `articles`")).
 		WithArgs(
 			sqlmock.AnyArg(),
+			"pura",
 			sqlmock.AnyArg(),
 			req.Title,
 			"berita-sama-1",
@@ -199,13 +315,29 @@ func TestArticleUsecase_Create_SlugCollision(t *testing.T) {
 			"DRAFT",
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			nil,
+			"html",
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			"",
+			"",
+			"",
+			"",
+			"",
+			nil,
+			nil,
+			nil,
+			nil,
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectArticleRevision(mock, 0)
 	mock.ExpectCommit()
 
-	created, err := u.Create(req)
+	created, err := u.Create(context.Background(), "pura", req)
 
 	assert.NoError(t, err)
 	if assert.NotNil(t, created) {
@@ -213,6 +345,39 @@ func TestArticleUsecase_Create_SlugCollision(t *testing.T) {
 	}
 }
 
+func TestArticleUsecase_Create_CategoryFromOtherEntity(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	req := model.CreateArticleRequest{
+		EntityType: "yayasan",
+		CategoryID: "cat-pura",
+		Title:      "Beasiswa",
+		AuthorName: "Admin",
+		Content:    "Isi konten beasiswa yayasan",
+		Excerpt:    "Isi konten beasiswa yayasan",
+		Status:     "DRAFT",
+		Images:     map[string]string{"lg": "https://img.com/lg.jpg"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND slug = ?")).
+		WithArgs("yayasan", "beasiswa").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `categories` WHERE entity_type = ? AND id = ?")).
+		WithArgs("yayasan", "cat-pura").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectRollback()
+
+	_, err := u.Create(context.Background(), "yayasan", req)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+		assert.Equal(t, "category not found", e.Message)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestArticleUsecase_Update(t *testing.T) {
 	u, mock := setupMockArticleUsecase(t)
 	id := "art-1"
@@ -226,18 +391,20 @@ func TestArticleUsecase_Update(t *testing.T) {
 		Images:     map[string]string{"lg": "https://new.jpg"},
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE id = ? LIMIT ?")).
-		WithArgs(id, 1).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "slug", "images"}).
-			AddRow(id, "Judul Lama", "judul-lama", []byte(`{"lg":"old.jpg"}`)))
-
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE slug = ? AND id != ?")).
-		WithArgs("judul-baru", id).
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE id = ? AND entity_type = ? LIMIT ? FOR UPDATE")).
+		WithArgs(id, "pura", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "title", "slug", "images"}).
+			AddRow(id, "pura", "Judul Lama", "judul-lama", []byte(`{"lg":"old.jpg"}`)))
+	expectArticleRevision(mock, 0)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE entity_type = ? AND (slug = ? AND id != ?)")).
+		WithArgs("pura", "judul-baru", id).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
-	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta("UPDATE `articles`")).
 		WithArgs(
+			"pura",
 			sqlmock.AnyArg(),
 			"Judul Baru",
 			"judul-baru",
@@ -249,14 +416,30 @@ func TestArticleUsecase_Update(t *testing.T) {
 			"PUBLISHED",
 			false,
 			sqlmock.AnyArg(),
+			nil,
+			"html",
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			"",
+			"",
+			"",
+			"",
+			"",
+			nil,
+			nil,
+			nil,
+			nil,
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			id,
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectArticleRevision(mock, 1)
 	mock.ExpectCommit()
 
-	updated, err := u.Update(id, req)
+	updated, err := u.Update(context.Background(), "pura", id, req)
 	assert.NoError(t, err)
 
 	if assert.NotNil(t, updated) {
@@ -279,11 +462,13 @@ func TestArticleUsecase_Update_NotFound(t *testing.T) {
 		Images:     map[string]string{"lg": "https://img.com/valid.jpg"},
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE id = ? LIMIT ?")).
-		WithArgs(id, 1).
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE id = ? AND entity_type = ? LIMIT ? FOR UPDATE")).
+		WithArgs(id, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectRollback()
 
-	updated, err := u.Update(id, req)
+	updated, err := u.Update(context.Background(), "pura", id, req)
 
 	assert.Error(t, err)
 	assert.Nil(t, updated)
@@ -299,9 +484,9 @@ func TestArticleUsecase_Delete(t *testing.T) {
 	u, mock := setupMockArticleUsecase(t)
 	id := "del-1"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE id = ?")).
-		WithArgs(id).
-		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(id, "pura", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type"}).AddRow(id, "pura"))
 
 	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `articles` WHERE `articles`.`id` = ?")).
@@ -309,7 +494,7 @@ func TestArticleUsecase_Delete(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := u.Delete(id)
+	err := u.Delete(context.Background(), "pura", id)
 	assert.NoError(t, err)
 }
 
@@ -317,11 +502,11 @@ func TestArticleUsecase_Delete_NotFound(t *testing.T) {
 	u, mock := setupMockArticleUsecase(t)
 	id := "missing-id"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE id = ?")).
-		WithArgs(id).
-		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(id, "pura", 1).
+		WillReturnRows(sqlmock.NewRows(nil))
 
-	err := u.Delete(id)
+	err := u.Delete(context.Background(), "pura", id)
 	assert.Error(t, err)
 
 	var e *model.ResponseError
@@ -330,3 +515,29 @@ func TestArticleUsecase_Delete_NotFound(t *testing.T) {
 		assert.Equal(t, "article not found", e.Message)
 	}
 }
+
+func TestArticleUsecase_Create_OtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	res, err := u.Create(context.Background(), "yayasan", model.CreateArticleRequest{EntityType: "pura", Title: "Hari Raya Galungan", AuthorName: "Admin", Excerpt: "Persiapan", Content: "Isi artikel galungan", Images: map[string]string{"lg": "a.webp"}, Status: "DRAFT"})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleUsecase_Update_MoveToOtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockArticleUsecase(t)
+
+	res, err := u.Update(context.Background(), "pura", "art-1", model.UpdateArticleRequest{EntityType: "yayasan", Title: "Hari Raya Galungan", AuthorName: "Admin", Excerpt: "Persiapan galungan", Content: "Isi artikel galungan", Images: map[string]string{"lg": "a.webp"}, Status: "DRAFT"})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
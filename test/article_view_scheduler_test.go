@@ -0,0 +1,45 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"pura-agung-kertajaya-backend/internal/delivery/scheduler"
+	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
+)
+
+func TestArticleViewScheduler_RunFlushesUntilCancelled(t *testing.T) {
+	uc := new(usecasemock.ArticleViewUsecaseMock)
+	s := scheduler.NewArticleViewScheduler(uc, logrus.New(), time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uc.On("FlushViews", mock.Anything).Return(int64(0), errors.New("redis down")).Once()
+	uc.On("FlushViews", mock.Anything).Return(int64(3), nil).Once().
+		Run(func(args mock.Arguments) { cancel() })
+
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after cancellation")
+	}
+	uc.AssertExpectations(t)
+}
+
+func TestNewArticleViewScheduler_DefaultInterval(t *testing.T) {
+	s := scheduler.NewArticleViewScheduler(new(usecasemock.ArticleViewUsecaseMock), logrus.New(), 0)
+	assert.Equal(t, scheduler.DefaultArticleViewFlushInterval, s.Interval)
+}
@@ -0,0 +1,149 @@
+package test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+func setupMockArticleViewUsecase(t *testing.T) (usecase.ArticleViewUsecase, sqlmock.Sqlmock, *util.ViewCounterUtil) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub db: %v", err)
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+
+	counter, _ := setupViewCounterUtil(t)
+	return usecase.NewArticleViewUsecase(gormDB, counter), mock, counter
+}
+
+func TestArticleViewUsecase_FlushViews(t *testing.T) {
+	u, mock, counter := setupMockArticleViewUsecase(t)
+	ctx := context.Background()
+
+	assert.NoError(t, u.RecordView(ctx, "a-1", "10.0.0.1", readerUserAgent))
+	assert.NoError(t, u.RecordView(ctx, "a-1", "10.0.0.2", readerUserAgent))
+	assert.NoError(t, u.RecordView(ctx, "a-1", "10.0.0.2", readerUserAgent))
+	assert.NoError(t, u.RecordView(ctx, "deleted", "10.0.0.1", readerUserAgent))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `articles` WHERE id IN (")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("a-1"))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `article_views` (`article_id`,`view_date`,`views`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `views`=views + ?")).
+		WithArgs("a-1", sqlmock.AnyArg(), int64(2), int64(2)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	flushed, err := u.FlushViews(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), flushed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	flushed, err = u.FlushViews(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, flushed)
+
+	assert.NoError(t, counter.Drain(ctx, func(views []util.ArticleDayViews) error {
+		assert.Empty(t, views)
+		return nil
+	}))
+}
+
+func TestArticleViewUsecase_GetPopular(t *testing.T) {
+	u, mock, _ := setupMockArticleViewUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT articles.id AS article_id, popular.views FROM `articles` JOIN (SELECT article_id, SUM(views) AS views FROM `article_views` WHERE view_date >= ? GROUP BY `article_id`) AS popular ON popular.article_id = articles.id WHERE entity_type = ? AND status = ? AND (published_at IS NULL OR published_at <= ?) AND (expires_at IS NULL OR expires_at > ?) ORDER BY popular.views DESC, articles.published_at DESC, articles.id ASC LIMIT ?")).
+		WithArgs(sqlmock.AnyArg(), "pura", entity.ArticleStatusPublished, sqlmock.AnyArg(), sqlmock.AnyArg(), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "views"}).AddRow("a-2", 30).AddRow("a-1", 12))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE id IN (?,?)")).
+		WithArgs("a-2", "a-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug", "images"}).
+			AddRow("a-1", "berita-a", []byte(`{}`)).
+			AddRow("a-2", "berita-b", []byte(`{}`)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_tags` WHERE `article_tags`.`article_id` IN (?,?)")).
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "tag_id"}))
+
+	results, err := u.GetPopular("pura", 30, 2)
+
+	assert.NoError(t, err)
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "berita-b", results[0].Slug)
+		assert.Equal(t, int64(30), results[0].Views)
+		assert.Equal(t, "berita-a", results[1].Slug)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleViewUsecase_GetPopular_InvalidRange(t *testing.T) {
+	u, _, _ := setupMockArticleViewUsecase(t)
+
+	_, err := u.GetPopular("pura", 0, 5)
+	assert.Equal(t, 400, err.(*model.ResponseError).Code)
+
+	_, err = u.GetPopular("pura", 30, 21)
+	assert.Equal(t, 400, err.(*model.ResponseError).Code)
+}
+
+func TestArticleViewUsecase_GetStats(t *testing.T) {
+	u, mock, _ := setupMockArticleViewUsecase(t)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	yesterday := today.AddDate(0, 0, -1)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs("a-1", "pura", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "images"}).AddRow("a-1", "pura", []byte(`{}`)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(views), 0) FROM `article_views` WHERE article_id = ?")).
+		WithArgs("a-1").
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(120))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_views` WHERE article_id = ? AND view_date >= ? ORDER BY view_date ASC")).
+		WithArgs("a-1", today.AddDate(0, 0, -6)).
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "view_date", "views"}).
+			AddRow("a-1", yesterday, 4).
+			AddRow("a-1", today, 6))
+
+	stats, err := u.GetStats(context.Background(), "pura", "a-1", 7)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, stats) {
+		assert.Equal(t, int64(120), stats.TotalViews)
+		assert.Equal(t, int64(10), stats.PeriodViews)
+		assert.Len(t, stats.Daily, 7)
+		assert.Equal(t, today.AddDate(0, 0, -6).Format(time.DateOnly), stats.Daily[0].Date)
+		assert.Zero(t, stats.Daily[0].Views)
+		assert.Equal(t, model.ArticleDailyViewsResponse{Date: yesterday.Format(time.DateOnly), Views: 4}, stats.Daily[5])
+		assert.Equal(t, model.ArticleDailyViewsResponse{Date: today.Format(time.DateOnly), Views: 6}, stats.Daily[6])
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleViewUsecase_GetStats_NotFound(t *testing.T) {
+	u, mock, _ := setupMockArticleViewUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs("a-1", "yayasan", 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := u.GetStats(context.Background(), "yayasan", "a-1", 30)
+
+	assert.Equal(t, 404, err.(*model.ResponseError).Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
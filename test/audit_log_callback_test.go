@@ -0,0 +1,214 @@
+package test
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/repository"
+	"pura-agung-kertajaya-backend/internal/usecase"
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+// auditChanges matches the JSON changes column of an audit_logs insert.
+type auditChanges func(changes entity.AuditChanges) bool
+
+func (m auditChanges) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+	var changes entity.AuditChanges
+	if err := json.Unmarshal([]byte(s), &changes); err != nil {
+		return false
+	}
+	return m(changes)
+}
+
+func setupAuditedHeroSlideUsecase(t *testing.T) (usecase.HeroSlideUsecase, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub db: %v", err)
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+
+	if err := repository.RegisterAuditCallbacks(gormDB); err != nil {
+		t.Fatalf("failed to register audit callbacks: %v", err)
+	}
+
+	return usecase.NewHeroSlideUsecase(gormDB, validator.New()), mock
+}
+
+func auditActorContext() context.Context {
+	return util.WithAuditActor(context.Background(), &model.AuditActor{
+		ID:         "user-1",
+		Role:       "pura",
+		EntityType: "pura",
+		IP:         "10.0.0.1",
+		RequestID:  "req-1",
+	})
+}
+
+func heroSlideRows(id string, orderIndex int, isActive bool) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "entity_type", "images", "order_index", "is_active", "created_at", "updated_at"}).
+		AddRow(id, "pura", []byte(`{"lg":"https://img.jpg"}`), orderIndex, isActive, time.Now(), time.Now())
+}
+
+func TestAuditCallback_Create_RecordsActorAndValues(t *testing.T) {
+	u, mock := setupAuditedHeroSlideUsecase(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `hero_slides`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `audit_logs`")).
+		WithArgs(
+			sqlmock.AnyArg(),
+			"user-1",
+			"pura",
+			"pura",
+			"hero_slides",
+			sqlmock.AnyArg(),
+			entity.AuditActionCreate,
+			auditChanges(func(changes entity.AuditChanges) bool {
+				_, hasCreatedAt := changes["created_at"]
+				return changes["order_index"].Before == nil &&
+					changes["order_index"].After == float64(3) &&
+					!hasCreatedAt
+			}),
+			"10.0.0.1",
+			"req-1",
+			sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	_, err := u.Create(auditActorContext(), "pura", model.HeroSlideRequest{
+		EntityType: "pura",
+		Images:     map[string]string{"lg": "https://img.jpg"},
+		OrderIndex: 3,
+		IsActive:   true,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditCallback_Update_RecordsOnlyChangedColumns(t *testing.T) {
+	u, mock := setupAuditedHeroSlideUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? AND entity_type = ?")).
+		WithArgs("hs-1", "pura", 1).
+		WillReturnRows(heroSlideRows("hs-1", 1, true))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE `id` = ?")).
+		WithArgs("hs-1", 1).
+		WillReturnRows(heroSlideRows("hs-1", 1, true))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `hero_slides`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE `id` = ?")).
+		WithArgs("hs-1", 1).
+		WillReturnRows(heroSlideRows("hs-1", 5, true))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `audit_logs`")).
+		WithArgs(
+			sqlmock.AnyArg(),
+			"user-1",
+			"pura",
+			"pura",
+			"hero_slides",
+			"hs-1",
+			entity.AuditActionUpdate,
+			auditChanges(func(changes entity.AuditChanges) bool {
+				return len(changes) == 1 &&
+					changes["order_index"].Before == float64(1) &&
+					changes["order_index"].After == float64(5)
+			}),
+			"10.0.0.1",
+			"req-1",
+			sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	_, err := u.Update(auditActorContext(), "pura", "hs-1", model.HeroSlideRequest{
+		EntityType: "pura",
+		Images:     map[string]string{"lg": "https://img.jpg"},
+		OrderIndex: 5,
+		IsActive:   true,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditCallback_Delete_WithoutActor_RecordsSystem(t *testing.T) {
+	u, mock := setupAuditedHeroSlideUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? AND entity_type = ?")).
+		WithArgs("hs-1", "pura", 1).
+		WillReturnRows(heroSlideRows("hs-1", 1, true))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE `id` = ?")).
+		WithArgs("hs-1", 1).
+		WillReturnRows(heroSlideRows("hs-1", 1, true))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `hero_slides`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `audit_logs`")).
+		WithArgs(
+			sqlmock.AnyArg(),
+			nil,
+			repository.AuditRoleSystem,
+			"pura",
+			"hero_slides",
+			"hs-1",
+			entity.AuditActionDelete,
+			auditChanges(func(changes entity.AuditChanges) bool {
+				return changes["order_index"].Before == float64(1) && changes["order_index"].After == nil
+			}),
+			"",
+			"",
+			sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := u.Delete(context.Background(), "pura", "hs-1")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditCallback_AuditInsertFails_RollsBackMutation(t *testing.T) {
+	u, mock := setupAuditedHeroSlideUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? AND entity_type = ?")).
+		WithArgs("hs-1", "pura", 1).
+		WillReturnRows(heroSlideRows("hs-1", 1, true))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE `id` = ?")).
+		WithArgs("hs-1", 1).
+		WillReturnRows(heroSlideRows("hs-1", 1, true))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `hero_slides`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `audit_logs`")).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	err := u.Delete(auditActorContext(), "pura", "hs-1")
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,104 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
+	"pura-agung-kertajaya-backend/internal/model"
+	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+func setupAuditLogController(auth *middleware.Auth) (*fiber.App, *usecasemock.AuditLogUsecaseMock) {
+	mockUC := &usecasemock.AuditLogUsecaseMock{}
+	app, logger, _ := NewTestApp()
+
+	controller := httpdelivery.NewAuditLogController(mockUC, logger)
+
+	authMiddleware := func(c *fiber.Ctx) error {
+		c.Locals("user", auth)
+		return c.Next()
+	}
+
+	app.Get("/api/audit-logs", authMiddleware, middleware.SuperAdminMiddleware(), controller.Search)
+
+	return app, mockUC
+}
+
+func TestAuditLogController_Search_Paging(t *testing.T) {
+	app, mockUC := setupAuditLogController(&middleware.Auth{ID: "super-1", Role: "super"})
+
+	expectedTo := time.Date(2026, 10, 17, 0, 0, 0, 0, time.Local).Add(24*time.Hour - time.Nanosecond)
+	mockUC.On("Search", mock.Anything, mock.MatchedBy(func(req *model.SearchAuditLogRequest) bool {
+		return req.Resource == "articles" && req.Page == 2 && req.Size == 5 &&
+			req.From == nil && req.To != nil && req.To.Equal(expectedTo)
+	})).Return([]model.AuditLogResponse{{ID: "log-1", Resource: "articles"}}, int64(12), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/audit-logs?resource=articles&page=2&size=5&to=2026-10-17", nil)
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body model.WebResponse[[]model.AuditLogResponse]
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Len(t, body.Data, 1)
+	if assert.NotNil(t, body.Paging) {
+		assert.Equal(t, 2, body.Paging.Page)
+		assert.Equal(t, int64(12), body.Paging.TotalItem)
+		assert.Equal(t, int64(3), body.Paging.TotalPage)
+	}
+	mockUC.AssertExpectations(t)
+}
+
+func TestAuditLogController_Search_InvalidFrom(t *testing.T) {
+	app, mockUC := setupAuditLogController(&middleware.Auth{ID: "super-1", Role: "super"})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/audit-logs?from=yesterday", nil)
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	mockUC.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+}
+
+func TestAuditLogController_Search_NotSuper_Forbidden(t *testing.T) {
+	app, mockUC := setupAuditLogController(&middleware.Auth{ID: "user-1", Role: "pura"})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/audit-logs", nil)
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	mockUC.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+}
+
+func TestAuditMiddleware_PutsActorInContext(t *testing.T) {
+	app, _, _ := NewTestApp()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", &middleware.Auth{ID: "user-1", Role: "pura"})
+		c.Locals(middleware.CtxEntityType, "pura")
+		return c.Next()
+	})
+	app.Use(middleware.AuditMiddleware())
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.JSON(util.AuditActorFromContext(c.UserContext()))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-ID", "req-42")
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "req-42", resp.Header.Get("X-Request-ID"))
+
+	var actor model.AuditActor
+	json.NewDecoder(resp.Body).Decode(&actor)
+	assert.Equal(t, "user-1", actor.ID)
+	assert.Equal(t, "pura", actor.Role)
+	assert.Equal(t, "pura", actor.EntityType)
+	assert.Equal(t, "req-42", actor.RequestID)
+}
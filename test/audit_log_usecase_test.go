@@ -0,0 +1,88 @@
+package test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/repository"
+	"pura-agung-kertajaya-backend/internal/usecase"
+)
+
+func setupAuditLogUsecase(t *testing.T) (usecase.AuditLogUseCase, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub db: %v", err)
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+
+	return usecase.NewAuditLogUseCase(gormDB, validator.New(), repository.NewAuditLogRepository(logrus.New())), mock
+}
+
+func TestAuditLogUsecase_Search_FiltersAndPaginates(t *testing.T) {
+	u, mock := setupAuditLogUsecase(t)
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"id", "actor_id", "actor_role", "entity_type", "resource", "record_id", "action", "changes", "ip", "request_id", "created_at"}).
+		AddRow("log-1", "user-1", "pura", "pura", "hero_slides", "hs-1", "update", []byte(`{"order_index":{"before":1,"after":5}}`), "10.0.0.1", "req-1", time.Now())
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `audit_logs` WHERE resource = ? AND action = ? AND created_at >= ? ORDER BY created_at DESC LIMIT ? OFFSET ?")).
+		WithArgs("hero_slides", "update", from, 10, 10).
+		WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `audit_logs` WHERE resource = ? AND action = ? AND created_at >= ?")).
+		WithArgs("hero_slides", "update", from).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+
+	logs, total, err := u.Search(context.Background(), &model.SearchAuditLogRequest{
+		Resource: "hero_slides",
+		Action:   "update",
+		From:     &from,
+		Page:     2,
+		Size:     10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), total)
+	if assert.Len(t, logs, 1) {
+		assert.Equal(t, "hs-1", logs[0].RecordID)
+		assert.Equal(t, float64(5), logs[0].Changes["order_index"].After)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditLogUsecase_Search_InvalidAction(t *testing.T) {
+	u, _ := setupAuditLogUsecase(t)
+
+	_, _, err := u.Search(context.Background(), &model.SearchAuditLogRequest{Action: "truncate", Page: 1, Size: 20})
+	assert.Error(t, err)
+}
+
+func TestAuditLogUsecase_Search_FromAfterTo(t *testing.T) {
+	u, _ := setupAuditLogUsecase(t)
+
+	from := time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	_, _, err := u.Search(context.Background(), &model.SearchAuditLogRequest{From: &from, To: &to, Page: 1, Size: 20})
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+	}
+}
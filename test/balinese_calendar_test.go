@@ -0,0 +1,87 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"pura-agung-kertajaya-backend/internal/calendar"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCalendar_Pawukon(t *testing.T) {
+	galungan := calendar.Compute(date(2026, time.June, 17))
+
+	assert.Equal(t, "Buda Kliwon Dungulan", galungan.Pawukon.String())
+	assert.Equal(t, "Beteng", calendar.Triwara[galungan.Pawukon.Triwara()])
+	assert.Contains(t, galungan.HolyDays, "Galungan")
+
+	kuningan := calendar.Compute(date(2026, time.June, 27))
+	assert.Equal(t, "Saniscara Kliwon Kuningan", kuningan.Pawukon.String())
+	assert.Contains(t, kuningan.HolyDays, "Kuningan")
+
+	assert.Equal(t, galungan.Pawukon, calendar.PawukonOf(date(2027, time.January, 13)))
+	assert.Equal(t, galungan.Pawukon, calendar.PawukonOf(date(2025, time.November, 19)))
+}
+
+func TestCalendar_Nyepi(t *testing.T) {
+	nyepi := map[int]time.Time{
+		2019: date(2019, time.March, 7),
+		2022: date(2022, time.March, 3),
+		2024: date(2024, time.March, 11),
+		2025: date(2025, time.March, 29),
+		2026: date(2026, time.March, 19),
+	}
+
+	for year, day := range nyepi {
+		tilem := calendar.Compute(day.AddDate(0, 0, -1))
+		assert.True(t, tilem.Lunar.Tilem, "%d", year)
+		assert.Equal(t, "Kasanga", tilem.Lunar.Sasih, "%d", year)
+		assert.Contains(t, tilem.HolyDays, "Pengerupukan", "%d", year)
+
+		d := calendar.Compute(day)
+		assert.Equal(t, "Kadasa", d.Lunar.Sasih, "%d", year)
+		assert.Equal(t, 1, d.Lunar.Penanggal, "%d", year)
+		assert.Contains(t, d.HolyDays, "Nyepi", "%d", year)
+	}
+}
+
+func TestCalendar_PurnamaTilem(t *testing.T) {
+	purnama := calendar.Compute(date(2025, time.October, 6))
+	assert.True(t, purnama.Lunar.Purnama)
+	assert.Equal(t, 15, purnama.Lunar.Penanggal)
+	assert.Equal(t, []string{"Purnama Kapat"}, purnama.HolyDays)
+
+	siwaratri := calendar.Compute(date(2026, time.January, 17))
+	assert.Equal(t, 14, siwaratri.Lunar.Pangelong)
+	assert.Contains(t, siwaratri.HolyDays, "Siwaratri")
+
+	var purnamas, tilems int
+	for _, d := range calendar.Range(date(2026, time.January, 1), date(2026, time.December, 31)) {
+		if d.Lunar.Purnama {
+			purnamas++
+		}
+		if d.Lunar.Tilem {
+			tilems++
+		}
+	}
+	assert.Equal(t, 13, purnamas)
+	assert.Equal(t, 12, tilems)
+}
+
+func TestCalendar_ParsePawukonDay(t *testing.T) {
+	day, err := calendar.ParsePawukonDay("saniscara umanis watugunung")
+	assert.NoError(t, err)
+	assert.Equal(t, calendar.PawukonDay(209), day)
+	assert.Equal(t, date(2026, time.October, 31), day.Next(date(2026, time.October, 17)))
+
+	_, err = calendar.ParsePawukonDay("Buda Umanis Dungulan")
+	assert.EqualError(t, err, "Buda Umanis does not fall in wuku Dungulan")
+
+	_, err = calendar.ParsePawukonDay("Galungan")
+	assert.Error(t, err)
+}
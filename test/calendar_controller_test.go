@@ -0,0 +1,53 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
+	"pura-agung-kertajaya-backend/internal/model"
+	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
+)
+
+func setupCalendarController(mockUC *usecasemock.CalendarUsecaseMock) *fiber.App {
+	app, logger, _ := NewTestApp()
+
+	controller := httpdelivery.NewCalendarController(mockUC, logger)
+	app.Get("/public/calendar", controller.GetRange)
+
+	return app
+}
+
+func TestCalendarController_GetRange(t *testing.T) {
+	mockUC := &usecasemock.CalendarUsecaseMock{}
+	app := setupCalendarController(mockUC)
+	req := &model.PublicCalendarRequest{EntityType: "pasraman", From: "2026-06-17", To: "2026-06-17"}
+	mockUC.On("GetRange", req).Return([]model.CalendarDayResponse{
+		{Date: "2026-06-17", Wuku: "Dungulan", HolyDays: []string{"Galungan"}},
+	}, nil)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/public/calendar?entity_type=pasraman&from=2026-06-17&to=2026-06-17", nil))
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "public, max-age=3600", resp.Header.Get("Cache-Control"))
+	body, _ := io.ReadAll(resp.Body)
+	var res model.WebResponse[[]model.CalendarDayResponse]
+	assert.NoError(t, json.Unmarshal(body, &res))
+	assert.Equal(t, []string{"Galungan"}, res.Data[0].HolyDays)
+}
+
+func TestCalendarController_GetRange_BadRequest(t *testing.T) {
+	mockUC := &usecasemock.CalendarUsecaseMock{}
+	app := setupCalendarController(mockUC)
+	req := &model.PublicCalendarRequest{EntityType: "pura", From: "2026-06-17", To: "2026-01-01"}
+	mockUC.On("GetRange", req).Return(nil, model.ErrBadRequest("to must not be before from"))
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/public/calendar?from=2026-06-17&to=2026-01-01", nil))
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,64 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"pura-agung-kertajaya-backend/internal/calendar"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+)
+
+func TestCalendarUsecase_GetRange(t *testing.T) {
+	odalan, _ := calendar.ParsePawukonDay("Buda Kliwon Pahang")
+	u := usecase.NewCalendarUsecase(validator.New(), map[string]calendar.PawukonDay{"pura": odalan})
+
+	days, err := u.GetRange(&model.PublicCalendarRequest{EntityType: "pura", From: "2026-06-16", To: "2026-06-18"})
+
+	assert.NoError(t, err)
+	assert.Len(t, days, 3)
+	assert.Equal(t, model.CalendarDayResponse{
+		Date:       "2026-06-17",
+		Wuku:       "Dungulan",
+		Saptawara:  "Buda",
+		Pancawara:  "Kliwon",
+		Triwara:    "Beteng",
+		PawukonDay: 74,
+		Sasih:      "Kasa",
+		Penanggal:  3,
+		HolyDays:   []string{"Galungan"},
+	}, days[1])
+
+	days, err = u.GetRange(&model.PublicCalendarRequest{EntityType: "pura", From: "2026-07-22", To: "2026-07-22"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Pegat Wakan", "Piodalan"}, days[0].HolyDays)
+
+	days, err = u.GetRange(&model.PublicCalendarRequest{EntityType: "yayasan", From: "2026-07-22", To: "2026-07-22"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Pegat Wakan"}, days[0].HolyDays)
+}
+
+func TestCalendarUsecase_GetRange_DefaultsToAMonth(t *testing.T) {
+	u := usecase.NewCalendarUsecase(validator.New(), nil)
+
+	days, err := u.GetRange(&model.PublicCalendarRequest{From: "2026-02-01"})
+
+	assert.NoError(t, err)
+	assert.Len(t, days, 31)
+	assert.Equal(t, "2026-03-03", days[30].Date)
+}
+
+func TestCalendarUsecase_GetRange_InvalidRange(t *testing.T) {
+	u := usecase.NewCalendarUsecase(validator.New(), nil)
+
+	_, err := u.GetRange(&model.PublicCalendarRequest{From: "2026-06-17", To: "2026-06-16"})
+	assert.EqualError(t, err, "to must not be before from")
+
+	_, err = u.GetRange(&model.PublicCalendarRequest{From: "2026-01-01", To: "2027-06-01"})
+	assert.EqualError(t, err, "the calendar covers at most 366 days at a time")
+
+	_, err = u.GetRange(&model.PublicCalendarRequest{From: "17-06-2026"})
+	assert.Error(t, err)
+}
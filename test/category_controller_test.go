@@ -10,8 +10,10 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
 	"pura-agung-kertajaya-backend/internal/model"
 	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
 )
@@ -20,6 +22,11 @@ func setupCategoryController(mockUC *usecasemock.CategoryUsecaseMock) *fiber.App
 	app, logger, _ := NewTestApp()
 	controller := httpdelivery.NewCategoryController(mockUC, logger)
 
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(middleware.CtxEntityType, "pura")
+		return c.Next()
+	})
+
 	app.Get("/public/categories", controller.GetAllPublic)
 	app.Get("/categories", controller.GetAll)
 	app.Get("/categories/:id", controller.GetByID)
@@ -39,7 +46,7 @@ func TestCategoryController_GetAllPublic_Success(t *testing.T) {
 		{ID: "2", Name: "Upacara", Slug: "upacara"},
 	}
 
-	mockUC.On("GetAll").Return(items, nil)
+	mockUC.On("GetAll", "pura", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/public/categories", nil)
 	resp, _ := app.Test(req)
@@ -58,7 +65,7 @@ func TestCategoryController_GetAllPublic_Error(t *testing.T) {
 	mockUC := &usecasemock.CategoryUsecaseMock{}
 	app := setupCategoryController(mockUC)
 
-	mockUC.On("GetAll").Return(([]model.CategoryResponse)(nil), errors.New("db error"))
+	mockUC.On("GetAll", "pura", mock.Anything).Return(([]model.CategoryResponse)(nil), nil, errors.New("db error"))
 
 	req := httptest.NewRequest("GET", "/public/categories", nil)
 	resp, _ := app.Test(req)
@@ -75,7 +82,7 @@ func TestCategoryController_GetByID_Success(t *testing.T) {
 	app := setupCategoryController(mockUC)
 
 	item := &model.CategoryResponse{ID: "1", Name: "Adat", Slug: "adat"}
-	mockUC.On("GetByID", "1").Return(item, nil)
+	mockUC.On("GetByID", "pura", "1").Return(item, nil)
 
 	req := httptest.NewRequest("GET", "/categories/1", nil)
 	resp, _ := app.Test(req)
@@ -87,7 +94,7 @@ func TestCategoryController_GetByID_NotFound(t *testing.T) {
 	mockUC := &usecasemock.CategoryUsecaseMock{}
 	app := setupCategoryController(mockUC)
 
-	mockUC.On("GetByID", "99").Return((*model.CategoryResponse)(nil), model.ErrNotFound("category not found"))
+	mockUC.On("GetByID", "pura", "99").Return((*model.CategoryResponse)(nil), model.ErrNotFound("category not found"))
 
 	req := httptest.NewRequest("GET", "/categories/99", nil)
 	resp, _ := app.Test(req)
@@ -106,7 +113,7 @@ func TestCategoryController_Create_Success(t *testing.T) {
 	payload := model.CreateCategoryRequest{Name: "Baru"}
 	mockResp := &model.CategoryResponse{ID: "100", Name: "Baru", Slug: "baru"}
 
-	mockUC.On("Create", payload).Return(mockResp, nil)
+	mockUC.On("Create", mock.Anything, "pura", payload).Return(mockResp, nil)
 
 	bodyBytes, _ := json.Marshal(payload)
 	req := httptest.NewRequest("POST", "/categories", bytes.NewReader(bodyBytes))
@@ -131,7 +138,7 @@ func TestCategoryController_Create_ValidationError(t *testing.T) {
 	}
 	realValErr := validate.Struct(Dummy{})
 
-	mockUC.On("Create", payload).Return((*model.CategoryResponse)(nil), realValErr)
+	mockUC.On("Create", mock.Anything, "pura", payload).Return((*model.CategoryResponse)(nil), realValErr)
 
 	bodyBytes, _ := json.Marshal(payload)
 	req := httptest.NewRequest("POST", "/categories", bytes.NewReader(bodyBytes))
@@ -161,7 +168,7 @@ func TestCategoryController_Update_Success(t *testing.T) {
 	payload := model.UpdateCategoryRequest{Name: "Updated"}
 	mockResp := &model.CategoryResponse{ID: "1", Name: "Updated", Slug: "updated"}
 
-	mockUC.On("Update", "1", payload).Return(mockResp, nil)
+	mockUC.On("Update", mock.Anything, "pura", "1", payload).Return(mockResp, nil)
 
 	bodyBytes, _ := json.Marshal(payload)
 	req := httptest.NewRequest("PUT", "/categories/1", bytes.NewReader(bodyBytes))
@@ -177,7 +184,7 @@ func TestCategoryController_Update_NotFound(t *testing.T) {
 	app := setupCategoryController(mockUC)
 
 	payload := model.UpdateCategoryRequest{Name: "Updated"}
-	mockUC.On("Update", "99", payload).Return((*model.CategoryResponse)(nil), model.ErrNotFound("category not found"))
+	mockUC.On("Update", mock.Anything, "pura", "99", payload).Return((*model.CategoryResponse)(nil), model.ErrNotFound("category not found"))
 
 	bodyBytes, _ := json.Marshal(payload)
 	req := httptest.NewRequest("PUT", "/categories/99", bytes.NewReader(bodyBytes))
@@ -193,7 +200,7 @@ func TestCategoryController_Delete_Success(t *testing.T) {
 	app := setupCategoryController(mockUC)
 
 	targetID := "uuid-123"
-	mockUC.On("Delete", targetID).Return(nil)
+	mockUC.On("Delete", mock.Anything, "pura", targetID).Return(nil)
 
 	req := httptest.NewRequest("DELETE", "/categories/"+targetID, nil)
 	resp, _ := app.Test(req)
@@ -207,7 +214,7 @@ func TestCategoryController_Delete_Conflict(t *testing.T) {
 	app := setupCategoryController(mockUC)
 
 	targetID := "uuid-used"
-	mockUC.On("Delete", targetID).Return(model.ErrConflict("category is currently in use"))
+	mockUC.On("Delete", mock.Anything, "pura", targetID).Return(model.ErrConflict("category is currently in use"))
 
 	req := httptest.NewRequest("DELETE", "/categories/"+targetID, nil)
 	resp, _ := app.Test(req)
@@ -220,7 +227,7 @@ func TestCategoryController_Delete_NotFound(t *testing.T) {
 	app := setupCategoryController(mockUC)
 
 	targetID := "uuid-missing"
-	mockUC.On("Delete", targetID).Return(model.ErrNotFound("category not found"))
+	mockUC.On("Delete", mock.Anything, "pura", targetID).Return(model.ErrNotFound("category not found"))
 
 	req := httptest.NewRequest("DELETE", "/categories/"+targetID, nil)
 	resp, _ := app.Test(req)
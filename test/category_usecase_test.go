@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"regexp"
 	"testing"
 	"time"
@@ -40,10 +41,11 @@ func TestCategoryUsecase_GetAll(t *testing.T) {
 		AddRow("c1", "Adat", "adat", time.Now(), time.Now()).
 		AddRow("c2", "Upacara", "upacara", time.Now(), time.Now())
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories` ORDER BY name ASC")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories` WHERE entity_type = ? ORDER BY name ASC,id ASC")).
+		WithArgs("pura").
 		WillReturnRows(rows)
 
-	list, err := u.GetAll()
+	list, _, err := u.GetAll("pura", &model.ListRequest{})
 
 	assert.NoError(t, err)
 	assert.Len(t, list, 2)
@@ -60,11 +62,11 @@ func TestCategoryUsecase_GetByID_Success(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"id", "name", "slug"}).
 		AddRow(id, "Adat", "adat")
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories` WHERE id = ? LIMIT ?")).
-		WithArgs(id, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(id, "pura", 1).
 		WillReturnRows(rows)
 
-	res, err := u.GetByID(id)
+	res, err := u.GetByID("pura", id)
 
 	assert.NoError(t, err)
 	if assert.NotNil(t, res) {
@@ -76,11 +78,11 @@ func TestCategoryUsecase_GetByID_NotFound(t *testing.T) {
 	u, mock := setupMockCategoryUsecase(t)
 	id := "missing"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories` WHERE id = ? LIMIT ?")).
-		WithArgs(id, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(id, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.GetByID(id)
+	res, err := u.GetByID("pura", id)
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
@@ -94,19 +96,19 @@ func TestCategoryUsecase_GetByID_NotFound(t *testing.T) {
 
 func TestCategoryUsecase_Create_Simple(t *testing.T) {
 	u, mock := setupMockCategoryUsecase(t)
-	req := model.CreateCategoryRequest{Name: "Upacara Besar"}
+	req := model.CreateCategoryRequest{EntityType: "pura", Name: "Upacara Besar"}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `categories` WHERE slug = ?")).
-		WithArgs("upacara-besar").
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `categories` WHERE entity_type = ? AND slug = ?")).
+		WithArgs("pura", "upacara-besar").
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
 	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `categories`")).
-		WithArgs(sqlmock.AnyArg(), req.Name, "upacara-besar", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(sqlmock.AnyArg(), "pura", req.Name, "upacara-besar", sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	created, err := u.Create(req)
+	created, err := u.Create(context.Background(), "pura", req)
 	assert.NoError(t, err)
 	if assert.NotNil(t, created) {
 		assert.Equal(t, "upacara-besar", created.Slug)
@@ -115,27 +117,27 @@ func TestCategoryUsecase_Create_Simple(t *testing.T) {
 
 func TestCategoryUsecase_Create_SlugCollision(t *testing.T) {
 	u, mock := setupMockCategoryUsecase(t)
-	req := model.CreateCategoryRequest{Name: "Upacara"}
+	req := model.CreateCategoryRequest{EntityType: "pura", Name: "Upacara"}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `categories` WHERE slug = ?")).
-		WithArgs("upacara").
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `categories` WHERE entity_type = ? AND slug = ?")).
+		WithArgs("pura", "upacara").
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `categories` WHERE slug = ?")).
-		WithArgs("upacara-1").
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `categories` WHERE entity_type = ? AND slug = ?")).
+		WithArgs("pura", "upacara-1").
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `categories` WHERE slug = ?")).
-		WithArgs("upacara-2").
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `categories` WHERE entity_type = ? AND slug = ?")).
+		WithArgs("pura", "upacara-2").
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
 	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `categories`")).
-		WithArgs(sqlmock.AnyArg(), "Upacara", "upacara-2", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(sqlmock.AnyArg(), "pura", "Upacara", "upacara-2", sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	created, err := u.Create(req)
+	created, err := u.Create(context.Background(), "pura", req)
 	assert.NoError(t, err)
 	if assert.NotNil(t, created) {
 		assert.Equal(t, "upacara-2", created.Slug)
@@ -147,21 +149,21 @@ func TestCategoryUsecase_Update(t *testing.T) {
 	targetID := "cat-123"
 	req := model.UpdateCategoryRequest{Name: "Baru"}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug"}).AddRow(targetID, "Lama", "lama"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "name", "slug"}).AddRow(targetID, "pura", "Lama", "lama"))
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `categories` WHERE slug = ? AND id != ?")).
-		WithArgs("baru", targetID).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `categories` WHERE entity_type = ? AND (slug = ? AND id != ?)")).
+		WithArgs("pura", "baru", targetID).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
 	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta("UPDATE `categories`")).
-		WithArgs("Baru", "baru", sqlmock.AnyArg(), sqlmock.AnyArg(), targetID).
+		WithArgs("pura", "Baru", "baru", sqlmock.AnyArg(), sqlmock.AnyArg(), targetID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	updated, err := u.Update(targetID, req)
+	updated, err := u.Update(context.Background(), "pura", targetID, req)
 
 	assert.NoError(t, err)
 	if assert.NotNil(t, updated) {
@@ -174,11 +176,11 @@ func TestCategoryUsecase_Update_NotFound(t *testing.T) {
 	targetID := "missing"
 	req := model.UpdateCategoryRequest{Name: "Baru"}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	updated, err := u.Update(targetID, req)
+	updated, err := u.Update(context.Background(), "pura", targetID, req)
 
 	assert.Error(t, err)
 	assert.Nil(t, updated)
@@ -194,9 +196,9 @@ func TestCategoryUsecase_Delete(t *testing.T) {
 	u, mock := setupMockCategoryUsecase(t)
 	targetID := "cat-delete"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `categories` WHERE id = ?")).
-		WithArgs(targetID).
-		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "name", "slug"}).AddRow(targetID, "pura", "Adat", "adat"))
 
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE category_id = ?")).
 		WithArgs(targetID).
@@ -208,7 +210,7 @@ func TestCategoryUsecase_Delete(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 	assert.NoError(t, err)
 }
 
@@ -216,11 +218,11 @@ func TestCategoryUsecase_Delete_NotFound(t *testing.T) {
 	u, mock := setupMockCategoryUsecase(t)
 	targetID := "missing"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `categories` WHERE id = ?")).
-		WithArgs(targetID).
-		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
+		WillReturnRows(sqlmock.NewRows(nil))
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 
 	assert.Error(t, err)
 	var e *model.ResponseError
@@ -234,15 +236,15 @@ func TestCategoryUsecase_Delete_Fail_Referenced(t *testing.T) {
 	u, mock := setupMockCategoryUsecase(t)
 	targetID := "cat-busy"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `categories` WHERE id = ?")).
-		WithArgs(targetID).
-		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "name", "slug"}).AddRow(targetID, "pura", "Adat", "adat"))
 
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE category_id = ?")).
 		WithArgs(targetID).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 	assert.Error(t, err)
 
 	var e *model.ResponseError
@@ -251,3 +253,29 @@ func TestCategoryUsecase_Delete_Fail_Referenced(t *testing.T) {
 		assert.Equal(t, "category is currently in use", e.Message)
 	}
 }
+
+func TestCategoryUsecase_Create_OtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockCategoryUsecase(t)
+
+	res, err := u.Create(context.Background(), "yayasan", model.CreateCategoryRequest{EntityType: "pura", Name: "Upacara"})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCategoryUsecase_Update_MoveToOtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockCategoryUsecase(t)
+
+	res, err := u.Update(context.Background(), "pura", "cat-1", model.UpdateCategoryRequest{EntityType: "yayasan", Name: "Upacara"})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -10,8 +10,10 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
 	"pura-agung-kertajaya-backend/internal/model"
 	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
 )
@@ -20,6 +22,11 @@ func setupContactInfoController(mockUC *usecasemock.ContactInfoUsecaseMock) *fib
 	app, logger, _ := NewTestApp()
 	controller := httpdelivery.NewContactInfoController(mockUC, logger)
 
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(middleware.CtxEntityType, "pura")
+		return c.Next()
+	})
+
 	app.Get("/contact-info", controller.GetAll)
 	app.Get("/contact-info/:id", controller.GetByID)
 	app.Post("/contact-info", controller.Create)
@@ -34,7 +41,7 @@ func TestContactInfoController_GetAll_Success(t *testing.T) {
 	app := setupContactInfoController(mockUC)
 
 	items := []model.ContactInfoResponse{{ID: "1", Address: "A"}, {ID: "2", Address: "B"}}
-	mockUC.On("GetAll", "").Return(items, nil)
+	mockUC.On("GetAll", "", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/contact-info", nil)
 	resp, _ := app.Test(req)
@@ -50,7 +57,7 @@ func TestContactInfoController_GetAll_Error(t *testing.T) {
 	mockUC := &usecasemock.ContactInfoUsecaseMock{}
 	app := setupContactInfoController(mockUC)
 
-	mockUC.On("GetAll", "").Return(([]model.ContactInfoResponse)(nil), errors.New("db error"))
+	mockUC.On("GetAll", "", mock.Anything).Return(([]model.ContactInfoResponse)(nil), nil, errors.New("db error"))
 
 	req := httptest.NewRequest("GET", "/contact-info", nil)
 	resp, _ := app.Test(req)
@@ -66,7 +73,7 @@ func TestContactInfoController_GetByID_Success(t *testing.T) {
 	app := setupContactInfoController(mockUC)
 
 	item := &model.ContactInfoResponse{ID: "x", Address: "Addr"}
-	mockUC.On("GetByID", "x").Return(item, nil)
+	mockUC.On("GetByID", "pura", "x").Return(item, nil)
 
 	req := httptest.NewRequest("GET", "/contact-info/x", nil)
 	resp, _ := app.Test(req)
@@ -81,7 +88,7 @@ func TestContactInfoController_GetByID_NotFound(t *testing.T) {
 	mockUC := &usecasemock.ContactInfoUsecaseMock{}
 	app := setupContactInfoController(mockUC)
 
-	mockUC.On("GetByID", "missing").Return((*model.ContactInfoResponse)(nil), model.ErrNotFound("contact info not found"))
+	mockUC.On("GetByID", "pura", "missing").Return((*model.ContactInfoResponse)(nil), model.ErrNotFound("contact info not found"))
 
 	req := httptest.NewRequest("GET", "/contact-info/missing", nil)
 	resp, _ := app.Test(req)
@@ -98,7 +105,7 @@ func TestContactInfoController_Create_Success(t *testing.T) {
 
 	reqBody := model.CreateContactInfoRequest{EntityType: "pura", Address: "A", Email: "e@x.com"}
 	resBody := &model.ContactInfoResponse{ID: "1", Address: "A"}
-	mockUC.On("Create", reqBody).Return(resBody, nil)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return(resBody, nil)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/contact-info", bytes.NewReader(b))
@@ -129,7 +136,7 @@ func TestContactInfoController_Create_ValidationError(t *testing.T) {
 	}
 	realValErr := validate.Struct(Dummy{})
 
-	mockUC.On("Create", reqBody).Return((*model.ContactInfoResponse)(nil), realValErr)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return((*model.ContactInfoResponse)(nil), realValErr)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/contact-info", bytes.NewReader(b))
@@ -144,7 +151,7 @@ func TestContactInfoController_Update_Success(t *testing.T) {
 
 	reqBody := model.UpdateContactInfoRequest{Address: "New"}
 	resBody := &model.ContactInfoResponse{ID: "2", Address: "New"}
-	mockUC.On("Update", "2", reqBody).Return(resBody, nil)
+	mockUC.On("Update", mock.Anything, "pura", "2", reqBody).Return(resBody, nil)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/contact-info/2", bytes.NewReader(b))
@@ -158,7 +165,7 @@ func TestContactInfoController_Update_NotFound(t *testing.T) {
 	app := setupContactInfoController(mockUC)
 
 	reqBody := model.UpdateContactInfoRequest{Address: "A"}
-	mockUC.On("Update", "3", reqBody).Return((*model.ContactInfoResponse)(nil), model.ErrNotFound("contact info not found"))
+	mockUC.On("Update", mock.Anything, "pura", "3", reqBody).Return((*model.ContactInfoResponse)(nil), model.ErrNotFound("contact info not found"))
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/contact-info/3", bytes.NewReader(b))
@@ -171,7 +178,7 @@ func TestContactInfoController_Delete_Success(t *testing.T) {
 	mockUC := &usecasemock.ContactInfoUsecaseMock{}
 	app := setupContactInfoController(mockUC)
 
-	mockUC.On("Delete", "7").Return(nil)
+	mockUC.On("Delete", mock.Anything, "pura", "7").Return(nil)
 
 	req := httptest.NewRequest("DELETE", "/contact-info/7", nil)
 	resp, _ := app.Test(req)
@@ -182,7 +189,7 @@ func TestContactInfoController_Delete_NotFound(t *testing.T) {
 	mockUC := &usecasemock.ContactInfoUsecaseMock{}
 	app := setupContactInfoController(mockUC)
 
-	mockUC.On("Delete", "8").Return(model.ErrNotFound("contact info not found"))
+	mockUC.On("Delete", mock.Anything, "pura", "8").Return(model.ErrNotFound("contact info not found"))
 
 	req := httptest.NewRequest("DELETE", "/contact-info/8", nil)
 	resp, _ := app.Test(req)
@@ -193,7 +200,7 @@ func TestContactInfoController_Delete_InternalError(t *testing.T) {
 	mockUC := &usecasemock.ContactInfoUsecaseMock{}
 	app := setupContactInfoController(mockUC)
 
-	mockUC.On("Delete", "9").Return(errors.New("db error"))
+	mockUC.On("Delete", mock.Anything, "pura", "9").Return(errors.New("db error"))
 
 	req := httptest.NewRequest("DELETE", "/contact-info/9", nil)
 	resp, _ := app.Test(req)
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"regexp"
 	"testing"
 	"time"
@@ -51,7 +52,7 @@ func TestContactInfoUsecase_Create_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	res, err := u.Create(req)
+	res, err := u.Create(context.Background(), req.EntityType, req)
 	assert.NoError(t, err)
 	if assert.NotNil(t, res) {
 		assert.Equal(t, req.Address, res.Address)
@@ -64,7 +65,7 @@ func TestContactInfoUsecase_Create_ValidationError(t *testing.T) {
 
 	req := model.CreateContactInfoRequest{}
 
-	res, err := u.Create(req)
+	res, err := u.Create(context.Background(), req.EntityType, req)
 	assert.Error(t, err)
 	assert.Nil(t, res)
 }
@@ -80,7 +81,7 @@ func TestContactInfoUsecase_GetAll(t *testing.T) {
 		WithArgs("pura").
 		WillReturnRows(rows)
 
-	list, err := u.GetAll("pura")
+	list, _, err := u.GetAll("pura", &model.ListRequest{})
 	assert.NoError(t, err)
 	assert.Len(t, list, 2)
 }
@@ -92,11 +93,11 @@ func TestContactInfoUsecase_GetByID_Success(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"id", "address", "email"}).
 		AddRow(id, "Addr", "email@test.com")
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `contact_info` WHERE id = ? LIMIT ?")).
-		WithArgs(id, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `contact_info` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(id, "pura", 1).
 		WillReturnRows(rows)
 
-	res, err := u.GetByID(id)
+	res, err := u.GetByID("pura", id)
 	assert.NoError(t, err)
 	if assert.NotNil(t, res) {
 		assert.Equal(t, "Addr", res.Address)
@@ -107,11 +108,11 @@ func TestContactInfoUsecase_GetByID_NotFound(t *testing.T) {
 	u, mock := setupMockContactInfoUsecase(t)
 	id := "missing"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `contact_info` WHERE id = ? LIMIT ?")).
-		WithArgs(id, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `contact_info` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(id, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.GetByID(id)
+	res, err := u.GetByID("pura", id)
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
@@ -135,8 +136,8 @@ func TestContactInfoUsecase_Update_Success(t *testing.T) {
 		MapEmbedURL:   "http://maps.com/new",
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `contact_info` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `contact_info` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "address", "email"}).
 			AddRow(targetID, "pura", "Old Addr", "old@example.com"))
 
@@ -156,7 +157,7 @@ func TestContactInfoUsecase_Update_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	res, err := u.Update(targetID, req)
+	res, err := u.Update(context.Background(), "pura", targetID, req)
 	assert.NoError(t, err)
 
 	if assert.NotNil(t, res) {
@@ -177,11 +178,11 @@ func TestContactInfoUsecase_Update_NotFound(t *testing.T) {
 		MapEmbedURL:   "http://maps.com/new",
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `contact_info` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `contact_info` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.Update(targetID, req)
+	res, err := u.Update(context.Background(), "pura", targetID, req)
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
@@ -197,8 +198,8 @@ func TestContactInfoUsecase_Delete_Success(t *testing.T) {
 	u, mock := setupMockContactInfoUsecase(t)
 	targetID := "to-del"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `contact_info` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `contact_info` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "address"}).AddRow(targetID, "Addr"))
 
 	mock.ExpectBegin()
@@ -207,7 +208,7 @@ func TestContactInfoUsecase_Delete_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 	assert.NoError(t, err)
 }
 
@@ -215,11 +216,11 @@ func TestContactInfoUsecase_Delete_NotFound(t *testing.T) {
 	u, mock := setupMockContactInfoUsecase(t)
 	targetID := "missing"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `contact_info` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `contact_info` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 
 	assert.Error(t, err)
 	var e *model.ResponseError
@@ -228,3 +229,52 @@ func TestContactInfoUsecase_Delete_NotFound(t *testing.T) {
 		assert.Equal(t, "contact info not found", e.Message)
 	}
 }
+
+func TestContactInfoUsecase_Delete_OtherEntity_NotFound(t *testing.T) {
+	u, mock := setupMockContactInfoUsecase(t)
+	targetID := "ci-1"
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `contact_info` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "yayasan", 1).
+		WillReturnRows(sqlmock.NewRows(nil))
+
+	err := u.Delete(context.Background(), "yayasan", targetID)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 404, e.Code)
+		assert.Equal(t, "contact info not found", e.Message)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContactInfoUsecase_Create_OtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockContactInfoUsecase(t)
+
+	req := model.CreateContactInfoRequest{
+		EntityType: "pura",
+		Address:    "Jl. Contoh No.1",
+	}
+
+	res, err := u.Create(context.Background(), "pasraman", req)
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContactInfoUsecase_Update_MoveToOtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockContactInfoUsecase(t)
+
+	res, err := u.Update(context.Background(), "pura", "ci-1", model.UpdateContactInfoRequest{EntityType: "yayasan", Address: "Jl. Raya"})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,129 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+func assetURL(key string) string {
+	return "https://cdn.example.com/" + key
+}
+
+func TestSanitizeHTML(t *testing.T) {
+	out := util.SanitizeHTML(`<p onclick="steal()">Halo <b>umat</b><script>alert(1)</script></p>` +
+		`<a href="javascript:alert(1)">x</a><a href="/berita" target="_blank">y</a>` +
+		`<img src="data:image/png;base64,AAA" alt="a"><img src="https://cdn.example.com/a.webp" onerror="x">` +
+		`<iframe src="https://evil.example.com/embed/1"></iframe>` +
+		`<iframe src="https://www.youtube-nocookie.com/embed/abc" allowfullscreen></iframe>` +
+		`<custom-tag>tetap</custom-tag><style>p{}</style>`)
+
+	assert.Equal(t, `<p>Halo <b>umat</b></p>`+
+		`<a rel="noopener noreferrer nofollow">x</a><a href="/berita" rel="noopener noreferrer nofollow">y</a>`+
+		`<img alt="a"><img src="https://cdn.example.com/a.webp">`+
+		`<iframe src="https://www.youtube-nocookie.com/embed/abc" allowfullscreen></iframe>`+
+		`tetap`, out)
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	out := util.RenderMarkdown("## Upacara\n\nHari **Galungan** dan *Kuningan*, lihat [jadwal](https://pura.example.com/jadwal).\n\n" +
+		"- Banten\n- Canang\n\n1. Datang\n2. Sembahyang\n\n> Om Swastiastu\n\n```\n<b>kode</b>\n```\n\n<script>x</script>")
+
+	assert.Equal(t, "<h2>Upacara</h2>\n"+
+		"<p>Hari <strong>Galungan</strong> dan <em>Kuningan</em>, lihat <a href=\"https://pura.example.com/jadwal\">jadwal</a>.</p>\n"+
+		"<ul>\n<li>Banten</li>\n<li>Canang</li>\n</ul>\n"+
+		"<ol>\n<li>Datang</li>\n<li>Sembahyang</li>\n</ol>\n"+
+		"<blockquote>\n<p>Om Swastiastu</p>\n</blockquote>\n"+
+		"<pre><code>&lt;b&gt;kode&lt;/b&gt;</code></pre>\n"+
+		"<p>&lt;script&gt;x&lt;/script&gt;</p>\n", out)
+}
+
+func TestRenderMarkdown_NestedEmphasis(t *testing.T) {
+	for source, want := range map[string]string{
+		"**bold *italic* bold**":   "<p><strong>bold <em>italic</em> bold</strong></p>\n",
+		"*italic **bold** italic*": "<p><em>italic <strong>bold</strong> italic</em></p>\n",
+		"_a **b** c_":              "<p><em>a <strong>b</strong> c</em></p>\n",
+		"***both*** ~~old~~":       "<p><em><strong>both</strong></em> <del>old</del></p>\n",
+		"**unclosed bold":          "<p>**unclosed bold</p>\n",
+		"snake_case_name":          "<p>snake_case_name</p>\n",
+	} {
+		assert.Equal(t, want, util.RenderMarkdown(source), source)
+	}
+}
+
+func TestRenderMarkdown_LinksInsideEmphasis(t *testing.T) {
+	for source, want := range map[string]string{
+		"**[jadwal](https://pura.example.com/jadwal)**":                    "<p><strong><a href=\"https://pura.example.com/jadwal\">jadwal</a></strong></p>\n",
+		"*lihat [hari raya](https://pura.example.com/hari_raya_galungan)*": "<p><em>lihat <a href=\"https://pura.example.com/hari_raya_galungan\">hari raya</a></em></p>\n",
+		"[**Galungan** `2026`](https://pura.example.com/a*b*c)":            "<p><a href=\"https://pura.example.com/a*b*c\"><strong>Galungan</strong> <code>2026</code></a></p>\n",
+		"*foto* ![canang *sari*](https://cdn.example.com/a_b_c.webp)":      "<p><em>foto</em> <img src=\"https://cdn.example.com/a_b_c.webp\" alt=\"canang *sari*\"></p>\n",
+	} {
+		assert.Equal(t, want, util.RenderMarkdown(source), source)
+	}
+}
+
+func TestRenderMarkdown_UnclosedFence(t *testing.T) {
+	assert.Equal(t, "<p>Contoh:</p>\n<pre><code>&lt;b&gt;*kode*&lt;/b&gt;\n\n# bukan judul</code></pre>\n",
+		util.RenderMarkdown("Contoh:\n```html\n<b>*kode*</b>\n\n# bukan judul"), "an unclosed fence runs to the end")
+	assert.Equal(t, "<pre><code>x\n```</code></pre>\n",
+		util.RenderMarkdown("~~~\nx\n```"), "only a fence of the same kind closes it")
+}
+
+func TestRenderMarkdown_NULCharacters(t *testing.T) {
+	assert.Equal(t, "<p>a\uFFFD999\uFFFDb</p>\n", util.RenderMarkdown("a\x00999\x00b"),
+		"a marker of a span that was never held must not panic")
+	assert.Equal(t, "<p><code>\uFFFD0\uFFFD</code> <em>x</em></p>\n", util.RenderMarkdown("`\x000\x00` *x*"),
+		"a code span holding its own marker must not loop")
+
+	rendered, err := util.RenderContent(util.ContentFormatBlocks, `[
+		{"type": "paragraph", "text": "\u0000999\u0000"},
+		{"type": "heading", "text": "`+"`"+`\u00000\u0000`+"`"+`"}
+	]`, assetURL)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "<p>\uFFFD999\uFFFD</p>\n<h2><code>\uFFFD0\uFFFD</code></h2>\n", rendered.HTML)
+	}
+}
+
+func TestRenderContent_Blocks(t *testing.T) {
+	source := `[
+		{"type": "heading", "text": "Piodalan"},
+		{"type": "paragraph", "text": "Upacara **besar** di pura"},
+		{"type": "image", "key": "uploads/piodalan_lg.webp", "alt": "Piodalan", "caption": "Suasana"},
+		{"type": "quote", "text": "Om Shanti", "cite": "Pemangku"},
+		{"type": "embed", "url": "https://youtu.be/dQw4w9WgXcQ"}
+	]`
+
+	rendered, err := util.RenderContent(util.ContentFormatBlocks, source, assetURL)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "<h2>Piodalan</h2>\n"+
+		"<p>Upacara <strong>besar</strong> di pura</p>\n"+
+		`<figure><img src="https://cdn.example.com/uploads/piodalan_lg.webp" alt="Piodalan" loading="lazy"><figcaption>Suasana</figcaption></figure>`+"\n"+
+		"<blockquote><p>Om Shanti</p><cite>Pemangku</cite></blockquote>\n"+
+		`<figure><iframe src="https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ" title="" loading="lazy" allowfullscreen></iframe></figure>`+"\n", rendered.HTML)
+	assert.Equal(t, "Piodalan Upacara besar di pura Suasana Om Shanti Pemangku", rendered.Text, "the searchable text holds no block keys")
+	assert.Equal(t, 9, rendered.WordCount)
+	assert.Equal(t, 1, rendered.ReadingTimeMinutes)
+}
+
+func TestRenderContent_InvalidBlocks(t *testing.T) {
+	_, err := util.RenderContent(util.ContentFormatBlocks, `{"type": "paragraph"}`, assetURL)
+	assert.Error(t, err)
+
+	_, err = util.RenderContent(util.ContentFormatBlocks, `[{"type": "video", "url": "https://x.example.com"}]`, assetURL)
+	assert.EqualError(t, err, `block 1: unknown block type "video"`)
+
+	_, err = util.RenderContent(util.ContentFormatBlocks, `[{"type": "embed", "url": "https://evil.example.com/v/1"}]`, assetURL)
+	assert.EqualError(t, err, "block 1: only YouTube, Vimeo and Google Maps can be embedded")
+}
+
+func TestRenderContent_ReadingTime(t *testing.T) {
+	rendered, err := util.RenderContent(util.ContentFormatMarkdown, strings.Repeat("kata ", 401), assetURL)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 401, rendered.WordCount)
+	assert.Equal(t, 3, rendered.ReadingTimeMinutes)
+}
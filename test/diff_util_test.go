@@ -0,0 +1,27 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+func TestDiffLines(t *testing.T) {
+	lines := util.DiffLines("a\nb\nc\nd", "a\nc\nd\ne")
+
+	assert.Equal(t, []util.DiffLine{
+		{Op: util.DiffEqual, Text: "a"},
+		{Op: util.DiffDelete, Text: "b"},
+		{Op: util.DiffEqual, Text: "c"},
+		{Op: util.DiffEqual, Text: "d"},
+		{Op: util.DiffInsert, Text: "e"},
+	}, lines)
+}
+
+func TestDiffLines_EmptySide(t *testing.T) {
+	assert.Equal(t, []util.DiffLine{{Op: util.DiffInsert, Text: "new"}}, util.DiffLines("", "new"))
+	assert.Equal(t, []util.DiffLine{{Op: util.DiffDelete, Text: "old"}}, util.DiffLines("old", ""))
+	assert.Equal(t, []util.DiffLine{{Op: util.DiffEqual, Text: "same"}}, util.DiffLines("same", "same"))
+}
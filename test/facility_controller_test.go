@@ -15,6 +15,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func setupFacilityController(mockUC *usecasemock.FacilityUsecaseMock) *fiber.App {
@@ -43,7 +44,7 @@ func TestFacilityController_GetAllPublic_Success(t *testing.T) {
 	app := setupFacilityController(mockUC)
 
 	items := []model.FacilityResponse{{ID: "1", Name: "A"}, {ID: "2", Name: "B"}}
-	mockUC.On("GetPublic", "").Return(items, nil)
+	mockUC.On("GetPublic", "", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/public/facilities", nil)
 	resp, _ := app.Test(req, -1)
@@ -59,7 +60,7 @@ func TestFacilityController_GetAllPublic_Error(t *testing.T) {
 	mockUC := &usecasemock.FacilityUsecaseMock{}
 	app := setupFacilityController(mockUC)
 
-	mockUC.On("GetPublic", "").Return(([]model.FacilityResponse)(nil), errors.New("db error"))
+	mockUC.On("GetPublic", "", mock.Anything).Return(([]model.FacilityResponse)(nil), nil, errors.New("db error"))
 
 	req := httptest.NewRequest("GET", "/api/public/facilities", nil)
 	resp, _ := app.Test(req, -1)
@@ -75,7 +76,7 @@ func TestFacilityController_GetAll_Success(t *testing.T) {
 	app := setupFacilityController(mockUC)
 
 	items := []model.FacilityResponse{{ID: "1", Name: "A"}}
-	mockUC.On("GetAll", "pura").Return(items, nil)
+	mockUC.On("GetAll", "pura", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/facilities", nil)
 	resp, _ := app.Test(req, -1)
@@ -88,7 +89,7 @@ func TestFacilityController_GetByID_Success(t *testing.T) {
 	app := setupFacilityController(mockUC)
 
 	item := &model.FacilityResponse{ID: "x", Name: "X"}
-	mockUC.On("GetByID", "x").Return(item, nil)
+	mockUC.On("GetByID", "pura", "x").Return(item, nil)
 
 	req := httptest.NewRequest("GET", "/api/facilities/x", nil)
 	resp, _ := app.Test(req, -1)
@@ -103,7 +104,7 @@ func TestFacilityController_GetByID_NotFound(t *testing.T) {
 	mockUC := &usecasemock.FacilityUsecaseMock{}
 	app := setupFacilityController(mockUC)
 
-	mockUC.On("GetByID", "missing").Return((*model.FacilityResponse)(nil), model.ErrNotFound("facility not found"))
+	mockUC.On("GetByID", "pura", "missing").Return((*model.FacilityResponse)(nil), model.ErrNotFound("facility not found"))
 
 	req := httptest.NewRequest("GET", "/api/facilities/missing", nil)
 	resp, _ := app.Test(req, -1)
@@ -121,7 +122,7 @@ func TestFacilityController_Create_Success(t *testing.T) {
 	reqBody := model.CreateFacilityRequest{EntityType: "pura", Name: "New Facility"}
 	resBody := &model.FacilityResponse{ID: "1", Name: "New Facility"}
 
-	mockUC.On("Create", "pura", reqBody).Return(resBody, nil)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return(resBody, nil)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/facilities", bytes.NewReader(b))
@@ -142,7 +143,7 @@ func TestFacilityController_Create_ValidationError(t *testing.T) {
 	}
 	realValErr := validate.Struct(Dummy{})
 
-	mockUC.On("Create", "pura", reqBody).Return((*model.FacilityResponse)(nil), realValErr)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return((*model.FacilityResponse)(nil), realValErr)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/facilities", bytes.NewReader(b))
@@ -158,7 +159,7 @@ func TestFacilityController_Update_Success(t *testing.T) {
 	reqBody := model.UpdateFacilityRequest{Name: "Updated"}
 	resBody := &model.FacilityResponse{ID: "2", Name: "Updated"}
 
-	mockUC.On("Update", "2", reqBody).Return(resBody, nil)
+	mockUC.On("Update", mock.Anything, "pura", "2", reqBody).Return(resBody, nil)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/facilities/2", bytes.NewReader(b))
@@ -172,7 +173,7 @@ func TestFacilityController_Update_NotFound(t *testing.T) {
 	app := setupFacilityController(mockUC)
 
 	reqBody := model.UpdateFacilityRequest{Name: "Updated"}
-	mockUC.On("Update", "3", reqBody).Return((*model.FacilityResponse)(nil), model.ErrNotFound("facility not found"))
+	mockUC.On("Update", mock.Anything, "pura", "3", reqBody).Return((*model.FacilityResponse)(nil), model.ErrNotFound("facility not found"))
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/facilities/3", bytes.NewReader(b))
@@ -185,7 +186,7 @@ func TestFacilityController_Delete_Success(t *testing.T) {
 	mockUC := &usecasemock.FacilityUsecaseMock{}
 	app := setupFacilityController(mockUC)
 
-	mockUC.On("Delete", "7").Return(nil)
+	mockUC.On("Delete", mock.Anything, "pura", "7").Return(nil)
 
 	req := httptest.NewRequest("DELETE", "/api/facilities/7", nil)
 	resp, _ := app.Test(req, -1)
@@ -196,7 +197,7 @@ func TestFacilityController_Delete_NotFound(t *testing.T) {
 	mockUC := &usecasemock.FacilityUsecaseMock{}
 	app := setupFacilityController(mockUC)
 
-	mockUC.On("Delete", "8").Return(model.ErrNotFound("facility not found"))
+	mockUC.On("Delete", mock.Anything, "pura", "8").Return(model.ErrNotFound("facility not found"))
 
 	req := httptest.NewRequest("DELETE", "/api/facilities/8", nil)
 	resp, _ := app.Test(req, -1)
@@ -207,7 +208,7 @@ func TestFacilityController_Delete_InternalError(t *testing.T) {
 	mockUC := &usecasemock.FacilityUsecaseMock{}
 	app := setupFacilityController(mockUC)
 
-	mockUC.On("Delete", "9").Return(errors.New("db error"))
+	mockUC.On("Delete", mock.Anything, "pura", "9").Return(errors.New("db error"))
 
 	req := httptest.NewRequest("DELETE", "/api/facilities/9", nil)
 	resp, _ := app.Test(req, -1)
@@ -0,0 +1,275 @@
+package test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
+	"pura-agung-kertajaya-backend/internal/model"
+	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
+)
+
+func setupFeedController(mockUC *usecasemock.FeedUsecaseMock) *fiber.App {
+	app, logger, _ := NewTestApp()
+
+	controller := httpdelivery.NewFeedController(mockUC, logger)
+	app.Get("/public/feeds/articles.:format", controller.GetArticles)
+	app.Get("/public/activities.ics", controller.GetActivities)
+	app.Get("/public/activities/:id.ics", controller.GetActivity)
+
+	return app
+}
+
+func sampleFeed() *model.Feed {
+	published := time.Date(2026, 10, 10, 8, 0, 0, 0, time.UTC)
+	updated := time.Date(2026, 10, 12, 9, 30, 0, 0, time.UTC)
+
+	return &model.Feed{
+		Title:       "Pura Agung Kertajaya",
+		Description: "Berita pura",
+		Link:        "https://pura.example.com",
+		Language:    "id",
+		Updated:     updated,
+		Items: []model.FeedItem{{
+			ID:          "urn:uuid:a-1",
+			Title:       "Hari Raya Galungan",
+			Link:        "https://pura.example.com/articles/hari-raya-galungan",
+			Summary:     "Persiapan Galungan",
+			ContentHTML: "<p>Isi <b>artikel</b></p>",
+			Author:      "Admin",
+			Categories:  []string{"Upacara", "Galungan"},
+			ImageURL:    "https://cdn.example.com/articles/galungan-lg.webp",
+			Published:   published,
+			Updated:     updated,
+		}},
+	}
+}
+
+func TestFeedController_RSS(t *testing.T) {
+	mockUC := &usecasemock.FeedUsecaseMock{}
+	app := setupFeedController(mockUC)
+	mockUC.On("GetArticleFeed", "yayasan", "upacara").Return(sampleFeed(), nil)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/public/feeds/articles.rss?entity_type=yayasan&category=upacara", nil))
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/rss+xml; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.NotEmpty(t, resp.Header.Get("ETag"))
+	assert.Equal(t, "Mon, 12 Oct 2026 09:30:00 GMT", resp.Header.Get("Last-Modified"))
+
+	var doc struct {
+		Channel struct {
+			Title string `xml:"title"`
+			Items []struct {
+				Title   string `xml:"title"`
+				Link    string `xml:"link"`
+				GUID    string `xml:"guid"`
+				PubDate string `xml:"pubDate"`
+				Content string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+				Media   struct {
+					URL  string `xml:"url,attr"`
+					Type string `xml:"type,attr"`
+				} `xml:"http://search.yahoo.com/mrss/ content"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	body, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, xml.Unmarshal(body, &doc))
+	assert.Equal(t, "Pura Agung Kertajaya", doc.Channel.Title)
+	if assert.Len(t, doc.Channel.Items, 1) {
+		item := doc.Channel.Items[0]
+		assert.Equal(t, "https://pura.example.com/articles/hari-raya-galungan", item.Link)
+		assert.Equal(t, "urn:uuid:a-1", item.GUID)
+		assert.Equal(t, "Sat, 10 Oct 2026 08:00:00 +0000", item.PubDate)
+		assert.Equal(t, "<p>Isi <b>artikel</b></p>", item.Content)
+		assert.Equal(t, "https://cdn.example.com/articles/galungan-lg.webp", item.Media.URL)
+		assert.Equal(t, "image/webp", item.Media.Type)
+	}
+}
+
+func TestFeedController_Atom(t *testing.T) {
+	mockUC := &usecasemock.FeedUsecaseMock{}
+	app := setupFeedController(mockUC)
+	mockUC.On("GetArticleFeed", "pura", "").Return(sampleFeed(), nil)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/public/feeds/articles.atom", nil))
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/atom+xml; charset=utf-8", resp.Header.Get("Content-Type"))
+
+	var doc struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		Updated string   `xml:"updated"`
+		Entries []struct {
+			ID      string `xml:"id"`
+			Content struct {
+				Type string `xml:"type,attr"`
+				Body string `xml:",chardata"`
+			} `xml:"content"`
+			Categories []struct {
+				Term string `xml:"term,attr"`
+			} `xml:"category"`
+		} `xml:"entry"`
+	}
+	body, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, xml.Unmarshal(body, &doc))
+	assert.Equal(t, "2026-10-12T09:30:00Z", doc.Updated)
+	if assert.Len(t, doc.Entries, 1) {
+		assert.Equal(t, "urn:uuid:a-1", doc.Entries[0].ID)
+		assert.Equal(t, "html", doc.Entries[0].Content.Type)
+		assert.Equal(t, "<p>Isi <b>artikel</b></p>", doc.Entries[0].Content.Body)
+		assert.Len(t, doc.Entries[0].Categories, 2)
+	}
+}
+
+func TestFeedController_JSONFeed(t *testing.T) {
+	mockUC := &usecasemock.FeedUsecaseMock{}
+	app := setupFeedController(mockUC)
+	mockUC.On("GetArticleFeed", "pura", "").Return(sampleFeed(), nil)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/public/feeds/articles.json", nil))
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/feed+json; charset=utf-8", resp.Header.Get("Content-Type"))
+
+	var doc struct {
+		Version string `json:"version"`
+		FeedURL string `json:"feed_url"`
+		Items   []struct {
+			ID    string `json:"id"`
+			Image string `json:"image"`
+		} `json:"items"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+	assert.Equal(t, "https://jsonfeed.org/version/1.1", doc.Version)
+	assert.Equal(t, "http://example.com/public/feeds/articles.json", doc.FeedURL)
+	if assert.Len(t, doc.Items, 1) {
+		assert.Equal(t, "https://cdn.example.com/articles/galungan-lg.webp", doc.Items[0].Image)
+	}
+}
+
+func TestFeedController_NotModified(t *testing.T) {
+	mockUC := &usecasemock.FeedUsecaseMock{}
+	app := setupFeedController(mockUC)
+	mockUC.On("GetArticleFeed", "pura", "").Return(sampleFeed(), nil)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/public/feeds/articles.rss", nil))
+	etag := resp.Header.Get("ETag")
+
+	req := httptest.NewRequest("GET", "/public/feeds/articles.rss", nil)
+	req.Header.Set("If-None-Match", etag)
+	resp, _ = app.Test(req)
+	assert.Equal(t, fiber.StatusNotModified, resp.StatusCode)
+
+	req = httptest.NewRequest("GET", "/public/feeds/articles.rss", nil)
+	req.Header.Set("If-Modified-Since", time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat))
+	resp, _ = app.Test(req)
+	assert.Equal(t, fiber.StatusNotModified, resp.StatusCode)
+
+	req = httptest.NewRequest("GET", "/public/feeds/articles.rss", nil)
+	req.Header.Set("If-None-Match", `"stale"`)
+	resp, _ = app.Test(req)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestFeedController_Errors(t *testing.T) {
+	mockUC := &usecasemock.FeedUsecaseMock{}
+	app := setupFeedController(mockUC)
+	mockUC.On("GetArticleFeed", "pura", "missing").Return(nil, model.ErrNotFound("category not found"))
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/public/feeds/articles.xml", nil))
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/public/feeds/articles.rss?category=missing", nil))
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	mockUC.AssertNumberOfCalls(t, "GetArticleFeed", 1)
+}
+
+func sampleCalendar() *model.ICalendar {
+	updated := time.Date(2026, 10, 12, 9, 30, 0, 0, time.UTC)
+
+	return &model.ICalendar{
+		Name:        "Pura Agung Kertajaya",
+		Description: "Kegiatan pura",
+		URL:         "https://pura.example.com/activities",
+		Updated:     updated,
+		Events: []model.ICalendarEvent{
+			{
+				UID:         "act-1@pura-agung-kertajaya",
+				Summary:     "Piodalan; Pura, Agung",
+				Description: "Persembahyangan bersama di Pura Agung Kertajaya, dilanjutkan dengan dharma wacana dan prasadam untuk seluruh umat\nWaktu: 08:00",
+				Location:    "Jaba Tengah",
+				URL:         "https://pura.example.com/activities",
+				Start:       time.Date(2026, 10, 21, 8, 0, 0, 0, time.FixedZone("WITA", 8*60*60)),
+				End:         time.Date(2026, 10, 21, 9, 0, 0, 0, time.FixedZone("WITA", 8*60*60)),
+				Updated:     updated,
+			},
+			{
+				UID:     "act-2-20261024@pura-agung-kertajaya",
+				Summary: "Kerja Bakti",
+				Start:   time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC),
+				End:     time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC),
+				AllDay:  true,
+				Updated: updated,
+			},
+		},
+	}
+}
+
+func TestFeedController_ActivityCalendar(t *testing.T) {
+	mockUC := &usecasemock.FeedUsecaseMock{}
+	app := setupFeedController(mockUC)
+	mockUC.On("GetActivityCalendar", "yayasan").Return(sampleCalendar(), nil)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/public/activities.ics?entity_type=yayasan", nil))
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/calendar; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "public, max-age=300", resp.Header.Get("Cache-Control"))
+	assert.NotEmpty(t, resp.Header.Get("ETag"))
+
+	body, _ := io.ReadAll(resp.Body)
+	text := string(body)
+	assert.True(t, strings.HasPrefix(text, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(text, "END:VCALENDAR\r\n"))
+	assert.Contains(t, text, "X-WR-CALNAME:Pura Agung Kertajaya\r\n")
+	assert.Contains(t, text, "BEGIN:VTIMEZONE\r\nTZID:Asia/Makassar\r\n")
+	assert.Contains(t, text, "UID:act-1@pura-agung-kertajaya\r\n")
+	assert.Contains(t, text, "DTSTAMP:20261012T093000Z\r\n")
+	assert.Contains(t, text, "DTSTART;TZID=Asia/Makassar:20261021T080000\r\n")
+	assert.Contains(t, text, "DTEND;TZID=Asia/Makassar:20261021T090000\r\n")
+	assert.Contains(t, text, "SUMMARY:Piodalan\\; Pura\\, Agung\r\n")
+	assert.Contains(t, text, "DTSTART;VALUE=DATE:20261024\r\n")
+	assert.Contains(t, text, "DTEND;VALUE=DATE:20261025\r\n")
+
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, line)
+		assert.NotContains(t, line, "\n")
+	}
+	unfolded := strings.ReplaceAll(text, "\r\n ", "")
+	assert.Contains(t, unfolded, "DESCRIPTION:Persembahyangan bersama di Pura Agung Kertajaya\\, dilanjutkan dengan dharma wacana dan prasadam untuk seluruh umat\\nWaktu: 08:00\r\n")
+}
+
+func TestFeedController_ActivityEvent(t *testing.T) {
+	mockUC := &usecasemock.FeedUsecaseMock{}
+	app := setupFeedController(mockUC)
+	mockUC.On("GetActivityEvent", "pura", "act-2", "2026-10-24").Return(sampleCalendar(), nil)
+	mockUC.On("GetActivityEvent", "pura", "missing", "").Return(nil, model.ErrNotFound("activity not found"))
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/public/activities/act-2.ics?date=2026-10-24", nil))
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/calendar; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="act-2.ics"`, resp.Header.Get("Content-Disposition"))
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/public/activities/missing.ics", nil))
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,228 @@
+package test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+func setupFeedUsecase(t *testing.T) (usecase.FeedUsecase, sqlmock.Sqlmock, *usecasemock.ArticleUsecaseMock, *usecasemock.SiteIdentityUsecaseMock) {
+	db, sqlMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub db: %v", err)
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+
+	siteURL := &util.SiteURLUtil{
+		BaseURLs:     map[string]string{"pura": "https://pura.example.com/", "yayasan": "https://yayasan.example.com"},
+		ArticlePath:  "/berita",
+		AssetBaseURL: "https://cdn.example.com",
+	}
+
+	articleUC := &usecasemock.ArticleUsecaseMock{}
+	siteIdentityUC := &usecasemock.SiteIdentityUsecaseMock{}
+	return usecase.NewFeedUsecase(gormDB, articleUC, &usecasemock.ActivityUsecaseMock{}, siteIdentityUC, siteURL), sqlMock, articleUC, siteIdentityUC
+}
+
+func TestFeedUsecase_GetArticleFeed(t *testing.T) {
+	u, sqlMock, articleUC, siteIdentityUC := setupFeedUsecase(t)
+
+	published := time.Date(2026, 10, 10, 8, 0, 0, 0, time.UTC)
+	edited := time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC)
+
+	siteIdentityUC.On("GetPublic", "pura").Return(&model.SiteIdentityResponse{SiteName: "Pura Agung Kertajaya", Tagline: "Berita pura"}, nil)
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories` WHERE entity_type = ? AND slug = ? LIMIT ?")).
+		WithArgs("pura", "upacara", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug"}).AddRow("c-1", "Upacara", "upacara"))
+	articleUC.On("GetPublic", "pura", mock.MatchedBy(func(req *model.ListRequest) bool {
+		return req.Sort == "-published_at" && req.Size == 20 && req.Filters["category_id"] == "c-1"
+	})).Return([]model.ArticleResponse{
+		{
+			ID: "a-1", Title: "Galungan", Slug: "galungan", AuthorName: "Admin",
+			Category:    &model.CategoryResponse{Name: "Upacara"},
+			Tags:        []model.TagResponse{{Name: "Hari Raya"}},
+			Images:      model.ImageVariants{Md: "articles/galungan-md.webp", Lg: "/articles/galungan-lg.webp"},
+			PublishedAt: &published, UpdatedAt: edited,
+		},
+		{
+			ID: "a-2", Title: "Kuningan", Slug: "kuningan",
+			Images:      model.ImageVariants{Sm: "https://other.example.com/kuningan-sm.jpg"},
+			PublishedAt: &published, UpdatedAt: published,
+		},
+	}, nil, nil)
+
+	feed, err := u.GetArticleFeed("pura", "upacara")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Pura Agung Kertajaya - Upacara", feed.Title)
+	assert.Equal(t, "Berita pura", feed.Description)
+	assert.Equal(t, "https://pura.example.com", feed.Link)
+	assert.Equal(t, edited, feed.Updated)
+	if assert.Len(t, feed.Items, 2) {
+		assert.Equal(t, "urn:uuid:a-1", feed.Items[0].ID)
+		assert.Equal(t, "https://pura.example.com/berita/galungan", feed.Items[0].Link)
+		assert.Equal(t, "https://cdn.example.com/articles/galungan-lg.webp", feed.Items[0].ImageURL)
+		assert.Equal(t, []string{"Upacara", "Hari Raya"}, feed.Items[0].Categories)
+		assert.Equal(t, "https://other.example.com/kuningan-sm.jpg", feed.Items[1].ImageURL)
+	}
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestFeedUsecase_GetArticleFeed_WithoutSiteIdentity(t *testing.T) {
+	u, _, articleUC, siteIdentityUC := setupFeedUsecase(t)
+
+	siteIdentityUC.On("GetPublic", "yayasan").Return(nil, model.ErrNotFound("site identity not found"))
+	articleUC.On("GetPublic", "yayasan", mock.Anything).Return([]model.ArticleResponse{}, nil, nil)
+
+	feed, err := u.GetArticleFeed("yayasan", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "yayasan", feed.Title)
+	assert.Empty(t, feed.Items)
+	assert.True(t, feed.Updated.IsZero())
+}
+
+func TestFeedUsecase_GetArticleFeed_UnknownCategory(t *testing.T) {
+	u, sqlMock, articleUC, siteIdentityUC := setupFeedUsecase(t)
+
+	siteIdentityUC.On("GetPublic", "pura").Return(&model.SiteIdentityResponse{SiteName: "Pura"}, nil)
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories`")).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := u.GetArticleFeed("pura", "missing")
+
+	assert.Equal(t, 404, err.(*model.ResponseError).Code)
+	articleUC.AssertNotCalled(t, "GetPublic", mock.Anything, mock.Anything)
+}
+
+func TestFeedUsecase_RequiresPublicSite(t *testing.T) {
+	u, _, articleUC, siteIdentityUC := setupFeedUsecase(t)
+
+	_, err := u.GetArticleFeed("kantor", "")
+	assert.Equal(t, 400, err.(*model.ResponseError).Code)
+
+	_, err = u.GetArticleFeed("pasraman", "")
+	assert.Equal(t, 404, err.(*model.ResponseError).Code, "pasraman has no public site")
+
+	_, err = u.GetActivityCalendar("kantor")
+	assert.Equal(t, 400, err.(*model.ResponseError).Code)
+
+	_, err = u.GetActivityCalendar("pasraman")
+	assert.Equal(t, 404, err.(*model.ResponseError).Code)
+
+	_, err = u.GetActivityEvent("pasraman", "act-1", "")
+	assert.Equal(t, 404, err.(*model.ResponseError).Code)
+
+	articleUC.AssertNotCalled(t, "GetPublic", mock.Anything, mock.Anything)
+	siteIdentityUC.AssertNotCalled(t, "GetPublic", mock.Anything)
+}
+
+func setupActivityCalendarUsecase() (usecase.FeedUsecase, *usecasemock.ActivityUsecaseMock, *usecasemock.SiteIdentityUsecaseMock) {
+	siteURL := &util.SiteURLUtil{
+		BaseURLs:     map[string]string{"pura": "https://pura.example.com/"},
+		ActivityPath: "/kegiatan",
+	}
+
+	activityUC := &usecasemock.ActivityUsecaseMock{}
+	siteIdentityUC := &usecasemock.SiteIdentityUsecaseMock{}
+	return usecase.NewFeedUsecase(nil, &usecasemock.ArticleUsecaseMock{}, activityUC, siteIdentityUC, siteURL), activityUC, siteIdentityUC
+}
+
+func TestFeedUsecase_GetActivityCalendar(t *testing.T) {
+	u, activityUC, siteIdentityUC := setupActivityCalendarUsecase()
+
+	day := time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)
+	edited := time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC)
+	wita := time.FixedZone("WITA", 8*60*60)
+
+	siteIdentityUC.On("GetPublic", "pura").Return(&model.SiteIdentityResponse{SiteName: "Pura Agung Kertajaya", Tagline: "Kegiatan pura"}, nil)
+	activityUC.On("GetPublic", "pura", mock.Anything, mock.MatchedBy(func(w *model.DateRange) bool {
+		return w.To.Sub(w.From) == 365*24*time.Hour
+	})).Return([]model.ActivityResponse{
+		{ID: "act-1", Title: "Piodalan", Description: "Persembahyangan", TimeInfo: "08:00 WITA", Location: "Jaba Tengah", EventDate: day, UpdatedAt: edited},
+		{ID: "act-2", Title: "Kidung", TimeInfo: "19.30 - 21.00", EventDate: day.AddDate(0, 0, 3), OccurrenceDate: "2026-10-24", UpdatedAt: day.AddDate(0, 0, -30)},
+		{ID: "act-3", Title: "Kerja Bakti", TimeInfo: "menyusul", EventDate: day.AddDate(0, 0, 5), UpdatedAt: day.AddDate(0, 0, -30)},
+	}, nil, nil)
+
+	cal, err := u.GetActivityCalendar("pura")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Pura Agung Kertajaya", cal.Name)
+	assert.Equal(t, "Kegiatan pura", cal.Description)
+	assert.Equal(t, "https://pura.example.com/kegiatan", cal.URL)
+	assert.Equal(t, edited, cal.Updated)
+	if assert.Len(t, cal.Events, 3) {
+		assert.Equal(t, "act-1@pura-agung-kertajaya", cal.Events[0].UID)
+		assert.Equal(t, "Persembahyangan\n\nWaktu: 08:00 WITA", cal.Events[0].Description)
+		assert.Equal(t, "https://pura.example.com/kegiatan", cal.Events[0].URL)
+		assert.True(t, cal.Events[0].Start.Equal(time.Date(2026, 10, 21, 8, 0, 0, 0, wita)))
+		assert.True(t, cal.Events[0].End.Equal(time.Date(2026, 10, 21, 9, 0, 0, 0, wita)))
+		assert.False(t, cal.Events[0].AllDay)
+
+		assert.Equal(t, "act-2-20261024@pura-agung-kertajaya", cal.Events[1].UID)
+		assert.True(t, cal.Events[1].Start.Equal(time.Date(2026, 10, 24, 19, 30, 0, 0, wita)))
+		assert.True(t, cal.Events[1].End.Equal(time.Date(2026, 10, 24, 21, 0, 0, 0, wita)))
+
+		assert.True(t, cal.Events[2].AllDay)
+		assert.Equal(t, "2026-10-26", cal.Events[2].Start.Format("2006-01-02"))
+		assert.Equal(t, "2026-10-27", cal.Events[2].End.Format("2006-01-02"))
+	}
+}
+
+func TestFeedUsecase_GetActivityEvent(t *testing.T) {
+	u, activityUC, siteIdentityUC := setupActivityCalendarUsecase()
+
+	start := time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC)
+	siteIdentityUC.On("GetPublic", "pura").Return(nil, model.ErrNotFound("site identity not found"))
+	activityUC.On("GetByID", "pura", "act-1").Return(&model.ActivityResponse{ID: "act-1", Title: "Piodalan", EventDate: start, IsActive: true}, nil)
+	activityUC.On("GetByID", "pura", "act-2").Return(&model.ActivityResponse{ID: "act-2", Title: "Kidung", EventDate: start, IsActive: true, PawukonRule: "Buda Kliwon Dungulan"}, nil)
+	activityUC.On("GetByID", "pura", "act-3").Return(&model.ActivityResponse{ID: "act-3", IsActive: false}, nil)
+	activityUC.On("GetPublic", "pura", mock.MatchedBy(func(req *model.ListRequest) bool {
+		return req.Filters["id"] == "act-2"
+	}), mock.MatchedBy(func(w *model.DateRange) bool {
+		return w.To.Sub(w.From) == 365*24*time.Hour
+	})).Return([]model.ActivityResponse{
+		{ID: "act-2", Title: "Kidung", EventDate: time.Date(2026, 6, 17, 0, 0, 0, 0, time.UTC), OccurrenceDate: "2026-06-17"},
+		{ID: "act-2", Title: "Kidung", EventDate: time.Date(2027, 1, 13, 0, 0, 0, 0, time.UTC), OccurrenceDate: "2027-01-13"},
+	}, nil, nil)
+
+	cal, err := u.GetActivityEvent("pura", "act-1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "pura", cal.Name)
+	if assert.Len(t, cal.Events, 1) {
+		assert.Equal(t, "act-1@pura-agung-kertajaya", cal.Events[0].UID)
+	}
+
+	cal, err = u.GetActivityEvent("pura", "act-2", "2026-06-17")
+	assert.NoError(t, err)
+	if assert.Len(t, cal.Events, 1) {
+		assert.Equal(t, "act-2-20260617@pura-agung-kertajaya", cal.Events[0].UID)
+	}
+
+	_, err = u.GetActivityEvent("pura", "act-2", "2026-06-18")
+	assert.Equal(t, 404, err.(*model.ResponseError).Code)
+
+	_, err = u.GetActivityEvent("pura", "act-2", "17-06-2026")
+	assert.Equal(t, 400, err.(*model.ResponseError).Code)
+
+	_, err = u.GetActivityEvent("pura", "act-3", "")
+	assert.Equal(t, 404, err.(*model.ResponseError).Code)
+}
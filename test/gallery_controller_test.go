@@ -10,6 +10,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
 	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
@@ -44,7 +45,7 @@ func TestGalleryController_GetAllPublic_Success(t *testing.T) {
 	app := setupGalleryController(mockUC)
 
 	items := []model.GalleryResponse{{ID: "g1", Title: "Image 1"}}
-	mockUC.On("GetPublic", "").Return(items, nil)
+	mockUC.On("GetPublic", "", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/public/galleries", nil)
 	resp, _ := app.Test(req, -1)
@@ -58,7 +59,7 @@ func TestGalleryController_GetAllPublic_WithFilter(t *testing.T) {
 	app := setupGalleryController(mockUC)
 
 	items := []model.GalleryResponse{{ID: "g1", Title: "Pura Image"}}
-	mockUC.On("GetPublic", "pura").Return(items, nil)
+	mockUC.On("GetPublic", "pura", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/public/galleries?entity_type=pura", nil)
 	resp, _ := app.Test(req, -1)
@@ -71,7 +72,7 @@ func TestGalleryController_GetAllPublic_Error(t *testing.T) {
 	mockUC := &usecasemock.GalleryUsecaseMock{}
 	app := setupGalleryController(mockUC)
 
-	mockUC.On("GetPublic", "").Return(([]model.GalleryResponse)(nil), errors.New("db error"))
+	mockUC.On("GetPublic", "", mock.Anything).Return(([]model.GalleryResponse)(nil), nil, errors.New("db error"))
 
 	req := httptest.NewRequest("GET", "/api/public/galleries", nil)
 	resp, _ := app.Test(req, -1)
@@ -85,7 +86,7 @@ func TestGalleryController_GetAll_Success(t *testing.T) {
 
 	items := []model.GalleryResponse{{ID: "g1", Title: "Admin View"}}
 	// "pura" injected by middleware mock
-	mockUC.On("GetAll", "pura").Return(items, nil)
+	mockUC.On("GetAll", "pura", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/galleries", nil)
 	resp, _ := app.Test(req, -1)
@@ -100,7 +101,7 @@ func TestGalleryController_GetByID_Success(t *testing.T) {
 
 	targetID := "g1"
 	item := &model.GalleryResponse{ID: targetID, Title: "Detail"}
-	mockUC.On("GetByID", targetID).Return(item, nil)
+	mockUC.On("GetByID", "pura", targetID).Return(item, nil)
 
 	req := httptest.NewRequest("GET", "/api/galleries/"+targetID, nil)
 	resp, _ := app.Test(req, -1)
@@ -113,7 +114,7 @@ func TestGalleryController_GetByID_NotFound(t *testing.T) {
 	app := setupGalleryController(mockUC)
 
 	targetID := "missing"
-	mockUC.On("GetByID", targetID).Return((*model.GalleryResponse)(nil), model.ErrNotFound("gallery not found"))
+	mockUC.On("GetByID", "pura", targetID).Return((*model.GalleryResponse)(nil), model.ErrNotFound("gallery not found"))
 
 	req := httptest.NewRequest("GET", "/api/galleries/"+targetID, nil)
 	resp, _ := app.Test(req, -1)
@@ -132,7 +133,7 @@ func TestGalleryController_Create_Success(t *testing.T) {
 	}
 	resBody := &model.GalleryResponse{ID: "1", Title: "New"}
 
-	mockUC.On("Create", "pura", reqBody).Return(resBody, nil)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return(resBody, nil)
 
 	body, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/galleries", bytes.NewReader(body))
@@ -155,7 +156,7 @@ func TestGalleryController_Create_ValidationError(t *testing.T) {
 	}
 	realValErr := validate.Struct(Dummy{})
 
-	mockUC.On("Create", "pura", reqBody).Return((*model.GalleryResponse)(nil), realValErr)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return((*model.GalleryResponse)(nil), realValErr)
 
 	body, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/galleries", bytes.NewReader(body))
@@ -174,7 +175,7 @@ func TestGalleryController_Update_Success(t *testing.T) {
 	reqBody := model.UpdateGalleryRequest{Title: "Updated"}
 	resBody := &model.GalleryResponse{ID: targetID, Title: "Updated"}
 
-	mockUC.On("Update", targetID, reqBody).Return(resBody, nil)
+	mockUC.On("Update", mock.Anything, "pura", targetID, reqBody).Return(resBody, nil)
 
 	body, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/galleries/"+targetID, bytes.NewReader(body))
@@ -191,7 +192,7 @@ func TestGalleryController_Update_NotFound(t *testing.T) {
 
 	targetID := "missing"
 	reqBody := model.UpdateGalleryRequest{Title: "Updated"}
-	mockUC.On("Update", targetID, reqBody).Return((*model.GalleryResponse)(nil), model.ErrNotFound("gallery not found"))
+	mockUC.On("Update", mock.Anything, "pura", targetID, reqBody).Return((*model.GalleryResponse)(nil), model.ErrNotFound("gallery not found"))
 
 	body, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/galleries/"+targetID, bytes.NewReader(body))
@@ -207,7 +208,7 @@ func TestGalleryController_Delete_Success(t *testing.T) {
 	app := setupGalleryController(mockUC)
 
 	targetID := "g1"
-	mockUC.On("Delete", targetID).Return(nil)
+	mockUC.On("Delete", mock.Anything, "pura", targetID).Return(nil)
 
 	req := httptest.NewRequest("DELETE", "/api/galleries/"+targetID, nil)
 	resp, _ := app.Test(req, -1)
@@ -220,7 +221,7 @@ func TestGalleryController_Delete_NotFound(t *testing.T) {
 	app := setupGalleryController(mockUC)
 
 	targetID := "missing"
-	mockUC.On("Delete", targetID).Return(model.ErrNotFound("gallery not found"))
+	mockUC.On("Delete", mock.Anything, "pura", targetID).Return(model.ErrNotFound("gallery not found"))
 
 	req := httptest.NewRequest("DELETE", "/api/galleries/"+targetID, nil)
 	resp, _ := app.Test(req, -1)
@@ -233,7 +234,7 @@ func TestGalleryController_Delete_InternalError(t *testing.T) {
 	app := setupGalleryController(mockUC)
 
 	targetID := "error"
-	mockUC.On("Delete", targetID).Return(errors.New("db error"))
+	mockUC.On("Delete", mock.Anything, "pura", targetID).Return(errors.New("db error"))
 
 	req := httptest.NewRequest("DELETE", "/api/galleries/"+targetID, nil)
 	resp, _ := app.Test(req, -1)
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"regexp"
 	"testing"
 
@@ -43,7 +44,7 @@ func TestGalleryUsecase_GetPublic_FilterActiveAndOrder(t *testing.T) {
 		WithArgs("pura", true).
 		WillReturnRows(rows)
 
-	list, err := u.GetPublic("pura")
+	list, _, err := u.GetPublic("pura", &model.ListRequest{})
 
 	assert.NoError(t, err)
 	assert.Len(t, list, 2)
@@ -57,11 +58,11 @@ func TestGalleryUsecase_GetByID_Success(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{"id", "title"}).AddRow(id, "Gallery Title")
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE id = ? LIMIT ?")).
-		WithArgs(id, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(id, "pura", 1).
 		WillReturnRows(rows)
 
-	res, err := u.GetByID(id)
+	res, err := u.GetByID("pura", id)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
 	assert.Equal(t, "Gallery Title", res.Title)
@@ -71,11 +72,11 @@ func TestGalleryUsecase_GetByID_NotFound(t *testing.T) {
 	u, mock := setupMockGalleryUsecase(t)
 	id := "missing"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE id = ? LIMIT ?")).
-		WithArgs(id, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(id, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.GetByID(id)
+	res, err := u.GetByID("pura", id)
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
@@ -114,7 +115,7 @@ func TestGalleryUsecase_Create(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	created, err := u.Create(req.EntityType, req)
+	created, err := u.Create(context.Background(), req.EntityType, req)
 	assert.NoError(t, err)
 	if assert.NotNil(t, created) {
 		assert.Equal(t, "https://img.com/lg.jpg", created.Images.Lg)
@@ -124,7 +125,7 @@ func TestGalleryUsecase_Create(t *testing.T) {
 func TestGalleryUsecase_Create_ValidationError(t *testing.T) {
 	u, _ := setupMockGalleryUsecase(t)
 	req := model.CreateGalleryRequest{}
-	res, err := u.Create("pura", req)
+	res, err := u.Create(context.Background(), "pura", req)
 	assert.Error(t, err)
 	assert.Nil(t, res)
 }
@@ -140,8 +141,8 @@ func TestGalleryUsecase_Update(t *testing.T) {
 		OrderIndex: 5,
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "title", "images"}).
 			AddRow(targetID, "pura", "Old Title", []byte(`{"lg":"old.jpg"}`)))
 
@@ -161,7 +162,7 @@ func TestGalleryUsecase_Update(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	updated, err := u.Update(targetID, req)
+	updated, err := u.Update(context.Background(), "pura", targetID, req)
 	assert.NoError(t, err)
 	if assert.NotNil(t, updated) {
 		assert.Equal(t, "New Title", updated.Title)
@@ -179,11 +180,11 @@ func TestGalleryUsecase_Update_NotFound(t *testing.T) {
 		Images: map[string]string{"lg": "img.jpg"},
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	updated, err := u.Update(targetID, req)
+	updated, err := u.Update(context.Background(), "pura", targetID, req)
 
 	assert.Error(t, err)
 	assert.Nil(t, updated)
@@ -199,8 +200,8 @@ func TestGalleryUsecase_Delete(t *testing.T) {
 	u, mock := setupMockGalleryUsecase(t)
 	targetID := "g-1"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "entity_type", "images"}).
 			AddRow(targetID, "Title", "pura", []byte(`{}`)))
 
@@ -210,7 +211,7 @@ func TestGalleryUsecase_Delete(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 	assert.NoError(t, err)
 }
 
@@ -218,11 +219,11 @@ func TestGalleryUsecase_Delete_NotFound(t *testing.T) {
 	u, mock := setupMockGalleryUsecase(t)
 	targetID := "missing"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 
 	assert.Error(t, err)
 	var e *model.ResponseError
@@ -231,3 +232,47 @@ func TestGalleryUsecase_Delete_NotFound(t *testing.T) {
 		assert.Equal(t, "gallery not found", e.Message)
 	}
 }
+
+func TestGalleryUsecase_Delete_OtherEntity_NotFound(t *testing.T) {
+	u, mock := setupMockGalleryUsecase(t)
+	targetID := "gal-1"
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "yayasan", 1).
+		WillReturnRows(sqlmock.NewRows(nil))
+
+	err := u.Delete(context.Background(), "yayasan", targetID)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 404, e.Code)
+		assert.Equal(t, "gallery not found", e.Message)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGalleryUsecase_Create_OtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockGalleryUsecase(t)
+
+	res, err := u.Create(context.Background(), "yayasan", model.CreateGalleryRequest{EntityType: "pura", Title: "Odalan", Images: map[string]string{"lg": "a.webp"}})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGalleryUsecase_Update_MoveToOtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockGalleryUsecase(t)
+
+	res, err := u.Update(context.Background(), "pura", "gal-1", model.UpdateGalleryRequest{EntityType: "yayasan", Title: "Odalan", Images: map[string]string{"lg": "a.webp"}})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
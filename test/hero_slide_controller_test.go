@@ -15,6 +15,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func setupHeroSlideController(mockUC *usecasemock.HeroSlideUsecaseMock) *fiber.App {
@@ -48,7 +49,7 @@ func TestHeroSlideController_GetAllPublic_Success(t *testing.T) {
 		{ID: "b", EntityType: "pura", Images: model.ImageVariants{Lg: "https://b"}},
 	}
 
-	mockUC.On("GetPublic", "pura").Return(items, nil)
+	mockUC.On("GetPublic", "pura", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/public/hero-slides?entity_type=pura", nil)
 	resp, _ := app.Test(req, -1)
@@ -61,7 +62,7 @@ func TestHeroSlideController_GetAllPublic_Error(t *testing.T) {
 	mockUC := &usecasemock.HeroSlideUsecaseMock{}
 	app := setupHeroSlideController(mockUC)
 
-	mockUC.On("GetPublic", "").Return(([]model.HeroSlideResponse)(nil), errors.New("db error"))
+	mockUC.On("GetPublic", "", mock.Anything).Return(([]model.HeroSlideResponse)(nil), nil, errors.New("db error"))
 
 	req := httptest.NewRequest("GET", "/api/public/hero-slides", nil)
 	resp, _ := app.Test(req, -1)
@@ -74,7 +75,7 @@ func TestHeroSlideController_GetAll_Success(t *testing.T) {
 	app := setupHeroSlideController(mockUC)
 
 	items := []model.HeroSlideResponse{{ID: "a"}}
-	mockUC.On("GetAll", "pura").Return(items, nil)
+	mockUC.On("GetAll", "pura", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/hero-slides", nil)
 	resp, _ := app.Test(req, -1)
@@ -88,7 +89,7 @@ func TestHeroSlideController_GetByID_Success(t *testing.T) {
 	app := setupHeroSlideController(mockUC)
 
 	item := &model.HeroSlideResponse{ID: "x", Images: model.ImageVariants{Lg: "https://x"}}
-	mockUC.On("GetByID", "x").Return(item, nil)
+	mockUC.On("GetByID", "pura", "x").Return(item, nil)
 
 	req := httptest.NewRequest("GET", "/api/hero-slides/x", nil)
 	resp, _ := app.Test(req, -1)
@@ -100,7 +101,7 @@ func TestHeroSlideController_GetByID_NotFound(t *testing.T) {
 	mockUC := &usecasemock.HeroSlideUsecaseMock{}
 	app := setupHeroSlideController(mockUC)
 
-	mockUC.On("GetByID", "missing").Return((*model.HeroSlideResponse)(nil), model.ErrNotFound("hero slide not found"))
+	mockUC.On("GetByID", "pura", "missing").Return((*model.HeroSlideResponse)(nil), model.ErrNotFound("hero slide not found"))
 
 	req := httptest.NewRequest("GET", "/api/hero-slides/missing", nil)
 	resp, _ := app.Test(req, -1)
@@ -119,7 +120,7 @@ func TestHeroSlideController_Create_Success(t *testing.T) {
 	reqBody := model.HeroSlideRequest{EntityType: "pura", Images: map[string]string{"lg": "https://img.com/lg.jpg"}, OrderIndex: 1, IsActive: true}
 	resBody := &model.HeroSlideResponse{ID: "1", EntityType: "pura"}
 
-	mockUC.On("Create", "pura", reqBody).Return(resBody, nil)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return(resBody, nil)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/hero-slides", bytes.NewReader(b))
@@ -141,7 +142,7 @@ func TestHeroSlideController_Create_ValidationError(t *testing.T) {
 	}
 	realValErr := validate.Struct(Dummy{})
 
-	mockUC.On("Create", "pura", reqBody).Return((*model.HeroSlideResponse)(nil), realValErr)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return((*model.HeroSlideResponse)(nil), realValErr)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/hero-slides", bytes.NewReader(b))
@@ -158,7 +159,7 @@ func TestHeroSlideController_Update_Success(t *testing.T) {
 	reqBody := model.HeroSlideRequest{EntityType: "pura", Images: map[string]string{"lg": "https://new"}, OrderIndex: 3, IsActive: false}
 	resBody := &model.HeroSlideResponse{ID: "2", EntityType: "pura", Images: model.ImageVariants{Lg: "https://new"}}
 
-	mockUC.On("Update", "2", reqBody).Return(resBody, nil)
+	mockUC.On("Update", mock.Anything, "pura", "2", reqBody).Return(resBody, nil)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/hero-slides/2", bytes.NewReader(b))
@@ -173,7 +174,7 @@ func TestHeroSlideController_Update_NotFound(t *testing.T) {
 	app := setupHeroSlideController(mockUC)
 
 	reqBody := model.HeroSlideRequest{Images: map[string]string{"lg": "https://img"}}
-	mockUC.On("Update", "3", reqBody).Return((*model.HeroSlideResponse)(nil), model.ErrNotFound("hero slide not found"))
+	mockUC.On("Update", mock.Anything, "pura", "3", reqBody).Return((*model.HeroSlideResponse)(nil), model.ErrNotFound("hero slide not found"))
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/hero-slides/3", bytes.NewReader(b))
@@ -187,7 +188,7 @@ func TestHeroSlideController_Delete_Success(t *testing.T) {
 	mockUC := &usecasemock.HeroSlideUsecaseMock{}
 	app := setupHeroSlideController(mockUC)
 
-	mockUC.On("Delete", "7").Return(nil)
+	mockUC.On("Delete", mock.Anything, "pura", "7").Return(nil)
 
 	req := httptest.NewRequest("DELETE", "/api/hero-slides/7", nil)
 	resp, _ := app.Test(req, -1)
@@ -199,7 +200,7 @@ func TestHeroSlideController_Delete_NotFound(t *testing.T) {
 	mockUC := &usecasemock.HeroSlideUsecaseMock{}
 	app := setupHeroSlideController(mockUC)
 
-	mockUC.On("Delete", "8").Return(model.ErrNotFound("hero slide not found"))
+	mockUC.On("Delete", mock.Anything, "pura", "8").Return(model.ErrNotFound("hero slide not found"))
 
 	req := httptest.NewRequest("DELETE", "/api/hero-slides/8", nil)
 	resp, _ := app.Test(req, -1)
@@ -211,7 +212,7 @@ func TestHeroSlideController_Delete_InternalError(t *testing.T) {
 	mockUC := &usecasemock.HeroSlideUsecaseMock{}
 	app := setupHeroSlideController(mockUC)
 
-	mockUC.On("Delete", "9").Return(errors.New("db error"))
+	mockUC.On("Delete", mock.Anything, "pura", "9").Return(errors.New("db error"))
 
 	req := httptest.NewRequest("DELETE", "/api/hero-slides/9", nil)
 	resp, _ := app.Test(req, -1)
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"regexp"
 	"testing"
 
@@ -43,7 +44,7 @@ func TestHeroSlideUsecase_GetPublic_FilterActiveAndOrder(t *testing.T) {
 		WithArgs(true).
 		WillReturnRows(rows)
 
-	list, err := u.GetPublic("")
+	list, _, err := u.GetPublic("", &model.ListRequest{})
 	assert.NoError(t, err)
 	assert.Len(t, list, 2)
 	assert.Equal(t, "https://img1.jpg", list[0].Images.Lg)
@@ -73,7 +74,7 @@ func TestHeroSlideUsecase_Create_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	created, err := u.Create(req.EntityType, req)
+	created, err := u.Create(context.Background(), req.EntityType, req)
 	assert.NoError(t, err)
 	if assert.NotNil(t, created) {
 		assert.Equal(t, req.EntityType, created.EntityType)
@@ -84,7 +85,7 @@ func TestHeroSlideUsecase_Create_Success(t *testing.T) {
 func TestHeroSlideUsecase_Create_ValidationError(t *testing.T) {
 	u, _ := setupMockHeroSlideUsecase(t)
 	req := model.HeroSlideRequest{}
-	res, err := u.Create("pura", req)
+	res, err := u.Create(context.Background(), "pura", req)
 	assert.Error(t, err)
 	assert.Nil(t, res)
 }
@@ -100,7 +101,7 @@ func TestHeroSlideUsecase_GetAll_OrderedByIndex(t *testing.T) {
 		WithArgs("pura").
 		WillReturnRows(rows)
 
-	list, err := u.GetAll("pura")
+	list, _, err := u.GetAll("pura", &model.ListRequest{})
 	assert.NoError(t, err)
 	assert.Len(t, list, 2)
 }
@@ -112,11 +113,11 @@ func TestHeroSlideUsecase_GetByID_Success(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"id", "entity_type", "images"}).
 		AddRow(id, "pura", []byte(`{"lg":"img.jpg"}`))
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? LIMIT ?")).
-		WithArgs(id, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(id, "pura", 1).
 		WillReturnRows(rows)
 
-	res, err := u.GetByID(id)
+	res, err := u.GetByID("pura", id)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
 }
@@ -125,11 +126,11 @@ func TestHeroSlideUsecase_GetByID_NotFound(t *testing.T) {
 	u, mock := setupMockHeroSlideUsecase(t)
 	id := "not-exist"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? LIMIT ?")).
-		WithArgs(id, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(id, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.GetByID(id)
+	res, err := u.GetByID("pura", id)
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
@@ -146,21 +147,21 @@ func TestHeroSlideUsecase_Update_Success(t *testing.T) {
 	targetID := "slide-1"
 
 	req := model.HeroSlideRequest{
-		EntityType: "yayasan",
+		EntityType: "pura",
 		Images:     map[string]string{"lg": "https://new.jpg"},
 		OrderIndex: 5,
 		IsActive:   false,
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "images"}).
 			AddRow(targetID, "pura", []byte(`{"lg":"https://old.jpg"}`)))
 
 	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta("UPDATE `hero_slides`")).
 		WithArgs(
-			"yayasan",
+			"pura",
 			sqlmock.AnyArg(),
 			5,
 			false,
@@ -171,7 +172,7 @@ func TestHeroSlideUsecase_Update_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	res, err := u.Update(targetID, req)
+	res, err := u.Update(context.Background(), "pura", targetID, req)
 	assert.NoError(t, err)
 	if assert.NotNil(t, res) {
 		assert.Equal(t, req.EntityType, res.EntityType)
@@ -189,11 +190,11 @@ func TestHeroSlideUsecase_Update_NotFound(t *testing.T) {
 		OrderIndex: 1,
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.Update(targetID, req)
+	res, err := u.Update(context.Background(), "pura", targetID, req)
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
@@ -209,8 +210,8 @@ func TestHeroSlideUsecase_Delete_Success(t *testing.T) {
 	u, mock := setupMockHeroSlideUsecase(t)
 	targetID := "to-delete"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "images"}).
 			AddRow(targetID, []byte(`{"lg":"https://img.jpg"}`)))
 
@@ -220,7 +221,7 @@ func TestHeroSlideUsecase_Delete_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 	assert.NoError(t, err)
 }
 
@@ -228,11 +229,11 @@ func TestHeroSlideUsecase_Delete_NotFound(t *testing.T) {
 	u, mock := setupMockHeroSlideUsecase(t)
 	targetID := "missing"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 
 	assert.Error(t, err)
 	var e *model.ResponseError
@@ -241,3 +242,53 @@ func TestHeroSlideUsecase_Delete_NotFound(t *testing.T) {
 		assert.Equal(t, "hero slide not found", e.Message)
 	}
 }
+
+func TestHeroSlideUsecase_Delete_OtherEntity_NotFound(t *testing.T) {
+	u, mock := setupMockHeroSlideUsecase(t)
+	targetID := "slide-1"
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `hero_slides` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "yayasan", 1).
+		WillReturnRows(sqlmock.NewRows(nil))
+
+	err := u.Delete(context.Background(), "yayasan", targetID)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 404, e.Code)
+		assert.Equal(t, "hero slide not found", e.Message)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHeroSlideUsecase_Update_MoveToOtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockHeroSlideUsecase(t)
+
+	req := model.HeroSlideRequest{
+		EntityType: "yayasan",
+		Images:     map[string]string{"lg": "https://new.jpg"},
+		OrderIndex: 5,
+	}
+
+	res, err := u.Update(context.Background(), "pura", "slide-1", req)
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHeroSlideUsecase_Create_OtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockHeroSlideUsecase(t)
+
+	res, err := u.Create(context.Background(), "yayasan", model.HeroSlideRequest{EntityType: "pura", Images: map[string]string{"lg": "a.webp"}, OrderIndex: 1})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,148 @@
+package test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/repository"
+)
+
+var galleryTestListSpec = repository.ListSpec{
+	Sorts: map[string]string{
+		"title":       "title",
+		"order_index": "order_index",
+	},
+	Filters: map[string]string{
+		"is_active": "is_active",
+	},
+	Search:      []string{"title"},
+	DefaultSort: "order_index",
+}
+
+func setupListQuery(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub db: %v", err)
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+	return gormDB, mock
+}
+
+func TestFindPage_Unpaged(t *testing.T) {
+	db, mock := setupListQuery(t)
+	repo := &repository.Repository[entity.Gallery]{DB: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE entity_type = ? ORDER BY order_index ASC,id ASC")).
+		WithArgs("pura").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow("g-1", "A").AddRow("g-2", "B"))
+
+	var items []entity.Gallery
+	paging, err := repo.FindPage(db.Where("entity_type = ?", "pura"), &items, galleryTestListSpec, &model.ListRequest{})
+
+	assert.NoError(t, err)
+	assert.Nil(t, paging)
+	assert.Len(t, items, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindPage_FilterSearchSortAndPage(t *testing.T) {
+	db, mock := setupListQuery(t)
+	repo := &repository.Repository[entity.Gallery]{DB: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `galleries` WHERE `is_active` = ? AND title LIKE ?")).
+		WithArgs(true, "%odalan%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE `is_active` = ? AND title LIKE ? ORDER BY title DESC,id ASC LIMIT ? OFFSET ?")).
+		WithArgs(true, "%odalan%", 3, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow("g-3", "C").AddRow("g-4", "D"))
+
+	var items []entity.Gallery
+	paging, err := repo.FindPage(db, &items, galleryTestListSpec, &model.ListRequest{
+		Page:    2,
+		Size:    2,
+		Sort:    "-title",
+		Search:  "odalan",
+		Filters: map[string]string{"is_active": "true", "password": "x"},
+	})
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, paging) {
+		assert.Equal(t, 2, paging.Page)
+		assert.Equal(t, int64(5), paging.TotalItem)
+		assert.Equal(t, int64(3), paging.TotalPage)
+	}
+	assert.Len(t, items, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindPage_Cursor(t *testing.T) {
+	db, mock := setupListQuery(t)
+	repo := &repository.Repository[entity.Gallery]{DB: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `galleries`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` ORDER BY order_index ASC,id ASC LIMIT ?")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "order_index"}).
+			AddRow("g-1", 1).AddRow("g-2", 3).AddRow("g-3", 3))
+
+	var items []entity.Gallery
+	paging, err := repo.FindPage(db, &items, galleryTestListSpec, &model.ListRequest{Size: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	if !assert.NotNil(t, paging) || !assert.NotEmpty(t, paging.NextCursor) {
+		return
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `galleries` WHERE (order_index > ?) OR (order_index <=> ? AND id > ?) ORDER BY order_index ASC,id ASC LIMIT ?")).
+		WithArgs(float64(3), float64(3), "g-2", 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "order_index"}).AddRow("g-3", 3))
+
+	var next []entity.Gallery
+	paging, err = repo.FindPage(db, &next, galleryTestListSpec, &model.ListRequest{Size: 2, Cursor: paging.NextCursor})
+
+	assert.NoError(t, err)
+	assert.Len(t, next, 1)
+	if assert.NotNil(t, paging) {
+		assert.Empty(t, paging.NextCursor)
+		assert.Equal(t, int64(0), paging.TotalItem)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindPage_RejectsUnknownSortAndBadCursor(t *testing.T) {
+	db, _ := setupListQuery(t)
+	repo := &repository.Repository[entity.Gallery]{DB: db}
+
+	var items []entity.Gallery
+	_, err := repo.FindPage(db, &items, galleryTestListSpec, &model.ListRequest{Sort: "-password"})
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+		assert.Equal(t, "unsupported sort field: password", e.Message)
+	}
+
+	_, err = repo.FindPage(db, &items, galleryTestListSpec, &model.ListRequest{Cursor: "not-a-cursor"})
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, "invalid cursor", e.Message)
+	}
+
+	_, err = repo.FindPage(db, &items, galleryTestListSpec, &model.ListRequest{Size: 500})
+	assert.ErrorAs(t, err, &e)
+}
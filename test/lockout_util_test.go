@@ -0,0 +1,53 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+func TestLockoutDuration_Backoff(t *testing.T) {
+	assert.Equal(t, time.Duration(0), util.LockoutDuration(util.LockoutFreeAttempts))
+	assert.Equal(t, util.LockoutBaseDuration, util.LockoutDuration(util.LockoutFreeAttempts+1))
+	assert.Equal(t, 2*util.LockoutBaseDuration, util.LockoutDuration(util.LockoutFreeAttempts+2))
+	assert.Equal(t, 4*util.LockoutBaseDuration, util.LockoutDuration(util.LockoutFreeAttempts+3))
+	assert.Equal(t, util.LockoutMaxDuration, util.LockoutDuration(100))
+}
+
+func TestLockoutUtil_LocksAndExpires(t *testing.T) {
+	tokenUtil, _ := setupTokenUtil(t)
+	lockoutUtil := util.NewLockoutUtil(tokenUtil.Redis)
+	ctx := context.Background()
+
+	now := time.Unix(1_700_000_000, 0)
+	lockoutUtil.Now = func() time.Time { return now }
+
+	for i := 0; i < util.LockoutFreeAttempts; i++ {
+		lockout, err := lockoutUtil.RegisterFailure(ctx, "Admin@Example.com")
+		assert.NoError(t, err)
+		assert.Zero(t, lockout)
+	}
+
+	lockout, err := lockoutUtil.RegisterFailure(ctx, "admin@example.com ")
+	assert.NoError(t, err)
+	assert.Equal(t, util.LockoutBaseDuration, lockout, "emails are normalized before counting")
+
+	remaining, err := lockoutUtil.LockedFor(ctx, "admin@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, util.LockoutBaseDuration, remaining)
+
+	now = now.Add(util.LockoutBaseDuration)
+	remaining, _ = lockoutUtil.LockedFor(ctx, "admin@example.com")
+	assert.Zero(t, remaining)
+
+	lockout, _ = lockoutUtil.RegisterFailure(ctx, "admin@example.com")
+	assert.Equal(t, 2*util.LockoutBaseDuration, lockout, "failures keep doubling until reset")
+
+	assert.NoError(t, lockoutUtil.Reset(ctx, "admin@example.com"))
+	remaining, _ = lockoutUtil.LockedFor(ctx, "admin@example.com")
+	assert.Zero(t, remaining)
+}
@@ -0,0 +1,46 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+func TestNewMailUtil_Drivers(t *testing.T) {
+	v := viper.New()
+	v.Set("mail.driver", "file")
+	v.Set("mail.file_dir", t.TempDir())
+	mailUtil, err := util.NewMailUtil(v)
+	if assert.NoError(t, err) {
+		assert.IsType(t, &util.FileMailer{}, mailUtil.Mailer)
+	}
+
+	v = viper.New()
+	v.Set("mail.driver", "smtp")
+	v.Set("mail.from", "noreply@example.com")
+	v.Set("mail.smtp.host", "smtp.example.com")
+	v.Set("mail.smtp.port", 587)
+	mailUtil, err = util.NewMailUtil(v)
+	if assert.NoError(t, err) {
+		assert.IsType(t, &util.SMTPMailer{}, mailUtil.Mailer)
+	}
+}
+
+func TestNewMailUtil_RejectsUnsafeConfiguration(t *testing.T) {
+	for name, settings := range map[string]map[string]any{
+		"no driver":      {},
+		"unknown driver": {"mail.driver": "smpt"},
+		"smtp no host":   {"mail.driver": "smtp", "mail.from": "noreply@example.com"},
+		"smtp no from":   {"mail.driver": "smtp", "mail.smtp.host": "smtp.example.com"},
+	} {
+		v := viper.New()
+		for key, value := range settings {
+			v.Set(key, value)
+		}
+		_, err := util.NewMailUtil(v)
+		assert.Error(t, err, name)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func setupOrganizationController(mockUC *usecasemock.OrganizationMemberUsecaseMock) *fiber.App {
@@ -45,7 +46,7 @@ func TestOrganizationController_GetAllPublic_Success(t *testing.T) {
 		{ID: "1", Name: "Member A", Position: "Ketua", PositionOrder: 1, IsActive: true},
 		{ID: "2", Name: "Member B", Position: "Sekretaris", PositionOrder: 2, IsActive: true},
 	}
-	mockUC.On("GetPublic", "pura").Return(items, nil)
+	mockUC.On("GetPublic", "pura", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/public/organization-members?entity_type=pura", nil)
 	resp, _ := app.Test(req, -1)
@@ -64,7 +65,7 @@ func TestOrganizationController_GetAllPublic_Error(t *testing.T) {
 	mockUC := &usecasemock.OrganizationMemberUsecaseMock{}
 	app := setupOrganizationController(mockUC)
 
-	mockUC.On("GetPublic", "").Return(([]model.OrganizationResponse)(nil), errors.New("db error"))
+	mockUC.On("GetPublic", "", mock.Anything).Return(([]model.OrganizationResponse)(nil), nil, errors.New("db error"))
 
 	req := httptest.NewRequest("GET", "/api/public/organization-members", nil)
 	resp, _ := app.Test(req, -1)
@@ -79,7 +80,7 @@ func TestOrganizationController_GetAll_Success(t *testing.T) {
 	mockResponse := []model.OrganizationResponse{
 		{ID: "1", Name: "Member A"},
 	}
-	mockUC.On("GetAll", "pura").Return(mockResponse, nil)
+	mockUC.On("GetAll", "pura", mock.Anything).Return(mockResponse, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/organization-members", nil)
 	resp, _ := app.Test(req, -1)
@@ -94,7 +95,7 @@ func TestOrganizationController_GetByID_Success(t *testing.T) {
 
 	memberID := "member123"
 	mockResponse := &model.OrganizationResponse{ID: memberID, Name: "Member Found"}
-	mockUC.On("GetByID", memberID).Return(mockResponse, nil)
+	mockUC.On("GetByID", "pura", memberID).Return(mockResponse, nil)
 
 	req := httptest.NewRequest("GET", "/api/organization-members/"+memberID, nil)
 	resp, _ := app.Test(req, -1)
@@ -108,7 +109,7 @@ func TestOrganizationController_GetByID_NotFound(t *testing.T) {
 	app := setupOrganizationController(mockUC)
 
 	memberID := "notfound"
-	mockUC.On("GetByID", memberID).Return((*model.OrganizationResponse)(nil), model.ErrNotFound("member not found"))
+	mockUC.On("GetByID", "pura", memberID).Return((*model.OrganizationResponse)(nil), model.ErrNotFound("member not found"))
 
 	req := httptest.NewRequest("GET", "/api/organization-members/"+memberID, nil)
 	resp, _ := app.Test(req, -1)
@@ -131,7 +132,7 @@ func TestOrganizationController_Create_Success(t *testing.T) {
 		Name: "New Member",
 	}
 
-	mockUC.On("Create", "pura", reqBody).Return(mockResponse, nil)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return(mockResponse, nil)
 
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/organization-members", bytes.NewReader(bodyBytes))
@@ -154,7 +155,7 @@ func TestOrganizationController_Create_ValidationError(t *testing.T) {
 	}
 	realValErr := validate.Struct(Dummy{})
 
-	mockUC.On("Create", "pura", reqBody).Return((*model.OrganizationResponse)(nil), realValErr)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return((*model.OrganizationResponse)(nil), realValErr)
 
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/organization-members", bytes.NewReader(bodyBytes))
@@ -180,7 +181,7 @@ func TestOrganizationController_Update_Success(t *testing.T) {
 		Name: "Updated Name",
 	}
 
-	mockUC.On("Update", memberID, reqBody).Return(mockResponse, nil)
+	mockUC.On("Update", mock.Anything, "pura", memberID, reqBody).Return(mockResponse, nil)
 
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/organization-members/"+memberID, bytes.NewReader(bodyBytes))
@@ -197,7 +198,7 @@ func TestOrganizationController_Update_NotFound(t *testing.T) {
 	memberID := "notfound"
 	reqBody := model.UpdateOrganizationRequest{Name: "Update"}
 
-	mockUC.On("Update", memberID, reqBody).Return((*model.OrganizationResponse)(nil), model.ErrNotFound("member not found"))
+	mockUC.On("Update", mock.Anything, "pura", memberID, reqBody).Return((*model.OrganizationResponse)(nil), model.ErrNotFound("member not found"))
 
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/organization-members/"+memberID, bytes.NewReader(bodyBytes))
@@ -212,7 +213,7 @@ func TestOrganizationController_Delete_Success(t *testing.T) {
 	app := setupOrganizationController(mockUC)
 
 	memberID := "deleteID"
-	mockUC.On("Delete", memberID).Return(nil)
+	mockUC.On("Delete", mock.Anything, "pura", memberID).Return(nil)
 
 	req := httptest.NewRequest("DELETE", "/api/organization-members/"+memberID, nil)
 	resp, _ := app.Test(req, -1)
@@ -225,7 +226,7 @@ func TestOrganizationController_Delete_NotFound(t *testing.T) {
 	app := setupOrganizationController(mockUC)
 
 	memberID := "notfound"
-	mockUC.On("Delete", memberID).Return(model.ErrNotFound("member not found"))
+	mockUC.On("Delete", mock.Anything, "pura", memberID).Return(model.ErrNotFound("member not found"))
 
 	req := httptest.NewRequest("DELETE", "/api/organization-members/"+memberID, nil)
 	resp, _ := app.Test(req, -1)
@@ -238,7 +239,7 @@ func TestOrganizationController_Delete_InternalError(t *testing.T) {
 	app := setupOrganizationController(mockUC)
 
 	memberID := "errorID"
-	mockUC.On("Delete", memberID).Return(errors.New("db error"))
+	mockUC.On("Delete", mock.Anything, "pura", memberID).Return(errors.New("db error"))
 
 	req := httptest.NewRequest("DELETE", "/api/organization-members/"+memberID, nil)
 	resp, _ := app.Test(req, -1)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
 	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
@@ -110,7 +111,7 @@ func TestOrganizationDetailController_Update_Success(t *testing.T) {
 		VisionMissionImageURL: "new.jpg",
 	}
 
-	mockUC.On("Update", "pura", reqBody).Return(resBody, nil)
+	mockUC.On("Update", mock.Anything, "pura", reqBody).Return(resBody, nil)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/organization-details", bytes.NewReader(b))
@@ -148,7 +149,7 @@ func TestOrganizationDetailController_Update_UsecaseError(t *testing.T) {
 
 	reqBody := model.UpdateOrganizationDetailRequest{Vision: "Test"}
 
-	mockUC.On("Update", "pura", reqBody).Return((*model.OrganizationDetailResponse)(nil), errors.New("db connection failed"))
+	mockUC.On("Update", mock.Anything, "pura", reqBody).Return((*model.OrganizationDetailResponse)(nil), errors.New("db connection failed"))
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/organization-details", bytes.NewReader(b))
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"regexp"
 	"testing"
 	"time"
@@ -112,7 +113,7 @@ func TestOrgDetailUsecase_Update_TriggerCreate(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	res, err := u.Update(entityType, req)
+	res, err := u.Update(context.Background(), entityType, req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
@@ -162,7 +163,7 @@ func TestOrgDetailUsecase_Update_TriggerUpdate(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
-	res, err := u.Update(entityType, req)
+	res, err := u.Update(context.Background(), entityType, req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
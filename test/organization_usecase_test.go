@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"regexp"
 	"testing"
 
@@ -60,7 +61,7 @@ func TestOrganizationMemberUsecase_Create_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	created, err := u.Create(req.EntityType, req)
+	created, err := u.Create(context.Background(), req.EntityType, req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, created)
@@ -77,7 +78,7 @@ func TestOrganizationMemberUsecase_Create_ValidationError(t *testing.T) {
 		IsActive:      true,
 	}
 
-	created, err := u.Create("pura", req)
+	created, err := u.Create(context.Background(), "pura", req)
 
 	assert.Error(t, err)
 	assert.Nil(t, created)
@@ -92,11 +93,11 @@ func TestOrganizationMemberUsecase_GetPublic_FilterActiveAndOrder(t *testing.T)
 		AddRow("m4", "Sekre 1", "Sekretaris", 3, 1, true).
 		AddRow("m3", "Sekre 2", "Sekretaris", 3, 2, true)
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `organization_members` WHERE entity_type = ? AND is_active = ? ORDER BY position_order ASC, order_index ASC")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `organization_members` WHERE entity_type = ? AND is_active = ? ORDER BY position_order ASC,order_index ASC,id ASC")).
 		WithArgs("pura", true).
 		WillReturnRows(rows)
 
-	list, err := u.GetPublic("pura")
+	list, _, err := u.GetPublic("pura", &model.ListRequest{})
 
 	assert.NoError(t, err)
 	assert.Len(t, list, 4)
@@ -109,11 +110,11 @@ func TestOrganizationMemberUsecase_GetByID_Success(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(id, "Member Name")
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `organization_members` WHERE id = ? LIMIT ?")).
-		WithArgs(id, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `organization_members` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(id, "pura", 1).
 		WillReturnRows(rows)
 
-	res, err := u.GetByID(id)
+	res, err := u.GetByID("pura", id)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
 }
@@ -122,11 +123,11 @@ func TestOrganizationMemberUsecase_GetByID_NotFound(t *testing.T) {
 	u, mock := setupMockOrganizationUsecase(t)
 	id := "non-existent-id"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `organization_members` WHERE id = ? LIMIT ?")).
-		WithArgs(id, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `organization_members` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(id, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	found, err := u.GetByID(id)
+	found, err := u.GetByID("pura", id)
 
 	assert.Error(t, err)
 	assert.Nil(t, found)
@@ -150,8 +151,8 @@ func TestOrganizationMemberUsecase_Update_Success(t *testing.T) {
 		IsActive:      false,
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `organization_members` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `organization_members` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "name"}).AddRow(targetID, "pura", "Old Name"))
 
 	mock.ExpectBegin()
@@ -170,7 +171,7 @@ func TestOrganizationMemberUsecase_Update_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	updated, err := u.Update(targetID, req)
+	updated, err := u.Update(context.Background(), "pura", targetID, req)
 	assert.NoError(t, err)
 	if assert.NotNil(t, updated) {
 		assert.Equal(t, "New Name", updated.Name)
@@ -189,11 +190,11 @@ func TestOrganizationMemberUsecase_Update_NotFound(t *testing.T) {
 		OrderIndex:    1,
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `organization_members` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `organization_members` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	updated, err := u.Update(targetID, req)
+	updated, err := u.Update(context.Background(), "pura", targetID, req)
 
 	assert.Error(t, err)
 	assert.Nil(t, updated)
@@ -209,8 +210,8 @@ func TestOrganizationMemberUsecase_Delete_Success(t *testing.T) {
 	u, mock := setupMockOrganizationUsecase(t)
 	targetID := "delete-me"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `organization_members` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `organization_members` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(targetID, "To Delete"))
 
 	mock.ExpectBegin()
@@ -219,7 +220,7 @@ func TestOrganizationMemberUsecase_Delete_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 	assert.NoError(t, err)
 }
 
@@ -227,11 +228,11 @@ func TestOrganizationMemberUsecase_Delete_NotFound(t *testing.T) {
 	u, mock := setupMockOrganizationUsecase(t)
 	targetID := "missing"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `organization_members` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `organization_members` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 
 	assert.Error(t, err)
 	var e *model.ResponseError
@@ -240,3 +241,47 @@ func TestOrganizationMemberUsecase_Delete_NotFound(t *testing.T) {
 		assert.Equal(t, "organization member not found", e.Message)
 	}
 }
+
+func TestOrganizationUsecase_Delete_OtherEntity_NotFound(t *testing.T) {
+	u, mock := setupMockOrganizationUsecase(t)
+	targetID := "org-1"
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `organization_members` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "yayasan", 1).
+		WillReturnRows(sqlmock.NewRows(nil))
+
+	err := u.Delete(context.Background(), "yayasan", targetID)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 404, e.Code)
+		assert.Equal(t, "organization member not found", e.Message)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrganizationUsecase_Create_OtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockOrganizationUsecase(t)
+
+	res, err := u.Create(context.Background(), "yayasan", model.CreateOrganizationRequest{EntityType: "pura", Name: "Made", Position: "Ketua", PositionOrder: 1})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrganizationUsecase_Update_MoveToOtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockOrganizationUsecase(t)
+
+	res, err := u.Update(context.Background(), "pura", "org-1", model.UpdateOrganizationRequest{EntityType: "yayasan", Name: "Made", Position: "Ketua", PositionOrder: 1})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
+	"pura-agung-kertajaya-backend/internal/model"
+	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
+)
+
+func setupPermissionController(auth *middleware.Auth) (*fiber.App, *usecasemock.PermissionUsecaseMock) {
+	mockUC := &usecasemock.PermissionUsecaseMock{}
+	app, logger, _ := NewTestApp()
+
+	controller := httpdelivery.NewPermissionController(mockUC, logger)
+
+	authMiddleware := func(c *fiber.Ctx) error {
+		c.Locals("user", auth)
+		return c.Next()
+	}
+	superOnly := middleware.SuperAdminMiddleware()
+
+	app.Get("/api/users/_current/permissions", authMiddleware, controller.Current)
+	app.Post("/api/roles", authMiddleware, superOnly, controller.CreateRole)
+	app.Put("/api/users/:id/roles", authMiddleware, superOnly, controller.SetUserRoles)
+
+	return app, mockUC
+}
+
+func TestPermissionController_Current_Grants(t *testing.T) {
+	app, _ := setupPermissionController(&middleware.Auth{
+		ID:     "user-1",
+		Role:   "pura",
+		Grants: model.PermissionGrants{"pura": {model.PermissionArticlesRead}},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users/_current/permissions", nil)
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body model.WebResponse[model.UserPermissionsResponse]
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.False(t, body.Data.Super)
+	assert.Equal(t, []string{model.PermissionArticlesRead}, body.Data.Grants["pura"])
+}
+
+func TestPermissionController_Current_Super(t *testing.T) {
+	app, _ := setupPermissionController(&middleware.Auth{ID: "super-1", Role: "super"})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users/_current/permissions", nil)
+	resp, _ := app.Test(req, -1)
+
+	var body model.WebResponse[model.UserPermissionsResponse]
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.True(t, body.Data.Super)
+	assert.Len(t, body.Data.Grants, len(model.EntityTypes))
+}
+
+func TestPermissionController_CreateRole_Forbidden(t *testing.T) {
+	app, mockUC := setupPermissionController(&middleware.Auth{ID: "user-1", Role: "pura"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/roles", strings.NewReader(`{"name":"x","permissions":["gallery:read"]}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	mockUC.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+}
+
+func TestPermissionController_SetUserRoles_Success(t *testing.T) {
+	app, mockUC := setupPermissionController(&middleware.Auth{ID: "super-1", Role: "super"})
+
+	expected := []model.RoleAssignmentResponse{{ID: "a1", RoleID: "r1", RoleName: "reviewer", EntityType: "yayasan"}}
+	mockUC.On("SetUserRoles", mock.Anything, "user-1", mock.MatchedBy(func(r *model.SetUserRolesRequest) bool {
+		return len(r.Assignments) == 1 && r.Assignments[0].EntityType == "yayasan"
+	})).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/users/user-1/roles", strings.NewReader(`{"assignments":[{"role_id":"r1","entity_type":"yayasan"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
@@ -0,0 +1,108 @@
+package test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
+	"pura-agung-kertajaya-backend/internal/model"
+	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
+)
+
+func setupPermissionApp(t *testing.T, role string, grants model.PermissionGrants) *fiber.App {
+	permissionUC := &usecasemock.PermissionUsecaseMock{}
+	permissionUC.On("GetGrants", mock.Anything, "user-1").Return(grants, nil)
+
+	app, _, _ := NewTestApp()
+
+	authMiddleware := func(c *fiber.Ctx) error {
+		c.Locals("user", &middleware.Auth{ID: "user-1", Role: role})
+		return c.Next()
+	}
+	echoEntity := func(c *fiber.Ctx) error {
+		entityType, _ := c.Locals(middleware.CtxEntityType).(string)
+		return c.SendString(entityType)
+	}
+
+	api := app.Group("/api", authMiddleware, middleware.EntityTypeMiddleware(permissionUC))
+	api.Get("/galleries", middleware.PermissionMiddleware(model.PermissionGalleryRead), echoEntity)
+	api.Post("/galleries", middleware.PermissionMiddleware(model.PermissionGalleryWrite), echoEntity)
+	api.Post("/articles", middleware.PermissionMiddleware(model.PermissionArticlesWrite), middleware.ArticlePublishMiddleware(), echoEntity)
+
+	return app
+}
+
+func doPermissionRequest(app *fiber.App, method string, target string, payload string) (int, string) {
+	req := httptest.NewRequest(method, target, strings.NewReader(payload))
+	if payload != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, _ := app.Test(req, -1)
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestPermissionMiddleware_ReviewerCannotWrite(t *testing.T) {
+	app := setupPermissionApp(t, "yayasan", model.PermissionGrants{
+		"yayasan": {model.PermissionGalleryRead},
+	})
+
+	status, body := doPermissionRequest(app, http.MethodGet, "/api/galleries", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "yayasan", body)
+
+	status, _ = doPermissionRequest(app, http.MethodPost, "/api/galleries", `{"entity_type":"yayasan"}`)
+	assert.Equal(t, http.StatusForbidden, status)
+}
+
+func TestPermissionMiddleware_MultipleEntities(t *testing.T) {
+	app := setupPermissionApp(t, "pura", model.PermissionGrants{
+		"pura":     {model.PermissionGalleryRead, model.PermissionGalleryWrite},
+		"pasraman": {model.PermissionGalleryRead, model.PermissionGalleryWrite},
+	})
+
+	status, body := doPermissionRequest(app, http.MethodGet, "/api/galleries", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "pura", body, "defaults to the entity of the account role")
+
+	status, body = doPermissionRequest(app, http.MethodPost, "/api/galleries", `{"entity_type":"pasraman"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "pasraman", body)
+
+	status, _ = doPermissionRequest(app, http.MethodGet, "/api/galleries?entity_type=yayasan", "")
+	assert.Equal(t, http.StatusForbidden, status, "no silent fallback to another entity")
+}
+
+func TestPermissionMiddleware_NoAssignments(t *testing.T) {
+	app := setupPermissionApp(t, "pura", model.PermissionGrants{})
+
+	status, _ := doPermissionRequest(app, http.MethodGet, "/api/galleries", "")
+	assert.Equal(t, http.StatusForbidden, status)
+}
+
+func TestPermissionMiddleware_SuperBypasses(t *testing.T) {
+	app := setupPermissionApp(t, "super", nil)
+
+	status, body := doPermissionRequest(app, http.MethodPost, "/api/galleries", `{"entity_type":"yayasan"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "yayasan", body)
+}
+
+func TestArticlePublishMiddleware_RequiresPublish(t *testing.T) {
+	app := setupPermissionApp(t, "pura", model.PermissionGrants{
+		"pura": {model.PermissionArticlesRead, model.PermissionArticlesWrite},
+	})
+
+	status, _ := doPermissionRequest(app, http.MethodPost, "/api/articles", `{"status":"DRAFT"}`)
+	assert.Equal(t, http.StatusOK, status)
+
+	status, _ = doPermissionRequest(app, http.MethodPost, "/api/articles", `{"status":"PUBLISHED"}`)
+	assert.Equal(t, http.StatusForbidden, status)
+}
@@ -0,0 +1,150 @@
+package test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/repository"
+	"pura-agung-kertajaya-backend/internal/usecase"
+)
+
+func setupMockPermissionUsecase(t *testing.T) (usecase.PermissionUseCase, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub db: %v", err)
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+
+	_, logger, _ := NewTestApp()
+	u := usecase.NewPermissionUseCase(
+		gormDB,
+		validator.New(),
+		repository.NewUserRepository(logger),
+		repository.NewRoleRepository(logger),
+		repository.NewUserRoleAssignmentRepository(logger),
+	)
+	return u, mock
+}
+
+func TestPermissionUsecase_CreateRole_Success(t *testing.T) {
+	u, mock := setupMockPermissionUsecase(t)
+
+	req := &model.CreateRoleRequest{
+		Name:        "gallery-editor",
+		Permissions: []string{model.PermissionGalleryRead, model.PermissionGalleryWrite, model.PermissionGalleryRead},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `roles` WHERE name = ? AND id != ?")).
+		WithArgs(req.Name, "").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `roles`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `role_permissions` WHERE role_id = ?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `role_permissions`")).
+		WithArgs(sqlmock.AnyArg(), model.PermissionGalleryRead, sqlmock.AnyArg(), model.PermissionGalleryWrite).
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	mock.ExpectCommit()
+
+	res, err := u.CreateRole(context.Background(), req)
+	assert.NoError(t, err)
+	if assert.NotNil(t, res) {
+		assert.NotEmpty(t, res.ID)
+		assert.Equal(t, []string{model.PermissionGalleryRead, model.PermissionGalleryWrite}, res.Permissions)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPermissionUsecase_CreateRole_UnknownPermission(t *testing.T) {
+	u, mock := setupMockPermissionUsecase(t)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	res, err := u.CreateRole(context.Background(), &model.CreateRoleRequest{
+		Name:        "broken",
+		Permissions: []string{"gallery:fly"},
+	})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+	}
+}
+
+func TestPermissionUsecase_DeleteRole_BuiltIn(t *testing.T) {
+	u, mock := setupMockPermissionUsecase(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `roles` WHERE id = ? LIMIT ?")).
+		WithArgs("role-editor", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "is_system"}).AddRow("role-editor", "editor", true))
+	mock.ExpectRollback()
+
+	err := u.DeleteRole(context.Background(), "role-editor")
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPermissionUsecase_SetUserRoles_UnknownRole(t *testing.T) {
+	u, mock := setupMockPermissionUsecase(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE id = ?")).
+		WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `roles` WHERE id IN (?)")).
+		WithArgs("missing-role").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectRollback()
+
+	res, err := u.SetUserRoles(context.Background(), "user-1", &model.SetUserRolesRequest{
+		Assignments: []model.RoleAssignmentRequest{{RoleID: "missing-role", EntityType: "yayasan"}},
+	})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPermissionUsecase_GetGrants_GroupsByEntity(t *testing.T) {
+	u, mock := setupMockPermissionUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT a.entity_type, p.permission FROM user_role_assignments AS a JOIN role_permissions AS p")).
+		WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"entity_type", "permission"}).
+			AddRow("pura", model.PermissionArticlesRead).
+			AddRow("pura", model.PermissionArticlesWrite).
+			AddRow("yayasan", model.PermissionGalleryRead))
+
+	grants, err := u.GetGrants(context.Background(), "user-1")
+	assert.NoError(t, err)
+	assert.True(t, grants.Has("pura", model.PermissionArticlesWrite))
+	assert.True(t, grants.Has("yayasan", model.PermissionGalleryRead))
+	assert.False(t, grants.Has("yayasan", model.PermissionGalleryWrite))
+	assert.False(t, grants.Has("pasraman", model.PermissionArticlesRead))
+}
@@ -0,0 +1,98 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"pura-agung-kertajaya-backend/internal/calendar"
+)
+
+func dates(days []time.Time) []string {
+	out := make([]string, len(days))
+	for i, d := range days {
+		out[i] = d.Format("2006-01-02")
+	}
+	return out
+}
+
+func TestRRule_Weekly(t *testing.T) {
+	rule, err := calendar.ParseRRule("FREQ=WEEKLY;BYDAY=TU,SA")
+	assert.NoError(t, err)
+
+	got := rule.Between(date(2026, time.October, 3), date(2026, time.October, 10), date(2026, time.October, 20))
+	assert.Equal(t, []string{"2026-10-10", "2026-10-13", "2026-10-17", "2026-10-20"}, dates(got))
+
+	biweekly, _ := calendar.ParseRRule("RRULE:FREQ=WEEKLY;INTERVAL=2;COUNT=3")
+	got = biweekly.Between(date(2026, time.October, 3), date(2026, time.January, 1), date(2026, time.December, 31))
+	assert.Equal(t, []string{"2026-10-03", "2026-10-17", "2026-10-31"}, dates(got))
+}
+
+func TestRRule_Monthly(t *testing.T) {
+	lastFriday, err := calendar.ParseRRule("FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20261231T000000Z")
+	assert.NoError(t, err)
+	got := lastFriday.Between(date(2026, time.September, 25), date(2026, time.October, 1), date(2027, time.March, 1))
+	assert.Equal(t, []string{"2026-10-30", "2026-11-27", "2026-12-25"}, dates(got))
+
+	monthly, _ := calendar.ParseRRule("FREQ=MONTHLY")
+	got = monthly.Between(date(2026, time.January, 31), date(2026, time.January, 1), date(2026, time.May, 31))
+	assert.Equal(t, []string{"2026-01-31", "2026-03-31", "2026-05-31"}, dates(got))
+
+	firstSunday, _ := calendar.ParseRRule("FREQ=YEARLY;BYMONTH=3,9;BYDAY=1SU")
+	got = firstSunday.Between(date(2026, time.March, 1), date(2026, time.January, 1), date(2027, time.December, 31))
+	assert.Equal(t, []string{"2026-03-01", "2026-09-06", "2027-03-07", "2027-09-05"}, dates(got))
+}
+
+func TestRRule_DailyByMonthDay(t *testing.T) {
+	lastDay, err := calendar.ParseRRule("FREQ=DAILY;BYMONTHDAY=-1")
+	assert.NoError(t, err)
+	got := lastDay.Between(date(2026, time.January, 31), date(2026, time.January, 1), date(2026, time.April, 30))
+	assert.Equal(t, []string{"2026-01-31", "2026-02-28", "2026-03-31", "2026-04-30"}, dates(got))
+
+	midAndEnd, _ := calendar.ParseRRule("FREQ=DAILY;BYMONTHDAY=15,-2")
+	got = midAndEnd.Between(date(2026, time.February, 15), date(2026, time.February, 1), date(2026, time.March, 31))
+	assert.Equal(t, []string{"2026-02-15", "2026-02-27", "2026-03-15", "2026-03-30"}, dates(got))
+}
+
+func TestRRule_YearlyWithoutByMonth(t *testing.T) {
+	firstOfMonth, err := calendar.ParseRRule("FREQ=YEARLY;BYMONTHDAY=1")
+	assert.NoError(t, err)
+	got := firstOfMonth.Between(date(2026, time.January, 1), date(2026, time.January, 1), date(2026, time.December, 31))
+	assert.Len(t, got, 12)
+	assert.Equal(t, []string{"2026-01-01", "2026-02-01", "2026-03-01"}, dates(got[:3]))
+	assert.Equal(t, "2026-12-01", dates(got[11:])[0])
+
+	mondays, _ := calendar.ParseRRule("FREQ=YEARLY;BYDAY=MO")
+	got = mondays.Between(date(2026, time.January, 5), date(2026, time.January, 1), date(2026, time.December, 31))
+	assert.Len(t, got, 52)
+	assert.Equal(t, []string{"2026-06-29", "2026-07-06"}, dates(got[25:27]))
+
+	firstAndLast, _ := calendar.ParseRRule("FREQ=YEARLY;BYDAY=1MO,-1FR")
+	got = firstAndLast.Between(date(2026, time.January, 5), date(2026, time.January, 1), date(2027, time.December, 31))
+	assert.Equal(t, []string{"2026-01-05", "2026-12-25", "2027-01-04", "2027-12-31"}, dates(got))
+}
+
+func TestRRule_Invalid(t *testing.T) {
+	for rule, message := range map[string]string{
+		"BYDAY=SA":                          "RRULE needs a FREQ",
+		"FREQ=HOURLY":                       `unsupported FREQ "HOURLY"`,
+		"FREQ=WEEKLY;BYSETPOS=1":            `unsupported RRULE part "BYSETPOS"`,
+		"FREQ=WEEKLY;BYDAY=2SA":             "numbered BYDAY needs FREQ=MONTHLY or FREQ=YEARLY",
+		"FREQ=DAILY;COUNT=2;UNTIL=20261231": "RRULE must not have both COUNT and UNTIL",
+		"FREQ=MONTHLY;BYMONTHDAY=0":         `invalid BYMONTHDAY "0"`,
+	} {
+		_, err := calendar.ParseRRule(rule)
+		assert.EqualError(t, err, message, rule)
+	}
+}
+
+func TestPawukonDay_Between(t *testing.T) {
+	odalan, _ := calendar.ParsePawukonDay("Buda Kliwon Pahang")
+
+	got := odalan.Between(date(2025, time.December, 24), date(2026, time.January, 1), date(2027, time.December, 31))
+
+	assert.Equal(t, []string{"2026-07-22", "2027-02-17", "2027-09-15"}, dates(got))
+	assert.True(t, calendar.Occurs(odalan, date(2025, time.December, 24), date(2026, time.July, 22)))
+	assert.False(t, calendar.Occurs(odalan, date(2026, time.July, 22), date(2025, time.December, 24)))
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func setupRemarkController(mockUC *usecasemock.RemarkUsecaseMock) *fiber.App {
@@ -46,7 +47,7 @@ func TestRemarkController_GetAllPublic_Success(t *testing.T) {
 		{ID: "uuid-2", Name: "Pak Wakil", Position: "Wakil", EntityType: "pura"},
 	}
 
-	mockUC.On("GetPublic", "").Return(items, nil)
+	mockUC.On("GetPublic", "", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/public/remarks", nil)
 	resp, _ := app.Test(req, -1)
@@ -60,7 +61,7 @@ func TestRemarkController_GetAllPublic_WithFilter_Success(t *testing.T) {
 	app := setupRemarkController(mockUC)
 
 	items := []model.RemarkResponse{}
-	mockUC.On("GetPublic", "yayasan").Return(items, nil)
+	mockUC.On("GetPublic", "yayasan", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/public/remarks?entity_type=yayasan", nil)
 	resp, _ := app.Test(req, -1)
@@ -73,7 +74,7 @@ func TestRemarkController_GetAllPublic_Error(t *testing.T) {
 	mockUC := &usecasemock.RemarkUsecaseMock{}
 	app := setupRemarkController(mockUC)
 
-	mockUC.On("GetPublic", "").Return(([]model.RemarkResponse)(nil), errors.New("db error"))
+	mockUC.On("GetPublic", "", mock.Anything).Return(([]model.RemarkResponse)(nil), nil, errors.New("db error"))
 
 	req := httptest.NewRequest("GET", "/api/public/remarks", nil)
 	resp, _ := app.Test(req, -1)
@@ -87,7 +88,7 @@ func TestRemarkController_GetAll_Success(t *testing.T) {
 
 	items := []model.RemarkResponse{{ID: "uuid-1", Name: "A"}}
 	// "pura" injected by middleware
-	mockUC.On("GetAll", "pura").Return(items, nil)
+	mockUC.On("GetAll", "pura", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/remarks", nil)
 	resp, _ := app.Test(req, -1)
@@ -103,7 +104,7 @@ func TestRemarkController_GetByID_Success(t *testing.T) {
 	targetID := "uuid-10"
 	item := &model.RemarkResponse{ID: targetID, Name: "Pak Bos"}
 
-	mockUC.On("GetByID", targetID).Return(item, nil)
+	mockUC.On("GetByID", "pura", targetID).Return(item, nil)
 
 	req := httptest.NewRequest("GET", "/api/remarks/"+targetID, nil)
 	resp, _ := app.Test(req, -1)
@@ -121,7 +122,7 @@ func TestRemarkController_GetByID_NotFound(t *testing.T) {
 	app := setupRemarkController(mockUC)
 
 	targetID := "uuid-unknown"
-	mockUC.On("GetByID", targetID).Return((*model.RemarkResponse)(nil), model.ErrNotFound("remark not found"))
+	mockUC.On("GetByID", "pura", targetID).Return((*model.RemarkResponse)(nil), model.ErrNotFound("remark not found"))
 
 	req := httptest.NewRequest("GET", "/api/remarks/"+targetID, nil)
 	resp, _ := app.Test(req, -1)
@@ -141,7 +142,7 @@ func TestRemarkController_Create_Success(t *testing.T) {
 	}
 	resBody := &model.RemarkResponse{ID: "new-uuid-1", Name: "Test"}
 
-	mockUC.On("Create", "pura", reqBody).Return(resBody, nil)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return(resBody, nil)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/remarks", bytes.NewReader(b))
@@ -167,7 +168,7 @@ func TestRemarkController_Create_ValidationError(t *testing.T) {
 	}
 	realValErr := validate.Struct(Dummy{})
 
-	mockUC.On("Create", "pura", reqBody).Return((*model.RemarkResponse)(nil), realValErr)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return((*model.RemarkResponse)(nil), realValErr)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/remarks", bytes.NewReader(b))
@@ -196,7 +197,7 @@ func TestRemarkController_Update_Success(t *testing.T) {
 		EntityType: "pura",
 	}
 
-	mockUC.On("Update", idToUpdate, reqBody).Return(resBody, nil)
+	mockUC.On("Update", mock.Anything, "pura", idToUpdate, reqBody).Return(resBody, nil)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/remarks/"+idToUpdate, bytes.NewReader(b))
@@ -217,7 +218,7 @@ func TestRemarkController_Update_NotFound(t *testing.T) {
 	idToUpdate := "uuid-missing"
 	reqBody := model.UpdateRemarkRequest{Name: "Update"}
 
-	mockUC.On("Update", idToUpdate, reqBody).Return((*model.RemarkResponse)(nil), model.ErrNotFound("remark not found"))
+	mockUC.On("Update", mock.Anything, "pura", idToUpdate, reqBody).Return((*model.RemarkResponse)(nil), model.ErrNotFound("remark not found"))
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/remarks/"+idToUpdate, bytes.NewReader(b))
@@ -233,7 +234,7 @@ func TestRemarkController_Delete_Success(t *testing.T) {
 	app := setupRemarkController(mockUC)
 
 	idToDelete := "uuid-7"
-	mockUC.On("Delete", idToDelete).Return(nil)
+	mockUC.On("Delete", mock.Anything, "pura", idToDelete).Return(nil)
 
 	req := httptest.NewRequest("DELETE", "/api/remarks/"+idToDelete, nil)
 	resp, _ := app.Test(req, -1)
@@ -246,7 +247,7 @@ func TestRemarkController_Delete_NotFound(t *testing.T) {
 	app := setupRemarkController(mockUC)
 
 	idToDelete := "uuid-missing"
-	mockUC.On("Delete", idToDelete).Return(model.ErrNotFound("remark not found"))
+	mockUC.On("Delete", mock.Anything, "pura", idToDelete).Return(model.ErrNotFound("remark not found"))
 
 	req := httptest.NewRequest("DELETE", "/api/remarks/"+idToDelete, nil)
 	resp, _ := app.Test(req, -1)
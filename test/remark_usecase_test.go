@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"regexp"
 	"testing"
 	"time"
@@ -48,7 +49,7 @@ func TestRemarkUsecase_GetAll_Success(t *testing.T) {
 		WithArgs("pura").
 		WillReturnRows(rows)
 
-	result, err := u.GetAll("pura")
+	result, _, err := u.GetAll("pura", &model.ListRequest{})
 
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
@@ -67,7 +68,7 @@ func TestRemarkUsecase_GetPublic_Success(t *testing.T) {
 		WithArgs(true, "pura").
 		WillReturnRows(rows)
 
-	result, err := u.GetPublic("pura")
+	result, _, err := u.GetPublic("pura", &model.ListRequest{})
 
 	assert.NoError(t, err)
 	assert.Len(t, result, 1)
@@ -80,11 +81,11 @@ func TestRemarkUsecase_GetByID_Success(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"id", "entity_type", "name", "position"}).
 		AddRow(targetUUID, "pura", "Pak Bos", "Ketua")
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `remarks` WHERE id = ? LIMIT ?")).
-		WithArgs(targetUUID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `remarks` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetUUID, "pura", 1).
 		WillReturnRows(rows)
 
-	res, err := u.GetByID(targetUUID)
+	res, err := u.GetByID("pura", targetUUID)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
@@ -95,11 +96,11 @@ func TestRemarkUsecase_GetByID_NotFound(t *testing.T) {
 	u, mock := setupMockRemarkUsecase(t)
 	targetUUID := "missing"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `remarks` WHERE id = ? LIMIT ?")).
-		WithArgs(targetUUID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `remarks` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetUUID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.GetByID(targetUUID)
+	res, err := u.GetByID("pura", targetUUID)
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
@@ -140,7 +141,7 @@ func TestRemarkUsecase_Create_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	res, err := u.Create(req.EntityType, req)
+	res, err := u.Create(context.Background(), req.EntityType, req)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
 	assert.Equal(t, "Ibu Ketua", res.Name)
@@ -149,7 +150,7 @@ func TestRemarkUsecase_Create_Success(t *testing.T) {
 func TestRemarkUsecase_Create_ValidationError(t *testing.T) {
 	u, _ := setupMockRemarkUsecase(t)
 	req := model.CreateRemarkRequest{}
-	res, err := u.Create("pura", req)
+	res, err := u.Create(context.Background(), "pura", req)
 	assert.Error(t, err)
 	assert.Nil(t, res)
 }
@@ -166,8 +167,8 @@ func TestRemarkUsecase_Update_Success(t *testing.T) {
 		IsActive:   false,
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `remarks` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `remarks` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "name"}).AddRow(targetID, "pura", "Old Name"))
 
 	mock.ExpectBegin()
@@ -187,7 +188,7 @@ func TestRemarkUsecase_Update_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	res, err := u.Update(targetID, req)
+	res, err := u.Update(context.Background(), "pura", targetID, req)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
 	assert.Equal(t, "New Name", res.Name)
@@ -203,11 +204,11 @@ func TestRemarkUsecase_Update_NotFound(t *testing.T) {
 		Content:  "Valid",
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `remarks` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `remarks` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.Update(targetID, req)
+	res, err := u.Update(context.Background(), "pura", targetID, req)
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
@@ -224,8 +225,8 @@ func TestRemarkUsecase_Delete_Success(t *testing.T) {
 	targetID := "uuid-delete-me"
 
 	rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(targetID, "Deleted Guy")
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `remarks` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `remarks` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(rows)
 
 	mock.ExpectBegin()
@@ -234,7 +235,7 @@ func TestRemarkUsecase_Delete_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 	assert.NoError(t, err)
 }
 
@@ -242,11 +243,11 @@ func TestRemarkUsecase_Delete_NotFound(t *testing.T) {
 	u, mock := setupMockRemarkUsecase(t)
 	targetID := "missing"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `remarks` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `remarks` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 
 	assert.Error(t, err)
 	var e *model.ResponseError
@@ -255,3 +256,47 @@ func TestRemarkUsecase_Delete_NotFound(t *testing.T) {
 		assert.Equal(t, "remark not found", e.Message)
 	}
 }
+
+func TestRemarkUsecase_Delete_OtherEntity_NotFound(t *testing.T) {
+	u, mock := setupMockRemarkUsecase(t)
+	targetID := "rem-1"
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `remarks` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "yayasan", 1).
+		WillReturnRows(sqlmock.NewRows(nil))
+
+	err := u.Delete(context.Background(), "yayasan", targetID)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 404, e.Code)
+		assert.Equal(t, "remark not found", e.Message)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemarkUsecase_Create_OtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockRemarkUsecase(t)
+
+	res, err := u.Create(context.Background(), "yayasan", model.CreateRemarkRequest{EntityType: "pura", Name: "Made", Position: "Ketua", Content: "Sambutan"})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemarkUsecase_Update_MoveToOtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockRemarkUsecase(t)
+
+	res, err := u.Update(context.Background(), "pura", "rem-1", model.UpdateRemarkRequest{EntityType: "yayasan", Name: "Made", Position: "Ketua", Content: "Sambutan"})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,58 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
+	"pura-agung-kertajaya-backend/internal/model"
+	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
+)
+
+func setupSearchController() (*fiber.App, *usecasemock.SearchUsecaseMock) {
+	mockUC := &usecasemock.SearchUsecaseMock{}
+	app, logger, _ := NewTestApp()
+
+	controller := httpdelivery.NewSearchController(mockUC, logger)
+	app.Get("/api/public/search", controller.Search)
+
+	return app, mockUC
+}
+
+func TestSearchController_Search(t *testing.T) {
+	app, mockUC := setupSearchController()
+
+	mockUC.On("Search", mock.Anything, mock.MatchedBy(func(req *model.PublicSearchRequest) bool {
+		return req.Query == "melasti" && req.EntityType == "pura" && req.Page == 1 && req.Size == 10 &&
+			len(req.Types) == 2 && req.Types[0] == "activity" && req.Types[1] == "gallery"
+	})).Return([]model.SearchResultResponse{{Type: "activity", ID: "act-1", Snippet: "<mark>melasti</mark>"}}, int64(11), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/public/search?q=melasti&entity_type=pura&type=activity,gallery", nil)
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body model.WebResponse[[]model.SearchResultResponse]
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Len(t, body.Data, 1)
+	if assert.NotNil(t, body.Paging) {
+		assert.Equal(t, int64(11), body.Paging.TotalItem)
+		assert.Equal(t, int64(2), body.Paging.TotalPage)
+	}
+	mockUC.AssertExpectations(t)
+}
+
+func TestSearchController_Search_BadRequest(t *testing.T) {
+	app, mockUC := setupSearchController()
+
+	mockUC.On("Search", mock.Anything, mock.Anything).Return(nil, int64(0), model.ErrBadRequest("q must contain at least one letter or digit"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/public/search?q=%2B%2B", nil)
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
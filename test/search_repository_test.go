@@ -0,0 +1,76 @@
+package test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/repository"
+)
+
+func TestSearchRepository_Search_ScopedTypes(t *testing.T) {
+	db, mock := setupListQuery(t)
+	repo := repository.NewSearchRepository(db, logrus.New())
+
+	union := "SELECT 'activity' AS type, id, entity_type, title, '' AS slug, CONCAT_WS(' ', description, location) AS body, event_date AS date, " +
+		"MATCH(title, description, location) AGAINST (? IN BOOLEAN MODE) AS score FROM activities " +
+		"WHERE MATCH(title, description, location) AGAINST (? IN BOOLEAN MODE) AND is_active = TRUE AND entity_type = ? " +
+		"UNION ALL " +
+		"SELECT 'gallery' AS type, id, entity_type, title, '' AS slug, description AS body, created_at AS date, " +
+		"MATCH(title, description) AGAINST (? IN BOOLEAN MODE) AS score FROM galleries " +
+		"WHERE MATCH(title, description) AGAINST (? IN BOOLEAN MODE) AND is_active = TRUE AND entity_type = ?"
+	args := []driver.Value{"melasti* pantai*", "melasti* pantai*", "pura", "melasti* pantai*", "melasti* pantai*", "pura"}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM (" + union + ") AS search_results")).
+		WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM (" + union + ") AS search_results ORDER BY score DESC, date DESC, id ASC LIMIT ? OFFSET ?")).
+		WithArgs(append(args, 2, 2)...).
+		WillReturnRows(sqlmock.NewRows([]string{"type", "id", "entity_type", "title", "slug", "body", "date", "score"}).
+			AddRow("activity", "act-1", "pura", "Melasti", "", "Upacara melasti di pantai", time.Now(), 1.5))
+
+	documents, total, err := repo.Search(context.Background(), &model.PublicSearchRequest{
+		Query:      "Melasti, pantai!",
+		EntityType: "pura",
+		Types:      []string{"activity", "gallery"},
+		Page:       2,
+		Size:       2,
+	}, time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	if assert.Len(t, documents, 1) {
+		assert.Equal(t, "activity", documents[0].Type)
+		assert.Equal(t, 1.5, documents[0].Score)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchRepository_Search_ArticlesOnlyVisible(t *testing.T) {
+	db, mock := setupListQuery(t)
+	repo := repository.NewSearchRepository(db, logrus.New())
+	now := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM articles WHERE MATCH(title, excerpt, content_text) AGAINST (? IN BOOLEAN MODE) AND status = ? AND (published_at IS NULL OR published_at <= ?) AND (expires_at IS NULL OR expires_at > ?)) AS search_results")).
+		WithArgs("odalan*", "odalan*", "PUBLISHED", now, now).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	documents, total, err := repo.Search(context.Background(), &model.PublicSearchRequest{
+		Query: "odalan",
+		Types: []string{"article"},
+		Page:  1,
+		Size:  10,
+	}, now)
+
+	assert.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, documents)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
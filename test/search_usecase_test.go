@@ -0,0 +1,85 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	repomock "pura-agung-kertajaya-backend/internal/repository/mock"
+	"pura-agung-kertajaya-backend/internal/usecase"
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+func TestSearchUsecase_Search_HighlightsSnippet(t *testing.T) {
+	repo := repomock.NewMockSearchRepository()
+	u := usecase.NewSearchUsecase(repo, validator.New())
+
+	req := &model.PublicSearchRequest{Query: "Odalan", EntityType: "pura", Page: 1, Size: 10}
+	repo.On("Search", mock.Anything, req, mock.AnythingOfType("time.Time")).Return([]entity.SearchDocument{
+		{
+			Type:  entity.SearchTypeArticle,
+			ID:    "a-1",
+			Title: "Piodalan Agung",
+			Slug:  "piodalan-agung",
+			Body:  "<p>Persiapan <b>odalan</b> di pura &amp; banjar</p>",
+			Score: 2.5,
+		},
+	}, int64(1), nil)
+
+	results, total, err := u.Search(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "article", results[0].Type)
+		assert.Equal(t, "piodalan-agung", results[0].Slug)
+		assert.Equal(t, "Persiapan <mark>odalan</mark> di pura &amp; banjar", results[0].Snippet)
+	}
+	repo.AssertExpectations(t)
+}
+
+func TestSearchUsecase_Search_RejectsQueryWithoutWords(t *testing.T) {
+	repo := repomock.NewMockSearchRepository()
+	u := usecase.NewSearchUsecase(repo, validator.New())
+
+	_, _, err := u.Search(context.Background(), &model.PublicSearchRequest{Query: "+-*", Page: 1, Size: 10})
+
+	var e *model.ResponseError
+	if assert.True(t, errors.As(err, &e)) {
+		assert.Equal(t, 400, e.Code)
+	}
+	repo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSearchUsecase_Search_ValidationError(t *testing.T) {
+	repo := repomock.NewMockSearchRepository()
+	u := usecase.NewSearchUsecase(repo, validator.New())
+
+	_, _, err := u.Search(context.Background(), &model.PublicSearchRequest{Query: "odalan", Types: []string{"user"}, Page: 1, Size: 10})
+
+	assert.Error(t, err)
+	repo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHighlightSnippet_TrimsAroundFirstMatch(t *testing.T) {
+	text := "Lorem ipsum dolor sit amet consectetur adipiscing elit sed do eiusmod tempor " +
+		"incididunt ut labore et dolore magna aliqua upacara melasti dilaksanakan di pantai " +
+		"ut enim ad minim veniam quis nostrud exercitation ullamco laboris nisi ut aliquip"
+
+	snippet := util.HighlightSnippet(text, util.SearchTerms("Melasti"), 60)
+
+	assert.Contains(t, snippet, "<mark>melasti</mark>")
+	assert.True(t, len(snippet) < len(text))
+	assert.Regexp(t, `^… `, snippet)
+	assert.Regexp(t, ` …$`, snippet)
+}
+
+func TestSearchTerms_DropsOperatorsAndDuplicates(t *testing.T) {
+	assert.Equal(t, []string{"pura", "agung", "odalan"}, util.SearchTerms(`+Pura -agung* "odalan" pura`))
+}
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
+	"pura-agung-kertajaya-backend/internal/model"
+	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
+)
+
+func setupSEOController(mockUC *usecasemock.SEOUsecaseMock) *fiber.App {
+	app, logger, _ := NewTestApp()
+
+	controller := httpdelivery.NewSEOController(mockUC, logger)
+	app.Get("/public/seo", controller.Get)
+
+	return app
+}
+
+func TestSEOController_Get(t *testing.T) {
+	mockUC := &usecasemock.SEOUsecaseMock{}
+	app := setupSEOController(mockUC)
+	mockUC.On("Resolve", "yayasan", "/berita/galungan").Return(&model.SEOMetadataResponse{
+		Path:         "/berita/galungan",
+		Type:         "article",
+		Title:        "Hari Raya Galungan",
+		CanonicalURL: "https://yayasan.example.com/berita/galungan",
+		JSONLD:       []map[string]any{{"@type": "Article"}},
+	}, nil)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/public/seo?entity_type=yayasan&path=/berita/galungan", nil))
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	var res struct {
+		Data struct {
+			Type         string           `json:"type"`
+			CanonicalURL string           `json:"canonical_url"`
+			JSONLD       []map[string]any `json:"json_ld"`
+		} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(body, &res))
+	assert.Equal(t, "article", res.Data.Type)
+	assert.Equal(t, "https://yayasan.example.com/berita/galungan", res.Data.CanonicalURL)
+	assert.Equal(t, "Article", res.Data.JSONLD[0]["@type"])
+}
+
+func TestSEOController_Get_MissingPath(t *testing.T) {
+	mockUC := &usecasemock.SEOUsecaseMock{}
+	app := setupSEOController(mockUC)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/public/seo", nil))
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUC.AssertNotCalled(t, "Resolve")
+}
+
+func TestSEOController_Get_NotFound(t *testing.T) {
+	mockUC := &usecasemock.SEOUsecaseMock{}
+	app := setupSEOController(mockUC)
+	mockUC.On("Resolve", "pura", "/nowhere").Return(nil, model.ErrNotFound("page not found"))
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/public/seo?path=/nowhere", nil))
+
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
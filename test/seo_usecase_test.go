@@ -0,0 +1,216 @@
+package test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+func setupSEOUsecase(t *testing.T) (usecase.SEOUsecase, sqlmock.Sqlmock, *usecasemock.ArticleUsecaseMock, *usecasemock.SiteIdentityUsecaseMock) {
+	db, sqlMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub db: %v", err)
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+
+	siteURL := &util.SiteURLUtil{
+		BaseURLs:     map[string]string{"pura": "https://pura.example.com"},
+		ArticlePath:  "/berita",
+		CategoryPath: "/kategori",
+		ActivityPath: "/kegiatan",
+		Pages:        []string{"/", "/kegiatan", "/struktur-organisasi"},
+		AssetBaseURL: "https://cdn.example.com",
+	}
+
+	articleUC := &usecasemock.ArticleUsecaseMock{}
+	siteIdentityUC := &usecasemock.SiteIdentityUsecaseMock{}
+	return usecase.NewSEOUsecase(gormDB, articleUC, siteIdentityUC, siteURL), sqlMock, articleUC, siteIdentityUC
+}
+
+func sampleSEOSite() *model.SiteIdentityResponse {
+	return &model.SiteIdentityResponse{
+		SiteName: "Pura Agung Kertajaya",
+		LogoURL:  "site/logo.png",
+		Tagline:  "Pura di Tangerang",
+		OGImages: model.ImageVariants{Lg: "site/og-lg.webp", Fhd: "site/og-fhd.webp"},
+	}
+}
+
+func TestSEOUsecase_Resolve_Article(t *testing.T) {
+	u, _, articleUC, siteIdentityUC := setupSEOUsecase(t)
+
+	published := time.Date(2026, 10, 10, 8, 0, 0, 0, time.UTC)
+	siteIdentityUC.On("GetPublic", "pura").Return(sampleSEOSite(), nil)
+	articleUC.On("GetBySlug", "pura", "galungan").Return(&model.ArticleResponse{
+		Title:          "Hari Raya Galungan",
+		Slug:           "galungan",
+		AuthorName:     "Admin",
+		Excerpt:        "Persiapan Galungan",
+		Category:       &model.CategoryResponse{Name: "Upacara"},
+		Tags:           []model.TagResponse{{Name: "Galungan"}, {Name: "Hari Raya"}},
+		Images:         model.ImageVariants{Md: "articles/galungan-md.webp", Xl: "articles/galungan-xl.webp"},
+		OGImageVariant: "md",
+		PublishedAt:    &published,
+		UpdatedAt:      published,
+	}, nil)
+
+	meta, err := u.Resolve("pura", "/berita/galungan/?utm_source=wa")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/berita/galungan", meta.Path)
+	assert.Equal(t, "article", meta.Type)
+	assert.Equal(t, "Hari Raya Galungan | Pura Agung Kertajaya", meta.Title)
+	assert.Equal(t, "Persiapan Galungan", meta.Description)
+	assert.Equal(t, "https://pura.example.com/berita/galungan", meta.CanonicalURL)
+	assert.Equal(t, &model.SEOImageResponse{URL: "https://cdn.example.com/articles/galungan-md.webp", Width: 768, Alt: "Hari Raya Galungan"}, meta.Image)
+	assert.Equal(t, "2026-10-10T08:00:00Z", meta.OpenGraph["article:published_time"])
+	assert.Equal(t, "768", meta.OpenGraph["og:image:width"])
+	assert.Equal(t, "summary_large_image", meta.Twitter["twitter:card"])
+
+	if assert.Len(t, meta.JSONLD, 2) {
+		assert.Equal(t, "Organization", meta.JSONLD[0]["@type"])
+		assert.Equal(t, "https://cdn.example.com/site/logo.png", meta.JSONLD[0]["logo"])
+
+		article := meta.JSONLD[1]
+		assert.Equal(t, "Article", article["@type"])
+		assert.Equal(t, "Hari Raya Galungan", article["headline"])
+		assert.Equal(t, "Upacara", article["articleSection"])
+		assert.Equal(t, "Galungan, Hari Raya", article["keywords"])
+		assert.Equal(t, []string{"https://cdn.example.com/articles/galungan-md.webp"}, article["image"])
+		assert.Equal(t, "Pura Agung Kertajaya", article["publisher"].(map[string]any)["name"])
+	}
+}
+
+func TestSEOUsecase_Resolve_ArticleOverrides(t *testing.T) {
+	u, _, articleUC, siteIdentityUC := setupSEOUsecase(t)
+
+	siteIdentityUC.On("GetPublic", "pura").Return(sampleSEOSite(), nil)
+	articleUC.On("GetBySlug", "pura", "galungan").Return(&model.ArticleResponse{
+		Title:           "Hari Raya Galungan",
+		Slug:            "galungan",
+		MetaTitle:       "Galungan 2026",
+		MetaDescription: "Jadwal Galungan",
+		CanonicalURL:    "https://yayasan.example.com/galungan",
+	}, nil)
+
+	meta, err := u.Resolve("pura", "berita/galungan")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Galungan 2026", meta.Title)
+	assert.Equal(t, "Jadwal Galungan", meta.Description)
+	assert.Equal(t, "https://yayasan.example.com/galungan", meta.CanonicalURL)
+	assert.Equal(t, "https://cdn.example.com/site/og-lg.webp", meta.Image.URL)
+	assert.Equal(t, 1024, meta.Image.Width)
+}
+
+func TestSEOUsecase_Resolve_ArticleNotFound(t *testing.T) {
+	u, _, articleUC, siteIdentityUC := setupSEOUsecase(t)
+
+	siteIdentityUC.On("GetPublic", "pura").Return(sampleSEOSite(), nil)
+	articleUC.On("GetBySlug", "pura", "missing").Return(nil, model.ErrNotFound("article not found"))
+
+	_, err := u.Resolve("pura", "/berita/missing")
+
+	assert.Equal(t, 404, err.(*model.ResponseError).Code)
+}
+
+func TestSEOUsecase_Resolve_Home(t *testing.T) {
+	u, _, _, siteIdentityUC := setupSEOUsecase(t)
+
+	site := sampleSEOSite()
+	site.MetaTitle = "Pura Agung Kertajaya Tangerang"
+	site.CanonicalURL = "https://www.pura.example.com/"
+	siteIdentityUC.On("GetPublic", "pura").Return(site, nil)
+
+	meta, err := u.Resolve("pura", "/")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "website", meta.Type)
+	assert.Equal(t, "Pura Agung Kertajaya Tangerang", meta.Title)
+	assert.Equal(t, "Pura di Tangerang", meta.Description)
+	assert.Equal(t, "https://www.pura.example.com/", meta.CanonicalURL)
+	assert.Equal(t, "https://cdn.example.com/site/og-lg.webp", meta.OpenGraph["og:image"])
+	assert.Equal(t, "id_ID", meta.OpenGraph["og:locale"])
+}
+
+func TestSEOUsecase_Resolve_Category(t *testing.T) {
+	u, sqlMock, _, siteIdentityUC := setupSEOUsecase(t)
+
+	siteIdentityUC.On("GetPublic", "pura").Return(nil, model.ErrNotFound("site identity not found"))
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `categories` WHERE entity_type = ? AND slug = ? LIMIT ?")).
+		WithArgs("pura", "upacara", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug"}).AddRow("c-1", "Upacara", "upacara"))
+
+	meta, err := u.Resolve("pura", "/kategori/upacara")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Upacara", meta.Title)
+	assert.Equal(t, "https://pura.example.com/kategori/upacara", meta.CanonicalURL)
+	assert.Nil(t, meta.Image)
+	assert.Empty(t, meta.JSONLD)
+	assert.Equal(t, "summary", meta.Twitter["twitter:card"])
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSEOUsecase_Resolve_ActivitiesPage(t *testing.T) {
+	u, sqlMock, _, siteIdentityUC := setupSEOUsecase(t)
+
+	siteIdentityUC.On("GetPublic", "pura").Return(sampleSEOSite(), nil)
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE entity_type = ? AND is_active = ? AND event_date >= ? ORDER BY event_date ASC,order_index ASC LIMIT ?")).
+		WithArgs("pura", true, sqlmock.AnyArg(), 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "location", "event_date"}).
+			AddRow("act-1", "Piodalan", "Piodalan pura", "Pura Agung", time.Date(2026, 11, 4, 0, 0, 0, 0, time.UTC)))
+
+	meta, err := u.Resolve("pura", "/kegiatan")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Kegiatan | Pura Agung Kertajaya", meta.Title)
+	assert.Equal(t, "https://pura.example.com/kegiatan", meta.CanonicalURL)
+	if assert.Len(t, meta.JSONLD, 2) {
+		event := meta.JSONLD[1]
+		assert.Equal(t, "Event", event["@type"])
+		assert.Equal(t, "Piodalan", event["name"])
+		assert.Equal(t, "2026-11-04", event["startDate"])
+		assert.Equal(t, "Pura Agung", event["location"].(map[string]any)["name"])
+		assert.Equal(t, "Pura Agung Kertajaya", event["organizer"].(map[string]any)["name"])
+	}
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSEOUsecase_Resolve_UnknownPath(t *testing.T) {
+	u, _, articleUC, siteIdentityUC := setupSEOUsecase(t)
+
+	siteIdentityUC.On("GetPublic", "pura").Return(sampleSEOSite(), nil)
+
+	_, err := u.Resolve("pura", "/berita/galungan/komentar")
+
+	assert.Equal(t, 404, err.(*model.ResponseError).Code)
+	articleUC.AssertNotCalled(t, "GetBySlug", mock.Anything, mock.Anything)
+}
+
+func TestSEOUsecase_Resolve_NoSite(t *testing.T) {
+	u, _, _, siteIdentityUC := setupSEOUsecase(t)
+
+	_, err := u.Resolve("yayasan", "/")
+
+	assert.Equal(t, 404, err.(*model.ResponseError).Code)
+	siteIdentityUC.AssertNotCalled(t, "GetPublic", mock.Anything)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func setupSiteIdentityController(mockUC *usecasemock.SiteIdentityUsecaseMock) *fiber.App {
@@ -84,7 +85,7 @@ func TestSiteIdentityController_GetAll_Success(t *testing.T) {
 	app := setupSiteIdentityController(mockUC)
 
 	items := []model.SiteIdentityResponse{{ID: "1", EntityType: "pura", SiteName: "A"}}
-	mockUC.On("GetAll", "pura").Return(items, nil)
+	mockUC.On("GetAll", "pura", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/site-identity", nil)
 	resp, _ := app.Test(req, -1)
@@ -98,7 +99,7 @@ func TestSiteIdentityController_GetByID_Success(t *testing.T) {
 	app := setupSiteIdentityController(mockUC)
 
 	item := &model.SiteIdentityResponse{ID: "x", SiteName: "X"}
-	mockUC.On("GetByID", "x").Return(item, nil)
+	mockUC.On("GetByID", "pura", "x").Return(item, nil)
 
 	req := httptest.NewRequest("GET", "/api/site-identity/x", nil)
 	resp, _ := app.Test(req, -1)
@@ -110,7 +111,7 @@ func TestSiteIdentityController_GetByID_NotFound(t *testing.T) {
 	mockUC := &usecasemock.SiteIdentityUsecaseMock{}
 	app := setupSiteIdentityController(mockUC)
 
-	mockUC.On("GetByID", "missing").Return((*model.SiteIdentityResponse)(nil), model.ErrNotFound("site identity not found"))
+	mockUC.On("GetByID", "pura", "missing").Return((*model.SiteIdentityResponse)(nil), model.ErrNotFound("site identity not found"))
 
 	req := httptest.NewRequest("GET", "/api/site-identity/missing", nil)
 	resp, _ := app.Test(req, -1)
@@ -125,7 +126,7 @@ func TestSiteIdentityController_Create_Success(t *testing.T) {
 	reqBody := model.SiteIdentityRequest{SiteName: "Pura", LogoURL: "http://logo.com/logo.png"}
 	resBody := &model.SiteIdentityResponse{ID: "1", SiteName: "Pura"}
 
-	mockUC.On("Create", "pura", reqBody).Return(resBody, nil)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return(resBody, nil)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/site-identity", bytes.NewReader(b))
@@ -147,7 +148,7 @@ func TestSiteIdentityController_Create_ValidationError(t *testing.T) {
 	}
 	realValErr := validate.Struct(Dummy{})
 
-	mockUC.On("Create", "pura", reqBody).Return((*model.SiteIdentityResponse)(nil), realValErr)
+	mockUC.On("Create", mock.Anything, "pura", reqBody).Return((*model.SiteIdentityResponse)(nil), realValErr)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/site-identity", bytes.NewReader(b))
@@ -163,7 +164,7 @@ func TestSiteIdentityController_Update_Success(t *testing.T) {
 
 	reqBody := model.SiteIdentityRequest{SiteName: "New"}
 	resBody := &model.SiteIdentityResponse{ID: "2", SiteName: "New"}
-	mockUC.On("Update", "2", reqBody).Return(resBody, nil)
+	mockUC.On("Update", mock.Anything, "pura", "2", reqBody).Return(resBody, nil)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/site-identity/2", bytes.NewReader(b))
@@ -178,7 +179,7 @@ func TestSiteIdentityController_Update_NotFound(t *testing.T) {
 	app := setupSiteIdentityController(mockUC)
 
 	reqBody := model.SiteIdentityRequest{SiteName: "X"}
-	mockUC.On("Update", "3", reqBody).Return((*model.SiteIdentityResponse)(nil), model.ErrNotFound("site identity not found"))
+	mockUC.On("Update", mock.Anything, "pura", "3", reqBody).Return((*model.SiteIdentityResponse)(nil), model.ErrNotFound("site identity not found"))
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/site-identity/3", bytes.NewReader(b))
@@ -192,7 +193,7 @@ func TestSiteIdentityController_Delete_Success(t *testing.T) {
 	mockUC := &usecasemock.SiteIdentityUsecaseMock{}
 	app := setupSiteIdentityController(mockUC)
 
-	mockUC.On("Delete", "7").Return(nil)
+	mockUC.On("Delete", mock.Anything, "pura", "7").Return(nil)
 
 	req := httptest.NewRequest("DELETE", "/api/site-identity/7", nil)
 	resp, _ := app.Test(req, -1)
@@ -204,7 +205,7 @@ func TestSiteIdentityController_Delete_NotFound(t *testing.T) {
 	mockUC := &usecasemock.SiteIdentityUsecaseMock{}
 	app := setupSiteIdentityController(mockUC)
 
-	mockUC.On("Delete", "8").Return(model.ErrNotFound("site identity not found"))
+	mockUC.On("Delete", mock.Anything, "pura", "8").Return(model.ErrNotFound("site identity not found"))
 
 	req := httptest.NewRequest("DELETE", "/api/site-identity/8", nil)
 	resp, _ := app.Test(req, -1)
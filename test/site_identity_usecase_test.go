@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"regexp"
 	"testing"
 
@@ -56,11 +57,16 @@ func TestSiteIdentityUsecase_Create_Success(t *testing.T) {
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	res, err := u.Create(req.EntityType, req)
+	res, err := u.Create(context.Background(), req.EntityType, req)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
 	assert.Equal(t, "Pura Agung", res.SiteName)
@@ -69,7 +75,7 @@ func TestSiteIdentityUsecase_Create_Success(t *testing.T) {
 func TestSiteIdentityUsecase_Create_ValidationError(t *testing.T) {
 	u, _ := setupMockSiteIdentityUsecase(t)
 	req := model.SiteIdentityRequest{}
-	res, err := u.Create("pura", req)
+	res, err := u.Create(context.Background(), "pura", req)
 	assert.Error(t, err)
 	assert.Nil(t, res)
 }
@@ -85,7 +91,7 @@ func TestSiteIdentityUsecase_GetAll(t *testing.T) {
 		WithArgs("pura").
 		WillReturnRows(rows)
 
-	list, err := u.GetAll("pura")
+	list, _, err := u.GetAll("pura", &model.ListRequest{})
 	assert.NoError(t, err)
 	assert.Len(t, list, 2)
 }
@@ -96,11 +102,11 @@ func TestSiteIdentityUsecase_GetByID_Success(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{"id", "site_name"}).AddRow(targetID, "My Site")
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `site_identity` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `site_identity` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(rows)
 
-	res, err := u.GetByID(targetID)
+	res, err := u.GetByID("pura", targetID)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
 }
@@ -108,11 +114,11 @@ func TestSiteIdentityUsecase_GetByID_Success(t *testing.T) {
 func TestSiteIdentityUsecase_GetByID_NotFound(t *testing.T) {
 	u, mock := setupMockSiteIdentityUsecase(t)
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `site_identity` WHERE id = ? LIMIT ?")).
-		WithArgs("missing", 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `site_identity` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs("missing", "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.GetByID("missing")
+	res, err := u.GetByID("pura", "missing")
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
@@ -129,20 +135,20 @@ func TestSiteIdentityUsecase_Update_Success(t *testing.T) {
 	targetID := "sid-1"
 
 	req := model.SiteIdentityRequest{
-		EntityType:        "yayasan",
+		EntityType:        "pura",
 		SiteName:          "New Site",
 		Tagline:           "New Tag",
 		PrimaryButtonText: "Go",
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `site_identity` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `site_identity` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "site_name", "tagline"}).AddRow(targetID, "pura", "Old", "Old"))
 
 	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta("UPDATE `site_identity`")).
 		WithArgs(
-			"yayasan",
+			"pura",
 			"New Site",
 			sqlmock.AnyArg(),
 			"New Tag",
@@ -152,12 +158,17 @@ func TestSiteIdentityUsecase_Update_Success(t *testing.T) {
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
 			targetID,
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	res, err := u.Update(targetID, req)
+	res, err := u.Update(context.Background(), "pura", targetID, req)
 	assert.NoError(t, err)
 	if assert.NotNil(t, res) {
 		assert.Equal(t, "New Site", res.SiteName)
@@ -174,11 +185,11 @@ func TestSiteIdentityUsecase_Update_NotFound(t *testing.T) {
 		SiteName:   "Valid Name",
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `site_identity` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `site_identity` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.Update(targetID, req)
+	res, err := u.Update(context.Background(), "pura", targetID, req)
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
@@ -194,8 +205,8 @@ func TestSiteIdentityUsecase_Delete_Success(t *testing.T) {
 	u, mock := setupMockSiteIdentityUsecase(t)
 	targetID := "del-1"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `site_identity` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `site_identity` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "site_name"}).AddRow(targetID, "Name"))
 
 	mock.ExpectBegin()
@@ -204,7 +215,7 @@ func TestSiteIdentityUsecase_Delete_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 	assert.NoError(t, err)
 }
 
@@ -212,11 +223,11 @@ func TestSiteIdentityUsecase_Delete_NotFound(t *testing.T) {
 	u, mock := setupMockSiteIdentityUsecase(t)
 	targetID := "missing"
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `site_identity` WHERE id = ? LIMIT ?")).
-		WithArgs(targetID, 1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `site_identity` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "pura", 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), "pura", targetID)
 
 	assert.Error(t, err)
 	var e *model.ResponseError
@@ -261,3 +272,52 @@ func TestSiteIdentityUsecase_GetPublic_NotFound(t *testing.T) {
 		assert.Equal(t, "site identity not found", e.Message)
 	}
 }
+
+func TestSiteIdentityUsecase_Delete_OtherEntity_NotFound(t *testing.T) {
+	u, mock := setupMockSiteIdentityUsecase(t)
+	targetID := "sid-1"
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `site_identity` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs(targetID, "yayasan", 1).
+		WillReturnRows(sqlmock.NewRows(nil))
+
+	err := u.Delete(context.Background(), "yayasan", targetID)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 404, e.Code)
+		assert.Equal(t, "site identity not found", e.Message)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSiteIdentityUsecase_Update_MoveToOtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockSiteIdentityUsecase(t)
+
+	req := model.SiteIdentityRequest{
+		EntityType: "yayasan",
+		SiteName:   "New Site",
+	}
+
+	res, err := u.Update(context.Background(), "pura", "sid-1", req)
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSiteIdentityUsecase_Create_OtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockSiteIdentityUsecase(t)
+
+	res, err := u.Create(context.Background(), "yayasan", model.SiteIdentityRequest{EntityType: "pura", SiteName: "Pura Agung Kertajaya"})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
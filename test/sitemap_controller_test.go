@@ -0,0 +1,98 @@
+package test
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
+	"pura-agung-kertajaya-backend/internal/model"
+	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
+)
+
+func setupSitemapController(mockUC *usecasemock.SitemapUsecaseMock) *fiber.App {
+	app, logger, _ := NewTestApp()
+
+	controller := httpdelivery.NewSitemapController(mockUC, logger)
+	app.Get("/api/public/sitemap.xml", controller.GetIndex)
+	app.Get("/api/public/sitemaps/:entity.xml", controller.GetSitemap)
+
+	return app
+}
+
+func TestSitemapController_GetIndex(t *testing.T) {
+	mockUC := &usecasemock.SitemapUsecaseMock{}
+	app := setupSitemapController(mockUC)
+
+	updated := time.Date(2026, 10, 14, 6, 0, 0, 0, time.UTC)
+	mockUC.On("GetIndex").Return([]model.SitemapIndexEntry{
+		{EntityType: "pura", LastMod: &updated},
+		{EntityType: "yayasan"},
+	}, nil)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/api/public/sitemap.xml", nil))
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/xml; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "Wed, 14 Oct 2026 06:00:00 GMT", resp.Header.Get("Last-Modified"))
+
+	var doc struct {
+		XMLName  xml.Name `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+		Sitemaps []struct {
+			Loc     string `xml:"loc"`
+			LastMod string `xml:"lastmod"`
+		} `xml:"sitemap"`
+	}
+	body, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, xml.Unmarshal(body, &doc))
+	if assert.Len(t, doc.Sitemaps, 2) {
+		assert.Equal(t, "http://example.com/api/public/sitemaps/pura.xml", doc.Sitemaps[0].Loc)
+		assert.Equal(t, "2026-10-14T06:00:00Z", doc.Sitemaps[0].LastMod)
+		assert.Empty(t, doc.Sitemaps[1].LastMod)
+	}
+}
+
+func TestSitemapController_GetSitemap(t *testing.T) {
+	mockUC := &usecasemock.SitemapUsecaseMock{}
+	app := setupSitemapController(mockUC)
+
+	updated := time.Date(2026, 10, 14, 6, 0, 0, 0, time.UTC)
+	mockUC.On("GetSitemap", "pasraman").Return(&model.Sitemap{
+		EntityType: "pasraman",
+		Updated:    updated,
+		URLs: []model.SitemapURL{
+			{Loc: "https://pasraman.example.com/"},
+			{Loc: "https://pasraman.example.com/articles/galungan", LastMod: &updated},
+		},
+	}, nil)
+	mockUC.On("GetSitemap", "yayasan").Return(nil, model.ErrNotFound("sitemap not found"))
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/api/public/sitemaps/pasraman.xml", nil))
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var doc struct {
+		URLs []struct {
+			Loc     string `xml:"loc"`
+			LastMod string `xml:"lastmod"`
+		} `xml:"url"`
+	}
+	body, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, xml.Unmarshal(body, &doc))
+	if assert.Len(t, doc.URLs, 2) {
+		assert.Equal(t, "https://pasraman.example.com/articles/galungan", doc.URLs[1].Loc)
+		assert.Equal(t, "2026-10-14T06:00:00Z", doc.URLs[1].LastMod)
+	}
+
+	req := httptest.NewRequest("GET", "/api/public/sitemaps/pasraman.xml", nil)
+	req.Header.Set("If-None-Match", resp.Header.Get("ETag"))
+	resp, _ = app.Test(req)
+	assert.Equal(t, fiber.StatusNotModified, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/api/public/sitemaps/yayasan.xml", nil))
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
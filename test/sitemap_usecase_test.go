@@ -0,0 +1,118 @@
+package test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"pura-agung-kertajaya-backend/internal/entity"
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+func setupSitemapUsecase(t *testing.T) (usecase.SitemapUsecase, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub db: %v", err)
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+
+	siteURL := &util.SiteURLUtil{
+		BaseURLs: map[string]string{
+			"pura":     "https://pura.example.com",
+			"pasraman": "https://pasraman.example.com/",
+		},
+		ArticlePath:  "/berita",
+		CategoryPath: "/kategori",
+		Pages:        []string{"/", "/about"},
+	}
+	return usecase.NewSitemapUsecase(gormDB, siteURL), mock
+}
+
+func TestSitemapUsecase_GetIndex(t *testing.T) {
+	u, mock := setupSitemapUsecase(t)
+
+	articleUpdate := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	categoryUpdate := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT entity_type, MAX(updated_at) AS updated_at FROM `articles` WHERE status = ? AND (published_at IS NULL OR published_at <= ?) AND (expires_at IS NULL OR expires_at > ?) GROUP BY `entity_type`")).
+		WithArgs(entity.ArticleStatusPublished, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"entity_type", "updated_at"}).
+			AddRow("pura", articleUpdate).
+			AddRow("yayasan", articleUpdate))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT entity_type, MAX(updated_at) AS updated_at FROM `categories` GROUP BY `entity_type`")).
+		WillReturnRows(sqlmock.NewRows([]string{"entity_type", "updated_at"}).AddRow("pura", categoryUpdate))
+
+	entries, err := u.GetIndex()
+
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2, "entities without a site are left out") {
+		assert.Equal(t, "pura", entries[0].EntityType)
+		assert.Equal(t, categoryUpdate, *entries[0].LastMod)
+		assert.Equal(t, "pasraman", entries[1].EntityType)
+		assert.Nil(t, entries[1].LastMod)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSitemapUsecase_GetSitemap(t *testing.T) {
+	u, mock := setupSitemapUsecase(t)
+
+	newest := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	older := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	categoryUpdate := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT slug, updated_at FROM `categories` WHERE entity_type = ? AND id IN (SELECT `category_id` FROM `articles` WHERE entity_type = ? AND status = ? AND (published_at IS NULL OR published_at <= ?) AND (expires_at IS NULL OR expires_at > ?)) ORDER BY slug ASC")).
+		WithArgs("pasraman", "pasraman", entity.ArticleStatusPublished, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"slug", "updated_at"}).AddRow("upacara", categoryUpdate))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT slug, updated_at FROM `articles` WHERE entity_type = ? AND status = ? AND (published_at IS NULL OR published_at <= ?) AND (expires_at IS NULL OR expires_at > ?) ORDER BY updated_at DESC LIMIT ?")).
+		WithArgs("pasraman", entity.ArticleStatusPublished, sqlmock.AnyArg(), sqlmock.AnyArg(), util.MaxSitemapURLs-4).
+		WillReturnRows(sqlmock.NewRows([]string{"slug", "updated_at"}).
+			AddRow("galungan", newest).
+			AddRow("kuningan", older))
+
+	sitemap, err := u.GetSitemap("pasraman")
+
+	assert.NoError(t, err)
+	assert.Equal(t, newest, sitemap.Updated)
+
+	locs := make([]string, len(sitemap.URLs))
+	for i, url := range sitemap.URLs {
+		locs[i] = url.Loc
+	}
+	assert.Equal(t, []string{
+		"https://pasraman.example.com/",
+		"https://pasraman.example.com/about",
+		"https://pasraman.example.com/berita",
+		"https://pasraman.example.com/kategori/upacara",
+		"https://pasraman.example.com/berita/galungan",
+		"https://pasraman.example.com/berita/kuningan",
+	}, locs)
+	assert.Equal(t, newest, *sitemap.URLs[0].LastMod)
+	assert.Nil(t, sitemap.URLs[1].LastMod)
+	assert.Equal(t, categoryUpdate, *sitemap.URLs[3].LastMod)
+	assert.Equal(t, older, *sitemap.URLs[5].LastMod)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSitemapUsecase_GetSitemap_NoSite(t *testing.T) {
+	u, mock := setupSitemapUsecase(t)
+
+	_, err := u.GetSitemap("yayasan")
+
+	assert.Equal(t, 404, err.(*model.ResponseError).Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
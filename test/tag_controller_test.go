@@ -0,0 +1,86 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	httpdelivery "pura-agung-kertajaya-backend/internal/delivery/http"
+	"pura-agung-kertajaya-backend/internal/delivery/http/middleware"
+	"pura-agung-kertajaya-backend/internal/model"
+	usecasemock "pura-agung-kertajaya-backend/internal/usecase/mock"
+)
+
+func setupTagController(mockUC *usecasemock.TagUsecaseMock) *fiber.App {
+	app, logger, _ := NewTestApp()
+	controller := httpdelivery.NewTagController(mockUC, logger)
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(middleware.CtxEntityType, "pura")
+		return c.Next()
+	})
+
+	app.Get("/public/tags", controller.GetAllPublic)
+	app.Get("/tags", controller.GetAll)
+	app.Get("/tags/:id", controller.GetByID)
+	app.Post("/tags", controller.Create)
+	app.Put("/tags/:id", controller.Update)
+	app.Delete("/tags/:id", controller.Delete)
+
+	return app
+}
+
+func TestTagController_GetAllPublic_EntityType(t *testing.T) {
+	mockUC := &usecasemock.TagUsecaseMock{}
+	app := setupTagController(mockUC)
+
+	items := []model.TagResponse{{ID: "1", EntityType: "yayasan", Name: "Beasiswa", Slug: "beasiswa"}}
+	mockUC.On("GetAll", "yayasan", mock.Anything).Return(items, nil, nil)
+
+	req := httptest.NewRequest("GET", "/public/tags?entity_type=yayasan", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var response model.WebResponse[[]model.TagResponse]
+	json.NewDecoder(resp.Body).Decode(&response)
+	assert.Len(t, response.Data, 1)
+	mockUC.AssertExpectations(t)
+}
+
+func TestTagController_Create_Success(t *testing.T) {
+	mockUC := &usecasemock.TagUsecaseMock{}
+	app := setupTagController(mockUC)
+
+	payload := model.CreateTagRequest{EntityType: "pura", Name: "Galungan"}
+	mockUC.On("Create", mock.Anything, "pura", payload).
+		Return(&model.TagResponse{ID: "t1", EntityType: "pura", Name: "Galungan", Slug: "galungan"}, nil)
+
+	bodyBytes, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", "/tags", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var response model.WebResponse[model.TagResponse]
+	json.NewDecoder(resp.Body).Decode(&response)
+	assert.Equal(t, "galungan", response.Data.Slug)
+}
+
+func TestTagController_Delete_NotFound(t *testing.T) {
+	mockUC := &usecasemock.TagUsecaseMock{}
+	app := setupTagController(mockUC)
+
+	mockUC.On("Delete", mock.Anything, "pura", "missing").Return(model.ErrNotFound("tag not found"))
+
+	req := httptest.NewRequest("DELETE", "/tags/missing", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,162 @@
+package test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/usecase"
+)
+
+func setupMockTagUsecase(t *testing.T) (usecase.TagUsecase, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub db: %v", err)
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+
+	u := usecase.NewTagUsecase(gormDB, validator.New())
+	return u, mock
+}
+
+func TestTagUsecase_GetAll(t *testing.T) {
+	u, mock := setupMockTagUsecase(t)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "slug", "created_at", "updated_at"}).
+		AddRow("t1", "Galungan", "galungan", time.Now(), time.Now()).
+		AddRow("t2", "Piodalan", "piodalan", time.Now(), time.Now())
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tags` WHERE entity_type = ? ORDER BY name ASC,id ASC")).
+		WithArgs("pura").
+		WillReturnRows(rows)
+
+	list, _, err := u.GetAll("pura", &model.ListRequest{})
+
+	assert.NoError(t, err)
+	if assert.Len(t, list, 2) {
+		assert.Equal(t, "galungan", list[0].Slug)
+	}
+}
+
+func TestTagUsecase_Create_SlugCollision(t *testing.T) {
+	u, mock := setupMockTagUsecase(t)
+	req := model.CreateTagRequest{EntityType: "pura", Name: "Galungan"}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `tags` WHERE entity_type = ? AND slug = ?")).
+		WithArgs("pura", "galungan").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `tags` WHERE entity_type = ? AND slug = ?")).
+		WithArgs("pura", "galungan-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `tags`")).
+		WithArgs(sqlmock.AnyArg(), "pura", "Galungan", "galungan-1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	created, err := u.Create(context.Background(), "pura", req)
+	assert.NoError(t, err)
+	if assert.NotNil(t, created) {
+		assert.Equal(t, "galungan-1", created.Slug)
+	}
+}
+
+func TestTagUsecase_Create_OtherEntity(t *testing.T) {
+	u, _ := setupMockTagUsecase(t)
+
+	_, err := u.Create(context.Background(), "pura", model.CreateTagRequest{EntityType: "yayasan", Name: "Beasiswa"})
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+}
+
+func TestTagUsecase_Update(t *testing.T) {
+	u, mock := setupMockTagUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tags` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs("t1", "pura", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "name", "slug"}).AddRow("t1", "pura", "Lama", "lama"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `tags` WHERE entity_type = ? AND (slug = ? AND id != ?)")).
+		WithArgs("pura", "kuningan", "t1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `tags` SET")).
+		WithArgs("pura", "Kuningan", "kuningan", sqlmock.AnyArg(), sqlmock.AnyArg(), "t1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	updated, err := u.Update(context.Background(), "pura", "t1", model.UpdateTagRequest{Name: "Kuningan"})
+	assert.NoError(t, err)
+	if assert.NotNil(t, updated) {
+		assert.Equal(t, "kuningan", updated.Slug)
+	}
+}
+
+func TestTagUsecase_Delete_DetachesArticles(t *testing.T) {
+	u, mock := setupMockTagUsecase(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tags` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs("t1", "pura", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entity_type", "name", "slug"}).AddRow("t1", "pura", "Galungan", "galungan"))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_tags` WHERE tag_id = ?")).
+		WithArgs("t1").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `tags` WHERE `tags`.`id` = ?")).
+		WithArgs("t1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := u.Delete(context.Background(), "pura", "t1")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagUsecase_Delete_NotFound(t *testing.T) {
+	u, mock := setupMockTagUsecase(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tags` WHERE id = ? AND entity_type = ? LIMIT ?")).
+		WithArgs("missing", "pura", 1).
+		WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectRollback()
+
+	err := u.Delete(context.Background(), "pura", "missing")
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 404, e.Code)
+		assert.Equal(t, "tag not found", e.Message)
+	}
+}
+
+func TestTagUsecase_Update_MoveToOtherEntity_Forbidden(t *testing.T) {
+	u, mock := setupMockTagUsecase(t)
+
+	res, err := u.Update(context.Background(), "pura", "tag-1", model.UpdateTagRequest{EntityType: "yayasan", Name: "Beasiswa"})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 403, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
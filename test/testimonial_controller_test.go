@@ -14,6 +14,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func setupTestimonialController(mockUC *usecasemock.TestimonialUsecaseMock) *fiber.App {
@@ -41,7 +42,7 @@ func TestTestimonialController_GetAllPublic_Success(t *testing.T) {
 		{ID: "uuid-1", Name: "A"},
 		{ID: "uuid-2", Name: "B"},
 	}
-	mockUC.On("GetPublic").Return(items, nil)
+	mockUC.On("GetPublic", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/public/testimonials", nil)
 	resp, _ := app.Test(req, -1)
@@ -54,7 +55,7 @@ func TestTestimonialController_GetAllPublic_Error(t *testing.T) {
 	mockUC := &usecasemock.TestimonialUsecaseMock{}
 	app := setupTestimonialController(mockUC)
 
-	mockUC.On("GetPublic").Return(([]model.TestimonialResponse)(nil), errors.New("db error"))
+	mockUC.On("GetPublic", mock.Anything).Return(([]model.TestimonialResponse)(nil), nil, errors.New("db error"))
 
 	req := httptest.NewRequest("GET", "/api/public/testimonials", nil)
 	resp, _ := app.Test(req, -1)
@@ -70,7 +71,7 @@ func TestTestimonialController_GetAll_Success(t *testing.T) {
 		{ID: "uuid-1", Name: "A"},
 		{ID: "uuid-2", Name: "B"},
 	}
-	mockUC.On("GetAll").Return(items, nil)
+	mockUC.On("GetAll", mock.Anything).Return(items, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/testimonials", nil)
 	resp, _ := app.Test(req, -1)
@@ -118,7 +119,7 @@ func TestTestimonialController_Create_Success(t *testing.T) {
 	reqBody := model.TestimonialRequest{Name: "John", Rating: 5, Comment: "Good", IsActive: true, OrderIndex: 1}
 	resBody := &model.TestimonialResponse{ID: "new-uuid-1", Name: "John"}
 
-	mockUC.On("Create", reqBody).Return(resBody, nil)
+	mockUC.On("Create", mock.Anything, reqBody).Return(resBody, nil)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/testimonials", bytes.NewReader(b))
@@ -140,7 +141,7 @@ func TestTestimonialController_Create_ValidationError(t *testing.T) {
 	}
 	realValErr := validate.Struct(Dummy{})
 
-	mockUC.On("Create", reqBody).Return((*model.TestimonialResponse)(nil), realValErr)
+	mockUC.On("Create", mock.Anything, reqBody).Return((*model.TestimonialResponse)(nil), realValErr)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/api/testimonials", bytes.NewReader(b))
@@ -159,7 +160,7 @@ func TestTestimonialController_Update_Success(t *testing.T) {
 	reqBody := model.TestimonialRequest{Name: "Jane", Rating: 4, Comment: "Nice", IsActive: true, OrderIndex: 1}
 	resBody := &model.TestimonialResponse{ID: idToUpdate, Name: "Jane"}
 
-	mockUC.On("Update", idToUpdate, reqBody).Return(resBody, nil)
+	mockUC.On("Update", mock.Anything, idToUpdate, reqBody).Return(resBody, nil)
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/testimonials/uuid-2", bytes.NewReader(b))
@@ -176,7 +177,7 @@ func TestTestimonialController_Update_NotFound(t *testing.T) {
 	idToUpdate := "non-existent-uuid"
 	reqBody := model.TestimonialRequest{Name: "Jane"}
 
-	mockUC.On("Update", idToUpdate, reqBody).Return((*model.TestimonialResponse)(nil), model.ErrNotFound("testimonial not found"))
+	mockUC.On("Update", mock.Anything, idToUpdate, reqBody).Return((*model.TestimonialResponse)(nil), model.ErrNotFound("testimonial not found"))
 
 	b, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/api/testimonials/non-existent-uuid", bytes.NewReader(b))
@@ -191,7 +192,7 @@ func TestTestimonialController_Delete_Success(t *testing.T) {
 	app := setupTestimonialController(mockUC)
 
 	idToDelete := "uuid-7"
-	mockUC.On("Delete", idToDelete).Return(nil)
+	mockUC.On("Delete", mock.Anything, idToDelete).Return(nil)
 
 	req := httptest.NewRequest("DELETE", "/api/testimonials/uuid-7", nil)
 	resp, _ := app.Test(req, -1)
@@ -204,7 +205,7 @@ func TestTestimonialController_Delete_NotFound(t *testing.T) {
 	app := setupTestimonialController(mockUC)
 
 	idToDelete := "uuid-8"
-	mockUC.On("Delete", idToDelete).Return(model.ErrNotFound("testimonial not found"))
+	mockUC.On("Delete", mock.Anything, idToDelete).Return(model.ErrNotFound("testimonial not found"))
 
 	req := httptest.NewRequest("DELETE", "/api/testimonials/uuid-8", nil)
 	resp, _ := app.Test(req, -1)
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"regexp"
 	"testing"
 	"time"
@@ -61,7 +62,7 @@ func TestTestimonialUsecase_Create_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	res, err := u.Create(req)
+	res, err := u.Create(context.Background(), req)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
 	assert.Equal(t, req.Name, res.Name)
@@ -78,7 +79,7 @@ func TestTestimonialUsecase_Create_ValidationError(t *testing.T) {
 		Comment: "",
 	}
 
-	res, err := u.Create(req)
+	res, err := u.Create(context.Background(), req)
 	assert.Error(t, err)
 	assert.Nil(t, res)
 }
@@ -93,7 +94,7 @@ func TestTestimonialUsecase_GetAll(t *testing.T) {
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `testimonials` ORDER BY order_index ASC")).
 		WillReturnRows(rows)
 
-	list, err := u.GetAll()
+	list, _, err := u.GetAll(&model.ListRequest{})
 	assert.NoError(t, err)
 	assert.Len(t, list, 2)
 	assert.Equal(t, "uuid-2", list[0].ID)
@@ -109,7 +110,7 @@ func TestTestimonialUsecase_GetPublic(t *testing.T) {
 		WithArgs(true).
 		WillReturnRows(rows)
 
-	list, err := u.GetPublic()
+	list, _, err := u.GetPublic(&model.ListRequest{})
 	assert.NoError(t, err)
 	assert.Len(t, list, 1)
 }
@@ -185,7 +186,7 @@ func TestTestimonialUsecase_Update_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	res, err := u.Update(targetID, req)
+	res, err := u.Update(context.Background(), targetID, req)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
 	assert.Equal(t, req.Name, res.Name)
@@ -205,7 +206,7 @@ func TestTestimonialUsecase_Update_NotFound(t *testing.T) {
 		WithArgs(targetID, 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	res, err := u.Update(targetID, req)
+	res, err := u.Update(context.Background(), targetID, req)
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
@@ -231,7 +232,7 @@ func TestTestimonialUsecase_Delete_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), targetID)
 	assert.NoError(t, err)
 }
 
@@ -243,7 +244,7 @@ func TestTestimonialUsecase_Delete_NotFound(t *testing.T) {
 		WithArgs(targetID, 1).
 		WillReturnRows(sqlmock.NewRows(nil))
 
-	err := u.Delete(targetID)
+	err := u.Delete(context.Background(), targetID)
 
 	assert.Error(t, err)
 	var e *model.ResponseError
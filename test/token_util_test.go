@@ -0,0 +1,261 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+func setupTokenUtil(t *testing.T) (*util.TokenUtil, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	return util.NewTokenUtil("test-secret", rdb, 15*time.Minute, 7*24*time.Hour), mr
+}
+
+func TestTokenUtil_CreateToken_StartsFamily(t *testing.T) {
+	tokenUtil, mr := setupTokenUtil(t)
+	ctx := context.Background()
+
+	pair, err := tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura", Email: "pura@example.com"}, nil)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pair.AccessToken)
+	assert.NotEmpty(t, pair.RefreshToken)
+
+	auth, jti, err := tokenUtil.ParseToken(ctx, pair.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", auth.ID)
+	assert.NotEmpty(t, auth.FamilyID)
+	assert.Equal(t, 15*time.Minute, mr.TTL("session:"+jti))
+
+	members, err := mr.SMembers("family:" + auth.FamilyID)
+	assert.NoError(t, err)
+	assert.Len(t, members, 2)
+}
+
+func TestTokenUtil_ConsumeRefreshToken_Rotates(t *testing.T) {
+	tokenUtil, _ := setupTokenUtil(t)
+	ctx := context.Background()
+
+	pair, _ := tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura"}, nil)
+	first, _, _ := tokenUtil.ParseToken(ctx, pair.AccessToken)
+
+	auth, err := tokenUtil.ConsumeRefreshToken(ctx, pair.RefreshToken)
+	assert.NoError(t, err)
+	assert.Equal(t, first.FamilyID, auth.FamilyID)
+
+	_, _, err = tokenUtil.ParseToken(ctx, pair.AccessToken)
+	assert.Error(t, err, "previous access token should be revoked on rotation")
+
+	next, err := tokenUtil.IssueToken(ctx, auth, nil)
+	assert.NoError(t, err)
+
+	nextAuth, _, err := tokenUtil.ParseToken(ctx, next.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, first.FamilyID, nextAuth.FamilyID)
+}
+
+func TestTokenUtil_ConsumeRefreshToken_ReuseRevokesFamily(t *testing.T) {
+	tokenUtil, mr := setupTokenUtil(t)
+	ctx := context.Background()
+
+	pair, _ := tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura"}, nil)
+
+	auth, err := tokenUtil.ConsumeRefreshToken(ctx, pair.RefreshToken)
+	assert.NoError(t, err)
+	next, _ := tokenUtil.IssueToken(ctx, auth, nil)
+
+	_, err = tokenUtil.ConsumeRefreshToken(ctx, pair.RefreshToken)
+	assert.ErrorIs(t, err, util.ErrRefreshTokenReused)
+
+	_, _, err = tokenUtil.ParseToken(ctx, next.AccessToken)
+	assert.Error(t, err, "access token issued after rotation should be revoked")
+
+	_, err = tokenUtil.ConsumeRefreshToken(ctx, next.RefreshToken)
+	assert.ErrorIs(t, err, util.ErrInvalidRefreshToken)
+	assert.False(t, mr.Exists("family:"+auth.FamilyID))
+}
+
+func TestTokenUtil_ConsumeRefreshToken_ConcurrentRefreshes(t *testing.T) {
+	tokenUtil, _ := setupTokenUtil(t)
+	ctx := context.Background()
+
+	pair, _ := tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura"}, nil)
+
+	const racers = 8
+	results := make(chan error, racers)
+	var wg sync.WaitGroup
+	for i := 0; i < racers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := tokenUtil.ConsumeRefreshToken(ctx, pair.RefreshToken)
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	rotated := 0
+	for err := range results {
+		if err == nil {
+			rotated++
+		} else if !errors.Is(err, util.ErrInvalidRefreshToken) {
+			assert.ErrorIs(t, err, util.ErrRefreshTokenReused, "a late racer finds the token reused or its family revoked")
+		}
+	}
+	assert.Equal(t, 1, rotated, "only one of the racing refreshes may rotate the token")
+}
+
+func TestTokenUtil_ConsumeRefreshToken_Unknown(t *testing.T) {
+	tokenUtil, _ := setupTokenUtil(t)
+
+	_, err := tokenUtil.ConsumeRefreshToken(context.Background(), "not-a-real-token")
+	assert.ErrorIs(t, err, util.ErrInvalidRefreshToken)
+}
+
+func TestTokenUtil_RevokeFamily(t *testing.T) {
+	tokenUtil, _ := setupTokenUtil(t)
+	ctx := context.Background()
+
+	pair, _ := tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura"}, nil)
+	auth, _, _ := tokenUtil.ParseToken(ctx, pair.AccessToken)
+
+	assert.NoError(t, tokenUtil.RevokeFamily(ctx, auth.FamilyID))
+
+	_, _, err := tokenUtil.ParseToken(ctx, pair.AccessToken)
+	assert.Error(t, err)
+	_, err = tokenUtil.ConsumeRefreshToken(ctx, pair.RefreshToken)
+	assert.ErrorIs(t, err, util.ErrInvalidRefreshToken)
+}
+
+func TestTokenUtil_ListSessions(t *testing.T) {
+	tokenUtil, _ := setupTokenUtil(t)
+	ctx := context.Background()
+
+	laptop := &model.SessionDevice{IP: "10.0.0.1", UserAgent: "Firefox"}
+	phone := &model.SessionDevice{IP: "10.0.0.2", UserAgent: "Safari"}
+
+	_, err := tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura"}, laptop)
+	assert.NoError(t, err)
+	_, err = tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura"}, phone)
+	assert.NoError(t, err)
+	_, err = tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-2", Role: "yayasan"}, phone)
+	assert.NoError(t, err)
+
+	sessions, err := tokenUtil.ListSessions(ctx, "user-1")
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+
+	agents := []string{sessions[0].UserAgent, sessions[1].UserAgent}
+	assert.ElementsMatch(t, []string{"Firefox", "Safari"}, agents)
+	assert.False(t, sessions[0].IssuedAt.IsZero())
+}
+
+func TestTokenUtil_RevokeUserSessions_KeepsCurrent(t *testing.T) {
+	tokenUtil, _ := setupTokenUtil(t)
+	ctx := context.Background()
+
+	current, _ := tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura"}, nil)
+	other, _ := tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura"}, nil)
+	currentAuth, _, _ := tokenUtil.ParseToken(ctx, current.AccessToken)
+
+	assert.NoError(t, tokenUtil.RevokeUserSessions(ctx, "user-1", currentAuth.FamilyID))
+
+	_, _, err := tokenUtil.ParseToken(ctx, current.AccessToken)
+	assert.NoError(t, err)
+	_, _, err = tokenUtil.ParseToken(ctx, other.AccessToken)
+	assert.Error(t, err)
+
+	sessions, _ := tokenUtil.ListSessions(ctx, "user-1")
+	if assert.Len(t, sessions, 1) {
+		assert.Equal(t, currentAuth.FamilyID, sessions[0].ID)
+	}
+}
+
+func TestTokenUtil_HasSession_OtherUser(t *testing.T) {
+	tokenUtil, _ := setupTokenUtil(t)
+	ctx := context.Background()
+
+	pair, _ := tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura"}, nil)
+	auth, _, _ := tokenUtil.ParseToken(ctx, pair.AccessToken)
+
+	owned, err := tokenUtil.HasSession(ctx, "user-2", auth.FamilyID)
+	assert.NoError(t, err)
+	assert.False(t, owned)
+}
+
+func TestTokenUtil_LoginChallenge_DroppedAfterMaxAttempts(t *testing.T) {
+	tokenUtil, _ := setupTokenUtil(t)
+	ctx := context.Background()
+
+	challenge, err := tokenUtil.CreateLoginChallenge(ctx, &model.Auth{ID: "user-1", Role: "pura", Email: "a@example.com"})
+	assert.NoError(t, err)
+
+	auth, err := tokenUtil.GetLoginChallenge(ctx, challenge)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", auth.ID)
+
+	for i := 0; i < util.MaxLoginChallengeAttempts; i++ {
+		assert.NoError(t, tokenUtil.FailLoginChallenge(ctx, challenge))
+	}
+
+	_, err = tokenUtil.GetLoginChallenge(ctx, challenge)
+	assert.ErrorIs(t, err, util.ErrInvalidLoginChallenge)
+}
+
+func TestTokenUtil_LoginChallenge_FailAfterExpiry(t *testing.T) {
+	tokenUtil, mr := setupTokenUtil(t)
+	ctx := context.Background()
+
+	challenge, err := tokenUtil.CreateLoginChallenge(ctx, &model.Auth{ID: "user-1", Role: "pura"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, tokenUtil.FailLoginChallenge(ctx, challenge))
+	for _, key := range mr.Keys() {
+		assert.Greater(t, mr.TTL(key), time.Duration(0), "the challenge keeps its TTL: %s", key)
+	}
+
+	mr.FastForward(util.LoginChallengeTTL + time.Second)
+
+	assert.NoError(t, tokenUtil.FailLoginChallenge(ctx, challenge))
+	assert.Empty(t, mr.Keys(), "a wrong code for an expired challenge must not recreate it")
+
+	_, err = tokenUtil.GetLoginChallenge(ctx, challenge)
+	assert.ErrorIs(t, err, util.ErrInvalidLoginChallenge)
+}
+
+func TestTokenUtil_PasswordResetToken_SingleUse(t *testing.T) {
+	tokenUtil, mr := setupTokenUtil(t)
+	ctx := context.Background()
+
+	first, err := tokenUtil.CreatePasswordResetToken(ctx, "user-1")
+	assert.NoError(t, err)
+	second, err := tokenUtil.CreatePasswordResetToken(ctx, "user-1")
+	assert.NoError(t, err)
+
+	_, err = tokenUtil.ConsumePasswordResetToken(ctx, first)
+	assert.ErrorIs(t, err, util.ErrInvalidPasswordReset, "issuing a new token invalidates the previous one")
+
+	userID, err := tokenUtil.ConsumePasswordResetToken(ctx, second)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+
+	_, err = tokenUtil.ConsumePasswordResetToken(ctx, second)
+	assert.ErrorIs(t, err, util.ErrInvalidPasswordReset)
+
+	expiring, _ := tokenUtil.CreatePasswordResetToken(ctx, "user-2")
+	mr.FastForward(util.PasswordResetTTL + time.Second)
+	_, err = tokenUtil.ConsumePasswordResetToken(ctx, expiring)
+	assert.ErrorIs(t, err, util.ErrInvalidPasswordReset)
+}
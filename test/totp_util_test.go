@@ -0,0 +1,88 @@
+package test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+// RFC 6238 appendix B secret ("12345678901234567890") in base32.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateTOTPCode_RFC6238Vectors(t *testing.T) {
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, expected := range vectors {
+		code, err := util.GenerateTOTPCode(rfc6238Secret, uint64(unix)/30)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code, "T=%d", unix)
+	}
+}
+
+func TestTOTPUtil_Validate_RejectsReplay(t *testing.T) {
+	tokenUtil, _ := setupTokenUtil(t)
+	totpUtil := util.NewTOTPUtil("", tokenUtil.Redis)
+	totpUtil.Now = func() time.Time { return time.Unix(1234567890, 0) }
+	ctx := context.Background()
+
+	ok, err := totpUtil.Validate(ctx, "user-1", rfc6238Secret, "005924")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = totpUtil.Validate(ctx, "user-1", rfc6238Secret, "005924")
+	assert.NoError(t, err)
+	assert.False(t, ok, "a code must not be accepted twice")
+}
+
+func TestTOTPUtil_Validate_AllowsOneStepDrift(t *testing.T) {
+	tokenUtil, _ := setupTokenUtil(t)
+	totpUtil := util.NewTOTPUtil("", tokenUtil.Redis)
+	totpUtil.Now = func() time.Time { return time.Unix(1234567890+30, 0) }
+
+	ok, err := totpUtil.Validate(context.Background(), "user-1", rfc6238Secret, "005924")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	totpUtil.Now = func() time.Time { return time.Unix(1234567890+120, 0) }
+	ok, _ = totpUtil.Validate(context.Background(), "user-2", rfc6238Secret, "005924")
+	assert.False(t, ok)
+}
+
+func TestTOTPUtil_ProvisioningURI(t *testing.T) {
+	totpUtil := util.NewTOTPUtil("Pura Agung Kertajaya", nil)
+
+	uri := totpUtil.ProvisioningURI("admin@puraagungkertajaya.com", rfc6238Secret)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Pura%20Agung%20Kertajaya:admin@puraagungkertajaya.com?"))
+
+	parsed, err := url.Parse(uri)
+	assert.NoError(t, err)
+	assert.Equal(t, rfc6238Secret, parsed.Query().Get("secret"))
+	assert.Equal(t, "Pura Agung Kertajaya", parsed.Query().Get("issuer"))
+	assert.Equal(t, "6", parsed.Query().Get("digits"))
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := util.GenerateRecoveryCodes(util.RecoveryCodeCount)
+	assert.NoError(t, err)
+	assert.Len(t, codes, util.RecoveryCodeCount)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		assert.Len(t, code, 11)
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+
+	assert.Equal(t, util.HashRecoveryCode("ABCDE-FGHIJ"), util.HashRecoveryCode("abcdefghij"))
+}
@@ -9,6 +9,7 @@ import (
 	usecase "pura-agung-kertajaya-backend/internal/usecase/mock"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/spf13/viper"
@@ -31,15 +32,40 @@ func setupUserController(t *testing.T) (*fiber.App, *usecase.UserUsecaseMock) {
 	controller := httpdelivery.NewUserController(mockUC, logger, cfg)
 
 	app.Post("/api/users/_login", controller.Login)
+	app.Post("/api/users/_refresh", controller.Refresh)
+	app.Post("/api/users/_logout", controller.Logout)
+	app.Post("/api/users/_login/2fa", controller.VerifyTwoFactorLogin)
+	app.Post("/api/users/_forgot-password", controller.ForgotPassword)
+	app.Post("/api/users/_reset-password", controller.ResetPasswordWithToken)
 
 	authMiddleware := func(c *fiber.Ctx) error {
-		c.Locals("user", &middleware.Auth{ID: "user-uuid", Role: "admin"})
+		c.Locals("user", &middleware.Auth{ID: "user-uuid", Role: "admin", SessionID: "session-current"})
 		return c.Next()
 	}
 
 	app.Get("/api/users/_current", authMiddleware, controller.Current)
 	app.Patch("/api/users/_current", authMiddleware, controller.UpdateProfile)
-	app.Post("/api/users/_logout", authMiddleware, controller.Logout)
+	app.Get("/api/users/_current/sessions", authMiddleware, controller.ListCurrentSessions)
+	app.Delete("/api/users/_current/sessions", authMiddleware, controller.RevokeAllCurrentSessions)
+	app.Delete("/api/users/_current/sessions/:id", authMiddleware, controller.RevokeCurrentSession)
+	app.Post("/api/users/_current/2fa/_setup", authMiddleware, controller.SetupTwoFactor)
+	app.Post("/api/users/_current/2fa/_enable", authMiddleware, controller.EnableTwoFactor)
+
+	superAuth := func(c *fiber.Ctx) error {
+		c.Locals("user", &middleware.Auth{ID: "super-uuid", Role: "super"})
+		return c.Next()
+	}
+	superOnly := middleware.SuperAdminMiddleware()
+
+	app.Get("/api/users", superAuth, superOnly, controller.GetAll)
+	app.Get("/api/users/login-attempts", superAuth, superOnly, controller.GetLoginAttempts)
+	app.Post("/api/users", superAuth, superOnly, controller.Create)
+	app.Patch("/api/users/:id/role", superAuth, superOnly, controller.UpdateRole)
+	app.Patch("/api/users/:id/status", superAuth, superOnly, controller.UpdateStatus)
+	app.Put("/api/users/:id/password", superAuth, superOnly, controller.ResetPassword)
+	app.Delete("/api/users/:id/sessions", superAuth, superOnly, controller.RevokeUserSessions)
+	app.Delete("/api/users/:id/2fa", superAuth, superOnly, controller.ResetTwoFactor)
+	app.Get("/api/editor/users", authMiddleware, superOnly, controller.GetAll)
 
 	return app, mockUC
 }
@@ -54,10 +80,15 @@ func TestUserController_Login_Success(t *testing.T) {
 	}
 
 	expectedUser := &model.UserResponse{ID: "user-uuid", Email: reqBody.Email, Name: "Admin"}
-	expectedToken := "mock-jwt-token"
+	expectedToken := &model.TokenPair{
+		AccessToken:      "mock-jwt-token",
+		RefreshToken:     "mock-refresh-token",
+		AccessExpiresIn:  15 * time.Minute,
+		RefreshExpiresIn: 7 * 24 * time.Hour,
+	}
 
 	mockUC.On("Login", mock.Anything, mock.AnythingOfType("*model.LoginUserRequest")).
-		Return(expectedUser, expectedToken, nil)
+		Return(&model.LoginResult{User: expectedUser, Token: expectedToken}, nil)
 
 	body, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest(http.MethodPost, "/api/users/_login", strings.NewReader(string(body)))
@@ -67,14 +98,19 @@ func TestUserController_Login_Success(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 
 	cookies := resp.Cookies()
-	found := false
+	foundAccess, foundRefresh := false, false
 	for _, c := range cookies {
-		if c.Name == "access_token" && c.Value == expectedToken {
-			found = true
-			break
+		if c.Name == "access_token" && c.Value == expectedToken.AccessToken {
+			foundAccess = true
+			assert.Equal(t, 900, c.MaxAge)
+		}
+		if c.Name == "refresh_token" && c.Value == expectedToken.RefreshToken {
+			foundRefresh = true
+			assert.Equal(t, "/api/users", c.Path)
 		}
 	}
-	assert.True(t, found, "Access token cookie should be set")
+	assert.True(t, foundAccess, "Access token cookie should be set")
+	assert.True(t, foundRefresh, "Refresh token cookie should be set")
 }
 
 func TestUserController_Login_Fail(t *testing.T) {
@@ -86,7 +122,7 @@ func TestUserController_Login_Fail(t *testing.T) {
 	}
 
 	mockUC.On("Login", mock.Anything, mock.AnythingOfType("*model.LoginUserRequest")).
-		Return((*model.UserResponse)(nil), "", errors.New("invalid credentials"))
+		Return(nil, errors.New("invalid credentials"))
 
 	body, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest(http.MethodPost, "/api/users/_login", strings.NewReader(string(body)))
@@ -97,6 +133,177 @@ func TestUserController_Login_Fail(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 }
 
+func TestUserController_Login_TwoFactorChallenge(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	challenge := &model.TwoFactorChallengeResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    "challenge-token",
+		ExpiresIn:         300,
+	}
+
+	mockUC.On("Login", mock.Anything, mock.AnythingOfType("*model.LoginUserRequest")).
+		Return(&model.LoginResult{Challenge: challenge}, nil)
+
+	body, _ := json.Marshal(model.LoginUserRequest{Email: "admin@puraagungkertajaya.com", Password: "rahasia"})
+	req := httptest.NewRequest(http.MethodPost, "/api/users/_login", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Cookies(), "no session cookies before the second factor")
+
+	var webResp model.WebResponse[model.TwoFactorChallengeResponse]
+	json.NewDecoder(resp.Body).Decode(&webResp)
+	assert.True(t, webResp.Data.TwoFactorRequired)
+	assert.Equal(t, "challenge-token", webResp.Data.ChallengeToken)
+}
+
+func TestUserController_VerifyTwoFactorLogin_Success(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	token := &model.TokenPair{
+		AccessToken:      "access",
+		RefreshToken:     "refresh",
+		AccessExpiresIn:  15 * time.Minute,
+		RefreshExpiresIn: 7 * 24 * time.Hour,
+	}
+
+	mockUC.On("VerifyTwoFactorLogin", mock.Anything, mock.MatchedBy(func(r *model.VerifyTwoFactorLoginRequest) bool {
+		return r.ChallengeToken == "challenge-token" && r.Code == "123456"
+	})).Return(&model.UserResponse{ID: "user-uuid"}, token, nil)
+
+	body := `{"challenge_token":"challenge-token","code":"123456"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/users/_login/2fa", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, resp.Cookies(), 2)
+}
+
+func TestUserController_VerifyTwoFactorLogin_InvalidCode(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("VerifyTwoFactorLogin", mock.Anything, mock.AnythingOfType("*model.VerifyTwoFactorLoginRequest")).
+		Return(nil, nil, model.ErrUnauthorized("Invalid authentication code"))
+
+	body := `{"challenge_token":"challenge-token","code":"000000"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/users/_login/2fa", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Empty(t, resp.Cookies())
+}
+
+func TestUserController_SetupTwoFactor(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("SetupTwoFactor", mock.Anything, "user-uuid").Return(&model.TwoFactorSetupResponse{
+		Secret:          "JBSWY3DPEHPK3PXP",
+		ProvisioningURI: "otpauth://totp/Pura:admin?secret=JBSWY3DPEHPK3PXP",
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/users/_current/2fa/_setup", nil)
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var webResp model.WebResponse[model.TwoFactorSetupResponse]
+	json.NewDecoder(resp.Body).Decode(&webResp)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", webResp.Data.Secret)
+}
+
+func TestUserController_EnableTwoFactor_ReturnsRecoveryCodes(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("EnableTwoFactor", mock.Anything, "user-uuid", mock.AnythingOfType("*model.TwoFactorCodeRequest")).
+		Return(&model.RecoveryCodesResponse{Codes: []string{"abcde-fghij"}}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/users/_current/2fa/_enable", strings.NewReader(`{"code":"123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var webResp model.WebResponse[model.RecoveryCodesResponse]
+	json.NewDecoder(resp.Body).Decode(&webResp)
+	assert.Equal(t, []string{"abcde-fghij"}, webResp.Data.Codes)
+}
+
+func TestUserController_ResetTwoFactor(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("ResetTwoFactor", mock.Anything, "target-uuid").Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/users/target-uuid/2fa", nil)
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestUserController_Refresh_Success(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	expectedUser := &model.UserResponse{ID: "user-uuid", Role: "pura"}
+	rotated := &model.TokenPair{
+		AccessToken:      "new-access",
+		RefreshToken:     "new-refresh",
+		AccessExpiresIn:  15 * time.Minute,
+		RefreshExpiresIn: 7 * 24 * time.Hour,
+	}
+
+	mockUC.On("Refresh", mock.Anything, mock.MatchedBy(func(r *model.RefreshTokenRequest) bool {
+		return r.RefreshToken == "old-refresh"
+	})).Return(expectedUser, rotated, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/users/_refresh", nil)
+	req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "old-refresh"})
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	values := map[string]string{}
+	for _, c := range resp.Cookies() {
+		values[c.Name] = c.Value
+	}
+	assert.Equal(t, "new-access", values["access_token"])
+	assert.Equal(t, "new-refresh", values["refresh_token"])
+}
+
+func TestUserController_Refresh_MissingCookie(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/users/_refresh", nil)
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	mockUC.AssertNotCalled(t, "Refresh", mock.Anything, mock.Anything)
+}
+
+func TestUserController_Refresh_ReuseClearsCookies(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("Refresh", mock.Anything, mock.AnythingOfType("*model.RefreshTokenRequest")).
+		Return(nil, nil, model.ErrUnauthorized("Session has been revoked, please log in again"))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/users/_refresh", nil)
+	req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "stolen-refresh"})
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	cleared := map[string]bool{}
+	for _, c := range resp.Cookies() {
+		if c.Value == "" {
+			cleared[c.Name] = true
+		}
+	}
+	assert.True(t, cleared["access_token"])
+	assert.True(t, cleared["refresh_token"])
+}
+
 func TestUserController_Current_Success(t *testing.T) {
 	app, mockUC := setupUserController(t)
 
@@ -127,8 +334,9 @@ func TestUserController_UpdateProfile_Success(t *testing.T) {
 	reqBody := model.UpdateUserRequest{Name: "New Name"}
 	expectedUser := &model.UserResponse{ID: "user-uuid", Name: "New Name"}
 
-	mockUC.On("UpdateProfile", mock.Anything, "user-uuid", mock.AnythingOfType("*model.UpdateUserRequest")).
-		Return(expectedUser, nil)
+	mockUC.On("UpdateProfile", mock.Anything, "user-uuid", mock.MatchedBy(func(r *model.UpdateUserRequest) bool {
+		return r.SessionID == "session-current"
+	})).Return(expectedUser, nil)
 
 	body, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest(http.MethodPatch, "/api/users/_current", strings.NewReader(string(body)))
@@ -143,7 +351,7 @@ func TestUserController_Logout_Success(t *testing.T) {
 
 	tokenString := "valid-token"
 
-	mockUC.On("Logout", mock.Anything, tokenString).Return(nil)
+	mockUC.On("Logout", mock.Anything, &model.LogoutRequest{AccessToken: tokenString}).Return(nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/api/users/_logout", nil)
 	req.AddCookie(&http.Cookie{Name: "access_token", Value: tokenString})
@@ -161,3 +369,257 @@ func TestUserController_Logout_Success(t *testing.T) {
 	}
 	assert.True(t, cleared, "Access token cookie should be cleared")
 }
+
+func TestUserController_Logout_WithRefreshTokenOnly(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("Logout", mock.Anything, &model.LogoutRequest{RefreshToken: "refresh-token"}).Return(nil)
+	mockUC.On("Logout", mock.Anything, &model.LogoutRequest{RefreshToken: "revoked"}).Return(model.ErrUnauthorized("Invalid or expired refresh token"))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/users/_logout", nil)
+	req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "refresh-token"})
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/users/_logout", nil)
+	req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "revoked"})
+	resp, _ = app.Test(req, -1)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodPost, "/api/users/_logout", nil), -1)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestUserController_GetAll_Success(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	users := []model.UserResponse{
+		{ID: "u1", Name: "Admin Pura", Role: "pura", IsActive: true},
+		{ID: "u2", Name: "Admin Yayasan", Role: "yayasan", IsActive: false},
+	}
+	mockUC.On("GetAll", mock.Anything, mock.Anything).Return(users, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users", nil)
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var webResp model.WebResponse[[]model.UserResponse]
+	json.NewDecoder(resp.Body).Decode(&webResp)
+	assert.Len(t, webResp.Data, 2)
+	assert.False(t, webResp.Data[1].IsActive)
+}
+
+func TestUserController_GetAll_ForbiddenForNonSuper(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/editor/users", nil)
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	mockUC.AssertNotCalled(t, "GetAll", mock.Anything)
+}
+
+func TestUserController_Create_Success(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	reqBody := model.CreateUserRequest{
+		Name:     "Admin Pasraman",
+		Email:    "pasraman@example.com",
+		Password: "password123",
+		Role:     "pasraman",
+	}
+	expected := &model.UserResponse{ID: "new-uuid", Name: reqBody.Name, Email: reqBody.Email, Role: reqBody.Role, IsActive: true}
+
+	mockUC.On("Create", mock.Anything, mock.AnythingOfType("*model.CreateUserRequest")).Return(expected, nil)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/users", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestUserController_Create_Conflict(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("Create", mock.Anything, mock.AnythingOfType("*model.CreateUserRequest")).
+		Return(nil, model.ErrConflict("email already registered"))
+
+	body, _ := json.Marshal(model.CreateUserRequest{Name: "x", Email: "dup@example.com", Password: "password123", Role: "pura"})
+	req := httptest.NewRequest(http.MethodPost, "/api/users", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestUserController_UpdateRole_PassesActor(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	expected := &model.UserResponse{ID: "target-uuid", Role: "yayasan"}
+	mockUC.On("UpdateRole", mock.Anything, "super-uuid", "target-uuid", mock.AnythingOfType("*model.UpdateUserRoleRequest")).
+		Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/users/target-uuid/role", strings.NewReader(`{"role":"yayasan"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestUserController_UpdateStatus_Disable(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	expected := &model.UserResponse{ID: "target-uuid", IsActive: false}
+	mockUC.On("UpdateStatus", mock.Anything, "super-uuid", "target-uuid", &model.UpdateUserStatusRequest{IsActive: false}).
+		Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/users/target-uuid/status", strings.NewReader(`{"is_active":false}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestUserController_ResetPassword_NotFound(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("ResetPassword", mock.Anything, "missing", mock.AnythingOfType("*model.ResetUserPasswordRequest")).
+		Return(model.ErrNotFound("user not found"))
+
+	req := httptest.NewRequest(http.MethodPut, "/api/users/missing/password", strings.NewReader(`{"password":"newpassword"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestUserController_ListCurrentSessions_MarksCurrent(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	sessions := []model.SessionResponse{
+		{ID: "session-current", IP: "10.0.0.1", UserAgent: "Firefox", Current: true},
+		{ID: "session-other", IP: "10.0.0.2", UserAgent: "Safari"},
+	}
+	mockUC.On("ListSessions", mock.Anything, "user-uuid", "session-current").Return(sessions, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users/_current/sessions", nil)
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var webResp model.WebResponse[[]model.SessionResponse]
+	json.NewDecoder(resp.Body).Decode(&webResp)
+	assert.Len(t, webResp.Data, 2)
+	assert.True(t, webResp.Data[0].Current)
+}
+
+func TestUserController_RevokeCurrentSession_Other(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("RevokeSession", mock.Anything, "user-uuid", "session-other").Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/users/_current/sessions/session-other", nil)
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Cookies(), "revoking another device must not clear this device's cookies")
+}
+
+func TestUserController_RevokeCurrentSession_NotOwned(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("RevokeSession", mock.Anything, "user-uuid", "someone-else").Return(model.ErrNotFound("session not found"))
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/users/_current/sessions/someone-else", nil)
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestUserController_RevokeAllCurrentSessions(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("RevokeAllSessions", mock.Anything, "user-uuid", "").Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/users/_current/sessions", nil)
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEmpty(t, resp.Cookies())
+	mockUC.AssertExpectations(t)
+}
+
+func TestUserController_RevokeUserSessions_ForceLogout(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("RevokeAllSessions", mock.Anything, "target-uuid", "").Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/users/target-uuid/sessions", nil)
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestUserController_GetLoginAttempts_PassesFilters(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("GetLoginAttempts", mock.Anything, mock.MatchedBy(func(r *model.SearchLoginAttemptRequest) bool {
+		return r.Email == "a@example.com" && r.Result == "LOCKED" && r.Limit == 20
+	})).Return([]model.LoginAttemptResponse{{ID: "la-1", Email: "a@example.com", Result: "LOCKED"}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users/login-attempts?email=a@example.com&result=LOCKED&limit=20", nil)
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var webResp model.WebResponse[[]model.LoginAttemptResponse]
+	json.NewDecoder(resp.Body).Decode(&webResp)
+	assert.Len(t, webResp.Data, 1)
+}
+
+func TestUserController_Login_LockedOut(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("Login", mock.Anything, mock.AnythingOfType("*model.LoginUserRequest")).
+		Return(nil, model.ErrTooManyRequests("Too many failed login attempts, please try again later"))
+
+	body := `{"email":"a@example.com","password":"guess"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/users/_login", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+}
+
+func TestUserController_ForgotPassword_AlwaysOK(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("ForgotPassword", mock.Anything, mock.MatchedBy(func(r *model.ForgotPasswordRequest) bool {
+		return r.Email == "nobody@example.com"
+	})).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/users/_forgot-password", strings.NewReader(`{"email":"nobody@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
+
+func TestUserController_ResetPasswordWithToken_InvalidToken(t *testing.T) {
+	app, mockUC := setupUserController(t)
+
+	mockUC.On("CompletePasswordReset", mock.Anything, mock.Anything).
+		Return(model.ErrBadRequest("Password reset link is invalid or has expired"))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/users/_reset-password", strings.NewReader(`{"token":"expired","password":"newpassword"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	mockUC.AssertExpectations(t)
+}
@@ -0,0 +1,590 @@
+package test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"pura-agung-kertajaya-backend/internal/model"
+	"pura-agung-kertajaya-backend/internal/repository"
+	"pura-agung-kertajaya-backend/internal/usecase"
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+func setupMockUserUsecase(t *testing.T) (usecase.UserUseCase, sqlmock.Sqlmock) {
+	u, mock, _ := setupMockUserUsecaseWithTokens(t)
+	return u, mock
+}
+
+func setupMockUserUsecaseWithTokens(t *testing.T) (usecase.UserUseCase, sqlmock.Sqlmock, *util.TokenUtil) {
+	u, mock, tokenUtil, _ := setupMockUserUsecaseWithMailer(t)
+	return u, mock, tokenUtil
+}
+
+func setupMockUserUsecaseWithMailer(t *testing.T) (usecase.UserUseCase, sqlmock.Sqlmock, *util.TokenUtil, *util.MemoryMailer) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open stub db: %v", err)
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+
+	tokenUtil, _ := setupTokenUtil(t)
+	totpUtil := util.NewTOTPUtil("", tokenUtil.Redis)
+	lockoutUtil := util.NewLockoutUtil(tokenUtil.Redis)
+	recaptchaUtil := &util.RecaptchaUtil{Env: "development"}
+	mailer := &util.MemoryMailer{}
+	mailUtil := &util.MailUtil{Mailer: mailer, AppName: "Test", PasswordResetURL: "https://cms.example.com/reset"}
+
+	_, logger, _ := NewTestApp()
+	u := usecase.NewUserUseCase(
+		gormDB,
+		validator.New(),
+		repository.NewUserRepository(logger),
+		repository.NewUserRecoveryCodeRepository(logger),
+		repository.NewLoginAttemptRepository(logger),
+		repository.NewUserRoleAssignmentRepository(logger),
+		tokenUtil,
+		recaptchaUtil,
+		totpUtil,
+		lockoutUtil,
+		mailUtil,
+	)
+	return u, mock, tokenUtil, mailer
+}
+
+func TestUserUsecase_GetAll(t *testing.T) {
+	u, mock := setupMockUserUsecase(t)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "email", "role", "is_active"}).
+		AddRow("u1", "Admin Pura", "pura@example.com", "pura", true).
+		AddRow("u2", "Admin Yayasan", "yayasan@example.com", "yayasan", false)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` ORDER BY role ASC,name ASC")).
+		WillReturnRows(rows)
+
+	list, _, err := u.GetAll(context.Background(), &model.ListRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "pura@example.com", list[0].Email)
+	assert.False(t, list[1].IsActive)
+}
+
+func TestUserUsecase_Create_Success(t *testing.T) {
+	u, mock := setupMockUserUsecase(t)
+
+	req := &model.CreateUserRequest{
+		Name:     "Admin Pasraman",
+		Email:    "pasraman@example.com",
+		Password: "password123",
+		Role:     "pasraman",
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE email = ?")).
+		WithArgs(req.Email).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users`")).
+		WithArgs(
+			sqlmock.AnyArg(),
+			req.Name,
+			req.Email,
+			sqlmock.AnyArg(),
+			req.Role,
+			true,
+			"",
+			false,
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO user_role_assignments")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "pasraman", sqlmock.AnyArg(), "editor").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	res, err := u.Create(context.Background(), req)
+	assert.NoError(t, err)
+	if assert.NotNil(t, res) {
+		assert.NotEmpty(t, res.ID)
+		assert.Equal(t, "pasraman", res.Role)
+		assert.True(t, res.IsActive)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserUsecase_Create_DuplicateEmail(t *testing.T) {
+	u, mock := setupMockUserUsecase(t)
+
+	req := &model.CreateUserRequest{
+		Name:     "Dup",
+		Email:    "dup@example.com",
+		Password: "password123",
+		Role:     "pura",
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE email = ?")).
+		WithArgs(req.Email).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	res, err := u.Create(context.Background(), req)
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 409, e.Code)
+	}
+}
+
+func TestUserUsecase_Create_InvalidRole(t *testing.T) {
+	u, mock := setupMockUserUsecase(t)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	res, err := u.Create(context.Background(), &model.CreateUserRequest{
+		Name:     "X",
+		Email:    "x@example.com",
+		Password: "password123",
+		Role:     "admin",
+	})
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
+
+func TestUserUsecase_UpdateRole_CannotDemoteSelf(t *testing.T) {
+	u, mock := setupMockUserUsecase(t)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	res, err := u.UpdateRole(context.Background(), "self", "self", &model.UpdateUserRoleRequest{Role: "pura"})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+	}
+}
+
+func TestUserUsecase_UpdateRole_MovesDefaultEditorRole(t *testing.T) {
+	u, mock, tokenUtil := setupMockUserUsecaseWithTokens(t)
+	ctx := context.Background()
+
+	pair, _ := tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura"}, nil)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ? LIMIT ?")).
+		WithArgs("user-1", 1).
+		WillReturnRows(twoFactorUserRows(t, ""))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM user_role_assignments WHERE user_id = ? AND entity_type = ? AND role_id IN (SELECT id FROM roles WHERE name = ?)")).
+		WithArgs("user-1", "pura", "editor").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO user_role_assignments")).
+		WithArgs(sqlmock.AnyArg(), "user-1", "yayasan", sqlmock.AnyArg(), "editor").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	res, err := u.UpdateRole(ctx, "super-1", "user-1", &model.UpdateUserRoleRequest{Role: "yayasan"})
+	assert.NoError(t, err)
+	if assert.NotNil(t, res) {
+		assert.Equal(t, "yayasan", res.Role)
+	}
+
+	_, _, err = tokenUtil.ParseToken(ctx, pair.AccessToken)
+	assert.Error(t, err, "changing the role should revoke existing sessions")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserUsecase_UpdateStatus_CannotDisableSelf(t *testing.T) {
+	u, mock := setupMockUserUsecase(t)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	res, err := u.UpdateStatus(context.Background(), "self", "self", &model.UpdateUserStatusRequest{IsActive: false})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+	}
+}
+
+func TestUserUsecase_UpdateStatus_Disable(t *testing.T) {
+	u, mock, tokenUtil := setupMockUserUsecaseWithTokens(t)
+	ctx := context.Background()
+
+	pair, _ := tokenUtil.CreateToken(ctx, &model.Auth{ID: "target", Role: "pura"}, nil)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ? LIMIT ?")).
+		WithArgs("target", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "role", "is_active"}).
+			AddRow("target", "Admin", "a@example.com", "pura", true))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	res, err := u.UpdateStatus(ctx, "super", "target", &model.UpdateUserStatusRequest{IsActive: false})
+	assert.NoError(t, err)
+	if assert.NotNil(t, res) {
+		assert.False(t, res.IsActive)
+	}
+
+	_, _, err = tokenUtil.ParseToken(ctx, pair.AccessToken)
+	assert.Error(t, err, "disabling a user should revoke their sessions")
+}
+
+func TestUserUsecase_ResetPassword_NotFound(t *testing.T) {
+	u, mock := setupMockUserUsecase(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ? LIMIT ?")).
+		WithArgs("missing", 1).
+		WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectRollback()
+
+	err := u.ResetPassword(context.Background(), "missing", &model.ResetUserPasswordRequest{Password: "newpassword"})
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 404, e.Code)
+	}
+}
+
+func TestUserUsecase_UpdateProfile_PasswordRevokesOtherSessions(t *testing.T) {
+	u, mock, tokenUtil := setupMockUserUsecaseWithTokens(t)
+	ctx := context.Background()
+
+	current, _ := tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura"}, nil)
+	other, _ := tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura"}, nil)
+	currentAuth, _, _ := tokenUtil.ParseToken(ctx, current.AccessToken)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ? LIMIT ?")).
+		WithArgs("user-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "role", "is_active"}).
+			AddRow("user-1", "Admin", "a@example.com", "pura", true))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	_, err := u.UpdateProfile(ctx, "user-1", &model.UpdateUserRequest{
+		Password:  "newpassword",
+		SessionID: currentAuth.FamilyID,
+	})
+	assert.NoError(t, err)
+
+	_, _, err = tokenUtil.ParseToken(ctx, current.AccessToken)
+	assert.NoError(t, err, "the session that changed the password stays logged in")
+	_, _, err = tokenUtil.ParseToken(ctx, other.AccessToken)
+	assert.Error(t, err)
+}
+
+func TestUserUsecase_RevokeSession_NotOwned(t *testing.T) {
+	u, _, tokenUtil := setupMockUserUsecaseWithTokens(t)
+	ctx := context.Background()
+
+	pair, _ := tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura"}, nil)
+	auth, _, _ := tokenUtil.ParseToken(ctx, pair.AccessToken)
+
+	err := u.RevokeSession(ctx, "user-2", auth.FamilyID)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 404, e.Code)
+	}
+
+	_, _, err = tokenUtil.ParseToken(ctx, pair.AccessToken)
+	assert.NoError(t, err)
+}
+
+func twoFactorUserRows(t *testing.T, secret string) *sqlmock.Rows {
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	return sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "is_active", "two_factor_secret", "two_factor_enabled"}).
+		AddRow("user-1", "Admin", "a@example.com", string(hashed), "pura", true, secret, true)
+}
+
+func TestUserUsecase_Login_TwoFactorReturnsChallenge(t *testing.T) {
+	u, mock := setupMockUserUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE email = ?")).
+		WillReturnRows(twoFactorUserRows(t, rfc6238Secret))
+
+	res, err := u.Login(context.Background(), &model.LoginUserRequest{Email: "a@example.com", Password: "password123"})
+	assert.NoError(t, err)
+	if assert.NotNil(t, res) {
+		assert.Nil(t, res.Token, "no session before the second factor")
+		if assert.NotNil(t, res.Challenge) {
+			assert.True(t, res.Challenge.TwoFactorRequired)
+			assert.NotEmpty(t, res.Challenge.ChallengeToken)
+		}
+	}
+}
+
+func TestUserUsecase_VerifyTwoFactorLogin(t *testing.T) {
+	u, mock, tokenUtil := setupMockUserUsecaseWithTokens(t)
+	ctx := context.Background()
+
+	secret := "JBSWY3DPEHPK3PXP"
+	challenge, _ := tokenUtil.CreateLoginChallenge(ctx, &model.Auth{ID: "user-1", Role: "pura"})
+	code, _ := util.GenerateTOTPCode(secret, uint64(time.Now().Unix())/30)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ? LIMIT ?")).
+		WithArgs("user-1", 1).
+		WillReturnRows(twoFactorUserRows(t, secret))
+	mock.ExpectCommit()
+	expectLoginAttempt(mock, "SUCCESS")
+
+	res, token, err := u.VerifyTwoFactorLogin(ctx, &model.VerifyTwoFactorLoginRequest{ChallengeToken: challenge, Code: code})
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", res.ID)
+	assert.NotEmpty(t, token.AccessToken)
+
+	_, err = tokenUtil.GetLoginChallenge(ctx, challenge)
+	assert.ErrorIs(t, err, util.ErrInvalidLoginChallenge, "a challenge can only be completed once")
+}
+
+func TestUserUsecase_VerifyTwoFactorLogin_WrongCodesLockOut(t *testing.T) {
+	u, mock, tokenUtil := setupMockUserUsecaseWithTokens(t)
+	ctx := context.Background()
+
+	challenge, _ := tokenUtil.CreateLoginChallenge(ctx, &model.Auth{ID: "user-1", Role: "pura", Email: "a@example.com"})
+
+	for i := 1; i <= util.LockoutFreeAttempts+1; i++ {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ? LIMIT ?")).
+			WithArgs("user-1", 1).
+			WillReturnRows(twoFactorUserRows(t, "JBSWY3DPEHPK3PXP"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `user_recovery_codes`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		expectLoginAttempt(mock, "INVALID_SECOND_FACTOR")
+		mock.ExpectRollback()
+
+		_, _, err := u.VerifyTwoFactorLogin(ctx, &model.VerifyTwoFactorLoginRequest{ChallengeToken: challenge, Code: "wrong-code"})
+
+		var e *model.ResponseError
+		if assert.ErrorAs(t, err, &e) {
+			if i <= util.LockoutFreeAttempts {
+				assert.Equal(t, 401, e.Code)
+			} else {
+				assert.Equal(t, 429, e.Code)
+			}
+		}
+	}
+
+	_, err := tokenUtil.GetLoginChallenge(ctx, challenge)
+	assert.ErrorIs(t, err, util.ErrInvalidLoginChallenge, "a locked out challenge is discarded")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserUsecase_EnableTwoFactor_InvalidCode(t *testing.T) {
+	u, mock := setupMockUserUsecase(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ? LIMIT ?")).
+		WithArgs("user-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "two_factor_secret", "two_factor_enabled"}).
+			AddRow("user-1", "a@example.com", "JBSWY3DPEHPK3PXP", false))
+	mock.ExpectRollback()
+
+	res, err := u.EnableTwoFactor(context.Background(), "user-1", &model.TwoFactorCodeRequest{Code: "000000"})
+	assert.Nil(t, res)
+
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code)
+	}
+}
+
+func expectLoginAttempt(mock sqlmock.Sqlmock, result string) {
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `login_attempts`")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), result, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+}
+
+func TestUserUsecase_Login_UnknownEmailIsIndistinguishable(t *testing.T) {
+	u, mock := setupMockUserUsecase(t)
+	ctx := context.Background()
+
+	var errs []*model.ResponseError
+	for _, email := range []string{"a@example.com", "nobody@example.com"} {
+		if email == "a@example.com" {
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE email = ?")).
+				WillReturnRows(twoFactorUserRows(t, ""))
+		} else {
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE email = ?")).
+				WillReturnRows(sqlmock.NewRows(nil))
+		}
+		expectLoginAttempt(mock, "INVALID_CREDENTIALS")
+
+		_, err := u.Login(ctx, &model.LoginUserRequest{Email: email, Password: "wrong-password"})
+
+		var e *model.ResponseError
+		assert.ErrorAs(t, err, &e)
+		errs = append(errs, e)
+	}
+
+	assert.Equal(t, errs[0], errs[1])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserUsecase_Login_LocksOutAfterRepeatedFailures(t *testing.T) {
+	u, mock := setupMockUserUsecase(t)
+	ctx := context.Background()
+
+	req := &model.LoginUserRequest{Email: "nobody@example.com", Password: "guess"}
+
+	for i := 1; i <= util.LockoutFreeAttempts; i++ {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE email = ?")).
+			WillReturnRows(sqlmock.NewRows(nil))
+		expectLoginAttempt(mock, "INVALID_CREDENTIALS")
+
+		_, err := u.Login(ctx, req)
+		var e *model.ResponseError
+		if assert.ErrorAs(t, err, &e) {
+			assert.Equal(t, 401, e.Code)
+		}
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE email = ?")).
+		WillReturnRows(sqlmock.NewRows(nil))
+	expectLoginAttempt(mock, "INVALID_CREDENTIALS")
+
+	_, err := u.Login(ctx, req)
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 429, e.Code)
+	}
+
+	// While locked, the password is not even checked.
+	expectLoginAttempt(mock, "LOCKED")
+
+	_, err = u.Login(ctx, req)
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 429, e.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserUsecase_GetLoginAttempts_Filters(t *testing.T) {
+	u, mock := setupMockUserUsecase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `login_attempts` WHERE email = ? AND result = ? ORDER BY created_at DESC LIMIT ?")).
+		WithArgs("a@example.com", "LOCKED", 50).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "result"}).AddRow("la-1", "a@example.com", "LOCKED"))
+
+	res, err := u.GetLoginAttempts(context.Background(), &model.SearchLoginAttemptRequest{Email: "a@example.com", Result: "LOCKED"})
+	assert.NoError(t, err)
+	if assert.Len(t, res, 1) {
+		assert.Equal(t, "LOCKED", res[0].Result)
+	}
+}
+
+func TestUserUsecase_ForgotPassword_UnknownEmailSendsNothing(t *testing.T) {
+	u, mock, _, mailer := setupMockUserUsecaseWithMailer(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE email = ?")).
+		WillReturnRows(sqlmock.NewRows(nil))
+
+	err := u.ForgotPassword(context.Background(), &model.ForgotPasswordRequest{Email: "nobody@example.com"})
+	assert.NoError(t, err)
+	assert.Nil(t, mailer.Last())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserUsecase_Logout_WithExpiredAccessToken(t *testing.T) {
+	u, _, tokenUtil := setupMockUserUsecaseWithTokens(t)
+	ctx := context.Background()
+
+	tokenUtil.AccessTokenTTL = -time.Minute
+	pair, _ := tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura"}, nil)
+
+	_, _, err := tokenUtil.ParseToken(ctx, pair.AccessToken)
+	assert.Error(t, err, "the access token has expired")
+	sessions, _ := tokenUtil.ListSessions(ctx, "user-1")
+	if !assert.Len(t, sessions, 1) {
+		return
+	}
+
+	err = u.Logout(ctx, &model.LogoutRequest{RefreshToken: pair.RefreshToken, AccessToken: pair.AccessToken})
+	assert.NoError(t, err)
+
+	has, err := tokenUtil.HasSession(ctx, "user-1", sessions[0].ID)
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	_, _, err = u.Refresh(ctx, &model.RefreshTokenRequest{RefreshToken: pair.RefreshToken})
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 401, e.Code)
+	}
+}
+
+func TestUserUsecase_PasswordReset_RevokesSessions(t *testing.T) {
+	u, mock, tokenUtil, mailer := setupMockUserUsecaseWithMailer(t)
+	ctx := context.Background()
+
+	pair, _ := tokenUtil.CreateToken(ctx, &model.Auth{ID: "user-1", Role: "pura"}, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE email = ?")).
+		WillReturnRows(twoFactorUserRows(t, ""))
+
+	err := u.ForgotPassword(ctx, &model.ForgotPasswordRequest{Email: "a@example.com"})
+	assert.NoError(t, err)
+
+	if !assert.Eventually(t, func() bool { return mailer.Last() != nil }, time.Second, 10*time.Millisecond, "the reset email is sent in the background") {
+		return
+	}
+	sent := mailer.Last()
+	assert.Equal(t, "a@example.com", sent.To)
+
+	match := regexp.MustCompile(`https://cms\.example\.com/reset\?token=(\S+)`).FindStringSubmatch(sent.Body)
+	if !assert.Len(t, match, 2) {
+		return
+	}
+	token := match[1]
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ? LIMIT ?")).
+		WithArgs("user-1", 1).
+		WillReturnRows(twoFactorUserRows(t, ""))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = u.CompletePasswordReset(ctx, &model.CompletePasswordResetRequest{Token: token, Password: "newpassword"})
+	assert.NoError(t, err)
+
+	_, _, err = tokenUtil.ParseToken(ctx, pair.AccessToken)
+	assert.Error(t, err, "completing a reset should revoke existing sessions")
+
+	err = u.CompletePasswordReset(ctx, &model.CompletePasswordResetRequest{Token: token, Password: "anotherpassword"})
+	var e *model.ResponseError
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 400, e.Code, "reset tokens are single use")
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,142 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"pura-agung-kertajaya-backend/internal/util"
+)
+
+const readerUserAgent = "Mozilla/5.0 (X11; Linux x86_64) Firefox/130.0"
+
+func setupViewCounterUtil(t *testing.T) (*util.ViewCounterUtil, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	return util.NewViewCounterUtil(rdb), mr
+}
+
+func TestViewCounterUtil_CountsOncePerVisitorPerDay(t *testing.T) {
+	counter, mr := setupViewCounterUtil(t)
+	ctx := context.Background()
+
+	now := time.Date(2026, 10, 17, 22, 0, 0, 0, time.UTC)
+	counter.Now = func() time.Time { return now }
+
+	counted, err := counter.Record(ctx, "a-1", "10.0.0.1", readerUserAgent)
+	assert.NoError(t, err)
+	assert.True(t, counted)
+
+	counted, err = counter.Record(ctx, "a-1", "10.0.0.1", readerUserAgent)
+	assert.NoError(t, err)
+	assert.False(t, counted, "the same visitor counts once a day")
+
+	counted, _ = counter.Record(ctx, "a-1", "10.0.0.2", readerUserAgent)
+	assert.True(t, counted)
+	counted, _ = counter.Record(ctx, "a-2", "10.0.0.1", readerUserAgent)
+	assert.True(t, counted)
+
+	now = now.Add(3 * time.Hour)
+	mr.FastForward(3 * time.Hour)
+	counted, _ = counter.Record(ctx, "a-1", "10.0.0.1", readerUserAgent)
+	assert.True(t, counted, "the visitor counts again the next day")
+
+	for _, key := range mr.Keys() {
+		assert.NotContains(t, key, "10.0.0.1", "visitor addresses are never stored")
+	}
+
+	var drained []util.ArticleDayViews
+	err = counter.Drain(ctx, func(views []util.ArticleDayViews) error {
+		drained = views
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []util.ArticleDayViews{
+		{ArticleID: "a-1", Date: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), Views: 2},
+		{ArticleID: "a-2", Date: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), Views: 1},
+		{ArticleID: "a-1", Date: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), Views: 1},
+	}, drained)
+
+	called := false
+	assert.NoError(t, counter.Drain(ctx, func([]util.ArticleDayViews) error {
+		called = true
+		return nil
+	}))
+	assert.False(t, called, "drained views are gone")
+}
+
+func TestViewCounterUtil_IgnoresBots(t *testing.T) {
+	counter, _ := setupViewCounterUtil(t)
+
+	counted, err := counter.Record(context.Background(), "a-1", "10.0.0.1", "Mozilla/5.0 (compatible; Googlebot/2.1)")
+	assert.NoError(t, err)
+	assert.False(t, counted)
+
+	counted, _ = counter.Record(context.Background(), "a-1", "10.0.0.1", "")
+	assert.False(t, counted)
+}
+
+func TestViewCounterUtil_DrainRestoresOnFailure(t *testing.T) {
+	counter, _ := setupViewCounterUtil(t)
+	ctx := context.Background()
+
+	_, _ = counter.Record(ctx, "a-1", "10.0.0.1", readerUserAgent)
+
+	err := counter.Drain(ctx, func([]util.ArticleDayViews) error { return errors.New("db down") })
+	assert.Error(t, err)
+
+	_, _ = counter.Record(ctx, "a-1", "10.0.0.2", readerUserAgent)
+
+	var drained []util.ArticleDayViews
+	assert.NoError(t, counter.Drain(ctx, func(views []util.ArticleDayViews) error {
+		drained = views
+		return nil
+	}))
+	if assert.Len(t, drained, 1) {
+		assert.Equal(t, int64(2), drained[0].Views, "views of a failed flush are kept for the next one")
+	}
+}
+
+func TestViewCounterUtil_DrainRecoversAbandonedFlushes(t *testing.T) {
+	counter, mr := setupViewCounterUtil(t)
+	ctx := context.Background()
+
+	now := time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)
+	counter.Now = func() time.Time { return now }
+
+	// A drain that died eleven minutes ago, one from before flush keys were stamped, and one still running.
+	mr.HSet("article_views:flushing:"+strconv.FormatInt(now.Add(-11*time.Minute).Unix(), 10)+":dead", "a-1|2026-10-17", "3")
+	mr.HSet("article_views:flushing:5f0e6a1c-legacy", "a-2|2026-10-16", "2")
+	runningKey := "article_views:flushing:" + strconv.FormatInt(now.Add(-time.Minute).Unix(), 10) + ":busy"
+	mr.HSet(runningKey, "a-3|2026-10-17", "5")
+
+	_, _ = counter.Record(ctx, "a-1", "10.0.0.1", readerUserAgent)
+
+	drained := map[string]int64{}
+	assert.NoError(t, counter.Drain(ctx, func(views []util.ArticleDayViews) error {
+		for _, v := range views {
+			drained[v.ArticleID+"|"+v.Date.Format(time.DateOnly)] = v.Views
+		}
+		return nil
+	}))
+
+	assert.Equal(t, map[string]int64{"a-1|2026-10-17": 4, "a-2|2026-10-16": 2}, drained)
+	assert.True(t, mr.Exists(runningKey), "a flush within its lease is left to its drain")
+
+	var flushing []string
+	for _, key := range mr.Keys() {
+		if strings.HasPrefix(key, "article_views:flushing:") {
+			flushing = append(flushing, key)
+		}
+	}
+	assert.Equal(t, []string{runningKey}, flushing)
+}